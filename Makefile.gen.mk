@@ -58,6 +58,11 @@ generate-proto: protoc-gen-go protoc-gen-go-grpc ## Generate Golang code from pr
 		--go_out=module=github.com/llm-d/llm-d-router:. \
 		--go-grpc_out=module=github.com/llm-d/llm-d-router:. \
 		pkg/epp/framework/plugins/requesthandling/parsers/vllmgrpc/api/proto/*.proto
+	PATH="$(LOCALBIN):$$PATH" $(PROTOC) \
+		-I cmd/epp/runner/externalscaler/api/proto \
+		--go_out=module=github.com/llm-d/llm-d-router:. \
+		--go-grpc_out=module=github.com/llm-d/llm-d-router:. \
+		cmd/epp/runner/externalscaler/api/proto/*.proto
 
 .PHONY: protoc-gen-go
 protoc-gen-go: $(PROTOC_GEN_GO) ## Download protoc-gen-go locally if necessary.
@@ -75,6 +75,12 @@ type EndpointPickerConfig struct {
 	// Deprecated: use requestHandler.parser instead. If both are set, the new field is used.
 	// Tracked in https://github.com/llm-d/llm-d-router/issues/1308
 	Parser *ParserConfig `json:"parser,omitempty"`
+
+	// +optional
+	// Spillover routes requests to a secondary set of endpoints serving the same model when the
+	// InferencePool of the EPP is saturated, sheds them or has no endpoint for them.
+	// If omitted, such requests fail with 429 or 503.
+	Spillover *SpilloverConfig `json:"spillover,omitempty"`
 }
 
 func (cfg EndpointPickerConfig) String() string {
@@ -103,6 +109,9 @@ func (cfg EndpointPickerConfig) String() string {
 	if cfg.Parser != nil {
 		parts = append(parts, fmt.Sprintf("Parser: %v", cfg.Parser))
 	}
+	if cfg.Spillover != nil {
+		parts = append(parts, fmt.Sprintf("Spillover: %v", cfg.Spillover))
+	}
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
@@ -150,6 +159,11 @@ type SchedulingProfile struct {
 	// Plugins is the list of plugins for this SchedulingProfile. They are assigned
 	// to the appropriate "slots" based on their type.
 	Plugins []SchedulingPlugin `json:"plugins"`
+
+	// +optional
+	// ScoreAggregation selects how the outputs of this profile's scorers are
+	// combined into a single score per endpoint. Defaults to a weighted sum.
+	ScoreAggregation *ScoreAggregation `json:"scoreAggregation,omitempty"`
 }
 
 func (sp SchedulingProfile) String() string {
@@ -158,6 +172,37 @@ func (sp SchedulingProfile) String() string {
 	if len(sp.Plugins) > 0 {
 		parts = append(parts, fmt.Sprintf("Plugins: %v", sp.Plugins))
 	}
+	if sp.ScoreAggregation != nil {
+		parts = append(parts, "ScoreAggregation: "+sp.ScoreAggregation.String())
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// ScoreAggregation configures the score aggregation strategy of a SchedulingProfile.
+type ScoreAggregation struct {
+	// +optional
+	// +kubebuilder:validation:Enum=weightedSum;minMax;rank;lexicographic;weightedGeometricMean
+	// Strategy is one of weightedSum (default), minMax, rank, lexicographic
+	// or weightedGeometricMean.
+	Strategy string `json:"strategy,omitempty"`
+
+	// +optional
+	// Epsilon is the tolerance under which two scores of the same tier are
+	// considered tied. Only used by the lexicographic strategy.
+	Epsilon *float64 `json:"epsilon,omitempty"`
+}
+
+func (sa *ScoreAggregation) String() string {
+	if sa == nil {
+		return nilString
+	}
+	var parts []string
+	if sa.Strategy != "" {
+		parts = append(parts, "Strategy: "+sa.Strategy)
+	}
+	if sa.Epsilon != nil {
+		parts = append(parts, fmt.Sprintf("Epsilon: %g", *sa.Epsilon))
+	}
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
@@ -263,6 +308,62 @@ func (dc *DiscoveryConfig) String() string {
 	return fmt.Sprintf("{PluginRef: %s}", dc.PluginRef)
 }
 
+// SpilloverConfig configures the secondary endpoints requests spill over to. Exactly one of
+// PoolRef and DiscoveryPluginRef must be set.
+type SpilloverConfig struct {
+	// +optional
+	// PoolRef names a secondary InferencePool whose endpoints receive spilled over requests.
+	// Only supported when the EPP discovers endpoints through Kubernetes.
+	PoolRef *SpilloverPoolRef `json:"poolRef,omitempty"`
+
+	// +optional
+	// DiscoveryPluginRef is the name of the plugin instance (from the Plugins list) that
+	// implements EndpointDiscovery and lists the spillover endpoints, e.g. a file-discovery
+	// plugin with a static endpoint list.
+	DiscoveryPluginRef string `json:"discoveryPluginRef,omitempty"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	// Objectives lists the InferenceObjectives whose requests may spill over. Requests of
+	// other objectives are never spilled over.
+	Objectives []string `json:"objectives"`
+}
+
+func (sc *SpilloverConfig) String() string {
+	if sc == nil {
+		return nilString
+	}
+	var parts []string
+	if sc.PoolRef != nil {
+		parts = append(parts, fmt.Sprintf("PoolRef: %v", sc.PoolRef))
+	}
+	if sc.DiscoveryPluginRef != "" {
+		parts = append(parts, "DiscoveryPluginRef: "+sc.DiscoveryPluginRef)
+	}
+	parts = append(parts, fmt.Sprintf("Objectives: %v", sc.Objectives))
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// SpilloverPoolRef identifies the secondary InferencePool of a spillover configuration.
+type SpilloverPoolRef struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// Name is the name of the InferencePool.
+	Name string `json:"name"`
+
+	// +optional
+	// Namespace is the namespace of the InferencePool. If omitted, the namespace of the
+	// InferencePool of the EPP is used.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (pr *SpilloverPoolRef) String() string {
+	if pr == nil {
+		return nilString
+	}
+	return fmt.Sprintf("{Name: %s, Namespace: %s}", pr.Name, pr.Namespace)
+}
+
 // DataLayerSource contains the configuration of a DataSource of the DataLayer feature
 type DataLayerSource struct {
 	// +required
@@ -401,6 +502,18 @@ type FlowControlConfig struct {
 	// SaturationDetector specifies which saturation detector plugin to use for both Admission and
 	// Flow Control. If omitted, "utilization-detector" is used by default.
 	SaturationDetector *SaturationDetectorConfig `json:"saturationDetector,omitempty"`
+
+	// +optional
+	// ScaleFromZero enables holding requests in the Flow Control layer while the pool has no
+	// ready endpoints, instead of failing them immediately. If omitted, such requests fail
+	// with 503 Service Unavailable. It requires the flowControl feature gate.
+	ScaleFromZero *ScaleFromZeroConfig `json:"scaleFromZero,omitempty"`
+
+	// +optional
+	// PriorityAging raises the effective priority of queued requests the longer they wait, so
+	// that lower priority bands are not starved by continuous higher priority traffic. If
+	// omitted, priority bands are served in strict priority order.
+	PriorityAging *PriorityAgingConfig `json:"priorityAging,omitempty"`
 }
 
 func (fcc *FlowControlConfig) String() string {
@@ -445,6 +558,86 @@ func (fcc *FlowControlConfig) String() string {
 		parts = append(parts, fmt.Sprintf("SaturationDetector: %v", fcc.SaturationDetector))
 	}
 
+	if fcc.ScaleFromZero != nil {
+		parts = append(parts, fmt.Sprintf("ScaleFromZero: %v", fcc.ScaleFromZero))
+	}
+
+	if fcc.PriorityAging != nil {
+		parts = append(parts, fmt.Sprintf("PriorityAging: %v", fcc.PriorityAging))
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// ScaleFromZeroConfig configures how requests are held while the pool has no ready endpoints.
+type ScaleFromZeroConfig struct {
+	// +optional
+	// HoldTimeout is the maximum time a request is held waiting for the pool to gain a ready
+	// endpoint. Requests still held after it elapses fail with 503 Service Unavailable.
+	// If omitted, defaults to 5 minutes.
+	HoldTimeout *metav1.Duration `json:"holdTimeout,omitempty"`
+
+	// +optional
+	// MaxHeldRequests is the maximum number of requests held at the same time. Requests arriving
+	// while the limit is reached are rejected with 429 Too Many Requests.
+	// If 0 or omitted, the number of held requests is only bounded by the Flow Control capacity.
+	MaxHeldRequests int32 `json:"maxHeldRequests,omitempty"`
+
+	// +optional
+	// ActivationURL is an HTTP(S) URL that receives a POST with the held demand of a model when
+	// requests for it start being held, and periodically while they remain held. It can be used
+	// to wake up an autoscaler. The held demand is always exported as a Prometheus gauge.
+	ActivationURL string `json:"activationURL,omitempty"`
+}
+
+func (sfz *ScaleFromZeroConfig) String() string {
+	if sfz == nil {
+		return nilString
+	}
+	var parts []string
+	if sfz.HoldTimeout != nil {
+		parts = append(parts, fmt.Sprintf("HoldTimeout: %s", sfz.HoldTimeout.Duration))
+	}
+	if sfz.MaxHeldRequests > 0 {
+		parts = append(parts, fmt.Sprintf("MaxHeldRequests: %d", sfz.MaxHeldRequests))
+	}
+	if sfz.ActivationURL != "" {
+		parts = append(parts, "ActivationURL: "+sfz.ActivationURL)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// PriorityAgingConfig configures how the effective priority of queued requests grows with their
+// queue time.
+type PriorityAgingConfig struct {
+	// +optional
+	// Curve is the shape of the priority boost over queue time: "linear" grows the boost by one
+	// priority level per Interval, "step" does the same in whole levels only and "exponential"
+	// grows it as 2^(queue time / Interval) - 1. If omitted, defaults to "linear".
+	Curve string `json:"curve,omitempty"`
+
+	// +optional
+	// Interval is the queue time after which a request has gained one priority level.
+	// If omitted, defaults to 10 seconds.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// MaxBoost caps the priority boost a request can gain. A request can only overtake bands
+	// whose priority is at most MaxBoost above its own, so it should be at least the gap between
+	// the bands that must not starve.
+	MaxBoost int32 `json:"maxBoost"`
+}
+
+func (pac *PriorityAgingConfig) String() string {
+	if pac == nil {
+		return nilString
+	}
+	parts := []string{fmt.Sprintf("MaxBoost: %d", pac.MaxBoost)}
+	if pac.Curve != "" {
+		parts = append(parts, "Curve: "+pac.Curve)
+	}
+	if pac.Interval != nil {
+		parts = append(parts, fmt.Sprintf("Interval: %s", pac.Interval.Duration))
+	}
 	return "{" + strings.Join(parts, ", ") + "}"
 }
 
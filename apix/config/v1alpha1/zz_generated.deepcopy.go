@@ -140,6 +140,11 @@ func (in *EndpointPickerConfig) DeepCopyInto(out *EndpointPickerConfig) {
 		*out = new(ParserConfig)
 		**out = **in
 	}
+	if in.Spillover != nil {
+		in, out := &in.Spillover, &out.Spillover
+		*out = new(SpilloverConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointPickerConfig.
@@ -219,6 +224,16 @@ func (in *FlowControlConfig) DeepCopyInto(out *FlowControlConfig) {
 		*out = new(SaturationDetectorConfig)
 		**out = **in
 	}
+	if in.ScaleFromZero != nil {
+		in, out := &in.ScaleFromZero, &out.ScaleFromZero
+		*out = new(ScaleFromZeroConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityAging != nil {
+		in, out := &in.PriorityAging, &out.PriorityAging
+		*out = new(PriorityAgingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowControlConfig.
@@ -266,6 +281,26 @@ func (in *PluginSpec) DeepCopy() *PluginSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityAgingConfig) DeepCopyInto(out *PriorityAgingConfig) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityAgingConfig.
+func (in *PriorityAgingConfig) DeepCopy() *PriorityAgingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityAgingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PriorityBandConfig) DeepCopyInto(out *PriorityBandConfig) {
 	*out = *in
@@ -326,6 +361,46 @@ func (in *SaturationDetectorConfig) DeepCopy() *SaturationDetectorConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleFromZeroConfig) DeepCopyInto(out *ScaleFromZeroConfig) {
+	*out = *in
+	if in.HoldTimeout != nil {
+		in, out := &in.HoldTimeout, &out.HoldTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleFromZeroConfig.
+func (in *ScaleFromZeroConfig) DeepCopy() *ScaleFromZeroConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleFromZeroConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScoreAggregation) DeepCopyInto(out *ScoreAggregation) {
+	*out = *in
+	if in.Epsilon != nil {
+		in, out := &in.Epsilon, &out.Epsilon
+		*out = new(float64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScoreAggregation.
+func (in *ScoreAggregation) DeepCopy() *ScoreAggregation {
+	if in == nil {
+		return nil
+	}
+	out := new(ScoreAggregation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulingPlugin) DeepCopyInto(out *SchedulingPlugin) {
 	*out = *in
@@ -356,6 +431,11 @@ func (in *SchedulingProfile) DeepCopyInto(out *SchedulingProfile) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ScoreAggregation != nil {
+		in, out := &in.ScoreAggregation, &out.ScoreAggregation
+		*out = new(ScoreAggregation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingProfile.
@@ -367,3 +447,43 @@ func (in *SchedulingProfile) DeepCopy() *SchedulingProfile {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpilloverConfig) DeepCopyInto(out *SpilloverConfig) {
+	*out = *in
+	if in.PoolRef != nil {
+		in, out := &in.PoolRef, &out.PoolRef
+		*out = new(SpilloverPoolRef)
+		**out = **in
+	}
+	if in.Objectives != nil {
+		in, out := &in.Objectives, &out.Objectives
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpilloverConfig.
+func (in *SpilloverConfig) DeepCopy() *SpilloverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpilloverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpilloverPoolRef) DeepCopyInto(out *SpilloverPoolRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpilloverPoolRef.
+func (in *SpilloverPoolRef) DeepCopy() *SpilloverPoolRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SpilloverPoolRef)
+	in.DeepCopyInto(out)
+	return out
+}
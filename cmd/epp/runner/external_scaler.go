@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	externalscalerPb "github.com/llm-d/llm-d-router/cmd/epp/runner/externalscaler/api/gen"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/datastore"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts"
+	fwkfc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+)
+
+const (
+	// desiredReplicasMetricName is the name of the metric reported to KEDA.
+	desiredReplicasMetricName = "desired-replicas"
+	// targetSaturationMetadataKey is the ScaledObject scaler metadata overriding the target saturation.
+	targetSaturationMetadataKey = "targetSaturation"
+	// streamIsActiveInterval is the interval at which StreamIsActive re-evaluates the activity of the pool.
+	streamIsActiveInterval = time.Second
+)
+
+// scalingSignal is a snapshot of the demand on the pool.
+type scalingSignal struct {
+	endpoints  int
+	saturation float64
+	// queued is the number of requests held by the flow controller.
+	queued uint64
+	// waiting and running are the requests queued and running on the model servers.
+	waiting int
+	running int
+}
+
+// active reports whether the pool has any demand, i.e. whether it should be scaled up from zero.
+func (s scalingSignal) active() bool {
+	return s.queued > 0 || s.waiting > 0 || s.running > 0
+}
+
+// desiredReplicas returns the number of replicas needed to serve the demand at the target saturation.
+// The replicas in use are scaled by their saturation relative to the target. Requests held by the flow
+// controller are not seen by the model servers, so they add the replicas needed to run them at the
+// current per-replica concurrency. Without endpoints, any queued request asks for one replica.
+func (s scalingSignal) desiredReplicas(targetSaturation float64) float64 {
+	if s.endpoints == 0 {
+		if s.active() {
+			return 1
+		}
+		return 0
+	}
+	desired := float64(s.endpoints) * s.saturation / targetSaturation
+	if s.queued > 0 {
+		perReplica := max(float64(s.running)/float64(s.endpoints), 1)
+		desired += float64(s.queued) / perReplica
+	}
+	return desired
+}
+
+// externalScaler implements the KEDA external scaler protocol, exposing the desired replica count of the
+// pool as a metric whose target is 1 so that the HPA scales the model servers to it.
+type externalScaler struct {
+	externalscalerPb.UnimplementedExternalScalerServer
+
+	logger             logr.Logger
+	datastore          datastore.Datastore
+	saturationDetector fwkfc.SaturationDetector
+	// flowRegistry is nil when the flow control layer is disabled.
+	flowRegistry     contracts.FlowRegistryObserver
+	targetSaturation float64
+	streamInterval   time.Duration
+}
+
+func newExternalScaler(logger logr.Logger, ds datastore.Datastore, sd fwkfc.SaturationDetector,
+	flowRegistry contracts.FlowRegistryObserver, targetSaturation float64) *externalScaler {
+	return &externalScaler{
+		logger:             logger,
+		datastore:          ds,
+		saturationDetector: sd,
+		flowRegistry:       flowRegistry,
+		targetSaturation:   targetSaturation,
+		streamInterval:     streamIsActiveInterval,
+	}
+}
+
+// signal collects the current demand on the pool.
+func (s *externalScaler) signal(ctx context.Context) scalingSignal {
+	endpoints := s.datastore.PodList(datastore.AllPodsPredicate)
+	signal := scalingSignal{endpoints: len(endpoints)}
+	for _, endpoint := range endpoints {
+		if metrics := endpoint.GetMetrics(); metrics != nil {
+			signal.waiting += metrics.WaitingQueueSize
+			signal.running += metrics.RunningRequestsSize
+		}
+	}
+	if s.saturationDetector != nil && len(endpoints) > 0 {
+		signal.saturation = s.saturationDetector.Saturation(ctx, endpoints)
+	}
+	if s.flowRegistry != nil {
+		signal.queued = s.flowRegistry.Stats().TotalLen
+	}
+	return signal
+}
+
+// targetSaturationFor returns the target saturation of the scaled object, which may override the default.
+func (s *externalScaler) targetSaturationFor(ref *externalscalerPb.ScaledObjectRef) (float64, error) {
+	raw, ok := ref.GetScalerMetadata()[targetSaturationMetadataKey]
+	if !ok {
+		return s.targetSaturation, nil
+	}
+	target, err := strconv.ParseFloat(raw, 64)
+	if err != nil || target <= 0 || target > 1 {
+		return 0, status.Errorf(codes.InvalidArgument, "scaler metadata %q must be a number in (0, 1], got %q",
+			targetSaturationMetadataKey, raw)
+	}
+	return target, nil
+}
+
+// IsActive reports whether any request is queued or running in the pool.
+func (s *externalScaler) IsActive(ctx context.Context, _ *externalscalerPb.ScaledObjectRef) (*externalscalerPb.IsActiveResponse, error) {
+	return &externalscalerPb.IsActiveResponse{Result: s.signal(ctx).active()}, nil
+}
+
+// StreamIsActive pushes the activity of the pool whenever it changes.
+func (s *externalScaler) StreamIsActive(_ *externalscalerPb.ScaledObjectRef, stream grpc.ServerStreamingServer[externalscalerPb.IsActiveResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.streamInterval)
+	defer ticker.Stop()
+
+	var last *bool
+	for {
+		active := s.signal(ctx).active()
+		if last == nil || *last != active {
+			if err := stream.Send(&externalscalerPb.IsActiveResponse{Result: active}); err != nil {
+				return err
+			}
+			last = &active
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetMetricSpec returns the desired replicas metric with a target of one, so that the HPA scales the pool
+// to the reported value.
+func (s *externalScaler) GetMetricSpec(_ context.Context, ref *externalscalerPb.ScaledObjectRef) (*externalscalerPb.GetMetricSpecResponse, error) {
+	if _, err := s.targetSaturationFor(ref); err != nil {
+		return nil, err
+	}
+	return &externalscalerPb.GetMetricSpecResponse{
+		MetricSpecs: []*externalscalerPb.MetricSpec{{MetricName: desiredReplicasMetricName, TargetSize: 1, TargetSizeFloat: 1}},
+	}, nil
+}
+
+// GetMetrics returns the desired replica count of the pool.
+func (s *externalScaler) GetMetrics(ctx context.Context, req *externalscalerPb.GetMetricsRequest) (*externalscalerPb.GetMetricsResponse, error) {
+	target, err := s.targetSaturationFor(req.GetScaledObjectRef())
+	if err != nil {
+		return nil, err
+	}
+	signal := s.signal(ctx)
+	desired := signal.desiredReplicas(target)
+	s.logger.V(logutil.DEBUG).Info("Computed desired replicas", "desired", desired, "endpoints", signal.endpoints,
+		"saturation", signal.saturation, "queued", signal.queued, "waiting", signal.waiting, "running", signal.running)
+
+	metricName := req.GetMetricName()
+	if metricName == "" {
+		metricName = desiredReplicasMetricName
+	}
+	return &externalscalerPb.GetMetricsResponse{
+		MetricValues: []*externalscalerPb.MetricValue{{
+			MetricName:       metricName,
+			MetricValue:      int64(math.Ceil(desired)),
+			MetricValueFloat: desired,
+		}},
+	}, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	externalscalerPb "github.com/llm-d/llm-d-router/cmd/epp/runner/externalscaler/api/gen"
+	"github.com/llm-d/llm-d-router/pkg/epp/datastore"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts/mocks"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fcmocks "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol/mocks"
+)
+
+// fakeEndpointDatastore is a datastore serving a fixed set of endpoints.
+type fakeEndpointDatastore struct {
+	datastore.Datastore
+	endpoints []fwkdl.Endpoint
+}
+
+func (f *fakeEndpointDatastore) PodList(predicate func(fwkdl.Endpoint) bool) []fwkdl.Endpoint {
+	var endpoints []fwkdl.Endpoint
+	for _, endpoint := range f.endpoints {
+		if predicate(endpoint) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+func newScalerEndpoints(running ...int) []fwkdl.Endpoint {
+	endpoints := make([]fwkdl.Endpoint, 0, len(running))
+	for _, r := range running {
+		endpoints = append(endpoints, fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{},
+			&fwkdl.Metrics{RunningRequestsSize: r}))
+	}
+	return endpoints
+}
+
+func newQueuedRegistry(queued uint64) contracts.FlowRegistryObserver {
+	return &mocks.MockRegistryDataPlane{
+		StatsFunc: func() contracts.AggregateStats { return contracts.AggregateStats{TotalLen: queued} },
+	}
+}
+
+func TestExternalScaler_GetMetrics(t *testing.T) {
+	tests := []struct {
+		name         string
+		endpoints    []fwkdl.Endpoint
+		saturation   float64
+		flowRegistry contracts.FlowRegistryObserver
+		metadata     map[string]string
+		wantActive   bool
+		wantDesired  float64
+		wantValue    int64
+	}{
+		{
+			name:        "idle pool without endpoints",
+			wantDesired: 0,
+			wantValue:   0,
+		},
+		{
+			name:         "scale from zero when requests are queued",
+			flowRegistry: newQueuedRegistry(3),
+			wantActive:   true,
+			wantDesired:  1,
+			wantValue:    1,
+		},
+		{
+			name:        "saturation at target keeps the replica count",
+			endpoints:   newScalerEndpoints(4, 4),
+			saturation:  0.8,
+			wantActive:  true,
+			wantDesired: 2,
+			wantValue:   2,
+		},
+		{
+			name:        "saturation above target scales up",
+			endpoints:   newScalerEndpoints(8, 8),
+			saturation:  1.0,
+			wantActive:  true,
+			wantDesired: 2.5,
+			wantValue:   3,
+		},
+		{
+			name:        "target saturation from scaler metadata",
+			endpoints:   newScalerEndpoints(8, 8),
+			saturation:  1.0,
+			metadata:    map[string]string{targetSaturationMetadataKey: "0.5"},
+			wantActive:  true,
+			wantDesired: 4,
+			wantValue:   4,
+		},
+		{
+			name:         "queued requests add replicas at the current concurrency",
+			endpoints:    newScalerEndpoints(4, 4),
+			saturation:   0.8,
+			flowRegistry: newQueuedRegistry(6),
+			wantActive:   true,
+			wantDesired:  3.5,
+			wantValue:    4,
+		},
+		{
+			name:        "idle endpoints scale down",
+			endpoints:   newScalerEndpoints(0, 0, 0),
+			saturation:  0,
+			wantDesired: 0,
+			wantValue:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaler := newExternalScaler(testr.New(t), &fakeEndpointDatastore{endpoints: tt.endpoints},
+				&fcmocks.MockSaturationDetector{SaturationV: tt.saturation}, tt.flowRegistry, 0.8)
+			ref := &externalscalerPb.ScaledObjectRef{Name: "model-server", Namespace: "default", ScalerMetadata: tt.metadata}
+
+			active, err := scaler.IsActive(t.Context(), ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActive, active.GetResult())
+
+			spec, err := scaler.GetMetricSpec(t.Context(), ref)
+			require.NoError(t, err)
+			require.Len(t, spec.GetMetricSpecs(), 1)
+			assert.Equal(t, desiredReplicasMetricName, spec.GetMetricSpecs()[0].GetMetricName())
+			assert.Equal(t, int64(1), spec.GetMetricSpecs()[0].GetTargetSize())
+
+			metrics, err := scaler.GetMetrics(t.Context(), &externalscalerPb.GetMetricsRequest{
+				ScaledObjectRef: ref, MetricName: "s0-" + desiredReplicasMetricName,
+			})
+			require.NoError(t, err)
+			require.Len(t, metrics.GetMetricValues(), 1)
+			value := metrics.GetMetricValues()[0]
+			assert.Equal(t, "s0-"+desiredReplicasMetricName, value.GetMetricName())
+			assert.InDelta(t, tt.wantDesired, value.GetMetricValueFloat(), 1e-9)
+			assert.Equal(t, tt.wantValue, value.GetMetricValue())
+		})
+	}
+}
+
+func TestExternalScaler_InvalidTargetSaturation(t *testing.T) {
+	scaler := newExternalScaler(testr.New(t), &fakeEndpointDatastore{}, nil, nil, 0.8)
+	for _, raw := range []string{"abc", "0", "1.5"} {
+		ref := &externalscalerPb.ScaledObjectRef{ScalerMetadata: map[string]string{targetSaturationMetadataKey: raw}}
+
+		_, err := scaler.GetMetricSpec(t.Context(), ref)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "targetSaturation %q", raw)
+		_, err = scaler.GetMetrics(t.Context(), &externalscalerPb.GetMetricsRequest{ScaledObjectRef: ref})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "targetSaturation %q", raw)
+	}
+}
+
+// TestExternalScaler_GRPC exercises the scaler over gRPC, as KEDA calls it.
+func TestExternalScaler_GRPC(t *testing.T) {
+	var queued atomic.Uint64
+	ds := &fakeEndpointDatastore{}
+	scaler := newExternalScaler(testr.New(t), ds, &fcmocks.MockSaturationDetector{SaturationV: 1},
+		&mocks.MockRegistryDataPlane{StatsFunc: func() contracts.AggregateStats {
+			return contracts.AggregateStats{TotalLen: queued.Load()}
+		}}, 0.8)
+	scaler.streamInterval = 10 * time.Millisecond
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	externalscalerPb.RegisterExternalScalerServer(srv, scaler)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := externalscalerPb.NewExternalScalerClient(conn)
+	ref := &externalscalerPb.ScaledObjectRef{Name: "model-server", Namespace: "default"}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	stream, err := client.StreamIsActive(ctx, ref)
+	require.NoError(t, err)
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.False(t, first.GetResult(), "an idle pool must not be active")
+
+	// A queued request activates the pool from zero.
+	queued.Store(1)
+	next, err := stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, next.GetResult())
+
+	metrics, err := client.GetMetrics(ctx, &externalscalerPb.GetMetricsRequest{ScaledObjectRef: ref, MetricName: desiredReplicasMetricName})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), metrics.GetMetricValues()[0].GetMetricValue())
+}
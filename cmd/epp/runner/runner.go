@@ -38,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -45,6 +46,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	configapi "github.com/llm-d/llm-d-router/apix/config/v1alpha1"
+	externalscalerPb "github.com/llm-d/llm-d-router/cmd/epp/runner/externalscaler/api/gen"
 	"github.com/llm-d/llm-d-router/internal/runnable"
 	"github.com/llm-d/llm-d-router/pkg/common"
 	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
@@ -52,6 +54,7 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/common/observability/tracing"
 	"github.com/llm-d/llm-d-router/pkg/epp/config"
 	"github.com/llm-d/llm-d-router/pkg/epp/config/loader"
+	"github.com/llm-d/llm-d-router/pkg/epp/controller"
 	"github.com/llm-d/llm-d-router/pkg/epp/datalayer"
 	"github.com/llm-d/llm-d-router/pkg/epp/datastore"
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol"
@@ -75,21 +78,28 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/fairness/roundrobin"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/edf"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/fcfs"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/sjf"
 	slodeadline "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/slodeadline"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/saturationdetector/composite"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/saturationdetector/concurrency"
+	latencydetector "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/saturationdetector/latency"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/saturationdetector/utilization"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/usagelimits"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/admitter/adaptiveconcurrency"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/admitter/latencyslo"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/admitter/probabilisticadmitter"
 	reqdataprodprefix "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/approximateprefix"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/inflightload"
 	mmproducer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/multimodal"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/orcaload"
 	preciseproducer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/preciseprefixcache"
 	latencyproducer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/predictedlatency"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/sessionid"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/loraloader"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/preadmitter/agentidentity"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/requestattributereporter"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/responsecache"
 	testresponsereceived "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/test/responsereceived"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/anthropic"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/openai"
@@ -100,7 +110,10 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/filter/bylabel"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/filter/prefixcacheaffinity"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/filter/sloheadroomtier"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/metricexpression"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker/boundedloadhash"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker/maxscore"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker/p2c"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker/random"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker/weightedrandom"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/profilehandler/dataparallel"
@@ -112,14 +125,18 @@ import (
 	latencyscorer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/latency"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/loadaware"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/loraaffinity"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/metricsfreshness"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/mmcacheaffinity"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/nohitlru"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/pdtopology"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/preciseprefixcache"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/prefix"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/queuedepth"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/runningrequests"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/sessionaffinity"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/slowstart"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/tokenload"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/trendaware"
 	testfilter "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/test/filter"
 	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
 	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
@@ -379,9 +396,29 @@ func (r *Runner) setup(ctx context.Context, cfg *rest.Config, opts *runserver.Op
 
 	endpointCandidates := contracts.EndpointCandidates(requestcontrol.NewDatastoreEndpointCandidates(ds,
 		requestcontrol.WithDisableEndpointSubsetFilter(opts.DisableEndpointSubsetFilter)))
-	endpointCandidates, admissionController, priorityBandControlPlane := r.initAdmissionControl(ctx, opts, eppConfig, endpointCandidates)
+	endpointCandidates, admissionController, priorityBandControlPlane, flowRegistry := r.initAdmissionControl(ctx, opts, eppConfig, endpointCandidates)
 
 	director := requestcontrol.NewDirectorWithConfig(ds, scheduler, admissionController, endpointCandidates, r.requestControlConfig)
+	if opts.RetryBudget > 0 {
+		director.SetRetryTracker(requestcontrol.NewRetryTracker(opts.RetryBudget, opts.RetryTrackingTTL))
+	}
+	newPoolCache := func(namespace string) (ctrlcache.Cache, error) {
+		return ctrlcache.New(cfg, ctrlcache.Options{
+			Scheme:            mgr.GetScheme(),
+			DefaultNamespaces: map[string]ctrlcache.Config{namespace: {}},
+		})
+	}
+	startSpillover, err := initSpillover(ctx, eppConfig, director, epf, newPoolCache, gknn.Namespace)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize spillover")
+		return nil, nil, err
+	}
+	if startSpillover != nil {
+		if err := mgr.Add(runnable.NoLeaderElection(manager.RunnableFunc(startSpillover))); err != nil {
+			setupLog.Error(err, "Failed to register spillover discovery runnable")
+			return nil, nil, err
+		}
+	}
 
 	serverRunner := &runserver.ExtProcServerRunner{
 		GrpcPort:                         opts.GRPCPort,
@@ -400,6 +437,8 @@ func (r *Runner) setup(ctx context.Context, cfg *rest.Config, opts *runserver.Op
 		PriorityBandControlPlane:         priorityBandControlPlane,
 		GRPCMaxRecvMsgSize:               opts.GRPCMaxRecvMsgSize,
 		GRPCMaxSendMsgSize:               opts.GRPCMaxSendMsgSize,
+		BodyMode:                         opts.ExtProcBodyMode,
+		HTTPProxyPort:                    opts.HTTPProxyPort,
 	}
 
 	if err := serverRunner.SetupWithManager(mgr); err != nil {
@@ -422,6 +461,22 @@ func (r *Runner) setup(ctx context.Context, cfg *rest.Config, opts *runserver.Op
 	if err := registerExtProcServer(mgr, serverRunner, ctrl.Log.WithName("ext-proc")); err != nil {
 		return nil, nil, err
 	}
+
+	// Register the KEDA external scaler server, if enabled.
+	if opts.ExternalScalerPort > 0 {
+		scaler := newExternalScaler(ctrl.Log.WithName("external-scaler"), ds, eppConfig.SaturationDetector, flowRegistry,
+			opts.ExternalScalerTargetSaturation)
+		if err := registerExternalScalerServer(mgr, scaler, opts.ExternalScalerPort); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Register the standalone HTTP proxy server, if enabled.
+	if opts.HTTPProxyPort > 0 {
+		if err := registerHTTPProxyServer(mgr, serverRunner); err != nil {
+			return nil, nil, err
+		}
+	}
 	return mgr, ds, nil
 }
 
@@ -491,6 +546,14 @@ func (r *Runner) registerInTreePlugins() {
 	fwkplugin.Register(loadaware.LoadAwareType, loadaware.Factory)
 	fwkplugin.Register(sessionaffinity.SessionAffinityType, sessionaffinity.Factory)
 	fwkplugin.Register(contextlengthaware.ContextLengthAwareType, contextlengthaware.Factory)
+	fwkplugin.Register(slowstart.SlowStartType, slowstart.Factory)
+	fwkplugin.Register(metricsfreshness.MetricsFreshnessType, metricsfreshness.Factory)
+	fwkplugin.Register(trendaware.TrendAwareType, trendaware.Factory)
+	fwkplugin.Register(pdtopology.PDTopologyType, pdtopology.Factory)
+
+	// metric expression scorer and filter
+	fwkplugin.Register(metricexpression.ScorerType, metricexpression.ScorerFactory)
+	fwkplugin.Register(metricexpression.FilterType, metricexpression.FilterFactory)
 
 	// data layer models source/extractor
 	fwkplugin.Register(srcmodels.ModelsDataSourceType, srcmodels.ModelDataSourceFactory)
@@ -500,6 +563,8 @@ func (r *Runner) registerInTreePlugins() {
 	fwkplugin.Register(maxscore.MaxScorePickerType, maxscore.MaxScorePickerFactory)
 	fwkplugin.Register(random.RandomPickerType, random.RandomPickerFactory)
 	fwkplugin.Register(weightedrandom.WeightedRandomPickerType, weightedrandom.WeightedRandomPickerFactory)
+	fwkplugin.Register(p2c.P2CPickerType, p2c.P2CPickerFactory)
+	fwkplugin.Register(boundedloadhash.BoundedLoadHashPickerType, boundedloadhash.BoundedLoadHashPickerFactory)
 	fwkplugin.Register(single.SingleProfileHandlerType, single.SingleProfileHandlerFactory)
 	fwkplugin.Register(disagg.DisaggHeadersHandlerType, disagg.HeadersHandlerFactory) //nolint:staticcheck // intentional: keep backward compatibility
 	fwkplugin.Register(disagg.PrefillHeaderHandlerType, disagg.HeadersHandlerFactory) //nolint:staticcheck // intentional: keep backward compatibility
@@ -525,7 +590,9 @@ func (r *Runner) registerInTreePlugins() {
 	fwkplugin.Register(fcfs.FCFSOrderingPolicyType, fcfs.FCFSOrderingPolicyFactory)
 	fwkplugin.Register(edf.EDFOrderingPolicyType, edf.EDFOrderingPolicyFactory)
 	fwkplugin.Register(slodeadline.SLODeadlineOrderingPolicyType, slodeadline.SLODeadlineOrderingPolicyFactory)
+	fwkplugin.Register(sjf.SJFOrderingPolicyType, sjf.SJFOrderingPolicyFactory)
 	fwkplugin.Register(usagelimits.StaticUsageLimitPolicyType, usagelimits.StaticPolicyFactory)
+	fwkplugin.Register(usagelimits.PriorityProportionalUsageLimitPolicyType, usagelimits.PriorityProportionalPolicyFactory)
 
 	// Register Request level data producer plugins as defaults for their respective data keys.
 	fwkplugin.RegisterAsDefaultProducer(reqdataprodprefix.ApproxPrefixCachePluginType, reqdataprodprefix.ApproxPrefixCacheFactory, attrprefix.PrefixCacheMatchInfoDataKey)
@@ -535,10 +602,12 @@ func (r *Runner) registerInTreePlugins() {
 	fwkplugin.RegisterAsDefaultProducer(tokenizer.PluginType, tokenizer.PluginFactory, tokenizer.TokenizedPromptDataKey)
 	fwkplugin.Register(tokenizer.LegacyPluginType, tokenizer.LegacyPluginFactory) //nolint:staticcheck // intentional: keep backward compatibility
 	fwkplugin.RegisterAsDefaultProducer(sessionid.SessionIDProducerType, sessionid.Factory, attrsession.SessionIDDataKey)
+	fwkplugin.Register(orcaload.OrcaLoadProducerType, orcaload.Factory)
 
 	// Latency predictor plugins
 	fwkplugin.Register(latencyslo.LatencyAdmissionPluginType, latencyslo.LatencyAdmissionFactory)
 	fwkplugin.Register(probabilisticadmitter.Type, probabilisticadmitter.Factory)
+	fwkplugin.Register(adaptiveconcurrency.Type, adaptiveconcurrency.Factory)
 
 	// Latency scoring and filtering plugins
 	fwkplugin.Register(prefixcacheaffinity.PluginType, prefixcacheaffinity.Factory)
@@ -554,11 +623,14 @@ func (r *Runner) registerInTreePlugins() {
 	// register datalayer metrics collection plugins
 	fwkplugin.Register(sourcemetrics.MetricsDataSourceType, sourcemetrics.MetricsDataSourceFactory)
 	fwkplugin.Register(extractormetrics.MetricsExtractorType, extractormetrics.CoreMetricsExtractorFactory)
+	fwkplugin.Register(extractormetrics.CustomMetricsExtractorType, extractormetrics.CustomMetricsExtractorFactory)
 	// register datalayer notification source plugins
 	fwkplugin.Register(sourcenotifications.NotificationSourceType, sourcenotifications.NotificationSourceFactory)
 	fwkplugin.Register(sourcenotifications.EndpointNotificationSourceType, sourcenotifications.EndpointSourceFactory)
 	// register request control plugins
 	fwkplugin.Register(requestattributereporter.RequestAttributeReporterType, requestattributereporter.RequestAttributeReporterPluginFactory)
+	fwkplugin.Register(responsecache.Type, responsecache.Factory)
+	fwkplugin.Register(loraloader.Type, loraloader.Factory)
 	fwkplugin.Register(anthropic.AnthropicParserType, anthropic.AnthropicParserPluginFactory)
 	fwkplugin.Register(openai.OpenAIParserType, openai.OpenAIParserPluginFactory)
 	fwkplugin.Register(vllmgrpc.VllmGRPCParserType, vllmgrpc.VllmGRPCParserPluginFactory)
@@ -568,6 +640,8 @@ func (r *Runner) registerInTreePlugins() {
 	// register saturation detector plugins
 	fwkplugin.Register(concurrency.ConcurrencyDetectorType, concurrency.ConcurrencyDetectorFactory)
 	fwkplugin.Register(utilization.UtilizationDetectorType, utilization.UtilizationDetectorFactory)
+	fwkplugin.Register(latencydetector.LatencyDetectorType, latencydetector.LatencyDetectorFactory)
+	fwkplugin.Register(composite.CompositeDetectorType, composite.CompositeDetectorFactory)
 	// register discovery plugins
 	fwkplugin.Register(discoveryfile.PluginType, discoveryfile.Factory)
 	// register pre-admission processor plugins
@@ -717,6 +791,16 @@ func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerR
 	return nil
 }
 
+// registerHTTPProxyServer adds the standalone HTTP proxy server as a Runnable to the manager.
+func registerHTTPProxyServer(mgr manager.Manager, runner *runserver.ExtProcServerRunner) error {
+	if err := mgr.Add(runner.AsHTTPProxyRunnable()); err != nil {
+		setupLog.Error(err, "Failed to register HTTP proxy server runnable")
+		return err
+	}
+	setupLog.Info("HTTP proxy server runner added to manager.", "port", runner.HTTPProxyPort)
+	return nil
+}
+
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
 func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, port int, isLeader *atomic.Bool, leaderElectionEnabled bool, supporters []appProtocolSupporter) error {
 	srv := grpc.NewServer()
@@ -735,6 +819,18 @@ func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.
 	return nil
 }
 
+// registerExternalScalerServer adds the KEDA external scaler gRPC server as a Runnable to the given manager.
+func registerExternalScalerServer(mgr manager.Manager, scaler *externalScaler, port int) error {
+	srv := grpc.NewServer()
+	externalscalerPb.RegisterExternalScalerServer(srv, scaler)
+	if err := mgr.Add(
+		runnable.NoLeaderElection(runnable.GRPCServer("external-scaler", srv, port))); err != nil {
+		setupLog.Error(err, "Failed to register external scaler server")
+		return err
+	}
+	return nil
+}
+
 func extractDeploymentName(podName string) (string, error) {
 	regex := regexp.MustCompile(`^(.+)-[a-z0-9]+-[a-z0-9]+$`)
 
@@ -815,20 +911,21 @@ func (r *Runner) resolveDiscovery(rawConfig *configapi.EndpointPickerConfig) (fw
 
 // initAdmissionControl builds the request admission controller, gated by the
 // FlowControl feature gate. With FC on it constructs the FlowRegistry and
-// FlowController and wraps endpointCandidates in a short-lived cache; with FC
-// off it returns the legacy saturation-only controller. Shared by the K8s and
+// FlowController, wraps endpointCandidates in a short-lived cache and, if
+// configured, adds scale-from-zero holding; with FC off it returns the legacy
+// saturation-only controller. Shared by the K8s and
 // file-discovery startup paths so the two cannot drift.
 func (r *Runner) initAdmissionControl(
 	ctx context.Context,
 	opts *runserver.Options,
 	eppConfig *config.Config,
 	endpointCandidates contracts.EndpointCandidates,
-) (contracts.EndpointCandidates, requestcontrol.AdmissionController, contracts.PriorityBandControlPlane) {
+) (contracts.EndpointCandidates, requestcontrol.AdmissionController, contracts.PriorityBandControlPlane, contracts.FlowRegistryObserver) {
 	if !r.featureGates[flowcontrol.FeatureGate] {
 		setupLog.Info("Experimental Flow Control layer is disabled, using legacy admission control")
 		return endpointCandidates,
 			requestcontrol.NewLegacyAdmissionController(eppConfig.SaturationDetector, endpointCandidates),
-			nil
+			nil, nil
 	}
 	endpointCandidates = requestcontrol.NewCachedEndpointCandidates(ctx, endpointCandidates, 50*time.Millisecond)
 	setupLog.Info("Initializing experimental Flow Control layer")
@@ -844,7 +941,46 @@ func (r *Runner) initAdmissionControl(
 			UsageLimitPolicy:   eppConfig.FlowControlConfig.UsageLimitPolicy,
 		},
 	)
-	return endpointCandidates, requestcontrol.NewFlowControlAdmissionController(fc, opts.PoolName), registry
+	var admissionController requestcontrol.AdmissionController = requestcontrol.NewFlowControlAdmissionController(fc, opts.PoolName)
+	if sfz := eppConfig.FlowControlConfig.ScaleFromZero; sfz != nil {
+		setupLog.Info("Scale-from-zero request holding is enabled", "config", sfz)
+		admissionController = requestcontrol.NewScaleFromZeroAdmissionController(admissionController, endpointCandidates, opts.PoolName, *sfz)
+	}
+	return endpointCandidates, admissionController, registry, registry
+}
+
+// initSpillover enables spillover on the director when it is configured, and returns the function that runs the
+// discovery of the spillover endpoints, or nil if spillover is disabled. The spillover endpoints are created by epf,
+// which scrapes their metrics until ctx is done. newPoolCache creates the cache watching the namespace of a secondary
+// InferencePool; it is nil outside Kubernetes, where only a discovery plugin can list the spillover endpoints.
+func initSpillover(ctx context.Context, eppConfig *config.Config, director *requestcontrol.Director, epf datalayer.EndpointFactory,
+	newPoolCache func(namespace string) (ctrlcache.Cache, error), namespace string) (func(context.Context) error, error) {
+	sc := eppConfig.Spillover
+	if sc == nil {
+		return nil, nil
+	}
+	discovery := sc.Discovery
+	if sc.PoolName != "" {
+		if newPoolCache == nil {
+			return nil, errors.New("spillover to an InferencePool requires Kubernetes endpoint discovery, use a discovery plugin instead")
+		}
+		pool := types.NamespacedName{Name: sc.PoolName, Namespace: sc.PoolNamespace}
+		if pool.Namespace == "" {
+			pool.Namespace = namespace
+		}
+		poolCache, err := newPoolCache(pool.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the cache for the spillover InferencePool - %w", err)
+		}
+		discovery = controller.NewInferencePoolDiscovery(poolCache, pool)
+	}
+
+	endpoints := requestcontrol.NewSpilloverEndpoints(ctx, epf)
+	director.SetSpillover(requestcontrol.NewSpillover(endpoints, eppConfig.SaturationDetector, sc.Objectives))
+	setupLog.Info("Spillover is enabled", "discovery", discovery.TypedName(), "objectives", sc.Objectives)
+	return func(ctx context.Context) error {
+		return discovery.Start(ctx, fwkdl.NewDiscoveryNotifier(endpoints))
+	}, nil
 }
 
 // runWithFileDiscovery handles the execution path when a discovery plugin is configured.
@@ -921,8 +1057,16 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 		requestcontrol.WithDisableEndpointSubsetFilter(opts.DisableEndpointSubsetFilter)))
 	// File-discovery mode has no InferenceObjective reconciler to drive the
 	// control plane; static bands from config apply at registry construction.
-	endpointCandidates, admissionController, _ := r.initAdmissionControl(ctx, opts, eppConfig, endpointCandidates)
+	endpointCandidates, admissionController, _, flowRegistry := r.initAdmissionControl(ctx, opts, eppConfig, endpointCandidates)
 	director := requestcontrol.NewDirectorWithConfig(ds, scheduler, admissionController, endpointCandidates, r.requestControlConfig)
+	if opts.RetryBudget > 0 {
+		director.SetRetryTracker(requestcontrol.NewRetryTracker(opts.RetryBudget, opts.RetryTrackingTTL))
+	}
+	startSpillover, err := initSpillover(ctx, eppConfig, director, epf, nil, namespace)
+	if err != nil {
+		setupLog.Error(err, "Failed to initialize spillover")
+		return err
+	}
 
 	gknn := common.GKNN{
 		NamespacedName: types.NamespacedName{Name: poolName, Namespace: namespace},
@@ -943,6 +1087,8 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 		SaturationDetector:               eppConfig.SaturationDetector,
 		GRPCMaxRecvMsgSize:               opts.GRPCMaxRecvMsgSize,
 		GRPCMaxSendMsgSize:               opts.GRPCMaxSendMsgSize,
+		BodyMode:                         opts.ExtProcBodyMode,
+		HTTPProxyPort:                    opts.HTTPProxyPort,
 	}
 
 	r.customCollectors = append(r.customCollectors, collectors.NewInferencePoolMetricsCollector(ds))
@@ -951,6 +1097,7 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 
 	setupLog.Info("EPP starting (file discovery mode)",
 		"grpcPort", opts.GRPCPort,
+		"httpProxyPort", opts.HTTPProxyPort,
 		"pool", poolName,
 		"namespace", namespace,
 		"discoveryPlugin", disc.TypedName())
@@ -976,6 +1123,9 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 	g.Add("discovery", func(ctx context.Context) error {
 		return disc.Start(ctx, fwkdl.NewDiscoveryNotifier(ds))
 	})
+	if startSpillover != nil {
+		g.Add("spillover-discovery", startSpillover)
+	}
 	// epp-server and health wait for the discovery plugin's initial sync before
 	// going live, so requests and probes never observe an empty datastore. See
 	// EndpointDiscovery.Ready contract.
@@ -987,6 +1137,16 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 		}
 		return serverRunner.AsRunnable(ctrl.Log.WithName("ext-proc")).Start(ctx)
 	})
+	if opts.HTTPProxyPort > 0 {
+		g.Add("http-proxy", func(ctx context.Context) error {
+			select {
+			case <-disc.Ready():
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return serverRunner.AsHTTPProxyRunnable().Start(ctx)
+		})
+	}
 	g.Add("health", func(ctx context.Context) error {
 		select {
 		case <-disc.Ready():
@@ -995,6 +1155,19 @@ func (r *Runner) runWithFileDiscovery(ctx context.Context, opts *runserver.Optio
 		}
 		return runnable.NoLeaderElection(runnable.GRPCServer("health", healthSrv, opts.GRPCHealthPort)).Start(ctx)
 	})
+	if opts.ExternalScalerPort > 0 {
+		scalerSrv := grpc.NewServer()
+		externalscalerPb.RegisterExternalScalerServer(scalerSrv, newExternalScaler(ctrl.Log.WithName("external-scaler"), ds,
+			eppConfig.SaturationDetector, flowRegistry, opts.ExternalScalerTargetSaturation))
+		g.Add("external-scaler", func(ctx context.Context) error {
+			select {
+			case <-disc.Ready():
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return runnable.NoLeaderElection(runnable.GRPCServer("external-scaler", scalerSrv, opts.ExternalScalerPort)).Start(ctx)
+		})
+	}
 	g.Add("metrics", func(ctx context.Context) error {
 		return serveMetrics(ctx, opts.MetricsPort, opts.EnablePprof)
 	})
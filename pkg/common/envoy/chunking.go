@@ -72,3 +72,16 @@ func BuildChunkedBodyResponses(bodyBytes []byte, setEos bool) []*extProcPb.Commo
 
 	return responses
 }
+
+// BuildReplacedBodyResponse returns a CommonResponse that replaces the whole body with bodyBytes.
+// Unlike BuildChunkedBodyResponses, this is the shape expected by the BUFFERED and STREAMED body modes, where the
+// proxy expects a single response per body message.
+func BuildReplacedBodyResponse(bodyBytes []byte) *extProcPb.CommonResponse {
+	return &extProcPb.CommonResponse{
+		BodyMutation: &extProcPb.BodyMutation{
+			Mutation: &extProcPb.BodyMutation_Body{
+				Body: bodyBytes,
+			},
+		},
+	}
+}
@@ -78,6 +78,18 @@ func TestBuildChunkedBodyResponses(t *testing.T) {
 	}
 }
 
+func TestBuildReplacedBodyResponse(t *testing.T) {
+	// The replaced body is never split, even above the streamed chunk limit.
+	arr := generateBytes(BodyByteLimit * 2)
+	response := BuildReplacedBodyResponse(arr)
+	if got := response.BodyMutation.GetBody(); len(got) != len(arr) {
+		t.Fatalf("Expected body of %v bytes, Got %v", len(arr), len(got))
+	}
+	if response.BodyMutation.GetStreamedResponse() != nil {
+		t.Fatalf("Streamed response should not be set")
+	}
+}
+
 func generateBytes(count int) []byte {
 	arr := make([]byte, count)
 	_, _ = rand.Read(arr)
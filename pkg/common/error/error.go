@@ -18,6 +18,7 @@ package error
 
 import (
 	"fmt"
+	"net/http"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
@@ -80,32 +81,40 @@ func CanonicalCode(err error) string {
 	return Unknown
 }
 
-// BuildErrResponse maps an error to an Envoy ImmediateResponse with the appropriate
-// HTTP status code and error message body. If the error code is not recognized,
-// it returns a gRPC error instead of an ImmediateResponse.
-func BuildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
-	var httpCode envoyTypePb.StatusCode
-
+// HTTPStatusCode maps an error to the HTTP status code returned to the client.
+// The second return value is false if the error code is not recognized.
+func HTTPStatusCode(err error) (int, bool) {
 	switch CanonicalCode(err) {
 	case BadRequest:
-		httpCode = envoyTypePb.StatusCode_BadRequest
+		return http.StatusBadRequest, true
 	case Unauthorized:
-		httpCode = envoyTypePb.StatusCode_Unauthorized
+		return http.StatusUnauthorized, true
 	case Forbidden:
-		httpCode = envoyTypePb.StatusCode_Forbidden
+		return http.StatusForbidden, true
 	case NotFound:
-		httpCode = envoyTypePb.StatusCode_NotFound
+		return http.StatusNotFound, true
 	case PreconditionFailed:
-		httpCode = envoyTypePb.StatusCode_PreconditionFailed
+		return http.StatusPreconditionFailed, true
 	case ResourceExhausted:
-		httpCode = envoyTypePb.StatusCode_TooManyRequests
+		return http.StatusTooManyRequests, true
 	case Internal:
-		httpCode = envoyTypePb.StatusCode_InternalServerError
+		return http.StatusInternalServerError, true
 	case ServiceUnavailable:
-		httpCode = envoyTypePb.StatusCode_ServiceUnavailable
+		return http.StatusServiceUnavailable, true
 	default:
+		return 0, false
+	}
+}
+
+// BuildErrResponse maps an error to an Envoy ImmediateResponse with the appropriate
+// HTTP status code and error message body. If the error code is not recognized,
+// it returns a gRPC error instead of an ImmediateResponse.
+func BuildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
+	code, ok := HTTPStatusCode(err)
+	if !ok {
 		return nil, status.Errorf(status.Code(err), "failed to handle request: %v", err)
 	}
+	httpCode := envoyTypePb.StatusCode(code)
 
 	ir := &extProcPb.ImmediateResponse{
 		Status: &envoyTypePb.HttpStatus{
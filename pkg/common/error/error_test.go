@@ -18,6 +18,7 @@ package error
 
 import (
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -343,3 +344,31 @@ func TestBuildErrResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantOK   bool
+	}{
+		{name: "BadRequest", err: Error{Code: BadRequest}, wantCode: http.StatusBadRequest, wantOK: true},
+		{name: "PreconditionFailed", err: Error{Code: PreconditionFailed}, wantCode: http.StatusPreconditionFailed, wantOK: true},
+		{name: "ResourceExhausted", err: Error{Code: ResourceExhausted}, wantCode: http.StatusTooManyRequests, wantOK: true},
+		{name: "ServiceUnavailable", err: Error{Code: ServiceUnavailable}, wantCode: http.StatusServiceUnavailable, wantOK: true},
+		{name: "ModelServerError is not mapped", err: Error{Code: ModelServerError}, wantOK: false},
+		{name: "plain error is not mapped", err: errors.New("unknown problem"), wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := HTTPStatusCode(tt.err)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if code != tt.wantCode {
+				t.Errorf("code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -18,4 +18,6 @@ package request
 
 const (
 	RequestIDHeaderKey = "x-request-id"
+	// AttemptCountHeaderKey carries the attempt number of a request retried by Envoy, starting at 1.
+	AttemptCountHeaderKey = "x-envoy-attempt-count"
 )
@@ -21,6 +21,7 @@ import (
 
 	"github.com/llm-d/llm-d-router/pkg/epp/datalayer"
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
 	fwkfc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
 	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
 	"github.com/llm-d/llm-d-router/pkg/epp/scheduling"
@@ -33,6 +34,19 @@ type Config struct {
 	DataConfig         *datalayer.Config
 	FlowControlConfig  *flowcontrol.Config
 	ParserRegistry     *handlers.ParserRegistry
+	Spillover          *SpilloverConfig
+}
+
+// SpilloverConfig is the loaded spillover configuration. Exactly one of PoolName and Discovery is set.
+type SpilloverConfig struct {
+	// PoolName and PoolNamespace identify the secondary InferencePool. An empty PoolNamespace stands for the
+	// namespace of the InferencePool of the EPP.
+	PoolName      string
+	PoolNamespace string
+	// Discovery lists the spillover endpoints in lieu of a secondary InferencePool.
+	Discovery fwkdl.EndpointDiscovery
+	// Objectives are the InferenceObjectives whose requests may spill over.
+	Objectives []string
 }
 
 func (c *Config) String() string {
@@ -194,15 +194,39 @@ func InstantiateAndConfigure(
 		return nil, fmt.Errorf("plugin '%s' is not a fwkfc.SaturationDetector", rawConfig.FlowControl.SaturationDetector.PluginRef)
 	}
 
+	spilloverConfig, err := buildSpilloverConfig(rawConfig.Spillover, handle)
+	if err != nil {
+		return nil, fmt.Errorf("spillover config build failed: %w", err)
+	}
+
 	return &config.Config{
 		SchedulerConfig:    schedulerConfig,
 		SaturationDetector: saturationDetector,
 		DataConfig:         dataConfig,
 		FlowControlConfig:  flowControlConfig,
 		ParserRegistry:     parserRegistry,
+		Spillover:          spilloverConfig,
 	}, nil
 }
 
+func buildSpilloverConfig(rawSpillover *configapi.SpilloverConfig, handle fwkplugin.Handle) (*config.SpilloverConfig, error) {
+	if rawSpillover == nil {
+		return nil, nil
+	}
+	cfg := &config.SpilloverConfig{Objectives: rawSpillover.Objectives}
+	if rawSpillover.PoolRef != nil {
+		cfg.PoolName = rawSpillover.PoolRef.Name
+		cfg.PoolNamespace = rawSpillover.PoolRef.Namespace
+		return cfg, nil
+	}
+	discovery, ok := handle.Plugin(rawSpillover.DiscoveryPluginRef).(fwkdl.EndpointDiscovery)
+	if !ok {
+		return nil, fmt.Errorf("plugin '%s' is not an EndpointDiscovery", rawSpillover.DiscoveryPluginRef)
+	}
+	cfg.Discovery = discovery
+	return cfg, nil
+}
+
 func decodeRawConfig(configBytes []byte) (*configapi.EndpointPickerConfig, error) {
 	cfg := &configapi.EndpointPickerConfig{}
 	codecs := serializer.NewCodecFactory(scheme, serializer.EnableStrict)
@@ -247,6 +271,17 @@ func buildSchedulerConfig(
 
 	for _, cfgProfile := range configProfiles {
 		fwProfile := scheduling.NewSchedulerProfile()
+		if agg := cfgProfile.ScoreAggregation; agg != nil && agg.Strategy != "" && agg.Strategy != scheduling.WeightedSumAggregation {
+			epsilon := 0.0
+			if agg.Epsilon != nil {
+				epsilon = *agg.Epsilon
+			}
+			aggregator, err := scheduling.NewScoreAggregator(agg.Strategy, epsilon)
+			if err != nil {
+				return nil, fmt.Errorf("invalid score aggregation in profile '%s': %w", cfgProfile.Name, err)
+			}
+			fwProfile.WithScoreAggregator(aggregator)
+		}
 
 		for _, pluginRef := range cfgProfile.Plugins {
 			plugin := handle.Plugin(pluginRef.PluginRef)
@@ -67,6 +67,7 @@ const (
 	testProfileHandler = "test-profile-handler"
 	testSourceType     = "test-source"
 	testExtractorType  = "test-extractor"
+	testDiscoveryType  = "test-discovery"
 )
 
 // --- Test: Phase 1 (Raw Loading & Static Defaults) ---
@@ -404,6 +405,21 @@ func TestInstantiateAndConfigure(t *testing.T) {
 				require.Equal(t, 1.0, *w, "Expected default scorer weight of 1.0")
 			},
 		},
+		{
+			name:       "Success - Score Aggregation",
+			configText: successScoreAggregationText,
+			wantErr:    false,
+			validate: func(t *testing.T, _ fwkplugin.Handle, rawCfg *configapi.EndpointPickerConfig, cfg *config.Config) {
+				require.Len(t, rawCfg.SchedulingProfiles, 1)
+				agg := rawCfg.SchedulingProfiles[0].ScoreAggregation
+				require.NotNil(t, agg, "ScoreAggregation should be parsed")
+				require.Equal(t, "lexicographic", agg.Strategy)
+				require.NotNil(t, agg.Epsilon)
+				require.Equal(t, 0.05, *agg.Epsilon)
+				require.Contains(t, cfg.SchedulerConfig.String(), "Aggregation: lexicographic",
+					"Profile should use the configured aggregation strategy")
+			},
+		},
 		{
 			name:       "Success - Default Profile Handler Injection",
 			configText: successWithNoProfileHandlersText,
@@ -427,6 +443,66 @@ func TestInstantiateAndConfigure(t *testing.T) {
 				require.Equal(t, 1.0, *scorerWeight, "Scorer weight should default to 1.0")
 			},
 		},
+		{
+			name:       "Success - Scale From Zero Config",
+			configText: successScaleFromZeroConfigText,
+			wantErr:    false,
+			validate: func(t *testing.T, _ fwkplugin.Handle, _ *configapi.EndpointPickerConfig, cfg *config.Config) {
+				require.NotNil(t, cfg.FlowControlConfig, "FlowControl config should have been loaded")
+				require.NotNil(t, cfg.FlowControlConfig.ScaleFromZero, "ScaleFromZero config should have been loaded")
+				require.Equal(t, 2*time.Minute, cfg.FlowControlConfig.ScaleFromZero.HoldTimeout)
+				require.Equal(t, 100, cfg.FlowControlConfig.ScaleFromZero.MaxHeldRequests)
+				require.Equal(t, "http://activator.example/activate", cfg.FlowControlConfig.ScaleFromZero.ActivationURL)
+			},
+		},
+		{
+			name:       "Error - Invalid Scale From Zero Activation URL",
+			configText: errorScaleFromZeroActivationURLText,
+			wantErr:    true,
+		},
+		{
+			name:       "Error - Scale From Zero Without Flow Control FeatureGate",
+			configText: errorScaleFromZeroWithoutFlowControlText,
+			wantErr:    true,
+		},
+		{
+			name:       "Success - Spillover To Pool",
+			configText: successSpilloverPoolText,
+			wantErr:    false,
+			validate: func(t *testing.T, _ fwkplugin.Handle, _ *configapi.EndpointPickerConfig, cfg *config.Config) {
+				require.NotNil(t, cfg.Spillover, "Spillover config should have been loaded")
+				require.Equal(t, "secondary-pool", cfg.Spillover.PoolName)
+				require.Equal(t, "other", cfg.Spillover.PoolNamespace)
+				require.Nil(t, cfg.Spillover.Discovery)
+				require.Equal(t, []string{"chat", "batch"}, cfg.Spillover.Objectives)
+			},
+		},
+		{
+			name:       "Success - Spillover To Discovered Endpoints",
+			configText: successSpilloverDiscoveryText,
+			wantErr:    false,
+			validate: func(t *testing.T, _ fwkplugin.Handle, _ *configapi.EndpointPickerConfig, cfg *config.Config) {
+				require.NotNil(t, cfg.Spillover, "Spillover config should have been loaded")
+				require.NotNil(t, cfg.Spillover.Discovery, "Spillover discovery should have been resolved")
+				require.Equal(t, "static-endpoints", cfg.Spillover.Discovery.TypedName().Name)
+				require.Empty(t, cfg.Spillover.PoolName)
+			},
+		},
+		{
+			name:       "Error - Spillover With Pool And Discovery",
+			configText: errorSpilloverPoolAndDiscoveryText,
+			wantErr:    true,
+		},
+		{
+			name:       "Error - Spillover Without Objectives",
+			configText: errorSpilloverNoObjectivesText,
+			wantErr:    true,
+		},
+		{
+			name:       "Error - Spillover Discovery Is Not An EndpointDiscovery",
+			configText: errorSpilloverNotDiscoveryText,
+			wantErr:    true,
+		},
 		{
 			name:       "Success - Flow Control Config",
 			configText: successFlowControlConfigText,
@@ -617,6 +693,16 @@ func TestInstantiateAndConfigure(t *testing.T) {
 			configText: errorDuplicateProfileText,
 			wantErr:    true,
 		},
+		{
+			name:       "Error (Deep Validation) - Unknown Score Aggregation Strategy",
+			configText: errorUnknownScoreAggregationText,
+			wantErr:    true,
+		},
+		{
+			name:       "Error (Deep Validation) - Negative Score Aggregation Epsilon",
+			configText: errorNegativeScoreAggregationEpsilonText,
+			wantErr:    true,
+		},
 
 		// --- Feature Validation: Scheduling ---
 		{
@@ -845,6 +931,21 @@ func (m *mockSaturationDetector) Saturation(ctx context.Context, endpoints []fwk
 	return 0.5
 }
 
+// Mock EndpointDiscovery
+type mockDiscovery struct{ mockPlugin }
+
+// compile-time type assertion
+var _ fwkdl.EndpointDiscovery = &mockDiscovery{}
+
+func (m *mockDiscovery) Start(ctx context.Context, _ fwkdl.DiscoveryNotifier) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (m *mockDiscovery) Ready() <-chan struct{} {
+	return nil
+}
+
 func (m *mockSource) Collect(_ context.Context, _ fwkdl.Endpoint) error {
 	return nil
 }
@@ -910,6 +1011,10 @@ func registerTestPlugins(t *testing.T) {
 		return &mockExtractor{mockPlugin{t: fwkplugin.TypedName{Name: name, Type: testExtractorType}}}, nil
 	})
 
+	fwkplugin.Register(testDiscoveryType, func(name string, _ *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+		return &mockDiscovery{mockPlugin{t: fwkplugin.TypedName{Name: name, Type: testDiscoveryType}}}, nil
+	})
+
 	fwkplugin.Register(globalstrict.GlobalStrictFairnessPolicyType, func(name string, _ *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
 		return &fwkfcmocks.MockFairnessPolicy{
 			TypedNameV: fwkplugin.TypedName{Name: name, Type: globalstrict.GlobalStrictFairnessPolicyType},
@@ -17,7 +17,10 @@ limitations under the License.
 package loader
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"slices"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 
@@ -58,8 +61,58 @@ func buildFlowControlConfig(
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve usage limit policy: %w", err)
 	}
+	if err := validateUsageLimitPolicy(usageLimitPolicy, apiConfig); err != nil {
+		return nil, fmt.Errorf("invalid usage limit policy '%s': %w", usageLimitRef, err)
+	}
+
+	cfg := flowcontrol.NewConfig(ctrlCfg, registryConfig, usageLimitPolicy)
+	if apiConfig != nil {
+		cfg.ScaleFromZero, err = flowcontrol.NewScaleFromZeroConfigFromAPI(apiConfig.ScaleFromZero)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scale-from-zero config: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// usageLimitProbeSaturations are the saturation levels at which the usage limit policy is probed during validation.
+var usageLimitProbeSaturations = []float64{0.0, 0.5, 1.0}
 
-	return flowcontrol.NewConfig(ctrlCfg, registryConfig, usageLimitPolicy), nil
+// validateUsageLimitPolicy probes the usage limit policy with the configured priority levels (plus a representative
+// sheddable/standard/critical domain, since unconfigured priorities are served by the default band) and checks that
+// it returns one ceiling per priority, that every ceiling is in [0.0, 1.0], and that a higher priority is never given
+// a lower ceiling than a lower priority.
+func validateUsageLimitPolicy(policy fwkfc.UsageLimitPolicy, apiConfig *configapi.FlowControlConfig) error {
+	priorities := []int{1, 0, -1}
+	if apiConfig != nil {
+		for _, band := range apiConfig.PriorityBands {
+			priorities = append(priorities, band.Priority)
+		}
+	}
+	// ComputeLimit expects the active priorities ordered highest first.
+	slices.Sort(priorities)
+	priorities = slices.Compact(priorities)
+	slices.Reverse(priorities)
+
+	ctx := context.Background()
+	for _, saturation := range usageLimitProbeSaturations {
+		ceilings := policy.ComputeLimit(ctx, saturation, priorities)
+		if len(ceilings) != len(priorities) {
+			return fmt.Errorf("returned %d ceilings for %d priorities at saturation %v",
+				len(ceilings), len(priorities), saturation)
+		}
+		for i, ceiling := range ceilings {
+			if math.IsNaN(ceiling) || ceiling < 0 || ceiling > 1 {
+				return fmt.Errorf("ceiling %v for priority %d at saturation %v must be in [0.0, 1.0]",
+					ceiling, priorities[i], saturation)
+			}
+			if i > 0 && ceiling > ceilings[i-1] {
+				return fmt.Errorf("ceiling %v for priority %d exceeds ceiling %v for higher priority %d at saturation %v",
+					ceiling, priorities[i], ceilings[i-1], priorities[i-1], saturation)
+			}
+		}
+	}
+	return nil
 }
 
 func buildPriorityBandPolicyDefaults(handle fwkplugin.Handle) (registry.PriorityBandPolicyDefaults, error) {
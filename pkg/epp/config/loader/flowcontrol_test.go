@@ -18,6 +18,7 @@ package loader
 
 import (
 	"context"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -563,6 +564,129 @@ func TestBuildFlowControlConfig_Errors(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "non-existent-policy")
 	})
+
+	t.Run("Error - UsageLimitPolicy inverts priority ordering", func(t *testing.T) {
+		t.Parallel()
+		const invertedPolicyName = "inverted-policy"
+		invertedHandle := newFlowControlTestHandle(t)
+		invertedHandle.AddPlugin(invertedPolicyName, usagelimits.NewPolicyFunc(invertedPolicyName,
+			func(_ context.Context, _ float64, priorities []int) []float64 {
+				result := make([]float64, len(priorities))
+				for i := range result {
+					result[i] = 0.5 + 0.1*float64(i)
+				}
+				return result
+			}))
+		_, err := buildFlowControlConfig(&configapi.FlowControlConfig{
+			UsageLimitPolicyPluginRef: invertedPolicyName,
+		}, invertedHandle)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), invertedPolicyName)
+		assert.Contains(t, err.Error(), "exceeds ceiling")
+	})
+}
+
+func TestValidateUsageLimitPolicy(t *testing.T) {
+	t.Parallel()
+
+	proportional, err := usagelimits.NewPriorityProportionalPolicy("proportional", 0.7, usagelimits.SpacingGeometric, 1.0/3.0)
+	require.NoError(t, err)
+
+	newFunc := func(f func(priorities []int) []float64) fwkfc.UsageLimitPolicy {
+		return usagelimits.NewPolicyFunc("func", func(_ context.Context, _ float64, priorities []int) []float64 {
+			return f(priorities)
+		})
+	}
+
+	testCases := []struct {
+		name        string
+		policy      fwkfc.UsageLimitPolicy
+		apiConfig   *configapi.FlowControlConfig
+		expectedErr string
+	}{
+		{
+			name:   "static policy",
+			policy: usagelimits.DefaultPolicy(),
+		},
+		{
+			name:   "priority-proportional policy with configured bands",
+			policy: proportional,
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBandConfig{{Priority: 100}, {Priority: 0}, {Priority: -100}},
+			},
+		},
+		{
+			name: "wrong number of ceilings",
+			policy: newFunc(func(_ []int) []float64 {
+				return []float64{1.0}
+			}),
+			expectedErr: "returned 1 ceilings for 3 priorities",
+		},
+		{
+			name: "ceiling above one",
+			policy: newFunc(func(priorities []int) []float64 {
+				result := make([]float64, len(priorities))
+				for i := range result {
+					result[i] = 1.5
+				}
+				return result
+			}),
+			expectedErr: "must be in [0.0, 1.0]",
+		},
+		{
+			name: "negative ceiling",
+			policy: newFunc(func(priorities []int) []float64 {
+				result := make([]float64, len(priorities))
+				for i := range result {
+					result[i] = -0.1
+				}
+				return result
+			}),
+			expectedErr: "must be in [0.0, 1.0]",
+		},
+		{
+			name: "NaN ceiling",
+			policy: newFunc(func(priorities []int) []float64 {
+				result := make([]float64, len(priorities))
+				for i := range result {
+					result[i] = math.NaN()
+				}
+				return result
+			}),
+			expectedErr: "must be in [0.0, 1.0]",
+		},
+		{
+			name: "lower priority exceeds higher priority",
+			policy: newFunc(func(priorities []int) []float64 {
+				result := make([]float64, len(priorities))
+				for i := range result {
+					if priorities[i] == 5 {
+						result[i] = 0.9
+					} else {
+						result[i] = 1.0
+					}
+				}
+				return result
+			}),
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityBands: []configapi.PriorityBandConfig{{Priority: 5}},
+			},
+			expectedErr: "exceeds ceiling 0.9 for higher priority 5",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateUsageLimitPolicy(tc.policy, tc.apiConfig)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
 }
 
 func TestBuildPriorityBandPolicyDefaults(t *testing.T) {
@@ -145,6 +145,26 @@ schedulingProfiles:
   - pluginRef: testScorer
 `
 
+// successScoreAggregationText selects a non-default score aggregation strategy.
+const successScoreAggregationText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: profileHandler
+  type: single-profile-handler
+- name: testScorer
+  type: test-scorer
+  parameters:
+    blockSize: 32
+schedulingProfiles:
+- name: default
+  scoreAggregation:
+    strategy: lexicographic
+    epsilon: 0.05
+  plugins:
+  - pluginRef: testScorer
+`
+
 // successWithNoProfileHandlersText tests that a default profile handler is injected.
 const successWithNoProfileHandlersText = `
 apiVersion: llm-d.ai/v1alpha1
@@ -192,6 +212,153 @@ flowControl:
   defaultRequestTTL: 1m
 `
 
+// successScaleFromZeroConfigText tests that the scale-from-zero configuration is correctly loaded.
+const successScaleFromZeroConfigText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+featureGates:
+- flowControl
+flowControl:
+  scaleFromZero:
+    holdTimeout: 2m
+    maxHeldRequests: 100
+    activationURL: http://activator.example/activate
+`
+
+// errorScaleFromZeroActivationURLText has an activation URL that is not an absolute http(s) URL.
+const errorScaleFromZeroActivationURLText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+featureGates:
+- flowControl
+flowControl:
+  scaleFromZero:
+    activationURL: activator/activate
+`
+
+// successSpilloverPoolText tests that a spillover to a secondary InferencePool is correctly loaded.
+const successSpilloverPoolText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+spillover:
+  poolRef:
+    name: secondary-pool
+    namespace: other
+  objectives:
+  - chat
+  - batch
+`
+
+// successSpilloverDiscoveryText tests that a spillover to endpoints listed by a discovery plugin is correctly loaded.
+const successSpilloverDiscoveryText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+- name: static-endpoints
+  type: test-discovery
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+spillover:
+  discoveryPluginRef: static-endpoints
+  objectives:
+  - chat
+`
+
+// errorSpilloverPoolAndDiscoveryText sets both spillover targets.
+const errorSpilloverPoolAndDiscoveryText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+- name: static-endpoints
+  type: test-discovery
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+spillover:
+  poolRef:
+    name: secondary-pool
+  discoveryPluginRef: static-endpoints
+  objectives:
+  - chat
+`
+
+// errorSpilloverNoObjectivesText has no objective opted in to spillover.
+const errorSpilloverNoObjectivesText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+spillover:
+  poolRef:
+    name: secondary-pool
+`
+
+// errorSpilloverNotDiscoveryText references a plugin that is not an EndpointDiscovery.
+const errorSpilloverNotDiscoveryText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+spillover:
+  discoveryPluginRef: maxScore
+  objectives:
+  - chat
+`
+
+// errorScaleFromZeroWithoutFlowControlText configures scale-from-zero without the flowControl feature gate.
+const errorScaleFromZeroWithoutFlowControlText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: maxScore
+  type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: maxScore
+flowControl:
+  scaleFromZero:
+    holdTimeout: 2m
+`
+
 const successflowControlConfigDisabledText = `
 apiVersion: llm-d.ai/v1alpha1
 kind: EndpointPickerConfig
@@ -404,6 +571,45 @@ schedulingProfiles:
   - pluginRef: non-existent-plugin
 `
 
+// errorUnknownScoreAggregationText selects a score aggregation strategy that doesn't exist.
+const errorUnknownScoreAggregationText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: profileHandler
+  type: single-profile-handler
+- name: testScorer
+  type: test-scorer
+  parameters:
+    blockSize: 32
+schedulingProfiles:
+- name: default
+  scoreAggregation:
+    strategy: median
+  plugins:
+  - pluginRef: testScorer
+`
+
+// errorNegativeScoreAggregationEpsilonText sets a negative lexicographic tolerance.
+const errorNegativeScoreAggregationEpsilonText = `
+apiVersion: llm-d.ai/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: profileHandler
+  type: single-profile-handler
+- name: testScorer
+  type: test-scorer
+  parameters:
+    blockSize: 32
+schedulingProfiles:
+- name: default
+  scoreAggregation:
+    strategy: lexicographic
+    epsilon: -0.1
+  plugins:
+  - pluginRef: testScorer
+`
+
 // errorDuplicatePluginText defines the same plugin name twice.
 const errorDuplicatePluginText = `
 apiVersion: llm-d.ai/v1alpha1
@@ -23,6 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	configapi "github.com/llm-d/llm-d-router/apix/config/v1alpha1"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/scheduling"
 )
 
 // validateConfig performs a deep validation of the configuration integrity.
@@ -40,6 +42,50 @@ func validateConfig(cfg *configapi.EndpointPickerConfig) error {
 	if err := validateParsers(cfg); err != nil {
 		return fmt.Errorf("parser validation failed: %w", err)
 	}
+	if err := validateSpillover(cfg); err != nil {
+		return fmt.Errorf("spillover validation failed: %w", err)
+	}
+	if err := validateScaleFromZero(cfg); err != nil {
+		return fmt.Errorf("scale-from-zero validation failed: %w", err)
+	}
+	return nil
+}
+
+// validateScaleFromZero rejects a scaleFromZero block that would be silently
+// ignored because requests are only held in the Flow Control layer.
+func validateScaleFromZero(cfg *configapi.EndpointPickerConfig) error {
+	if cfg.FlowControl == nil || cfg.FlowControl.ScaleFromZero == nil {
+		return nil
+	}
+	if !loadFeatureConfig(cfg.FeatureGates)[flowcontrol.FeatureGate] {
+		return fmt.Errorf("scaleFromZero requires the '%s' feature gate", flowcontrol.FeatureGate)
+	}
+	return nil
+}
+
+func validateSpillover(cfg *configapi.EndpointPickerConfig) error {
+	sc := cfg.Spillover
+	if sc == nil {
+		return nil
+	}
+	if (sc.PoolRef == nil) == (sc.DiscoveryPluginRef == "") {
+		return errors.New("exactly one of 'poolRef' and 'discoveryPluginRef' must be set")
+	}
+	if sc.PoolRef != nil && sc.PoolRef.Name == "" {
+		return errors.New("poolRef is missing a name")
+	}
+	if sc.DiscoveryPluginRef != "" {
+		definedPlugins := sets.New[string]()
+		for _, p := range cfg.Plugins {
+			definedPlugins.Insert(p.Name)
+		}
+		if !definedPlugins.Has(sc.DiscoveryPluginRef) {
+			return fmt.Errorf("spillover references undefined plugin '%s'", sc.DiscoveryPluginRef)
+		}
+	}
+	if len(sc.Objectives) == 0 {
+		return errors.New("at least one objective must opt in to spillover")
+	}
 	return nil
 }
 
@@ -98,6 +144,15 @@ func validateSchedulingProfiles(cfg *configapi.EndpointPickerConfig) error {
 		}
 		seenProfileNames.Insert(profile.Name)
 
+		if agg := profile.ScoreAggregation; agg != nil {
+			if agg.Strategy != "" && !scheduling.IsValidScoreAggregationStrategy(agg.Strategy) {
+				return fmt.Errorf("schedulingProfiles[%s] has unknown score aggregation strategy '%s'", profile.Name, agg.Strategy)
+			}
+			if agg.Epsilon != nil && !(*agg.Epsilon >= 0) {
+				return fmt.Errorf("schedulingProfiles[%s] has negative score aggregation epsilon %v", profile.Name, *agg.Epsilon)
+			}
+		}
+
 		for j, pluginRef := range profile.Plugins {
 			if pluginRef.PluginRef == "" {
 				return fmt.Errorf("schedulingProfiles[%s].plugins[%d] is missing a 'pluginRef'", profile.Name, j)
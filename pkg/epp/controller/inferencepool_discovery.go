@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"strconv"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	podutil "github.com/llm-d/llm-d-router/pkg/epp/util/pod"
+	poolutil "github.com/llm-d/llm-d-router/pkg/epp/util/pool"
+)
+
+// InferencePoolDiscoveryType is the type of the InferencePoolDiscovery.
+const InferencePoolDiscoveryType = "inferencepool-discovery"
+
+// InferencePoolDiscovery discovers the endpoints of an InferencePool other than the one the EPP is associated with.
+// The manager cache of the EPP only holds its own InferencePool, so the pool and the pods of its namespace are watched
+// through a dedicated cache, and the endpoints are resynced from that cache whenever one of them changes.
+type InferencePoolDiscovery struct {
+	reader    client.Reader
+	informers ctrlcache.Informers
+	pool      types.NamespacedName
+
+	// endpoints is the set of endpoints notified by the last successful sync.
+	endpoints sets.Set[types.NamespacedName]
+	// changed is signalled by the informers when the pool or a pod of its namespace changes.
+	changed chan struct{}
+
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+var _ fwkdl.EndpointDiscovery = (*InferencePoolDiscovery)(nil)
+
+// NewInferencePoolDiscovery creates an InferencePoolDiscovery for the given pool. The cache must be scoped to the
+// namespace of the pool; it is started by the discovery.
+func NewInferencePoolDiscovery(cache ctrlcache.Cache, pool types.NamespacedName) *InferencePoolDiscovery {
+	return newInferencePoolDiscovery(cache, cache, pool)
+}
+
+func newInferencePoolDiscovery(reader client.Reader, informers ctrlcache.Informers, pool types.NamespacedName) *InferencePoolDiscovery {
+	return &InferencePoolDiscovery{
+		reader:    reader,
+		informers: informers,
+		pool:      pool,
+		endpoints: sets.New[types.NamespacedName](),
+		changed:   make(chan struct{}, 1),
+		ready:     make(chan struct{}),
+	}
+}
+
+func (d *InferencePoolDiscovery) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: InferencePoolDiscoveryType, Name: d.pool.String()}
+}
+
+// Ready returns a channel closed after the first successful sync.
+func (d *InferencePoolDiscovery) Ready() <-chan struct{} { return d.ready }
+
+// Start watches the pool and its pods and syncs the endpoints of the pool on every change until ctx is cancelled.
+// A failed sync is logged and retried on the next change.
+func (d *InferencePoolDiscovery) Start(ctx context.Context, notifier fwkdl.DiscoveryNotifier) error {
+	logger := log.FromContext(ctx).WithValues("discovery", InferencePoolDiscoveryType, "pool", d.pool)
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { d.notifyChanged() },
+		UpdateFunc: func(any, any) { d.notifyChanged() },
+		DeleteFunc: func(any) { d.notifyChanged() },
+	}
+	for _, obj := range []client.Object{&v1.InferencePool{}, &corev1.Pod{}} {
+		informer, err := d.informers.GetInformer(ctx, obj)
+		if err != nil {
+			return fmt.Errorf("unable to get informer for %T - %w", obj, err)
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return fmt.Errorf("unable to watch %T - %w", obj, err)
+		}
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- d.informers.Start(ctx)
+	}()
+	if !d.informers.WaitForCacheSync(ctx) {
+		select {
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("unable to start the InferencePool cache - %w", err)
+			}
+		default:
+		}
+		return nil
+	}
+
+	for {
+		if err := d.sync(ctx, notifier); err != nil {
+			logger.Error(err, "Failed to sync InferencePool endpoints")
+		} else {
+			d.readyOnce.Do(func() { close(d.ready) })
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("InferencePool cache stopped - %w", err)
+			}
+			errCh = nil
+		case <-d.changed:
+		}
+	}
+}
+
+// notifyChanged schedules a sync. Changes arriving while a sync is pending are folded into it.
+func (d *InferencePoolDiscovery) notifyChanged() {
+	select {
+	case d.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (d *InferencePoolDiscovery) sync(ctx context.Context, notifier fwkdl.DiscoveryNotifier) error {
+	incoming := sets.New[types.NamespacedName]()
+
+	pool := &v1.InferencePool{}
+	if err := d.reader.Get(ctx, d.pool, pool); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get InferencePool - %w", err)
+		}
+		log.FromContext(ctx).V(logutil.DEFAULT).Info("InferencePool not found", "pool", d.pool)
+	} else {
+		endpointPool := poolutil.InferencePoolToEndpointPool(pool)
+		pods := &corev1.PodList{}
+		if err := d.reader.List(ctx, pods, client.InNamespace(d.pool.Namespace), client.MatchingLabels(endpointPool.Selector)); err != nil {
+			return fmt.Errorf("unable to list pods - %w", err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !podutil.IsPodReady(pod) || pod.Status.PodIP == "" {
+				continue
+			}
+			for idx, port := range endpointPool.TargetPorts {
+				meta := &fwkdl.EndpointMetadata{
+					NamespacedName: types.NamespacedName{Name: pod.Name + "-rank-" + strconv.Itoa(idx), Namespace: pod.Namespace},
+					PodName:        pod.Name,
+					Address:        pod.Status.PodIP,
+					Port:           strconv.Itoa(port),
+					MetricsHost:    net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port)),
+					Labels:         maps.Clone(pod.Labels),
+					RankIndex:      idx,
+				}
+				notifier.Upsert(meta)
+				incoming.Insert(meta.NamespacedName)
+			}
+		}
+	}
+
+	for id := range d.endpoints.Difference(incoming) {
+		notifier.Delete(id)
+	}
+	d.endpoints = incoming
+	return nil
+}
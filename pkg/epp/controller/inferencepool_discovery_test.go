@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	testutil "github.com/llm-d/llm-d-router/pkg/epp/util/testing"
+)
+
+// recordingNotifier keeps the endpoints notified by a discovery.
+type recordingNotifier struct {
+	mu        sync.Mutex
+	endpoints map[types.NamespacedName]*fwkdl.EndpointMetadata
+}
+
+func (n *recordingNotifier) Upsert(endpoint *fwkdl.EndpointMetadata) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.endpoints[endpoint.NamespacedName] = endpoint
+}
+
+func (n *recordingNotifier) Delete(id types.NamespacedName) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.endpoints, id)
+}
+
+func (n *recordingNotifier) addresses() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	addresses := []string{}
+	for _, endpoint := range n.endpoints {
+		addresses = append(addresses, endpoint.Address+":"+endpoint.Port)
+	}
+	return addresses
+}
+
+func TestInferencePoolDiscovery(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.Install(scheme)
+
+	pool := testutil.MakeInferencePool("secondary").
+		Namespace("other").
+		Selector(selectorV1).
+		TargetPorts(8000).
+		EndpointPickerRef("epp-service").ObjRef()
+	ready := testutil.MakePod("ready").Namespace("other").Labels(selectorV1).IP("10.1.0.1").ReadyCondition().ObjRef()
+	otherReady := testutil.MakePod("other-ready").Namespace("other").Labels(selectorV1).IP("10.1.0.2").ReadyCondition().ObjRef()
+	notReady := testutil.MakePod("not-ready").Namespace("other").Labels(selectorV1).IP("10.1.0.3").ObjRef()
+	otherPool := testutil.MakePod("other-pool").Namespace("other").Labels(selectorV2).IP("10.1.0.4").ReadyCondition().ObjRef()
+	otherNamespace := testutil.MakePod("other-namespace").Namespace("default").Labels(selectorV1).IP("10.1.0.5").ReadyCondition().ObjRef()
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, ready, otherReady, notReady, otherPool, otherNamespace).
+		Build()
+	discovery := newInferencePoolDiscovery(fakeClient, &informertest.FakeInformers{Scheme: scheme}, types.NamespacedName{Name: "secondary", Namespace: "other"})
+	notifier := &recordingNotifier{endpoints: map[types.NamespacedName]*fwkdl.EndpointMetadata{}}
+
+	require.NoError(t, discovery.sync(ctx, notifier))
+	assert.ElementsMatch(t, []string{"10.1.0.1:8000", "10.1.0.2:8000"}, notifier.addresses())
+	endpoint := notifier.endpoints[types.NamespacedName{Name: "ready-rank-0", Namespace: "other"}]
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "ready", endpoint.PodName)
+
+	// Pods leaving the pool are deleted.
+	require.NoError(t, fakeClient.Delete(ctx, otherReady))
+	require.NoError(t, discovery.sync(ctx, notifier))
+	assert.ElementsMatch(t, []string{"10.1.0.1:8000"}, notifier.addresses())
+
+	// All endpoints are deleted with the pool.
+	require.NoError(t, fakeClient.Delete(ctx, pool))
+	require.NoError(t, discovery.sync(ctx, notifier))
+	assert.Empty(t, notifier.endpoints)
+}
+
+func TestInferencePoolDiscovery_StartClosesReady(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.Install(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	discovery := newInferencePoolDiscovery(fakeClient, &informertest.FakeInformers{Scheme: scheme}, types.NamespacedName{Name: "missing", Namespace: "other"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- discovery.Start(ctx, &recordingNotifier{endpoints: map[types.NamespacedName]*fwkdl.EndpointMetadata{}})
+	}()
+
+	select {
+	case <-discovery.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the initial sync")
+	}
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestInferencePoolDiscovery_SyncsOnChange(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1.Install(scheme)
+
+	pool := testutil.MakeInferencePool("secondary").
+		Namespace("other").
+		Selector(selectorV1).
+		TargetPorts(8000).
+		EndpointPickerRef("epp-service").ObjRef()
+	ready := testutil.MakePod("ready").Namespace("other").Labels(selectorV1).IP("10.1.0.1").ReadyCondition().ObjRef()
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool, ready).Build()
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	discovery := newInferencePoolDiscovery(fakeClient, informers, types.NamespacedName{Name: "secondary", Namespace: "other"})
+	notifier := &recordingNotifier{endpoints: map[types.NamespacedName]*fwkdl.EndpointMetadata{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- discovery.Start(ctx, notifier)
+	}()
+	select {
+	case <-discovery.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the initial sync")
+	}
+	assert.ElementsMatch(t, []string{"10.1.0.1:8000"}, notifier.addresses())
+
+	// A pod joining the pool is picked up on the informer event, without polling.
+	added := testutil.MakePod("added").Namespace("other").Labels(selectorV1).IP("10.1.0.2").ReadyCondition().ObjRef()
+	require.NoError(t, fakeClient.Create(ctx, added))
+	podInformer, err := informers.FakeInformerFor(ctx, &corev1.Pod{})
+	require.NoError(t, err)
+	podInformer.Add(added)
+	assert.Eventually(t, func() bool {
+		return len(notifier.addresses()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
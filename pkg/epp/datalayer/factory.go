@@ -18,6 +18,7 @@ package datalayer
 
 import (
 	"context"
+	"time"
 
 	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
 )
@@ -34,6 +35,22 @@ type PoolInfo interface {
 	PodList(func(fwkdl.Endpoint) bool) []fwkdl.Endpoint
 }
 
+// FreshMetricsPredicate returns a PodList predicate that selects endpoints whose metrics were
+// updated at most stalenessThreshold ago.
+func FreshMetricsPredicate(stalenessThreshold time.Duration) func(fwkdl.Endpoint) bool {
+	return func(ep fwkdl.Endpoint) bool {
+		return ep != nil && ep.GetMetrics().Age(time.Now()) <= stalenessThreshold
+	}
+}
+
+// StaleMetricsPredicate returns a PodList predicate that selects endpoints whose metrics were
+// last updated more than stalenessThreshold ago, or never.
+func StaleMetricsPredicate(stalenessThreshold time.Duration) func(fwkdl.Endpoint) bool {
+	return func(ep fwkdl.Endpoint) bool {
+		return ep != nil && ep.GetMetrics().Age(time.Now()) > stalenessThreshold
+	}
+}
+
 // EndpointFactory defines an interface for managing Endpoint lifecycle. Specifically,
 // providing methods to allocate, update, and retire endpoints. This can potentially be
 // used for pooled memory or other management chores in the implementation.
@@ -59,3 +59,20 @@ func TestFactory(t *testing.T) {
 	// This test would need modification to verify polling - for now just verify it works
 	_ = endpoint2
 }
+
+func TestMetricsFreshnessPredicates(t *testing.T) {
+	fresh := fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{}, &fwkdl.Metrics{UpdateTime: time.Now()})
+	stale := fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{}, &fwkdl.Metrics{UpdateTime: time.Now().Add(-time.Minute)})
+	never := fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{}, nil)
+
+	isFresh := FreshMetricsPredicate(time.Second)
+	isStale := StaleMetricsPredicate(time.Second)
+	assert.True(t, isFresh(fresh))
+	assert.False(t, isStale(fresh))
+	assert.False(t, isFresh(stale))
+	assert.True(t, isStale(stale))
+	assert.False(t, isFresh(never))
+	assert.True(t, isStale(never), "endpoints whose metrics were never collected are stale")
+	assert.False(t, isFresh(nil))
+	assert.False(t, isStale(nil))
+}
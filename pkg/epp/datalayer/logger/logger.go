@@ -49,6 +49,7 @@ func runPrometheusRefresher(ctx context.Context, logger logr.Logger, datastore d
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	ages := newEndpointMetricsAges()
 	for {
 		select {
 		case <-ctx.Done():
@@ -56,6 +57,7 @@ func runPrometheusRefresher(ctx context.Context, logger logr.Logger, datastore d
 			return
 		case <-ticker.C:
 			refreshPrometheusMetrics(logger, datastore, stalenessThreshold)
+			ages.refresh(datastore, time.Now())
 		}
 	}
 }
@@ -75,27 +77,9 @@ func runDebugLogger(ctx context.Context, logger logr.Logger, datastore datalayer
 	}
 }
 
-func podsWithFreshMetrics(stalenessThreshold time.Duration) func(fwkdl.Endpoint) bool {
-	return func(ep fwkdl.Endpoint) bool {
-		if ep == nil {
-			return false // Skip nil pods
-		}
-		return time.Since(ep.GetMetrics().UpdateTime) <= stalenessThreshold
-	}
-}
-
-func podsWithStaleMetrics(stalenessThreshold time.Duration) func(fwkdl.Endpoint) bool {
-	return func(ep fwkdl.Endpoint) bool {
-		if ep == nil {
-			return false // Skip nil pods
-		}
-		return time.Since(ep.GetMetrics().UpdateTime) > stalenessThreshold
-	}
-}
-
 func printDebugMetrics(logger logr.Logger, datastore datalayer.PoolInfo, stalenessThreshold time.Duration) {
-	freshPods := datastore.PodList(podsWithFreshMetrics(stalenessThreshold))
-	stalePods := datastore.PodList(podsWithStaleMetrics(stalenessThreshold))
+	freshPods := datastore.PodList(datalayer.FreshMetricsPredicate(stalenessThreshold))
+	stalePods := datastore.PodList(datalayer.StaleMetricsPredicate(stalenessThreshold))
 
 	logger.V(logutil.TRACE).Info("Current Pods and metrics gathered",
 		"Fresh metrics", fmt.Sprintf("%+v", freshPods), "Stale metrics", fmt.Sprintf("%+v", stalePods))
@@ -108,7 +92,7 @@ func refreshPrometheusMetrics(logger logr.Logger, datastore datalayer.PoolInfo,
 		return
 	}
 
-	podMetrics := datastore.PodList(podsWithFreshMetrics(stalenessThreshold))
+	podMetrics := datastore.PodList(datalayer.FreshMetricsPredicate(stalenessThreshold))
 	logger.V(logutil.TRACE).Info("Refreshing Prometheus Metrics", "ReadyPods", len(podMetrics))
 	podCount := len(podMetrics)
 	metrics.RecordInferencePoolReadyPods(pool.Name, float64(podCount))
@@ -141,3 +125,46 @@ func calculateTotals(endpoints []fwkdl.Endpoint) totals {
 	}
 	return result
 }
+
+// endpointMetricsAgeSeries identifies the metrics age series of an endpoint.
+type endpointMetricsAgeSeries struct {
+	pool, endpointName, namespace, port string
+}
+
+// endpointMetricsAges publishes the metrics age of every endpoint and removes the series of
+// endpoints that left the pool.
+type endpointMetricsAges struct {
+	recorded map[endpointMetricsAgeSeries]struct{}
+}
+
+func newEndpointMetricsAges() *endpointMetricsAges {
+	return &endpointMetricsAges{recorded: map[endpointMetricsAgeSeries]struct{}{}}
+}
+
+// refresh records the metrics age of every endpoint in the pool. Endpoints whose metrics were
+// never updated are skipped.
+func (a *endpointMetricsAges) refresh(datastore datalayer.PoolInfo, now time.Time) {
+	current := map[endpointMetricsAgeSeries]struct{}{}
+	if pool, err := datastore.PoolGet(); err == nil {
+		for _, ep := range datastore.PodList(func(fwkdl.Endpoint) bool { return true }) {
+			metadata, epMetrics := ep.GetMetadata(), ep.GetMetrics()
+			if metadata == nil || epMetrics == nil || epMetrics.UpdateTime.IsZero() {
+				continue
+			}
+			series := endpointMetricsAgeSeries{
+				pool:         pool.Name,
+				endpointName: metadata.PodName,
+				namespace:    metadata.NamespacedName.Namespace,
+				port:         metadata.Port,
+			}
+			metrics.RecordEndpointMetricsAge(series.pool, series.endpointName, series.namespace, series.port, epMetrics.Age(now))
+			current[series] = struct{}{}
+		}
+	}
+	for series := range a.recorded {
+		if _, ok := current[series]; !ok {
+			metrics.DeleteEndpointMetricsAge(series.pool, series.endpointName, series.namespace, series.port)
+		}
+	}
+	a.recorded = current
+}
@@ -180,6 +180,71 @@ func TestRefreshPrometheusMetricsAvgValues(t *testing.T) {
 	assert.InDelta(t, 1.5, avgQueue, 0.001, "average queue size should be 1.5, not truncated to 1")
 }
 
+func TestEndpointMetricsAges(t *testing.T) {
+	metrics.Register()
+	metrics.Reset()
+
+	now := time.Now()
+	newEndpoint := func(name string, updateTime time.Time) fwkdl.Endpoint {
+		return fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{
+			NamespacedName: types.NamespacedName{Name: name + "-rank-0", Namespace: "default"},
+			PodName:        name,
+			Port:           "8000",
+		}, &fwkdl.Metrics{UpdateTime: updateTime})
+	}
+	ds := &fakeEndpointsDataStore{endpoints: []fwkdl.Endpoint{
+		newEndpoint("pod1", now.Add(-3*time.Second)),
+		newEndpoint("pod2", now.Add(-time.Second)),
+		newEndpoint("pod3", time.Time{}),
+	}}
+
+	ageSeries := func() map[string]float64 {
+		families, err := ctrlmetrics.Registry.Gather()
+		assert.NoError(t, err)
+		result := map[string]float64{}
+		for _, f := range families {
+			if f.GetName() != "llm_d_router_epp_endpoint_metrics_age_seconds" {
+				continue
+			}
+			for _, m := range f.GetMetric() {
+				for _, label := range m.GetLabel() {
+					if label.GetName() == "endpoint_name" {
+						result[label.GetValue()] = m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return result
+	}
+
+	ages := newEndpointMetricsAges()
+	ages.refresh(ds, now)
+	assert.Equal(t, map[string]float64{"pod1": 3, "pod2": 1}, ageSeries(), "endpoints without metrics must not be reported")
+
+	ds.endpoints = ds.endpoints[1:]
+	ages.refresh(ds, now.Add(time.Second))
+	assert.Equal(t, map[string]float64{"pod2": 2}, ageSeries(), "series of removed endpoints must be deleted")
+}
+
+type fakeEndpointsDataStore struct {
+	endpoints []fwkdl.Endpoint
+}
+
+func (f *fakeEndpointsDataStore) PoolGet() (*datalayer.EndpointPool, error) {
+	pool := &v1.InferencePool{Spec: v1.InferencePoolSpec{TargetPorts: []v1.Port{{Number: 8000}}}}
+	return poolutil.InferencePoolToEndpointPool(pool), nil
+}
+
+func (f *fakeEndpointsDataStore) PodList(predicate func(fwkdl.Endpoint) bool) []fwkdl.Endpoint {
+	res := []fwkdl.Endpoint{}
+	for _, ep := range f.endpoints {
+		if predicate(ep) {
+			res = append(res, ep)
+		}
+	}
+	return res
+}
+
 type FakeOddMetricsDataStore struct{}
 
 func (f *FakeOddMetricsDataStore) PoolGet() (*datalayer.EndpointPool, error) {
@@ -269,8 +269,9 @@ func (ds *datastore) ModelRewriteGetAll() []*v1alpha2.InferenceModelRewrite {
 }
 
 // /// Pods/endpoints APIs ///
-// TODO: add a flag for callers to specify the staleness threshold for metrics.
-// ref: https://github.com/kubernetes-sigs/gateway-api-inference-extension/pull/1046#discussion_r2246351694
+
+// PodList returns the endpoints matching predicate. Callers that must not act on stale metrics
+// select endpoints with datalayer.FreshMetricsPredicate.
 func (ds *datastore) PodList(predicate func(fwkdl.Endpoint) bool) []fwkdl.Endpoint {
 	res := []fwkdl.Endpoint{}
 
@@ -330,6 +331,7 @@ func (ds *datastore) podUpdateOrAddIfNotExist(ctx context.Context, pod *corev1.P
 				MetricsHost:    net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(metricsPort)),
 				Labels:         labels,
 				RankIndex:      idx,
+				ReadySince:     podutil.ReadySince(pod),
 			})
 	}
 
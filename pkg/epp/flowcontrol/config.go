@@ -18,7 +18,10 @@ package flowcontrol
 
 import (
 	"fmt"
+	"net/url"
+	"time"
 
+	configapi "github.com/llm-d/llm-d-router/apix/config/v1alpha1"
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/controller"
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/registry"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
@@ -26,6 +29,9 @@ import (
 
 const FeatureGate = "flowControl"
 
+// DefaultScaleFromZeroHoldTimeout is the default maximum time a request is held while the pool has no ready endpoints.
+const DefaultScaleFromZeroHoldTimeout = 5 * time.Minute
+
 // Config is the top-level configuration for the entire flow control module.
 // It embeds the configurations for the controller and the registry, providing a single point of entry for validation
 // and initialization.
@@ -33,6 +39,8 @@ type Config struct {
 	Controller       *controller.Config
 	Registry         *registry.Config
 	UsageLimitPolicy flowcontrol.UsageLimitPolicy
+	// ScaleFromZero is nil unless requests should be held while the pool has no ready endpoints.
+	ScaleFromZero *ScaleFromZeroConfig
 }
 
 func (c *Config) String() string {
@@ -55,3 +63,42 @@ func NewConfig(ctrl *controller.Config, reg *registry.Config, ulp flowcontrol.Us
 		UsageLimitPolicy: ulp,
 	}
 }
+
+// ScaleFromZeroConfig configures holding requests while the pool has no ready endpoints.
+type ScaleFromZeroConfig struct {
+	// HoldTimeout is the maximum time a request is held waiting for a ready endpoint.
+	HoldTimeout time.Duration
+	// MaxHeldRequests caps the number of requests held at the same time. 0 means no cap.
+	MaxHeldRequests int
+	// ActivationURL, when set, receives a POST with the held demand of a model.
+	ActivationURL string
+}
+
+// NewScaleFromZeroConfigFromAPI creates a ScaleFromZeroConfig from the API configuration, applying defaults and
+// validation. It returns nil if scale-from-zero is not configured.
+func NewScaleFromZeroConfigFromAPI(apiConfig *configapi.ScaleFromZeroConfig) (*ScaleFromZeroConfig, error) {
+	if apiConfig == nil {
+		return nil, nil //nolint:nilnil
+	}
+	c := &ScaleFromZeroConfig{
+		HoldTimeout:     DefaultScaleFromZeroHoldTimeout,
+		MaxHeldRequests: int(apiConfig.MaxHeldRequests),
+		ActivationURL:   apiConfig.ActivationURL,
+	}
+	if apiConfig.HoldTimeout != nil {
+		c.HoldTimeout = apiConfig.HoldTimeout.Duration
+	}
+	if c.HoldTimeout <= 0 {
+		return nil, fmt.Errorf("scaleFromZero.holdTimeout must be positive, but got %v", c.HoldTimeout)
+	}
+	if c.MaxHeldRequests < 0 {
+		return nil, fmt.Errorf("scaleFromZero.maxHeldRequests cannot be negative, but got %d", c.MaxHeldRequests)
+	}
+	if c.ActivationURL != "" {
+		u, err := url.Parse(c.ActivationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("scaleFromZero.activationURL must be an absolute http(s) URL, but got %q", c.ActivationURL)
+		}
+	}
+	return c, nil
+}
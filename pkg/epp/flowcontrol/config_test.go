@@ -18,8 +18,13 @@ package flowcontrol
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configapi "github.com/llm-d/llm-d-router/apix/config/v1alpha1"
 
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/controller"
 	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/registry"
@@ -55,3 +60,65 @@ func TestNewConfig(t *testing.T) {
 		assert.Nil(t, cfg.UsageLimitPolicy, "UsageLimitPolicy should be nil when nil was passed")
 	})
 }
+
+func TestNewScaleFromZeroConfigFromAPI(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		apiConfig *configapi.ScaleFromZeroConfig
+		want      *ScaleFromZeroConfig
+		wantErr   bool
+	}{
+		{
+			name: "nil config disables scale from zero",
+		},
+		{
+			name:      "defaults are applied",
+			apiConfig: &configapi.ScaleFromZeroConfig{},
+			want:      &ScaleFromZeroConfig{HoldTimeout: DefaultScaleFromZeroHoldTimeout},
+		},
+		{
+			name: "all fields are correctly assigned",
+			apiConfig: &configapi.ScaleFromZeroConfig{
+				HoldTimeout:     &metav1.Duration{Duration: 30 * time.Second},
+				MaxHeldRequests: 10,
+				ActivationURL:   "https://activator.example/activate",
+			},
+			want: &ScaleFromZeroConfig{
+				HoldTimeout:     30 * time.Second,
+				MaxHeldRequests: 10,
+				ActivationURL:   "https://activator.example/activate",
+			},
+		},
+		{
+			name:      "zero hold timeout is rejected",
+			apiConfig: &configapi.ScaleFromZeroConfig{HoldTimeout: &metav1.Duration{}},
+			wantErr:   true,
+		},
+		{
+			name:      "negative max held requests is rejected",
+			apiConfig: &configapi.ScaleFromZeroConfig{MaxHeldRequests: -1},
+			wantErr:   true,
+		},
+		{
+			name:      "relative activation URL is rejected",
+			apiConfig: &configapi.ScaleFromZeroConfig{ActivationURL: "/activate"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewScaleFromZeroConfigFromAPI(tc.apiConfig)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	configapi "github.com/llm-d/llm-d-router/apix/config/v1alpha1"
@@ -28,6 +29,21 @@ const (
 	defaultExpiryCleanupInterval = 1 * time.Second
 	// defaultEnqueueChannelBufferSize is the default size of a worker's incoming request buffer.
 	defaultEnqueueChannelBufferSize = 100
+	// defaultPriorityAgingInterval is the default queue time after which a request has gained one priority level.
+	defaultPriorityAgingInterval = 10 * time.Second
+)
+
+// AgingCurve is the shape of the priority boost a queued request gains over its queue time.
+type AgingCurve string
+
+const (
+	// LinearAgingCurve grows the boost by one priority level per interval.
+	LinearAgingCurve AgingCurve = "linear"
+	// StepAgingCurve grows the boost by one whole priority level at the end of every interval.
+	StepAgingCurve AgingCurve = "step"
+	// ExponentialAgingCurve grows the boost as 2^(queue time / interval) - 1, reaching one priority level after the
+	// first interval and roughly doubling with every further interval.
+	ExponentialAgingCurve AgingCurve = "exponential"
 )
 
 // Config holds the configuration for the `FlowController`.
@@ -46,6 +62,54 @@ type Config struct {
 	// serial execution loop and allowing the system to handle short bursts of traffic without blocking.
 	// Optional: Defaults to `defaultEnqueueChannelBufferSize` (100).
 	EnqueueChannelBufferSize int
+
+	// PriorityAging raises the effective priority of queued requests with their queue time.
+	// Optional: If nil, priority bands are served in strict priority order.
+	PriorityAging *PriorityAgingConfig
+}
+
+// PriorityAgingConfig configures how the effective priority of a queued request grows with its queue time.
+type PriorityAgingConfig struct {
+	// Curve is the shape of the boost over queue time.
+	Curve AgingCurve
+	// Interval is the queue time after which a request has gained one priority level.
+	Interval time.Duration
+	// MaxBoost caps the boost, in priority levels.
+	MaxBoost int
+}
+
+// Boost returns the number of priority levels a request gains after waiting for the given duration.
+func (c *PriorityAgingConfig) Boost(waited time.Duration) float64 {
+	if waited <= 0 {
+		return 0
+	}
+	intervals := float64(waited) / float64(c.Interval)
+	var boost float64
+	switch c.Curve {
+	case StepAgingCurve:
+		boost = math.Floor(intervals)
+	case ExponentialAgingCurve:
+		boost = math.Exp2(intervals) - 1
+	default:
+		boost = intervals
+	}
+	return math.Min(boost, float64(c.MaxBoost))
+}
+
+func (c *PriorityAgingConfig) validate() error {
+	switch c.Curve {
+	case LinearAgingCurve, StepAgingCurve, ExponentialAgingCurve:
+	default:
+		return fmt.Errorf("PriorityAging.Curve must be one of %q, %q or %q, but got %q",
+			LinearAgingCurve, StepAgingCurve, ExponentialAgingCurve, c.Curve)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("PriorityAging.Interval must be positive, but got %v", c.Interval)
+	}
+	if c.MaxBoost <= 0 {
+		return fmt.Errorf("PriorityAging.MaxBoost must be positive, but got %d", c.MaxBoost)
+	}
+	return nil
 }
 
 func (c *Config) String() string {
@@ -69,6 +133,20 @@ func NewConfigFromAPI(apiConfig *configapi.FlowControlConfig) (*Config, error) {
 		if apiConfig.DefaultRequestTTL != nil {
 			opts = append(opts, WithDefaultRequestTTL(apiConfig.DefaultRequestTTL.Duration))
 		}
+		if aging := apiConfig.PriorityAging; aging != nil {
+			agingConfig := &PriorityAgingConfig{
+				Curve:    AgingCurve(aging.Curve),
+				Interval: defaultPriorityAgingInterval,
+				MaxBoost: int(aging.MaxBoost),
+			}
+			if agingConfig.Curve == "" {
+				agingConfig.Curve = LinearAgingCurve
+			}
+			if aging.Interval != nil {
+				agingConfig.Interval = aging.Interval.Duration
+			}
+			opts = append(opts, WithPriorityAging(agingConfig))
+		}
 	}
 	return NewConfig(opts...)
 }
@@ -111,6 +189,13 @@ func WithEnqueueChannelBufferSize(size int) ConfigOption {
 	}
 }
 
+// WithPriorityAging enables priority aging.
+func WithPriorityAging(aging *PriorityAgingConfig) ConfigOption {
+	return func(c *Config) {
+		c.PriorityAging = aging
+	}
+}
+
 // validate checks the configuration for validity.
 func (c *Config) validate() error {
 	if c.DefaultRequestTTL < 0 {
@@ -122,5 +207,10 @@ func (c *Config) validate() error {
 	if c.EnqueueChannelBufferSize < 0 {
 		return fmt.Errorf("EnqueueChannelBufferSize cannot be negative, but got %d", c.EnqueueChannelBufferSize)
 	}
+	if c.PriorityAging != nil {
+		if err := c.PriorityAging.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
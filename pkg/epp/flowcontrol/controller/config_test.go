@@ -175,6 +175,54 @@ func TestNewConfigFromAPI(t *testing.T) {
 			},
 			expectedErr: "DefaultRequestTTL cannot be negative",
 		},
+		{
+			name: "PriorityAging_ShouldApplyDefaults",
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityAging: &configapi.PriorityAgingConfig{MaxBoost: 10},
+			},
+			assertion: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, &PriorityAgingConfig{
+					Curve:    LinearAgingCurve,
+					Interval: defaultPriorityAgingInterval,
+					MaxBoost: 10,
+				}, cfg.PriorityAging)
+			},
+		},
+		{
+			name: "PriorityAging_ShouldTranslateFields",
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityAging: &configapi.PriorityAgingConfig{
+					Curve:    "exponential",
+					Interval: &metav1.Duration{Duration: time.Second},
+					MaxBoost: 5,
+				},
+			},
+			assertion: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, &PriorityAgingConfig{Curve: ExponentialAgingCurve, Interval: time.Second, MaxBoost: 5},
+					cfg.PriorityAging)
+			},
+		},
+		{
+			name: "InvalidConfig_PriorityAgingUnknownCurve_ShouldError",
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityAging: &configapi.PriorityAgingConfig{Curve: "quadratic", MaxBoost: 10},
+			},
+			expectedErr: "PriorityAging.Curve must be one of",
+		},
+		{
+			name: "InvalidConfig_PriorityAgingZeroMaxBoost_ShouldError",
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityAging: &configapi.PriorityAgingConfig{},
+			},
+			expectedErr: "PriorityAging.MaxBoost must be positive",
+		},
+		{
+			name: "InvalidConfig_PriorityAgingZeroInterval_ShouldError",
+			apiConfig: &configapi.FlowControlConfig{
+				PriorityAging: &configapi.PriorityAgingConfig{Interval: &metav1.Duration{}, MaxBoost: 10},
+			},
+			expectedErr: "PriorityAging.Interval must be positive",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -196,3 +244,31 @@ func TestNewConfigFromAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestPriorityAgingConfig_Boost(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		curve  AgingCurve
+		waited time.Duration
+		want   float64
+	}{
+		{name: "Linear_NotWaited", curve: LinearAgingCurve, waited: 0, want: 0},
+		{name: "Linear_PartialInterval", curve: LinearAgingCurve, waited: 1500 * time.Millisecond, want: 1.5},
+		{name: "Linear_Capped", curve: LinearAgingCurve, waited: time.Minute, want: 4},
+		{name: "Step_PartialInterval", curve: StepAgingCurve, waited: 1500 * time.Millisecond, want: 1},
+		{name: "Step_BeforeFirstInterval", curve: StepAgingCurve, waited: 999 * time.Millisecond, want: 0},
+		{name: "Exponential_FirstInterval", curve: ExponentialAgingCurve, waited: time.Second, want: 1},
+		{name: "Exponential_SecondInterval", curve: ExponentialAgingCurve, waited: 2 * time.Second, want: 3},
+		{name: "Exponential_Capped", curve: ExponentialAgingCurve, waited: 3 * time.Second, want: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			aging := &PriorityAgingConfig{Curve: tc.curve, Interval: time.Second, MaxBoost: 4}
+			assert.InDelta(t, tc.want, aging.Boost(tc.waited), 1e-9)
+		})
+	}
+}
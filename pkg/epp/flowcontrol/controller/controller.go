@@ -153,6 +153,10 @@ func NewFlowController(
 			enqueueChannelBufferSize int,
 			logger logr.Logger,
 		) processor {
+			var opts []internal.ProcessorOption
+			if config.PriorityAging != nil {
+				opts = append(opts, internal.WithPriorityAging(config.PriorityAging.Boost))
+			}
 			return internal.NewProcessor(
 				ctx,
 				poolName,
@@ -165,6 +169,7 @@ func NewFlowController(
 				cleanupSweepInterval,
 				enqueueChannelBufferSize,
 				logger,
+				opts...,
 			)
 		}
 	} else {
@@ -17,9 +17,11 @@ limitations under the License.
 package internal
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -75,6 +77,10 @@ type Processor struct {
 	cleanupSweepInterval time.Duration
 	logger               logr.Logger
 
+	// priorityBoost returns the priority levels an item gains after being queued for the given duration.
+	// If nil, priority bands are served in strict priority order.
+	priorityBoost func(waited time.Duration) float64
+
 	// lifecycleCtx controls the processor's lifetime. Monitored by Submit* methods for safe shutdown.
 	lifecycleCtx context.Context
 
@@ -87,6 +93,17 @@ type Processor struct {
 	shutdownOnce   sync.Once
 }
 
+// ProcessorOption configures optional behavior of a Processor.
+type ProcessorOption func(*Processor)
+
+// WithPriorityAging makes the processor rank the priority bands by the priority of their selected item raised by
+// boost(queue time), instead of serving them in strict priority order.
+func WithPriorityAging(boost func(waited time.Duration) float64) ProcessorOption {
+	return func(sp *Processor) {
+		sp.priorityBoost = boost
+	}
+}
+
 // NewProcessor creates a new Processor instance.
 func NewProcessor(
 	ctx context.Context,
@@ -100,8 +117,9 @@ func NewProcessor(
 	cleanupSweepInterval time.Duration,
 	enqueueChannelBufferSize int,
 	logger logr.Logger,
+	opts ...ProcessorOption,
 ) *Processor {
-	return &Processor{
+	sp := &Processor{
 		registry:             registry,
 		registryBackground:   registryBackground,
 		poolName:             poolName,
@@ -114,6 +132,10 @@ func NewProcessor(
 		lifecycleCtx:         ctx,
 		enqueueChan:          make(chan *FlowItem, enqueueChannelBufferSize),
 	}
+	for _, opt := range opts {
+		opt(sp)
+	}
+	return sp
 }
 
 // Submit attempts a non-blocking handoff of an item to the processor's internal enqueue channel.
@@ -352,6 +374,10 @@ func (sp *Processor) dispatchCycle(ctx context.Context) bool {
 	priorities := sp.registry.AllOrderedPriorityLevels()
 	ceilings := sp.usageLimitPolicy.ComputeLimit(ctx, saturation, priorities)
 
+	if sp.priorityBoost != nil {
+		return sp.dispatchAgedCycle(ctx, saturation, priorities, ceilings)
+	}
+
 	for i, priority := range priorities {
 		// --- Viability Check (Saturation/HoL Blocking) ---
 		// Check before selecting an item: if we are already saturated for this priority, stop immediately.
@@ -392,6 +418,120 @@ func (sp *Processor) dispatchCycle(ctx context.Context) bool {
 	return false
 }
 
+// agedCandidate is a priority band ranked by the aged priority of its oldest queue head.
+type agedCandidate struct {
+	band              flowcontrol.PriorityBandAccessor
+	priority          int
+	effectivePriority float64
+}
+
+// dispatchAgedCycle is the dispatch cycle used when priority aging is enabled. It ranks every non-empty band by its
+// priority raised by the boost the oldest of its queue heads has earned, capped by the configured maximum, and
+// attempts to dispatch from the bands in that order.
+//
+// Bands are ranked by peeking at their queue heads; the band's FairnessPolicy only picks a queue once the band is
+// chosen and has passed the viability check, so stateful policies only advance for bands that are dispatched from.
+// An aged band is subject to the usage limit of the highest band whose priority it has reached, so that it gains that
+// band's eligibility as well as its position. HoL blocking applies as in the strict cycle: if the best ranked band is
+// saturated, the cycle stops.
+func (sp *Processor) dispatchAgedCycle(ctx context.Context, saturation float64, priorities []int, ceilings []float64) bool {
+	now := sp.clock.Now()
+	candidates := make([]agedCandidate, 0, len(priorities))
+	for _, priority := range priorities {
+		band, err := sp.registry.PriorityBandAccessor(priority)
+		if err != nil {
+			sp.logger.Error(err, "Failed to get PriorityBandAccessor, skipping band", "priority", priority)
+			continue
+		}
+		oldest, ok := oldestHeadEnqueueTime(band)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, agedCandidate{
+			band:              band,
+			priority:          priority,
+			effectivePriority: float64(priority) + sp.priorityBoost(now.Sub(oldest)),
+		})
+	}
+
+	// The priorities are ordered from highest to lowest, so the stable sort resolves ties in strict priority order.
+	slices.SortStableFunc(candidates, func(a, b agedCandidate) int {
+		return cmp.Compare(b.effectivePriority, a.effectivePriority)
+	})
+
+	for i, candidate := range candidates {
+		usageLimit := agedUsageLimit(candidate, priorities, ceilings)
+		if saturation >= usageLimit {
+			sp.logger.V(logutil.DEBUG).Info("Priority band is saturated; enforcing HoL blocking.",
+				"priority", candidate.priority, "effectivePriority", candidate.effectivePriority, "usageLimit", usageLimit)
+			return false
+		}
+
+		item, err := sp.selectItem(ctx, candidate.band)
+		if err != nil {
+			sp.logger.Error(err, "Failed to select item, skipping priority band for this cycle",
+				"priority", candidate.priority)
+			continue
+		}
+		if item == nil {
+			continue
+		}
+
+		req := item.OriginalRequest()
+		if err := sp.dispatchItem(item); err != nil {
+			sp.logger.Error(err, "Failed to dispatch item, skipping priority band for this cycle",
+				"flowKey", req.FlowKey(), "reqID", req.ID())
+			continue
+		}
+		// The dispatch is aged if it overtook a higher priority band.
+		for _, overtaken := range candidates[i+1:] {
+			if overtaken.priority > candidate.priority {
+				metrics.IncFlowControlAgedDispatches(sp.poolName, strconv.Itoa(candidate.priority))
+				sp.logger.V(logutil.TRACE).Info("Dispatched aged item ahead of a higher priority band.",
+					"flowKey", req.FlowKey(), "reqID", req.ID(), "effectivePriority", candidate.effectivePriority)
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// oldestHeadEnqueueTime returns the earliest enqueue time among the heads of the band's queues, and false if every
+// queue is empty.
+func oldestHeadEnqueueTime(band flowcontrol.PriorityBandAccessor) (time.Time, bool) {
+	var oldest time.Time
+	found := false
+	band.IterateQueues(func(queue flowcontrol.FlowQueueAccessor) bool {
+		head := queue.PeekHead()
+		if head == nil {
+			return true
+		}
+		if enqueued := head.EnqueueTime(); !found || enqueued.Before(oldest) {
+			oldest, found = enqueued, true
+		}
+		return true
+	})
+	return oldest, found
+}
+
+// agedUsageLimit returns the usage limit of the highest priority band the candidate's effective priority has reached,
+// and at least the limit of its own band.
+func agedUsageLimit(candidate agedCandidate, priorities []int, ceilings []float64) float64 {
+	limit := 0.0
+	reached := false
+	for i, priority := range priorities {
+		if priority == candidate.priority {
+			limit = max(limit, ceilings[i])
+		}
+		if !reached && float64(priority) <= candidate.effectivePriority {
+			limit = max(limit, ceilings[i])
+			reached = true
+		}
+	}
+	return limit
+}
+
 // selectItem applies the configured fairness and ordering policies to select a single item.
 func (sp *Processor) selectItem(
 	ctx context.Context,
@@ -1058,6 +1058,106 @@ func TestShardProcessor(t *testing.T) {
 				}
 				assert.Equal(t, 0, qLow.Len(), "Low-priority queue should be empty")
 			})
+
+			t.Run("should dispatch aged lower priority items ahead of higher priority items", func(t *testing.T) {
+				t.Parallel()
+				// --- ARRANGE ---
+				h := newTestHarness(t, testCleanupTick)
+				// One priority level per second, capped at 15 levels.
+				WithPriorityAging(func(waited time.Duration) float64 {
+					return min(waited.Seconds(), 15)
+				})(h.processor)
+				keyHigh := flowcontrol.FlowKey{ID: "flow-high", Priority: 20}
+				keyLow := flowcontrol.FlowKey{ID: "flow-low", Priority: 10}
+				qHigh := h.addQueue(keyHigh)
+				qLow := h.addQueue(keyLow)
+
+				itemLow := h.newTestItem("req-low", keyLow, testTTL)
+				require.NoError(t, qLow.Add(itemLow))
+				h.clock.Step(5 * time.Second)
+				itemHigh1 := h.newTestItem("req-high-1", keyHigh, testTTL)
+				require.NoError(t, qHigh.Add(itemHigh1))
+
+				// --- ACT & ASSERT ---
+				// The low priority item has aged to 15, below the fresh high priority item.
+				require.True(t, h.processor.dispatchCycle(context.Background()))
+				assert.Equal(t, types.QueueOutcomeDispatched, itemHigh1.FinalState().Outcome,
+					"A partially aged item should not overtake a higher priority band")
+				assert.Nil(t, itemLow.FinalState(), "Low-priority item should still be queued")
+
+				h.clock.Step(6 * time.Second)
+				itemHigh2 := h.newTestItem("req-high-2", keyHigh, testTTL)
+				require.NoError(t, qHigh.Add(itemHigh2))
+
+				// The low priority item has aged to 21, above the fresh high priority item.
+				require.True(t, h.processor.dispatchCycle(context.Background()))
+				assert.Equal(t, types.QueueOutcomeDispatched, itemLow.FinalState().Outcome,
+					"An aged item should overtake a higher priority band")
+				assert.Nil(t, itemHigh2.FinalState(), "High-priority item should still be queued")
+
+				require.True(t, h.processor.dispatchCycle(context.Background()))
+				assert.Equal(t, types.QueueOutcomeDispatched, itemHigh2.FinalState().Outcome)
+			})
+
+			t.Run("should only pick from the band it dispatches from", func(t *testing.T) {
+				t.Parallel()
+				// --- ARRANGE ---
+				h := newTestHarness(t, testCleanupTick)
+				WithPriorityAging(func(waited time.Duration) float64 {
+					return min(waited.Seconds(), 15)
+				})(h.processor)
+				keyHigh := flowcontrol.FlowKey{ID: "flow-high", Priority: 20}
+				keyLow := flowcontrol.FlowKey{ID: "flow-low", Priority: 10}
+				qHigh := h.addQueue(keyHigh)
+				qLow := h.addQueue(keyLow)
+				require.NoError(t, qLow.Add(h.newTestItem("req-low", keyLow, testTTL)))
+				itemHigh := h.newTestItem("req-high", keyHigh, testTTL)
+				require.NoError(t, qHigh.Add(itemHigh))
+
+				var picked []int
+				h.fairnessPolicyPick = func(_ context.Context, band flowcontrol.PriorityBandAccessor) (flowcontrol.FlowQueueAccessor, error) {
+					picked = append(picked, band.Priority())
+					var selected flowcontrol.FlowQueueAccessor
+					band.IterateQueues(func(fqa flowcontrol.FlowQueueAccessor) bool {
+						if fqa.Len() > 0 {
+							selected = fqa
+							return false
+						}
+						return true
+					})
+					return selected, nil
+				}
+
+				// --- ACT & ASSERT ---
+				require.True(t, h.processor.dispatchCycle(context.Background()))
+				assert.Equal(t, types.QueueOutcomeDispatched, itemHigh.FinalState().Outcome)
+				assert.Equal(t, []int{20}, picked, "Only the dispatching band's fairness policy should pick")
+
+				// A saturated top ranked band blocks the cycle before any pick.
+				picked = nil
+				h.saturationDetector.SaturationFunc = func(context.Context, []fwkdl.Endpoint) float64 { return 1.0 }
+				assert.False(t, h.processor.dispatchCycle(context.Background()))
+				assert.Empty(t, picked, "No fairness policy should pick when the cycle is blocked")
+			})
+
+			t.Run("should enforce the usage limit of the band an aged item reached", func(t *testing.T) {
+				t.Parallel()
+				priorities := []int{20, 10, 0}
+				ceilings := []float64{0.9, 0.6, 0.3}
+				testCases := []struct {
+					name      string
+					candidate agedCandidate
+					want      float64
+				}{
+					{name: "not aged", candidate: agedCandidate{priority: 0, effectivePriority: 0}, want: 0.3},
+					{name: "aged below the next band", candidate: agedCandidate{priority: 0, effectivePriority: 9.5}, want: 0.3},
+					{name: "aged to the next band", candidate: agedCandidate{priority: 0, effectivePriority: 10}, want: 0.6},
+					{name: "aged above the top band", candidate: agedCandidate{priority: 10, effectivePriority: 25}, want: 0.9},
+				}
+				for _, tc := range testCases {
+					assert.Equal(t, tc.want, agedUsageLimit(tc.candidate, priorities, ceilings), tc.name)
+				}
+			})
 		})
 
 		t.Run("dispatchItem", func(t *testing.T) {
@@ -19,6 +19,7 @@ package datalayer
 import (
 	"fmt"
 	"maps"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -34,6 +35,8 @@ type EndpointMetadata struct {
 	// RankIndex is this endpoint's position in the pool's TargetPorts,
 	// identifying the pod-local rank in multi-port deployments.
 	RankIndex int
+	// ReadySince is when the pod last became ready; zero if unknown.
+	ReadySince time.Time
 }
 
 // String returns a string representation of the endpoint.
@@ -63,6 +66,7 @@ func (epm *EndpointMetadata) Clone() *EndpointMetadata {
 		MetricsHost: epm.MetricsHost,
 		Labels:      clonedLabels,
 		RankIndex:   epm.RankIndex,
+		ReadySince:  epm.ReadySince,
 	}
 }
 
@@ -78,6 +82,7 @@ func (epm *EndpointMetadata) Equal(other *EndpointMetadata) bool {
 		epm.Port == other.Port &&
 		epm.MetricsHost == other.MetricsHost &&
 		epm.RankIndex == other.RankIndex &&
+		epm.ReadySince.Equal(other.ReadySince) &&
 		maps.Equal(epm.Labels, other.Labels)
 }
 
@@ -18,6 +18,7 @@ package datalayer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -75,6 +76,7 @@ func TestEndpointMetadataEqual(t *testing.T) {
 		MetricsHost:    "10.0.0.1:9000",
 		Labels:         map[string]string{"app": "vllm"},
 		RankIndex:      1,
+		ReadySince:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
 	}
 
 	assert.True(t, base.Equal(base.Clone()))
@@ -134,6 +136,12 @@ func TestEndpointMetadataEqual(t *testing.T) {
 				meta.RankIndex = 2
 			},
 		},
+		{
+			name: "ready since",
+			mutate: func(meta *EndpointMetadata) {
+				meta.ReadySince = meta.ReadySince.Add(time.Second)
+			},
+		},
 	}
 
 	for _, tt := range tests {
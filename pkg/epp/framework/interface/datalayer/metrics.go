@@ -19,6 +19,7 @@ package datalayer
 import (
 	"fmt"
 	"maps"
+	"math"
 	"time"
 )
 
@@ -57,6 +58,15 @@ func (m *Metrics) String() string {
 	return fmt.Sprintf("%+v", *m)
 }
 
+// Age returns how long before now the metrics were last updated. Metrics that were
+// never updated are reported as infinitely old.
+func (m *Metrics) Age(now time.Time) time.Duration {
+	if m == nil || m.UpdateTime.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	return now.Sub(m.UpdateTime)
+}
+
 // Clone creates a copy of Metrics and returns its pointer.
 // Clone returns nil if the object being cloned is nil.
 func (m *Metrics) Clone() *Metrics {
@@ -17,6 +17,7 @@ limitations under the License.
 package datalayer
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -60,3 +61,14 @@ func TestMetricsToString(t *testing.T) {
 	var none *Metrics
 	assert.Equal(t, "", none.String())
 }
+
+func TestMetricsAge(t *testing.T) {
+	now := time.Now()
+	m := NewMetrics()
+	m.UpdateTime = now.Add(-2 * time.Second)
+	assert.Equal(t, 2*time.Second, m.Age(now))
+
+	assert.Equal(t, time.Duration(math.MaxInt64), NewMetrics().Age(now), "never updated metrics are infinitely old")
+	var none *Metrics
+	assert.Equal(t, time.Duration(math.MaxInt64), none.Age(now))
+}
@@ -129,6 +129,8 @@ type SaturationDetector interface {
 	//   - A value >= 1.0 indicates that the system is fully saturated. Values strictly > 1.0
 	//     represent the depth of overload, scaling proportionally with the excess load.
 	//   - A value < 1.0 indicates the ratio of used capacity to total available capacity.
+	//   - An empty set of endpoints must be reported as fully saturated, so that requests are
+	//     buffered until capacity appears. Scale-from-zero holding relies on this.
 	//
 	// The FlowController consumes this signal to make dispatch decisions:
 	//   - If Saturation() >= 1.0: Stop dispatching and apply backpressure (buffer requests).
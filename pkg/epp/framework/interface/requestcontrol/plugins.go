@@ -31,6 +31,7 @@ const (
 	ResponseReceivedExtensionPoint  = "ResponseReceived"
 	ResponseStreamingExtensionPoint = "ResponseStreaming"
 	ResponseCompleteExtensionPoint  = "ResponseComplete"
+	ResponseCacheExtensionPoint     = "ResponseCache"
 )
 
 // PreRequest is called by the director after a getting result from scheduling layer and
@@ -66,6 +67,14 @@ type ResponseBodyProcessor interface {
 	ResponseBody(ctx context.Context, request *fwksched.InferenceRequest, response *Response, targetEndpoint *datalayer.EndpointMetadata)
 }
 
+// ResponseCache is consulted by the director after the PreAdmitter plugins and before admission control.
+// When Lookup returns a response, the request is answered with it directly: admission, scheduling and
+// the model server are skipped. Lookup returns nil on a miss.
+type ResponseCache interface {
+	plugin.Plugin
+	Lookup(ctx context.Context, request *fwksched.InferenceRequest) *CachedResponse
+}
+
 // DataProducer is implemented by data producers which produce data from different sources.
 // Produce is called by the director before scheduling requests.
 type DataProducer interface {
@@ -38,7 +38,17 @@ type Response struct {
 	ReqMetadata map[string]any
 	// Token usage counts parsed from the response body.
 	Usage requesthandling.Usage
+	// Body is the complete response body. It is only set on the final call for non-streaming responses.
+	Body []byte
 	// DynamicMetadata is a map of metadata that can be passed to the Envoy. It is populated into the dynamic
 	// metadata when processing ProcessingResponse_RequestHeaders.
 	DynamicMetadata *structpb.Struct
 }
+
+// CachedResponse is a previously captured model server response that answers a request without routing it.
+type CachedResponse struct {
+	// Headers are added to the response sent to the client.
+	Headers map[string]string
+	// Body is the complete response body.
+	Body []byte
+}
@@ -76,3 +76,10 @@ type Picker interface {
 	plugin.Plugin
 	Pick(ctx context.Context, scoredPods []*ScoredEndpoint) *ProfileRunResult
 }
+
+// RequestAwarePicker is an optional extension of Picker for pickers whose choice depends on the request,
+// e.g. on a session key. When a profile's picker implements it, PickForRequest is called instead of Pick.
+type RequestAwarePicker interface {
+	Picker
+	PickForRequest(ctx context.Context, request *InferenceRequest, scoredPods []*ScoredEndpoint) *ProfileRunResult
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"math"
+	"time"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+)
+
+// InFlightWeight returns the share a blended load value takes from the real-time in-flight
+// load rather than from polled metrics of the given age: 0 while the metrics are at most
+// stalenessThreshold old, rising linearly to 1 when they are twice as old. A non-positive
+// stalenessThreshold disables blending.
+func InFlightWeight(metricsAge, stalenessThreshold time.Duration) float64 {
+	if stalenessThreshold <= 0 || metricsAge <= stalenessThreshold {
+		return 0
+	}
+	return math.Min(1, float64(metricsAge-stalenessThreshold)/float64(stalenessThreshold))
+}
+
+// BlendedWaitingQueueSize estimates the waiting queue size of an endpoint. Fresh polled metrics
+// are used as is. As they go stale, the value moves towards the requests the EPP has in flight
+// on the endpoint beyond the last polled running requests, so a scorer keeps reacting to the
+// traffic it routes while the endpoint's metrics are not updated. Without in-flight load the
+// polled value is used regardless of its age.
+func BlendedWaitingQueueSize(metrics *fwkdl.Metrics, load *InFlightLoad, now time.Time, stalenessThreshold time.Duration) float64 {
+	if metrics == nil {
+		metrics = fwkdl.NewMetrics()
+	}
+	polled := float64(metrics.WaitingQueueSize)
+	if load == nil {
+		return polled
+	}
+	weight := InFlightWeight(metrics.Age(now), stalenessThreshold)
+	if weight == 0 {
+		return polled
+	}
+	estimated := math.Max(0, float64(load.Requests-int64(metrics.RunningRequestsSize)))
+	return (1-weight)*polled + weight*estimated
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+)
+
+func TestInFlightWeight(t *testing.T) {
+	assert.Equal(t, 0.0, InFlightWeight(time.Second, 2*time.Second))
+	assert.Equal(t, 0.5, InFlightWeight(3*time.Second, 2*time.Second))
+	assert.Equal(t, 1.0, InFlightWeight(time.Hour, 2*time.Second))
+	assert.Equal(t, 0.0, InFlightWeight(time.Hour, 0), "a non-positive threshold disables blending")
+}
+
+func TestBlendedWaitingQueueSize(t *testing.T) {
+	now := time.Now()
+	load := &InFlightLoad{Requests: 10}
+	metricsOfAge := func(age time.Duration) *fwkdl.Metrics {
+		return &fwkdl.Metrics{WaitingQueueSize: 2, RunningRequestsSize: 4, UpdateTime: now.Add(-age)}
+	}
+
+	assert.Equal(t, 2.0, BlendedWaitingQueueSize(metricsOfAge(time.Second), load, now, 2*time.Second))
+	assert.Equal(t, 4.0, BlendedWaitingQueueSize(metricsOfAge(3*time.Second), load, now, 2*time.Second))
+	assert.Equal(t, 6.0, BlendedWaitingQueueSize(metricsOfAge(time.Hour), load, now, 2*time.Second))
+	assert.Equal(t, 2.0, BlendedWaitingQueueSize(metricsOfAge(time.Hour), nil, now, 2*time.Second),
+		"without in-flight load the polled value is used")
+	assert.Equal(t, 10.0, BlendedWaitingQueueSize(nil, load, now, 2*time.Second),
+		"missing metrics are infinitely old")
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package disagg declares the request attributes the disaggregation profile
+// handlers publish for the plugins of later scheduling profiles. The values
+// are published on the InferenceRequest attribute store during the
+// scheduling cycle.
+package disagg
+
+import (
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+// PrefillEndpointDataKey identifies the prefill endpoint selected for the
+// request, published before the decode profile runs again to be paired with
+// it. The default producer is the disagg-profile-handler.
+var PrefillEndpointDataKey = plugin.NewDataKey("PrefillEndpointDataKey", "disagg-profile-handler")
+
+// WritePrefillEndpoint publishes the prefill endpoint selected for the
+// request. A nil endpoint clears the endpoint of a previous scheduling cycle.
+func WritePrefillEndpoint(r *fwksched.InferenceRequest, endpoint fwksched.Endpoint) {
+	r.PutAttribute(PrefillEndpointDataKey.String(), endpoint)
+}
+
+// ReadPrefillEndpoint returns the prefill endpoint selected for the request,
+// or nil and false if no prefill endpoint was selected yet.
+func ReadPrefillEndpoint(r *fwksched.InferenceRequest) (fwksched.Endpoint, bool) {
+	return fwksched.ReadRequestAttribute[fwksched.Endpoint](r, PrefillEndpointDataKey.String())
+}
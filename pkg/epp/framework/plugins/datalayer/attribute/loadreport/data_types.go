@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"maps"
+	"time"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	orcaloadconstants "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/orcaload/constants"
+)
+
+// LoadReportDataKey carries the most recent ORCA load report received from an
+// endpoint in a response header. Populated by the orca-load-producer on the
+// endpoint that served the request.
+var LoadReportDataKey = plugin.NewDataKey("LoadReportDataKey", orcaloadconstants.OrcaLoadProducerType)
+
+// LoadReport is the endpoint-reported load snapshot carried by an ORCA
+// (Open Request Cost Aggregation) response header.
+type LoadReport struct {
+	// CPUUtilization is the endpoint reported CPU utilization in [0, 1].
+	CPUUtilization float64
+	// MemUtilization is the endpoint reported memory utilization in [0, 1].
+	MemUtilization float64
+	// ApplicationUtilization is the application specific utilization, typically in [0, 1].
+	ApplicationUtilization float64
+	// RPS is the endpoint reported queries per second.
+	RPS float64
+	// EPS is the endpoint reported errors per second.
+	EPS float64
+	// NamedMetrics holds the free-form named metrics of the report
+	// (e.g. kv_cache_usage_perc, num_requests_waiting).
+	NamedMetrics map[string]float64
+	// Utilization holds the named utilization values of the report.
+	Utilization map[string]float64
+	// RequestCost holds the per-request cost values of the report.
+	RequestCost map[string]float64
+	// ReceivedAt is the time the report was received by the EPP. Consumers can
+	// compare it to Metrics.UpdateTime to decide which source is fresher.
+	ReceivedAt time.Time
+}
+
+// Clone returns a deep copy of the LoadReport.
+func (r *LoadReport) Clone() fwkdl.Cloneable {
+	if r == nil {
+		return nil
+	}
+	cp := *r
+	cp.NamedMetrics = maps.Clone(r.NamedMetrics)
+	cp.Utilization = maps.Clone(r.Utilization)
+	cp.RequestCost = maps.Clone(r.RequestCost)
+	return &cp
+}
+
+// ReadLoadReport returns the LoadReport stored under key in attrs.
+func ReadLoadReport(attrs fwkdl.AttributeMap, key string) (*LoadReport, bool) {
+	return fwkdl.ReadAttribute[*LoadReport](attrs, key)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"maps"
+	"time"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+// Names of the core metrics kept in the metrics history.
+const (
+	WaitingQueueSizeMetric    = "WaitingQueueSize"
+	RunningRequestsSizeMetric = "RunningRequestsSize"
+	KVCacheUsagePercentMetric = "KVCacheUsagePercent"
+)
+
+var (
+	// MetricsHistoryDataKey carries the recent samples of an endpoint's core metrics.
+	// Populated by the core-metrics-extractor when its history is enabled.
+	MetricsHistoryDataKey = plugin.NewDataKey("MetricsHistoryDataKey", "core-metrics-extractor")
+	// MetricsTrendsDataKey carries the trends derived from an endpoint's metrics history.
+	// Populated by the core-metrics-extractor when its history is enabled.
+	MetricsTrendsDataKey = plugin.NewDataKey("MetricsTrendsDataKey", "core-metrics-extractor")
+)
+
+// MetricsSample is a snapshot of the core metrics of an endpoint.
+type MetricsSample struct {
+	Time   time.Time
+	Values map[string]float64
+}
+
+// MetricsHistory is a bounded time series of the core metrics of an endpoint, oldest first.
+type MetricsHistory struct {
+	Samples []MetricsSample
+}
+
+// Clone returns an independent copy of the MetricsHistory.
+func (h *MetricsHistory) Clone() fwkdl.Cloneable {
+	if h == nil {
+		return nil
+	}
+	cp := &MetricsHistory{Samples: make([]MetricsSample, len(h.Samples))}
+	for i, sample := range h.Samples {
+		cp.Samples[i] = MetricsSample{Time: sample.Time, Values: maps.Clone(sample.Values)}
+	}
+	return cp
+}
+
+// MetricTrend holds the values derived from the history of a single metric.
+type MetricTrend struct {
+	// Latest is the most recent value of the metric.
+	Latest float64
+	// EWMA is the exponentially weighted moving average of the metric.
+	EWMA float64
+	// RatePerSecond is the change of the metric per second over its rate window,
+	// positive while the metric is rising.
+	RatePerSecond float64
+	// WindowMax is the highest value of the metric within its max window.
+	WindowMax float64
+	// UpdateTime is the time of the sample the trend was last updated with.
+	UpdateTime time.Time
+}
+
+// MetricsTrends maps the name of a metric to its trend.
+type MetricsTrends map[string]MetricTrend
+
+// Clone returns an independent copy of the MetricsTrends.
+func (t MetricsTrends) Clone() fwkdl.Cloneable {
+	if t == nil {
+		return nil
+	}
+	return maps.Clone(t)
+}
+
+// ReadMetricsHistory returns the MetricsHistory stored under key in attrs.
+func ReadMetricsHistory(attrs fwkdl.AttributeMap, key string) (*MetricsHistory, bool) {
+	return fwkdl.ReadAttribute[*MetricsHistory](attrs, key)
+}
+
+// ReadMetricsTrends returns the MetricsTrends stored under key in attrs.
+func ReadMetricsTrends(attrs fwkdl.AttributeMap, key string) (MetricsTrends, bool) {
+	return fwkdl.ReadAttribute[MetricsTrends](attrs, key)
+}
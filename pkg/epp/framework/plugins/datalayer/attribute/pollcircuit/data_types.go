@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pollcircuit
+
+import (
+	"time"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+const (
+	// CircuitClosed is the state of an endpoint that is polled normally.
+	CircuitClosed = "closed"
+	// CircuitOpen is the state of an endpoint whose recent polls kept failing and
+	// which is only polled again after a backoff.
+	CircuitOpen = "open"
+)
+
+// PollCircuitDataKey carries the polling state of an endpoint. Populated by
+// polling data sources configured with adaptive polling; the default producer
+// is the metrics-data-source.
+var PollCircuitDataKey = plugin.NewDataKey("PollCircuitDataKey", "metrics-data-source")
+
+// PollCircuit describes how a polling data source currently polls an endpoint.
+type PollCircuit struct {
+	// State is CircuitClosed or CircuitOpen.
+	State string
+	// ConsecutiveFailures is the number of polls that failed since the last
+	// successful one.
+	ConsecutiveFailures int
+	// LastSuccess is the time of the last successful poll, zero if there was none.
+	LastSuccess time.Time
+	// NextPoll is the earliest time the endpoint is polled again.
+	NextPoll time.Time
+}
+
+// Open reports whether the circuit of the endpoint is open.
+func (c *PollCircuit) Open() bool {
+	return c != nil && c.State == CircuitOpen
+}
+
+// Clone returns an independent copy of the PollCircuit.
+func (c *PollCircuit) Clone() fwkdl.Cloneable {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	return &cp
+}
+
+// ReadPollCircuit returns the PollCircuit stored under key in attrs.
+func ReadPollCircuit(attrs fwkdl.AttributeMap, key string) (*PollCircuit, bool) {
+	return fwkdl.ReadAttribute[*PollCircuit](attrs, key)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package warmup
+
+import (
+	"time"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	slowstartconstants "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/slowstart/constants"
+)
+
+// WarmUpDataKey carries the warm-up state of an endpoint. Populated by the
+// slow-start plugin as a dynamic attribute, so the factor is computed at the
+// time the attribute is read.
+var WarmUpDataKey = plugin.NewDataKey("WarmUpDataKey", slowstartconstants.SlowStartType)
+
+// WarmUp describes how far an endpoint is into its slow-start window.
+type WarmUp struct {
+	// FirstSeen is when the endpoint's ramp started: when its pod became ready, or
+	// when it was first added to the datastore if that is unknown.
+	FirstSeen time.Time
+	// Factor is the share of its full traffic the endpoint should receive, in
+	// (0, 1]. It is 1 once the endpoint has left its slow-start window.
+	Factor float64
+}
+
+// Clone returns an independent copy of the WarmUp.
+func (w *WarmUp) Clone() fwkdl.Cloneable {
+	if w == nil {
+		return nil
+	}
+	cp := *w
+	return &cp
+}
+
+// ReadWarmUp returns the WarmUp stored under key in attrs.
+func ReadWarmUp(attrs fwkdl.AttributeMap, key string) (*WarmUp, bool) {
+	return fwkdl.ReadAttribute[*WarmUp](attrs, key)
+}
@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+	sourcemetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/metrics"
+)
+
+const (
+	CustomMetricsExtractorType = "custom-metrics-extractor"
+)
+
+// Aggregations combining the series matched by a custom metric selector.
+const (
+	AggregationSum  = "sum"
+	AggregationMax  = "max"
+	AggregationMin  = "min"
+	AggregationAvg  = "avg"
+	AggregationRate = "rate"
+)
+
+var customMetricAggregations = []string{AggregationSum, AggregationMax, AggregationMin, AggregationAvg, AggregationRate}
+
+type (
+	// customMetricsExtractorParams holds the configuration parameters for the custom metrics extractor plugin.
+	customMetricsExtractorParams struct {
+		// Metrics lists the metrics to extract.
+		Metrics []customSeriesConfigParams `json:"metrics"`
+	}
+
+	// customSeriesConfigParams declares a single metric extracted as an endpoint attribute.
+	customSeriesConfigParams struct {
+		// Name is the endpoint attribute key the value is stored under.
+		Name string `json:"name"`
+		// Series is a PromQL instant vector selector, supporting the =, !=, =~ and !~ matchers.
+		Series string `json:"series"`
+		// Aggregation combines the matched series: sum, max, min, avg or rate. Defaults to sum.
+		Aggregation string `json:"aggregation,omitempty"`
+	}
+)
+
+// customMetric is a validated custom metric declaration.
+type customMetric struct {
+	name        string
+	selector    *seriesSelector
+	aggregation string
+	// rateKey is the endpoint attribute holding the previous sample of a rate aggregation.
+	rateKey string
+}
+
+// rateSample is the previous sum of the counters of a rate aggregation. It is only written
+// by the endpoint's collector goroutine.
+type rateSample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Clone returns a copy of the sample.
+func (s *rateSample) Clone() fwkdl.Cloneable {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	return &clone
+}
+
+// CustomMetricsExtractor extracts arbitrary, config-declared Prometheus series and stores them
+// as scalar endpoint attributes (attrmetrics.ScalarMetricValue), so that scheduling plugins can
+// consume engine-specific signals without code changes.
+type CustomMetricsExtractor struct {
+	typedName fwkplugin.TypedName
+	metrics   []customMetric
+	now       func() time.Time
+}
+
+// CustomMetricsExtractorFactory is a factory function used to instantiate custom metrics
+// Extractor plugins specified in a configuration.
+func CustomMetricsExtractorFactory(name string, parameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	params := &customMetricsExtractorParams{}
+	if parameters != nil {
+		if err := parameters.Decode(params); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", CustomMetricsExtractorType, err)
+		}
+	}
+	ext, err := newCustomMetricsExtractor(name, params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", CustomMetricsExtractorType, err)
+	}
+	return ext, nil
+}
+
+// newCustomMetricsExtractor validates the parameters and constructs a CustomMetricsExtractor.
+func newCustomMetricsExtractor(name string, params *customMetricsExtractorParams) (*CustomMetricsExtractor, error) {
+	if len(params.Metrics) == 0 {
+		return nil, errors.New("at least one metric must be configured")
+	}
+	if name == "" {
+		name = CustomMetricsExtractorType
+	}
+
+	ext := &CustomMetricsExtractor{
+		typedName: fwkplugin.TypedName{Type: CustomMetricsExtractorType, Name: name},
+		now:       time.Now,
+	}
+	seen := make(map[string]bool, len(params.Metrics))
+	for _, p := range params.Metrics {
+		if p.Name == "" {
+			return nil, fmt.Errorf("metric with series %q has no name", p.Series)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("metric %q is configured more than once", p.Name)
+		}
+		seen[p.Name] = true
+		if slices.Contains(historyMetrics, p.Name) {
+			return nil, fmt.Errorf("metric name %q is reserved for a core metric", p.Name)
+		}
+
+		selector, err := parseSeriesSelector(p.Series)
+		if err != nil {
+			return nil, fmt.Errorf("metric %q: %w", p.Name, err)
+		}
+		aggregation := p.Aggregation
+		if aggregation == "" {
+			aggregation = AggregationSum
+		}
+		if !slices.Contains(customMetricAggregations, aggregation) {
+			return nil, fmt.Errorf("metric %q: aggregation %q is not one of %v", p.Name, aggregation, customMetricAggregations)
+		}
+
+		metric := customMetric{name: p.Name, selector: selector, aggregation: aggregation}
+		if aggregation == AggregationRate {
+			metric.rateKey = name + "/" + p.Name + "/rate-sample"
+		}
+		ext.metrics = append(ext.metrics, metric)
+	}
+	return ext, nil
+}
+
+// TypedName returns the type and name of the CustomMetricsExtractor.
+func (ext *CustomMetricsExtractor) TypedName() fwkplugin.TypedName {
+	return ext.typedName
+}
+
+// Produces returns no data keys: like the core extractor's custom metrics, the attribute keys
+// are configuration defined.
+func (ext *CustomMetricsExtractor) Produces() map[fwkplugin.DataKey]any {
+	return map[fwkplugin.DataKey]any{}
+}
+
+// Extract aggregates the configured series and stores them as endpoint attributes. A metric
+// whose series are missing is reported as an error and keeps its previous value.
+func (ext *CustomMetricsExtractor) Extract(ctx context.Context, in fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]) error {
+	attrs := in.Endpoint.GetAttributes()
+	now := ext.now()
+
+	var errs []error
+	extracted := make(map[string]float64, len(ext.metrics))
+	for _, metric := range ext.metrics {
+		values, err := metric.selector.values(in.Payload)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("custom metric %q: %w", metric.name, err))
+			continue
+		}
+		value, ok := metric.aggregate(attrs, values, now)
+		if !ok {
+			continue
+		}
+		attrs.Put(metric.name, attrmetrics.ScalarMetricValue(value))
+		extracted[metric.name] = value
+	}
+
+	log.FromContext(ctx).V(logutil.TRACE).Info("Extracted custom metrics",
+		"endpoint", in.Endpoint.GetMetadata().GetNamespacedName(), "metrics", extracted)
+	return errors.Join(errs...)
+}
+
+// values returns the values of all series of the payload matched by the selector.
+func (sel *seriesSelector) values(families sourcemetrics.PrometheusMetricMap) ([]float64, error) {
+	family, err := extractFamily(&Spec{Name: sel.name}, families)
+	if err != nil {
+		return nil, err
+	}
+	var values []float64
+	for _, metric := range family.GetMetric() {
+		if sel.matches(metric.GetLabel()) {
+			values = append(values, extractValue(metric))
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no series matching %s", sel)
+	}
+	return values, nil
+}
+
+// aggregate combines the matched values. It returns false when no value is available yet, which
+// is the case on the first sample of a rate.
+func (m customMetric) aggregate(attrs fwkdl.AttributeMap, values []float64, now time.Time) (float64, bool) {
+	switch m.aggregation {
+	case AggregationMax:
+		return slices.Max(values), true
+	case AggregationMin:
+		return slices.Min(values), true
+	case AggregationAvg:
+		return sum(values) / float64(len(values)), true
+	case AggregationRate:
+		return m.rate(attrs, sum(values), now)
+	default:
+		return sum(values), true
+	}
+}
+
+// rate returns the per-second increase of the summed counters since the previous sample. A
+// decrease is treated as a counter reset, in which case the current value is the increase.
+func (m customMetric) rate(attrs fwkdl.AttributeMap, value float64, now time.Time) (float64, bool) {
+	prev, found := fwkdl.ReadAttribute[*rateSample](attrs, m.rateKey)
+	attrs.Put(m.rateKey, &rateSample{Time: now, Value: value})
+	if !found || prev == nil {
+		return 0, false
+	}
+	elapsed := now.Sub(prev.Time).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	increase := value - prev.Value
+	if increase < 0 {
+		increase = value
+	}
+	return increase / elapsed, true
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
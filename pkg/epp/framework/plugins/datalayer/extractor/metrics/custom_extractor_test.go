@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+	sourcemetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/metrics"
+)
+
+func TestCustomMetricsExtractorFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			params: `{"metrics": [
+				{"name": "acceptance", "series": "vllm:spec_decode_num_accepted_tokens_total", "aggregation": "rate"},
+				{"name": "preemptions", "series": "vllm:num_preemptions_total{model_name=~\"llama.*\"}"}
+			]}`,
+		},
+		{name: "no metrics", params: `{}`, wantErr: true},
+		{name: "unknown field", params: `{"metric": []}`, wantErr: true},
+		{name: "missing name", params: `{"metrics": [{"series": "m"}]}`, wantErr: true},
+		{name: "duplicate name", params: `{"metrics": [{"name": "a", "series": "m"}, {"name": "a", "series": "n"}]}`, wantErr: true},
+		{name: "reserved name", params: `{"metrics": [{"name": "WaitingQueueSize", "series": "m"}]}`, wantErr: true},
+		{name: "invalid series", params: `{"metrics": [{"name": "a", "series": "m{"}]}`, wantErr: true},
+		{name: "invalid aggregation", params: `{"metrics": [{"name": "a", "series": "m", "aggregation": "p99"}]}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plugin, err := CustomMetricsExtractorFactory("custom", fwkplugin.StrictDecoder(json.RawMessage(tt.params)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: CustomMetricsExtractorType, Name: "custom"}, plugin.TypedName())
+		})
+	}
+}
+
+func TestCustomMetricsExtractorAggregations(t *testing.T) {
+	t.Parallel()
+
+	families := sourcemetrics.PrometheusMetricMap{
+		"tokens": makeMetricFamily("tokens",
+			makeMetric(map[string]string{"position": "0"}, 6, 0),
+			makeMetric(map[string]string{"position": "1"}, 2, 0),
+			makeMetric(map[string]string{"position": "2"}, 1, 0),
+		),
+	}
+
+	tests := []struct {
+		aggregation string
+		want        float64
+	}{
+		{aggregation: "", want: 9},
+		{aggregation: AggregationSum, want: 9},
+		{aggregation: AggregationMax, want: 6},
+		{aggregation: AggregationMin, want: 1},
+		{aggregation: AggregationAvg, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.aggregation, func(t *testing.T) {
+			t.Parallel()
+			ext, err := newCustomMetricsExtractor("custom", &customMetricsExtractorParams{
+				Metrics: []customSeriesConfigParams{{Name: "tokens", Series: "tokens", Aggregation: tt.aggregation}},
+			})
+			require.NoError(t, err)
+			ep := newEndpointAt("127.0.0.1:8000", nil)
+
+			require.NoError(t, ext.Extract(context.Background(), fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]{Payload: families, Endpoint: ep}))
+			got, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "tokens")
+			require.True(t, ok)
+			assert.InDelta(t, tt.want, float64(got), 1e-9)
+		})
+	}
+}
+
+func TestCustomMetricsExtractorSelectsSeries(t *testing.T) {
+	t.Parallel()
+
+	ext, err := newCustomMetricsExtractor("custom", &customMetricsExtractorParams{
+		Metrics: []customSeriesConfigParams{{Name: "early", Series: `tokens{position=~"0|1"}`}},
+	})
+	require.NoError(t, err)
+	ep := newEndpointAt("127.0.0.1:8000", nil)
+	families := sourcemetrics.PrometheusMetricMap{
+		"tokens": makeMetricFamily("tokens",
+			makeMetric(map[string]string{"position": "0"}, 6, 0),
+			makeMetric(map[string]string{"position": "1"}, 2, 0),
+			makeMetric(map[string]string{"position": "2"}, 1, 0),
+		),
+	}
+
+	require.NoError(t, ext.Extract(context.Background(), fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]{Payload: families, Endpoint: ep}))
+	got, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "early")
+	require.True(t, ok)
+	assert.InDelta(t, 8.0, float64(got), 1e-9)
+}
+
+func TestCustomMetricsExtractorRate(t *testing.T) {
+	t.Parallel()
+
+	ext, err := newCustomMetricsExtractor("custom", &customMetricsExtractorParams{
+		Metrics: []customSeriesConfigParams{{Name: "preemptions", Series: "preemptions_total", Aggregation: AggregationRate}},
+	})
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	ext.now = func() time.Time { return now }
+	ep := newEndpointAt("127.0.0.1:8000", nil)
+
+	extract := func(value float64) (float64, bool) {
+		families := sourcemetrics.PrometheusMetricMap{
+			"preemptions_total": makeMetricFamily("preemptions_total", makeMetric(nil, value, 0)),
+		}
+		require.NoError(t, ext.Extract(context.Background(), fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]{Payload: families, Endpoint: ep}))
+		got, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "preemptions")
+		return float64(got), ok
+	}
+
+	_, ok := extract(10)
+	assert.False(t, ok, "the first sample must not produce a rate")
+
+	now = now.Add(2 * time.Second)
+	got, ok := extract(20)
+	require.True(t, ok)
+	assert.InDelta(t, 5.0, got, 1e-9)
+
+	now = now.Add(4 * time.Second) // counter reset: the new value is the increase
+	got, ok = extract(8)
+	require.True(t, ok)
+	assert.InDelta(t, 2.0, got, 1e-9)
+}
+
+func TestCustomMetricsExtractorMissingSeries(t *testing.T) {
+	t.Parallel()
+
+	ext, err := newCustomMetricsExtractor("custom", &customMetricsExtractorParams{
+		Metrics: []customSeriesConfigParams{
+			{Name: "present", Series: "present"},
+			{Name: "filtered", Series: `present{model="other"}`},
+			{Name: "absent", Series: "absent"},
+		},
+	})
+	require.NoError(t, err)
+	ep := newEndpointAt("127.0.0.1:8000", nil)
+	ep.GetAttributes().Put("absent", attrmetrics.ScalarMetricValue(3))
+	families := sourcemetrics.PrometheusMetricMap{
+		"present": makeMetricFamily("present", makeMetric(map[string]string{"model": "m"}, 4, 0)),
+	}
+
+	err = ext.Extract(context.Background(), fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]{Payload: families, Endpoint: ep})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"filtered"`)
+	assert.Contains(t, err.Error(), `"absent"`)
+
+	got, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "present")
+	require.True(t, ok)
+	assert.InDelta(t, 4.0, float64(got), 1e-9)
+	got, ok = attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "absent")
+	require.True(t, ok, "a missing series must keep the previous value")
+	assert.InDelta(t, 3.0, float64(got), 1e-9)
+	_, ok = attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "filtered")
+	assert.False(t, ok)
+}
+
+// TestCustomMetricsExtractionFromSource verifies that the custom extractor runs alongside the
+// core extractor on a metrics data source.
+func TestCustomMetricsExtractionFromSource(t *testing.T) {
+	srv := createMockServer([]MetricMock{
+		{Name: WaitingMetric, Value: 2},
+		{Name: "vllm:spec_decode_acceptance", Value: 0.25, Labels: map[string]string{"position": "0"}},
+		{Name: "vllm:spec_decode_acceptance", Value: 0.75, Labels: map[string]string{"position": "1"}},
+	})
+	defer srv.Close()
+
+	source := buildSource(t, srv.URL)
+	custom, err := newCustomMetricsExtractor("custom", &customMetricsExtractorParams{
+		Metrics: []customSeriesConfigParams{{Name: "acceptance", Series: "vllm:spec_decode_acceptance", Aggregation: AggregationAvg}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.AppendExtractor(buildExtractor(t, nil)))
+	require.NoError(t, source.AppendExtractor(custom))
+
+	ep := newEndpointAt(mustHost(t, srv.URL), nil)
+	require.NoError(t, source.Dispatch(context.Background(), ep))
+
+	assert.Equal(t, 2, ep.GetMetrics().WaitingQueueSize)
+	got, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "acceptance")
+	require.True(t, ok)
+	assert.InDelta(t, 0.5, float64(got), 1e-9)
+}
@@ -60,6 +60,8 @@ type Extractor struct {
 	typedName      fwkplugin.TypedName
 	registry       *MappingRegistry
 	engineLabelKey string
+	// history is nil unless the metrics history is enabled.
+	history *metricsHistory
 }
 
 // NewCoreMetricsExtractor returns a new model server protocol (MSP) metrics extractor,
@@ -86,6 +88,17 @@ func (ext *Extractor) TypedName() fwkplugin.TypedName {
 	return ext.typedName
 }
 
+// Produces declares the metrics history and trend attributes when the history is enabled.
+func (ext *Extractor) Produces() map[fwkplugin.DataKey]any {
+	if ext.history == nil {
+		return map[fwkplugin.DataKey]any{}
+	}
+	return map[fwkplugin.DataKey]any{
+		ext.history.historyKey: attrmetrics.MetricsHistory{},
+		ext.history.trendsKey:  attrmetrics.MetricsTrends{},
+	}
+}
+
 // Extract transforms the typed metrics payload into endpoint attributes.
 func (ext *Extractor) Extract(ctx context.Context, in fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]) error {
 	families := in.Payload
@@ -190,6 +203,9 @@ func (ext *Extractor) Extract(ctx context.Context, in fwkdl.PollInput[sourcemetr
 			"updated", clone,
 		)
 		ep.UpdateMetrics(clone)
+		if ext.history != nil {
+			ext.history.record(ep, clone)
+		}
 	}
 
 	if len(errs) != 0 {
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
@@ -82,6 +83,29 @@ type (
 		// EngineConfigs defines metric specifications for specific engine types.
 		// Built-in configs (vLLM, SGLang, trtllm-serve, triton-tensorrt-llm, triton) are automatically appended if not explicitly defined.
 		EngineConfigs []engineConfigParams `json:"engineConfigs"`
+		// History enables a bounded per-endpoint history of the core metrics and the trends
+		// derived from it. Disabled if unset.
+		History *historyConfigParams `json:"history,omitempty"`
+	}
+
+	// historyConfigParams configures the metrics history kept per endpoint.
+	historyConfigParams struct {
+		// MaxSamples bounds the number of samples kept per endpoint. Defaults to 64.
+		MaxSamples int `json:"maxSamples,omitempty"`
+		// Metrics lists the core metrics to track. Defaults to all of them.
+		Metrics []historyMetricConfigParams `json:"metrics,omitempty"`
+	}
+
+	// historyMetricConfigParams configures the trend derived for a single core metric.
+	historyMetricConfigParams struct {
+		// Name is one of WaitingQueueSize, RunningRequestsSize or KVCacheUsagePercent.
+		Name string `json:"name"`
+		// EWMAHalfLife is the time after which a sample has half its weight in the EWMA. Defaults to 5s.
+		EWMAHalfLife *metav1.Duration `json:"ewmaHalfLife,omitempty"`
+		// RateWindow is the window the rate of change is computed over. Defaults to 10s.
+		RateWindow *metav1.Duration `json:"rateWindow,omitempty"`
+		// MaxWindow is the window the maximum is computed over. Defaults to 10s.
+		MaxWindow *metav1.Duration `json:"maxWindow,omitempty"`
 	}
 )
 
@@ -253,6 +277,11 @@ func newCoreMetricsExtractorPlugin(ctx context.Context, name string, params *mod
 		return nil, err
 	}
 	extractor.typedName.Name = name
+	if params.History != nil {
+		if extractor.history, err = newMetricsHistory(params.History, name); err != nil {
+			return nil, err
+		}
+	}
 	return extractor, nil
 }
 
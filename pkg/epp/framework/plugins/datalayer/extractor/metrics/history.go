@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+)
+
+// Defaults of the metrics history.
+const (
+	defaultHistoryMaxSamples   = 64
+	defaultHistoryEWMAHalfLife = 5 * time.Second
+	defaultHistoryRateWindow   = 10 * time.Second
+	defaultHistoryMaxWindow    = 10 * time.Second
+)
+
+// historyMetrics are the core metrics the history can track, in their default order.
+var historyMetrics = []string{
+	attrmetrics.WaitingQueueSizeMetric,
+	attrmetrics.RunningRequestsSizeMetric,
+	attrmetrics.KVCacheUsagePercentMetric,
+}
+
+// metricHistoryConfig configures the trend derived for a single metric.
+type metricHistoryConfig struct {
+	name         string
+	ewmaHalfLife time.Duration
+	rateWindow   time.Duration
+	maxWindow    time.Duration
+}
+
+// metricsHistory keeps a bounded time series of the core metrics of every endpoint and derives
+// their trends. The series and the trends are stored as endpoint attributes, which are only
+// written by the endpoint's collector goroutine.
+type metricsHistory struct {
+	historyKey fwkplugin.DataKey
+	trendsKey  fwkplugin.DataKey
+	maxSamples int
+	retention  time.Duration
+	metrics    []metricHistoryConfig
+}
+
+// newMetricsHistory validates the history parameters and applies their defaults. If no metric
+// is configured, all core metrics are tracked.
+func newMetricsHistory(params *historyConfigParams, extractorName string) (*metricsHistory, error) {
+	history := &metricsHistory{
+		historyKey: attrmetrics.MetricsHistoryDataKey.WithNonEmptyProducerName(extractorName),
+		trendsKey:  attrmetrics.MetricsTrendsDataKey.WithNonEmptyProducerName(extractorName),
+		maxSamples: defaultHistoryMaxSamples,
+	}
+	if params.MaxSamples < 0 {
+		return nil, fmt.Errorf("history 'maxSamples' must not be negative, got %d", params.MaxSamples)
+	}
+	if params.MaxSamples > 0 {
+		history.maxSamples = params.MaxSamples
+	}
+
+	metricParams := params.Metrics
+	if len(metricParams) == 0 {
+		for _, name := range historyMetrics {
+			metricParams = append(metricParams, historyMetricConfigParams{Name: name})
+		}
+	}
+	seen := make(map[string]bool, len(metricParams))
+	for _, p := range metricParams {
+		if !isHistoryMetric(p.Name) {
+			return nil, fmt.Errorf("history metric %q is not one of %v", p.Name, historyMetrics)
+		}
+		if seen[p.Name] {
+			return nil, fmt.Errorf("history metric %q is configured more than once", p.Name)
+		}
+		seen[p.Name] = true
+
+		cfg := metricHistoryConfig{
+			name:         p.Name,
+			ewmaHalfLife: durationOrDefault(p.EWMAHalfLife, defaultHistoryEWMAHalfLife),
+			rateWindow:   durationOrDefault(p.RateWindow, defaultHistoryRateWindow),
+			maxWindow:    durationOrDefault(p.MaxWindow, defaultHistoryMaxWindow),
+		}
+		if cfg.ewmaHalfLife <= 0 || cfg.rateWindow <= 0 || cfg.maxWindow <= 0 {
+			return nil, fmt.Errorf("history metric %q: 'ewmaHalfLife', 'rateWindow' and 'maxWindow' must be positive", p.Name)
+		}
+		history.retention = max(history.retention, cfg.rateWindow, cfg.maxWindow)
+		history.metrics = append(history.metrics, cfg)
+	}
+	return history, nil
+}
+
+// record appends the endpoint's latest metrics to its history and updates its trends.
+func (h *metricsHistory) record(ep fwkdl.Endpoint, metrics *fwkdl.Metrics) {
+	attrs := ep.GetAttributes()
+	now := metrics.UpdateTime
+
+	sample := attrmetrics.MetricsSample{Time: now, Values: make(map[string]float64, len(h.metrics))}
+	for _, cfg := range h.metrics {
+		sample.Values[cfg.name] = coreMetricValue(metrics, cfg.name)
+	}
+	history, ok := attrmetrics.ReadMetricsHistory(attrs, h.historyKey.String())
+	if !ok {
+		history = &attrmetrics.MetricsHistory{}
+	}
+	samples := append(history.Samples, sample)
+	first := 0
+	for first < len(samples)-1 && (now.Sub(samples[first].Time) > h.retention || len(samples)-first > h.maxSamples) {
+		first++
+	}
+	history.Samples = samples[first:]
+	attrs.Put(h.historyKey.String(), history)
+
+	previous, _ := attrmetrics.ReadMetricsTrends(attrs, h.trendsKey.String())
+	trends := make(attrmetrics.MetricsTrends, len(h.metrics))
+	for _, cfg := range h.metrics {
+		value := sample.Values[cfg.name]
+		trend := attrmetrics.MetricTrend{Latest: value, EWMA: value, UpdateTime: now}
+		if prev, ok := previous[cfg.name]; ok && now.After(prev.UpdateTime) {
+			// The weight of the new value grows with the time since the previous sample, so
+			// the average does not depend on the polling interval.
+			alpha := 1 - math.Exp2(-float64(now.Sub(prev.UpdateTime))/float64(cfg.ewmaHalfLife))
+			trend.EWMA = prev.EWMA + alpha*(value-prev.EWMA)
+		}
+		trend.RatePerSecond = ratePerSecond(history.Samples, cfg.name, now.Add(-cfg.rateWindow))
+		trend.WindowMax = windowMax(history.Samples, cfg.name, now.Add(-cfg.maxWindow))
+		trends[cfg.name] = trend
+	}
+	attrs.Put(h.trendsKey.String(), trends)
+}
+
+// ratePerSecond returns the least squares slope of the metric over the samples taken after since,
+// or 0 if there are too few of them.
+func ratePerSecond(samples []attrmetrics.MetricsSample, name string, since time.Time) float64 {
+	var n, sumT, sumV, sumTT, sumTV float64
+	var origin time.Time
+	for _, sample := range samples {
+		if sample.Time.Before(since) {
+			continue
+		}
+		if origin.IsZero() {
+			origin = sample.Time
+		}
+		t := sample.Time.Sub(origin).Seconds()
+		v := sample.Values[name]
+		n++
+		sumT += t
+		sumV += v
+		sumTT += t * t
+		sumTV += t * v
+	}
+	denominator := n*sumTT - sumT*sumT
+	if n < 2 || denominator == 0 {
+		return 0
+	}
+	return (n*sumTV - sumT*sumV) / denominator
+}
+
+// windowMax returns the highest value of the metric over the samples taken after since.
+func windowMax(samples []attrmetrics.MetricsSample, name string, since time.Time) float64 {
+	highest := math.Inf(-1)
+	for _, sample := range samples {
+		if !sample.Time.Before(since) {
+			highest = math.Max(highest, sample.Values[name])
+		}
+	}
+	if math.IsInf(highest, -1) {
+		return 0
+	}
+	return highest
+}
+
+func coreMetricValue(metrics *fwkdl.Metrics, name string) float64 {
+	switch name {
+	case attrmetrics.WaitingQueueSizeMetric:
+		return float64(metrics.WaitingQueueSize)
+	case attrmetrics.RunningRequestsSizeMetric:
+		return float64(metrics.RunningRequestsSize)
+	case attrmetrics.KVCacheUsagePercentMetric:
+		return metrics.KVCacheUsagePercent
+	}
+	return 0
+}
+
+func isHistoryMetric(name string) bool {
+	return slices.Contains(historyMetrics, name)
+}
+
+func durationOrDefault(d *metav1.Duration, defaultValue time.Duration) time.Duration {
+	if d == nil {
+		return defaultValue
+	}
+	return d.Duration
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+	sourcemetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/metrics"
+)
+
+func TestNewMetricsHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  historyConfigParams
+		wantErr bool
+	}{
+		{name: "defaults track all core metrics", params: historyConfigParams{}},
+		{name: "single metric", params: historyConfigParams{Metrics: []historyMetricConfigParams{{Name: attrmetrics.WaitingQueueSizeMetric}}}},
+		{name: "unknown metric", params: historyConfigParams{Metrics: []historyMetricConfigParams{{Name: "Latency"}}}, wantErr: true},
+		{
+			name: "duplicate metric",
+			params: historyConfigParams{Metrics: []historyMetricConfigParams{
+				{Name: attrmetrics.WaitingQueueSizeMetric}, {Name: attrmetrics.WaitingQueueSizeMetric},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "zero rate window",
+			params: historyConfigParams{Metrics: []historyMetricConfigParams{
+				{Name: attrmetrics.WaitingQueueSizeMetric, RateWindow: &metav1.Duration{}},
+			}},
+			wantErr: true,
+		},
+		{name: "negative max samples", params: historyConfigParams{MaxSamples: -1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := newMetricsHistory(&tt.params, MetricsExtractorType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if len(tt.params.Metrics) == 0 {
+				assert.Len(t, history.metrics, len(historyMetrics))
+			}
+		})
+	}
+}
+
+func TestMetricsHistoryRecord(t *testing.T) {
+	history, err := newMetricsHistory(&historyConfigParams{
+		MaxSamples: 4,
+		Metrics: []historyMetricConfigParams{{
+			Name:         attrmetrics.WaitingQueueSizeMetric,
+			EWMAHalfLife: &metav1.Duration{Duration: time.Second},
+			RateWindow:   &metav1.Duration{Duration: 3 * time.Second},
+			MaxWindow:    &metav1.Duration{Duration: 2 * time.Second},
+		}},
+	}, MetricsExtractorType)
+	require.NoError(t, err)
+
+	ep := fwkdl.NewEndpoint(nil, nil)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := func(offset time.Duration, waiting int) attrmetrics.MetricTrend {
+		history.record(ep, &fwkdl.Metrics{WaitingQueueSize: waiting, UpdateTime: start.Add(offset)})
+		trends, ok := attrmetrics.ReadMetricsTrends(ep.GetAttributes(), attrmetrics.MetricsTrendsDataKey.String())
+		require.True(t, ok)
+		return trends[attrmetrics.WaitingQueueSizeMetric]
+	}
+
+	trend := record(0, 8)
+	assert.Equal(t, attrmetrics.MetricTrend{Latest: 8, EWMA: 8, WindowMax: 8, UpdateTime: start}, trend)
+
+	trend = record(time.Second, 4)
+	assert.InDelta(t, 6, trend.EWMA, 1e-9, "a sample one half-life later has half the weight")
+	assert.InDelta(t, -4, trend.RatePerSecond, 1e-9)
+	assert.Equal(t, 8.0, trend.WindowMax)
+
+	record(2*time.Second, 6)
+	trend = record(3*time.Second, 8)
+	assert.InDelta(t, 0.2, trend.RatePerSecond, 1e-9, "the rate is the least squares slope over the rate window")
+	assert.Equal(t, 8.0, trend.WindowMax)
+	trend = record(4*time.Second, 2)
+	assert.Equal(t, 8.0, trend.WindowMax)
+	trend = record(6*time.Second, 2)
+	assert.Equal(t, 2.0, trend.WindowMax, "samples outside the max window are ignored")
+
+	stored, ok := attrmetrics.ReadMetricsHistory(ep.GetAttributes(), attrmetrics.MetricsHistoryDataKey.String())
+	require.True(t, ok)
+	require.Len(t, stored.Samples, 3, "samples older than the longest window are dropped")
+	assert.Equal(t, start.Add(3*time.Second), stored.Samples[0].Time)
+}
+
+func TestMetricsHistoryMaxSamples(t *testing.T) {
+	history, err := newMetricsHistory(&historyConfigParams{MaxSamples: 2}, MetricsExtractorType)
+	require.NoError(t, err)
+
+	ep := fwkdl.NewEndpoint(nil, nil)
+	start := time.Now()
+	for i := range 5 {
+		history.record(ep, &fwkdl.Metrics{WaitingQueueSize: i, UpdateTime: start.Add(time.Duration(i) * time.Millisecond)})
+	}
+	stored, ok := attrmetrics.ReadMetricsHistory(ep.GetAttributes(), attrmetrics.MetricsHistoryDataKey.String())
+	require.True(t, ok)
+	require.Len(t, stored.Samples, 2)
+	assert.Equal(t, 4.0, stored.Samples[1].Values[attrmetrics.WaitingQueueSizeMetric])
+}
+
+func TestCoreMetricsExtractorHistory(t *testing.T) {
+	plugin, err := CoreMetricsExtractorFactory("core", json.NewDecoder(strings.NewReader(`{"history": {}}`)), nil)
+	require.NoError(t, err)
+	extractor := plugin.(*Extractor)
+
+	trendsKey := attrmetrics.MetricsTrendsDataKey.WithNonEmptyProducerName("core")
+	assert.Contains(t, plugin.(fwkplugin.ProducerPlugin).Produces(), trendsKey)
+
+	ep := fwkdl.NewEndpoint(nil, nil)
+	families := sourcemetrics.PrometheusMetricMap{
+		defaultTotalQueuedRequestsMetric: &dto.MetricFamily{
+			Type:   dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{{Gauge: &dto.Gauge{Value: ptr.To(3.0)}}},
+		},
+	}
+	_ = extractor.Extract(context.Background(), fwkdl.PollInput[sourcemetrics.PrometheusMetricMap]{Payload: families, Endpoint: ep})
+
+	trends, ok := attrmetrics.ReadMetricsTrends(ep.GetAttributes(), trendsKey.String())
+	require.True(t, ok, "the trends must be published once metrics were extracted")
+	assert.Equal(t, 3.0, trends[attrmetrics.WaitingQueueSizeMetric].Latest)
+
+	plain, err := CoreMetricsExtractorFactory("core", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plain.(fwkplugin.ProducerPlugin).Produces(), "the history is disabled by default")
+}
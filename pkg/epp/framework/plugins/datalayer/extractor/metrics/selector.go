@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+)
+
+// matchOp is a label matching operator of a series selector.
+type matchOp string
+
+const (
+	matchEqual     matchOp = "="
+	matchNotEqual  matchOp = "!="
+	matchRegexp    matchOp = "=~"
+	matchNotRegexp matchOp = "!~"
+)
+
+// labelMatcher matches a single label of a series.
+type labelMatcher struct {
+	name  string
+	op    matchOp
+	value string
+	// re is set for the regular expression operators. Like in PromQL, it is anchored on both ends.
+	re *regexp.Regexp
+}
+
+// matches reports whether the label value satisfies the matcher. A missing label matches as
+// the empty string, as in PromQL.
+func (m labelMatcher) matches(value string) bool {
+	switch m.op {
+	case matchEqual:
+		return value == m.value
+	case matchNotEqual:
+		return value != m.value
+	case matchRegexp:
+		return m.re.MatchString(value)
+	case matchNotRegexp:
+		return !m.re.MatchString(value)
+	}
+	return false
+}
+
+// seriesSelector selects the series of a metric family whose labels satisfy all matchers.
+type seriesSelector struct {
+	name     string
+	matchers []labelMatcher
+}
+
+// parseSeriesSelector parses a selector in PromQL Instant Vector Selector syntax, e.g.
+// metric_name{label1="value1",label2=~"v.*",label3!="x"}. Label values may be left unquoted
+// when they contain no separators.
+func parseSeriesSelector(selector string) (*seriesSelector, error) {
+	name, block, hasBlock := strings.Cut(strings.TrimSpace(selector), "{")
+	name = strings.TrimSpace(name)
+	if !model.LegacyValidation.IsValidMetricName(name) {
+		return nil, fmt.Errorf("not a valid series selector: %q", selector)
+	}
+	sel := &seriesSelector{name: name}
+	if !hasBlock {
+		return sel, nil
+	}
+	block = strings.TrimSpace(block)
+	if !strings.HasSuffix(block, "}") {
+		return nil, fmt.Errorf("unterminated label block in series selector: %q", selector)
+	}
+	block = strings.TrimSuffix(block, "}")
+
+	for rest := strings.TrimSpace(block); rest != ""; {
+		matcher, remaining, err := parseLabelMatcher(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid series selector %q: %w", selector, err)
+		}
+		sel.matchers = append(sel.matchers, matcher)
+		rest = strings.TrimSpace(remaining)
+		if rest == "" {
+			break
+		}
+		if rest[0] != ',' {
+			return nil, fmt.Errorf("invalid series selector %q: expected ',' before %q", selector, rest)
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+	return sel, nil
+}
+
+// parseLabelMatcher parses the leading label matcher of s and returns the unparsed remainder.
+func parseLabelMatcher(s string) (labelMatcher, string, error) {
+	end := strings.IndexAny(s, "=!")
+	if end < 0 {
+		return labelMatcher{}, "", fmt.Errorf("missing operator in %q", s)
+	}
+	matcher := labelMatcher{name: strings.TrimSpace(s[:end])}
+	if !model.LegacyValidation.IsValidLabelName(matcher.name) {
+		return labelMatcher{}, "", fmt.Errorf("invalid label name %q", matcher.name)
+	}
+
+	s = s[end:]
+	switch {
+	case strings.HasPrefix(s, string(matchRegexp)):
+		matcher.op = matchRegexp
+	case strings.HasPrefix(s, string(matchNotRegexp)):
+		matcher.op = matchNotRegexp
+	case strings.HasPrefix(s, string(matchNotEqual)):
+		matcher.op = matchNotEqual
+	case strings.HasPrefix(s, string(matchEqual)):
+		matcher.op = matchEqual
+	default:
+		return labelMatcher{}, "", fmt.Errorf("invalid operator for label %q", matcher.name)
+	}
+	s = strings.TrimSpace(s[len(matcher.op):])
+
+	value, rest, err := parseLabelValue(s)
+	if err != nil {
+		return labelMatcher{}, "", fmt.Errorf("label %q: %w", matcher.name, err)
+	}
+	matcher.value = value
+	if matcher.op == matchRegexp || matcher.op == matchNotRegexp {
+		if matcher.re, err = regexp.Compile("^(?:" + value + ")$"); err != nil {
+			return labelMatcher{}, "", fmt.Errorf("label %q: invalid regular expression: %w", matcher.name, err)
+		}
+	}
+	return matcher, rest, nil
+}
+
+// parseLabelValue parses the leading, optionally double-quoted, label value of s and returns
+// the unparsed remainder.
+func parseLabelValue(s string) (string, string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		end := strings.IndexByte(s, ',')
+		if end < 0 {
+			end = len(s)
+		}
+		return strings.TrimSpace(s[:end]), s[end:], nil
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case '"':
+			value, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("invalid quoted value %s: %w", s[:i+1], err)
+			}
+			return value, s[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quoted value %s", s)
+}
+
+// matches reports whether the series labels satisfy all matchers of the selector.
+func (sel *seriesSelector) matches(labels []*dto.LabelPair) bool {
+	for _, m := range sel.matchers {
+		value := ""
+		for _, label := range labels {
+			if label.GetName() == m.name {
+				value = label.GetValue()
+				break
+			}
+		}
+		if !m.matches(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector's name followed by its matchers.
+func (sel *seriesSelector) String() string {
+	if len(sel.matchers) == 0 {
+		return sel.name
+	}
+	matchers := make([]string, 0, len(sel.matchers))
+	for _, m := range sel.matchers {
+		matchers = append(matchers, m.name+string(m.op)+strconv.Quote(m.value))
+	}
+	return sel.name + "{" + strings.Join(matchers, ",") + "}"
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeriesSelector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantErr  bool
+		matching []map[string]string
+		other    []map[string]string
+	}{
+		{
+			name:     "name only",
+			input:    "vllm:num_preemptions_total",
+			want:     "vllm:num_preemptions_total",
+			matching: []map[string]string{nil, {"model": "a"}},
+		},
+		{
+			name:     "quoted and unquoted equality",
+			input:    `metric{model="a", engine=v1}`,
+			want:     `metric{model="a",engine="v1"}`,
+			matching: []map[string]string{{"model": "a", "engine": "v1"}},
+			other:    []map[string]string{{"model": "a"}, {"model": "b", "engine": "v1"}},
+		},
+		{
+			name:     "inequality matches missing label",
+			input:    `metric{phase!="prefill"}`,
+			want:     `metric{phase!="prefill"}`,
+			matching: []map[string]string{nil, {"phase": "decode"}},
+			other:    []map[string]string{{"phase": "prefill"}},
+		},
+		{
+			name:     "anchored regular expressions",
+			input:    `metric{position=~"[0-3]", le!~"\\+Inf|0\\..*"}`,
+			want:     `metric{position=~"[0-3]",le!~"\\+Inf|0\\..*"}`,
+			matching: []map[string]string{{"position": "2", "le": "10"}},
+			other:    []map[string]string{{"position": "12", "le": "10"}, {"position": "1", "le": "+Inf"}},
+		},
+		{
+			name:     "quoted value with separators",
+			input:    `metric{adapters="a,b}"}`,
+			want:     `metric{adapters="a,b}"}`,
+			matching: []map[string]string{{"adapters": "a,b}"}},
+		},
+		{name: "empty", input: "", wantErr: true},
+		{name: "invalid name", input: "1metric", wantErr: true},
+		{name: "unterminated block", input: "metric{a=b", wantErr: true},
+		{name: "missing operator", input: "metric{a}", wantErr: true},
+		{name: "invalid label name", input: `metric{1a="b"}`, wantErr: true},
+		{name: "invalid regular expression", input: `metric{a=~"("}`, wantErr: true},
+		{name: "unterminated quote", input: `metric{a="b}`, wantErr: true},
+		{name: "missing separator", input: `metric{a="b" c="d"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sel, err := parseSeriesSelector(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sel.String())
+			for _, labels := range tt.matching {
+				assert.True(t, sel.matches(makeMetric(labels, 0, 0).GetLabel()), "expected %v to match", labels)
+			}
+			for _, labels := range tt.other {
+				assert.False(t, sel.matches(makeMetric(labels, 0, 0).GetLabel()), "expected %v not to match", labels)
+			}
+		})
+	}
+}
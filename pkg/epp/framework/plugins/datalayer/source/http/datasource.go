@@ -35,6 +35,7 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/common/observability/logging"
 	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
 	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrpollcircuit "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/pollcircuit"
 	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
 )
 
@@ -58,6 +59,10 @@ type HTTPDataSource[T any] struct {
 
 	mu   sync.RWMutex
 	exts []fwkdl.PollingExtractor[T]
+
+	// poller is nil unless adaptive polling is configured, in which case every tick
+	// polls the endpoints that are due only.
+	poller *adaptivePoller
 }
 
 // NewHTTPDataSource constructs a typed polling dispatcher.
@@ -88,6 +93,20 @@ func NewHTTPDataSource[T any](scheme, path string, skipCertVerification bool,
 
 func (s *HTTPDataSource[T]) TypedName() fwkplugin.TypedName { return s.typedName }
 
+// WithPollingPolicy enables adaptive polling with the given policy, which must be valid.
+func (s *HTTPDataSource[T]) WithPollingPolicy(policy PollingPolicy) *HTTPDataSource[T] {
+	s.poller = newAdaptivePoller(policy, s.typedName.Name)
+	return s
+}
+
+// Produces declares the PollCircuit attribute when adaptive polling is enabled.
+func (s *HTTPDataSource[T]) Produces() map[fwkplugin.DataKey]any {
+	if s.poller == nil {
+		return map[fwkplugin.DataKey]any{}
+	}
+	return map[fwkplugin.DataKey]any{s.poller.circuitKey: attrpollcircuit.PollCircuit{}}
+}
+
 // Poll fetches and parses one tick. Exposed for tests; runtime uses Dispatch.
 func (s *HTTPDataSource[T]) Poll(ctx context.Context, ep fwkdl.Endpoint) (T, error) {
 	target := s.getEndpoint(ep.GetMetadata())
@@ -115,13 +134,32 @@ func (s *HTTPDataSource[T]) Poll(ctx context.Context, ep fwkdl.Endpoint) (T, err
 // dispatcher could not produce data). Per-extractor failures are recorded
 // in DataLayerExtractErrorsTotal and do NOT surface as a returned error.
 // This keeps the collector's poll/extract counters cleanly separated.
+//
+// With adaptive polling, endpoints that are not due yet are skipped and the
+// outcome of the poll schedules the next one.
 func (s *HTTPDataSource[T]) Dispatch(ctx context.Context, ep fwkdl.Endpoint) error {
+	var circuit *attrpollcircuit.PollCircuit
+	loadBefore := 0
+	if s.poller != nil {
+		circuit = s.poller.circuit(ep)
+		if !s.poller.due(circuit, s.poller.now()) {
+			return nil
+		}
+		loadBefore = endpointLoad(ep)
+	}
+
 	pollCtx, cancelPoll := context.WithTimeout(ctx, defaultStepTimeout)
 	data, err := s.Poll(pollCtx, ep)
 	cancelPoll()
 	if err != nil {
+		if s.poller != nil {
+			s.poller.recordFailure(ctx, ep, circuit, s.poller.now())
+		}
 		return err
 	}
+	if s.poller != nil {
+		defer func() { s.poller.recordSuccess(ctx, ep, circuit, loadBefore, s.poller.now()) }()
+	}
 	in := fwkdl.PollInput[T]{Payload: data, Endpoint: ep}
 	s.mu.RLock()
 	exts := slices.Clone(s.exts)
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrpollcircuit "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/pollcircuit"
+)
+
+// Default values of a PollingPolicy.
+const (
+	DefaultInitialBackoff          = time.Second
+	DefaultMaxBackoff              = 30 * time.Second
+	DefaultCircuitFailureThreshold = 3
+)
+
+// PollingPolicy configures adaptive polling of the endpoints of an HTTPDataSource.
+//
+// Polls are triggered by the endpoint's collector, which ticks every
+// refresh-metrics-interval; the policy decides on every tick whether the endpoint
+// is due. Intervals shorter than the collector's tick therefore have no effect.
+type PollingPolicy struct {
+	// Interval is the time between two polls of an endpoint. Zero polls on every tick.
+	Interval time.Duration
+	// FastInterval is used instead of Interval after a poll observed that the load of
+	// the endpoint changed by at least LoadChangeThreshold requests. Zero disables it.
+	FastInterval time.Duration
+	// LoadChangeThreshold is the change of the endpoint's waiting plus running requests
+	// between two polls that switches to FastInterval.
+	LoadChangeThreshold int
+	// Jitter randomizes every interval by up to this fraction in either direction, in [0, 1).
+	Jitter float64
+	// InitialBackoff is the delay before polling an endpoint again after a failed poll.
+	// It doubles with every consecutive failure, up to MaxBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay after consecutive failed polls.
+	MaxBackoff time.Duration
+	// CircuitFailureThreshold is the number of consecutive failed polls that opens the
+	// endpoint's circuit.
+	CircuitFailureThreshold int
+}
+
+// WithDefaults returns a copy of the policy with unset backoff and circuit parameters defaulted.
+func (p PollingPolicy) WithDefaults() PollingPolicy {
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = DefaultInitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = max(DefaultMaxBackoff, p.InitialBackoff)
+	}
+	if p.CircuitFailureThreshold == 0 {
+		p.CircuitFailureThreshold = DefaultCircuitFailureThreshold
+	}
+	return p
+}
+
+// Validate checks that the policy is consistent.
+func (p PollingPolicy) Validate() error {
+	var errs []error
+	if p.Interval < 0 {
+		errs = append(errs, fmt.Errorf("'interval' must not be negative, got %s", p.Interval))
+	}
+	if p.FastInterval < 0 {
+		errs = append(errs, fmt.Errorf("'fastInterval' must not be negative, got %s", p.FastInterval))
+	}
+	if p.FastInterval > 0 && p.FastInterval >= p.Interval {
+		errs = append(errs, fmt.Errorf("'fastInterval' must be shorter than 'interval', got %s", p.FastInterval))
+	}
+	if p.FastInterval > 0 && p.LoadChangeThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("'loadChangeThreshold' must be positive when 'fastInterval' is set, got %d", p.LoadChangeThreshold))
+	}
+	if !(p.Jitter >= 0 && p.Jitter < 1) {
+		errs = append(errs, fmt.Errorf("'jitter' must be in [0, 1), got %v", p.Jitter))
+	}
+	if p.InitialBackoff <= 0 {
+		errs = append(errs, fmt.Errorf("'initialBackoff' must be positive, got %s", p.InitialBackoff))
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		errs = append(errs, fmt.Errorf("'maxBackoff' must not be shorter than 'initialBackoff', got %s", p.MaxBackoff))
+	}
+	if p.CircuitFailureThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("'circuitFailureThreshold' must be positive, got %d", p.CircuitFailureThreshold))
+	}
+	return errors.Join(errs...)
+}
+
+// adaptivePoller applies a PollingPolicy. Its state is kept per endpoint in the endpoint's
+// PollCircuit attribute, which is only written by the endpoint's collector goroutine.
+type adaptivePoller struct {
+	policy     PollingPolicy
+	circuitKey fwkplugin.DataKey
+
+	now    func() time.Time
+	random func() float64
+}
+
+func newAdaptivePoller(policy PollingPolicy, sourceName string) *adaptivePoller {
+	return &adaptivePoller{
+		policy:     policy,
+		circuitKey: attrpollcircuit.PollCircuitDataKey.WithNonEmptyProducerName(sourceName),
+		now:        time.Now,
+		random:     rand.Float64,
+	}
+}
+
+// circuit returns the polling state of the endpoint, or a closed circuit that is due now if
+// the endpoint was not polled yet.
+func (p *adaptivePoller) circuit(ep fwkdl.Endpoint) *attrpollcircuit.PollCircuit {
+	if circuit, ok := attrpollcircuit.ReadPollCircuit(ep.GetAttributes(), p.circuitKey.String()); ok {
+		return circuit
+	}
+	return &attrpollcircuit.PollCircuit{State: attrpollcircuit.CircuitClosed}
+}
+
+// due reports whether the endpoint should be polled now.
+func (p *adaptivePoller) due(circuit *attrpollcircuit.PollCircuit, now time.Time) bool {
+	return !now.Before(circuit.NextPoll)
+}
+
+// recordSuccess closes the endpoint's circuit and schedules its next poll, at the fast
+// interval if its load changed by at least the threshold since the previous poll.
+func (p *adaptivePoller) recordSuccess(ctx context.Context, ep fwkdl.Endpoint, circuit *attrpollcircuit.PollCircuit,
+	loadBefore int, now time.Time) {
+	if circuit.Open() {
+		log.FromContext(ctx).V(logging.DEFAULT).Info("Closing poll circuit, endpoint is answering again",
+			"endpoint", ep.GetMetadata().GetIPAddress(), "consecutiveFailures", circuit.ConsecutiveFailures)
+	}
+	interval := p.policy.Interval
+	if p.policy.FastInterval > 0 && abs(endpointLoad(ep)-loadBefore) >= p.policy.LoadChangeThreshold {
+		interval = p.policy.FastInterval
+	}
+	circuit.State = attrpollcircuit.CircuitClosed
+	circuit.ConsecutiveFailures = 0
+	circuit.LastSuccess = now
+	circuit.NextPoll = now.Add(p.jittered(interval))
+	ep.GetAttributes().Put(p.circuitKey.String(), circuit)
+}
+
+// recordFailure backs off exponentially from the endpoint and opens its circuit once the
+// failure threshold is reached.
+func (p *adaptivePoller) recordFailure(ctx context.Context, ep fwkdl.Endpoint, circuit *attrpollcircuit.PollCircuit, now time.Time) {
+	circuit.ConsecutiveFailures++
+	backoff := p.backoff(circuit.ConsecutiveFailures)
+	if !circuit.Open() && circuit.ConsecutiveFailures >= p.policy.CircuitFailureThreshold {
+		circuit.State = attrpollcircuit.CircuitOpen
+		log.FromContext(ctx).V(logging.DEFAULT).Info("Opening poll circuit after consecutive poll failures",
+			"endpoint", ep.GetMetadata().GetIPAddress(), "consecutiveFailures", circuit.ConsecutiveFailures, "backoff", backoff)
+	}
+	circuit.NextPoll = now.Add(p.jittered(backoff))
+	ep.GetAttributes().Put(p.circuitKey.String(), circuit)
+}
+
+// backoff returns InitialBackoff doubled for every consecutive failure after the first, capped
+// at MaxBackoff.
+func (p *adaptivePoller) backoff(consecutiveFailures int) time.Duration {
+	backoff := float64(p.policy.InitialBackoff) * math.Pow(2, float64(consecutiveFailures-1))
+	return time.Duration(math.Min(backoff, float64(p.policy.MaxBackoff)))
+}
+
+// jittered randomizes d by up to the policy's jitter fraction in either direction.
+func (p *adaptivePoller) jittered(d time.Duration) time.Duration {
+	if p.policy.Jitter == 0 || d == 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + p.policy.Jitter*(2*p.random()-1)))
+}
+
+// endpointLoad returns the requests the endpoint last reported as waiting or running.
+func endpointLoad(ep fwkdl.Endpoint) int {
+	metrics := ep.GetMetrics()
+	if metrics == nil {
+		return 0
+	}
+	return metrics.WaitingQueueSize + metrics.RunningRequestsSize
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrpollcircuit "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/pollcircuit"
+)
+
+// loadExtractor reports the polled value as the endpoint's waiting queue size.
+type loadExtractor struct{}
+
+func (loadExtractor) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "load", Name: "load"}
+}
+
+func (loadExtractor) Extract(_ context.Context, in fwkdl.PollInput[int]) error {
+	metrics := in.Endpoint.GetMetrics().Clone()
+	metrics.WaitingQueueSize = in.Payload
+	in.Endpoint.UpdateMetrics(metrics)
+	return nil
+}
+
+type pollingFixture struct {
+	ds     *HTTPDataSource[int]
+	client *fakeClient
+	ext    *stubExtractor
+	ep     fwkdl.Endpoint
+	now    time.Time
+}
+
+func newPollingFixture(t *testing.T, policy PollingPolicy) *pollingFixture {
+	t.Helper()
+	require.NoError(t, policy.Validate())
+	f := &pollingFixture{
+		client: &fakeClient{},
+		ext:    newStubExtractor("ext"),
+		ep:     newTestEndpoint(),
+		now:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	f.ds = newDS(t, "src-polling", f.client).WithPollingPolicy(policy)
+	f.ds.poller.now = func() time.Time { return f.now }
+	f.ds.poller.random = func() float64 { return 0.5 }
+	require.NoError(t, f.ds.AppendExtractor(f.ext))
+	require.NoError(t, f.ds.AppendExtractor(loadExtractor{}))
+	return f
+}
+
+// dispatchAt dispatches at the given offset from the fixture's start time.
+func (f *pollingFixture) dispatchAt(offset time.Duration) error {
+	f.now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(offset)
+	return f.ds.Dispatch(context.Background(), f.ep)
+}
+
+func (f *pollingFixture) circuit(t *testing.T) *attrpollcircuit.PollCircuit {
+	t.Helper()
+	circuit, ok := attrpollcircuit.ReadPollCircuit(f.ep.GetAttributes(), f.ds.poller.circuitKey.String())
+	require.True(t, ok, "the poll circuit attribute must be set")
+	return circuit
+}
+
+func TestAdaptivePolling_SkipsUntilDue(t *testing.T) {
+	f := newPollingFixture(t, PollingPolicy{Interval: time.Second}.WithDefaults())
+
+	require.NoError(t, f.dispatchAt(0))
+	require.NoError(t, f.dispatchAt(500*time.Millisecond))
+	assert.Equal(t, 1, f.ext.calls(), "an endpoint must not be polled before its interval passed")
+
+	require.NoError(t, f.dispatchAt(time.Second))
+	assert.Equal(t, 2, f.ext.calls())
+	assert.Equal(t, attrpollcircuit.CircuitClosed, f.circuit(t).State)
+}
+
+func TestAdaptivePolling_BackoffAndCircuit(t *testing.T) {
+	f := newPollingFixture(t, PollingPolicy{
+		InitialBackoff:          time.Second,
+		MaxBackoff:              4 * time.Second,
+		CircuitFailureThreshold: 2,
+	})
+	f.client.err = errors.New("connection refused")
+
+	steps := []struct {
+		at           time.Duration
+		wantState    string
+		wantNextPoll time.Duration
+	}{
+		{at: 0, wantState: attrpollcircuit.CircuitClosed, wantNextPoll: time.Second},
+		{at: time.Second, wantState: attrpollcircuit.CircuitOpen, wantNextPoll: 3 * time.Second},
+		{at: 3 * time.Second, wantState: attrpollcircuit.CircuitOpen, wantNextPoll: 7 * time.Second},
+		{at: 7 * time.Second, wantState: attrpollcircuit.CircuitOpen, wantNextPoll: 11 * time.Second},
+	}
+	for i, step := range steps {
+		require.Error(t, f.dispatchAt(step.at))
+		circuit := f.circuit(t)
+		assert.Equal(t, i+1, circuit.ConsecutiveFailures)
+		assert.Equal(t, step.wantState, circuit.State)
+		assert.Equal(t, f.now.Add(step.wantNextPoll-step.at), circuit.NextPoll)
+	}
+
+	// Ticks during the backoff neither poll nor report an error.
+	require.NoError(t, f.dispatchAt(8*time.Second))
+	assert.Equal(t, 4, f.circuit(t).ConsecutiveFailures)
+
+	f.client.err = nil
+	require.NoError(t, f.dispatchAt(11*time.Second))
+	circuit := f.circuit(t)
+	assert.Equal(t, attrpollcircuit.CircuitClosed, circuit.State)
+	assert.Equal(t, 0, circuit.ConsecutiveFailures)
+	assert.Equal(t, f.now, circuit.LastSuccess)
+}
+
+func TestAdaptivePolling_FastIntervalOnLoadChange(t *testing.T) {
+	f := newPollingFixture(t, PollingPolicy{
+		Interval:            time.Second,
+		FastInterval:        100 * time.Millisecond,
+		LoadChangeThreshold: 5,
+	}.WithDefaults())
+
+	f.client.value = 10
+	require.NoError(t, f.dispatchAt(0))
+	assert.Equal(t, f.now.Add(100*time.Millisecond), f.circuit(t).NextPoll, "a load change must speed up polling")
+
+	f.client.value = 11
+	require.NoError(t, f.dispatchAt(100*time.Millisecond))
+	assert.Equal(t, f.now.Add(time.Second), f.circuit(t).NextPoll, "a stable load must poll at the base interval")
+}
+
+func TestAdaptivePolling_Jitter(t *testing.T) {
+	f := newPollingFixture(t, PollingPolicy{Interval: time.Second, Jitter: 0.2}.WithDefaults())
+
+	f.ds.poller.random = func() float64 { return 1 }
+	require.NoError(t, f.dispatchAt(0))
+	assert.Equal(t, f.now.Add(1200*time.Millisecond), f.circuit(t).NextPoll)
+
+	f.ds.poller.random = func() float64 { return 0 }
+	require.NoError(t, f.dispatchAt(1200*time.Millisecond))
+	assert.Equal(t, f.now.Add(800*time.Millisecond), f.circuit(t).NextPoll)
+}
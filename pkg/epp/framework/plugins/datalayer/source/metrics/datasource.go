@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/model"
 	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/http"
@@ -48,6 +50,39 @@ type metricsDatasourceParams struct {
 	Path string `json:"path"`
 	// InsecureSkipVerify defines whether model server certificate should be verified or not.
 	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+	// Polling enables adaptive polling of the model servers. If unset, every endpoint
+	// is polled every refresh-metrics-interval.
+	Polling *pollingParams `json:"polling,omitempty"`
+}
+
+// pollingParams configures adaptive polling, see http.PollingPolicy.
+type pollingParams struct {
+	Interval                *metav1.Duration `json:"interval,omitempty"`
+	FastInterval            *metav1.Duration `json:"fastInterval,omitempty"`
+	LoadChangeThreshold     int              `json:"loadChangeThreshold,omitempty"`
+	Jitter                  float64          `json:"jitter,omitempty"`
+	InitialBackoff          *metav1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff              *metav1.Duration `json:"maxBackoff,omitempty"`
+	CircuitFailureThreshold int              `json:"circuitFailureThreshold,omitempty"`
+}
+
+// policy converts the parameters to a defaulted http.PollingPolicy.
+func (p *pollingParams) policy() http.PollingPolicy {
+	duration := func(d *metav1.Duration) time.Duration {
+		if d == nil {
+			return 0
+		}
+		return d.Duration
+	}
+	return http.PollingPolicy{
+		Interval:                duration(p.Interval),
+		FastInterval:            duration(p.FastInterval),
+		LoadChangeThreshold:     p.LoadChangeThreshold,
+		Jitter:                  p.Jitter,
+		InitialBackoff:          duration(p.InitialBackoff),
+		MaxBackoff:              duration(p.MaxBackoff),
+		CircuitFailureThreshold: p.CircuitFailureThreshold,
+	}.WithDefaults()
 }
 
 // NewHTTPMetricsDataSource constructs a MetricsDataSource with the given scheme and path.
@@ -72,8 +107,19 @@ func MetricsDataSourceFactory(name string, parameters *json.Decoder, handle fwkp
 		}
 	}
 
-	return http.NewHTTPDataSource(cfg.Scheme, cfg.Path, cfg.InsecureSkipVerify,
+	ds, err := http.NewHTTPDataSource(cfg.Scheme, cfg.Path, cfg.InsecureSkipVerify,
 		MetricsDataSourceType, name, parseMetrics)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Polling != nil {
+		policy := cfg.Polling.policy()
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid 'polling' parameters of the '%s' plugin - %w", MetricsDataSourceType, err)
+		}
+		ds.WithPollingPolicy(policy)
+	}
+	return ds, nil
 }
 
 // These flags are registered in options.go (server package) and marked as deprecated there.
@@ -19,9 +19,14 @@ package metrics
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/spf13/pflag"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	attrpollcircuit "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/pollcircuit"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/http"
 )
 
 func resetFlags() {
@@ -161,3 +166,58 @@ func TestDataSourceConfigParams_BoolCLIOnlyWhenChanged(t *testing.T) {
 			cfg.InsecureSkipVerify)
 	}
 }
+
+func TestMetricsDataSourceFactory_Polling(t *testing.T) {
+	resetFlags()
+
+	tests := []struct {
+		name       string
+		params     string
+		wantPolicy bool
+		wantErr    bool
+	}{
+		{name: "no polling parameters", params: `{}`},
+		{name: "defaults", params: `{"polling": {}}`, wantPolicy: true},
+		{
+			name:       "all parameters",
+			params:     `{"polling": {"interval": "200ms", "fastInterval": "50ms", "loadChangeThreshold": 4, "jitter": 0.1, "initialBackoff": "500ms", "maxBackoff": "10s", "circuitFailureThreshold": 2}}`,
+			wantPolicy: true,
+		},
+		{name: "fast interval without threshold", params: `{"polling": {"interval": "200ms", "fastInterval": "50ms"}}`, wantErr: true},
+		{name: "fast interval not shorter", params: `{"polling": {"interval": "50ms", "fastInterval": "50ms", "loadChangeThreshold": 1}}`, wantErr: true},
+		{name: "jitter out of range", params: `{"polling": {"jitter": 1}}`, wantErr: true},
+		{name: "max backoff below initial", params: `{"polling": {"initialBackoff": "5s", "maxBackoff": "1s"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := MetricsDataSourceFactory("metrics", json.NewDecoder(strings.NewReader(tt.params)), nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			produces := plugin.(fwkplugin.ProducerPlugin).Produces()
+			_, ok := produces[attrpollcircuit.PollCircuitDataKey.WithNonEmptyProducerName("metrics")]
+			if ok != tt.wantPolicy {
+				t.Fatalf("expected the poll circuit to be produced: %v, got %v", tt.wantPolicy, produces)
+			}
+		})
+	}
+}
+
+func TestPollingParams_Defaults(t *testing.T) {
+	got := (&pollingParams{}).policy()
+	expected := http.PollingPolicy{
+		InitialBackoff:          http.DefaultInitialBackoff,
+		MaxBackoff:              http.DefaultMaxBackoff,
+		CircuitFailureThreshold: http.DefaultCircuitFailureThreshold,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
@@ -56,4 +56,8 @@ limitations under the License.
 //     computed as ReceivedTimestamp + x-llm-d-slo-ttft-ms header (interpreted as milliseconds).
 //     Requests without a valid header are scheduled after SLO-bound requests.
 //     This maximizes the number of requests served before the deadlines computed on the defined SLO expire.
+//
+//   - SJF ("Shortest Job First") ("sjf-ordering-policy"): Orders requests by their estimated job size (prompt tokens
+//     plus estimated output tokens), reduced by the time they have been waiting to prevent starvation.
+//     This minimizes the mean queueing time when short and long requests share a flow.
 package ordering
@@ -27,6 +27,7 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/edf"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/fcfs"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/sjf"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/flowcontrol/ordering/slodeadline"
 )
 
@@ -40,6 +41,7 @@ func TestOrderingPolicyConformance(t *testing.T) {
 		fcfs.FCFSOrderingPolicyType:               fcfs.FCFSOrderingPolicyFactory,
 		edf.EDFOrderingPolicyType:                 edf.EDFOrderingPolicyFactory,
 		slodeadline.SLODeadlineOrderingPolicyType: slodeadline.SLODeadlineOrderingPolicyFactory,
+		sjf.SJFOrderingPolicyType:                 sjf.SJFOrderingPolicyFactory,
 	}
 
 	for name, factory := range policies {
@@ -0,0 +1,327 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sjf implements an ordering policy that selects the request with the smallest estimated job size first
+// (Shortest Job First), with age-based boosting to prevent starvation.
+//
+// For detailed documentation, see README.md.
+package sjf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const (
+	// SJFOrderingPolicyType is the registration type for the Shortest Job First ordering policy.
+	//
+	// It selects the request with the smallest estimated job size, boosted by the time it has been waiting.
+	// For detailed documentation, see README.md.
+	SJFOrderingPolicyType = "sjf-ordering-policy"
+
+	defaultAgingTokensPerSecond = 1000
+	defaultOutputTokens         = 256
+	defaultBytesPerToken        = 4
+	defaultSmoothing            = 0.1
+	defaultMaxTrackedFlows      = 10000
+)
+
+// maxTokensFields are the request body fields bounding the output length, in order of precedence.
+var maxTokensFields = []string{"max_completion_tokens", "max_tokens", "max_output_tokens"}
+
+// Parameters configures the SJF ordering policy.
+type Parameters struct {
+	// AgingTokensPerSecond is the number of tokens by which the estimated job size of a request shrinks for every
+	// second it waits. It bounds how long a large request can be overtaken by smaller ones.
+	// Defaults to 1000.
+	AgingTokensPerSecond float64 `json:"agingTokensPerSecond,omitempty"`
+	// DefaultOutputTokens is the output length assumed for requests without max_tokens and without a learned
+	// estimate.
+	// Defaults to 256.
+	DefaultOutputTokens int64 `json:"defaultOutputTokens,omitempty"`
+	// BytesPerToken is used to estimate the prompt tokens from the request size when the prompt is not tokenized.
+	// Defaults to 4.
+	BytesPerToken float64 `json:"bytesPerToken,omitempty"`
+	// OutputLengthEstimation enables learning the output length per model and fairness ID from the token usage
+	// reported in responses. Disabled if nil.
+	OutputLengthEstimation *OutputLengthEstimationParameters `json:"outputLengthEstimation,omitempty"`
+}
+
+// OutputLengthEstimationParameters configures the learned output length estimate.
+type OutputLengthEstimationParameters struct {
+	// Smoothing is the weight of a new observation in the moving average of the output length, in (0, 1].
+	// Defaults to 0.1.
+	Smoothing float64 `json:"smoothing,omitempty"`
+	// MaxTrackedFlows bounds the number of model and fairness ID pairs with an estimate. The least recently
+	// updated pairs are evicted first.
+	// Defaults to 10000.
+	MaxTrackedFlows int `json:"maxTrackedFlows,omitempty"`
+}
+
+var (
+	_ flowcontrol.OrderingPolicy           = &SJFPolicy{}
+	_ requestcontrol.ResponseBodyProcessor = &SJFPolicy{}
+)
+
+// SJFOrderingPolicyFactory creates an SJF ordering policy from its parameters.
+func SJFOrderingPolicyFactory(name string, rawParameters *json.Decoder, _ plugin.Handle) (plugin.Plugin, error) {
+	parameters := Parameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", SJFOrderingPolicyType, err)
+		}
+	}
+	policy, err := newSJFPolicy(parameters)
+	if err != nil {
+		return nil, err
+	}
+	return policy.withName(name), nil
+}
+
+// SJFPolicy orders requests by their estimated job size, smallest first. The job size of a request is its prompt
+// tokens plus its estimated output tokens, less AgingTokensPerSecond for every second it has been queued.
+//
+// Because every queued request ages at the same rate, the aged job size of a request relative to another does not
+// change while they wait. The policy therefore compares size + AgingTokensPerSecond * enqueueTime, which keeps the
+// order of a heap-based queue valid without re-sorting. For the same reason, the estimated size of a request is
+// computed once, the first time the request is compared, and pinned for its lifetime in the queue.
+type SJFPolicy struct {
+	name                 string
+	agingTokensPerSecond float64
+	defaultOutputTokens  float64
+	bytesPerToken        float64
+	sizeAttributeKey     string
+	// epoch is the reference the enqueue times are measured from, keeping the aged sizes small.
+	epoch time.Time
+
+	// outputLengths holds the learned output length per model and fairness ID. Nil if learning is disabled.
+	outputLengths *lru.Cache[outputLengthKey, float64]
+	smoothing     float64
+	mu            sync.Mutex
+}
+
+// outputLengthKey identifies a learned output length estimate. An empty fairnessID holds the estimate of the model.
+type outputLengthKey struct {
+	model      string
+	fairnessID string
+}
+
+// jobSize is the estimated job size of a request, pinned as a request attribute.
+type jobSize float64
+
+func newSJFPolicy(parameters Parameters) (*SJFPolicy, error) {
+	policy := &SJFPolicy{
+		name:                 SJFOrderingPolicyType,
+		agingTokensPerSecond: defaultAgingTokensPerSecond,
+		defaultOutputTokens:  defaultOutputTokens,
+		bytesPerToken:        defaultBytesPerToken,
+		epoch:                time.Now(),
+	}
+	if parameters.AgingTokensPerSecond < 0 || parameters.DefaultOutputTokens < 0 || parameters.BytesPerToken < 0 {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'agingTokensPerSecond', 'defaultOutputTokens' and 'bytesPerToken' must not be negative",
+			SJFOrderingPolicyType)
+	}
+	if parameters.AgingTokensPerSecond > 0 {
+		policy.agingTokensPerSecond = parameters.AgingTokensPerSecond
+	}
+	if parameters.DefaultOutputTokens > 0 {
+		policy.defaultOutputTokens = float64(parameters.DefaultOutputTokens)
+	}
+	if parameters.BytesPerToken > 0 {
+		policy.bytesPerToken = parameters.BytesPerToken
+	}
+
+	if estimation := parameters.OutputLengthEstimation; estimation != nil {
+		policy.smoothing = defaultSmoothing
+		if estimation.Smoothing != 0 {
+			policy.smoothing = estimation.Smoothing
+		}
+		if policy.smoothing <= 0 || policy.smoothing > 1 {
+			return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'smoothing' must be in (0, 1], got %v",
+				SJFOrderingPolicyType, policy.smoothing)
+		}
+		maxTrackedFlows := defaultMaxTrackedFlows
+		if estimation.MaxTrackedFlows != 0 {
+			maxTrackedFlows = estimation.MaxTrackedFlows
+		}
+		cache, err := lru.New[outputLengthKey, float64](maxTrackedFlows)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'maxTrackedFlows' - %w", SJFOrderingPolicyType, err)
+		}
+		policy.outputLengths = cache
+	}
+	policy.sizeAttributeKey = policy.name + "/job-size"
+	return policy, nil
+}
+
+func (p *SJFPolicy) withName(name string) *SJFPolicy {
+	if name != "" {
+		p.name = name
+		p.sizeAttributeKey = name + "/job-size"
+	}
+	return p
+}
+
+func (p *SJFPolicy) Name() string {
+	return p.name
+}
+
+// RequiredQueueCapabilities returns the queue capabilities required by this policy.
+// It requires a priority-configurable queue (e.g., heap-based) to maintain items in job size order.
+func (p *SJFPolicy) RequiredQueueCapabilities() []flowcontrol.QueueCapability {
+	return []flowcontrol.QueueCapability{flowcontrol.CapabilityPriorityConfigurable}
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (p *SJFPolicy) TypedName() plugin.TypedName {
+	return plugin.TypedName{
+		Type: SJFOrderingPolicyType,
+		Name: p.name,
+	}
+}
+
+// Less returns true if item 'a' should be dispatched before item 'b'.
+// SJF orders by aged job size (smallest first), using FCFS as a tie-breaker.
+func (p *SJFPolicy) Less(a, b flowcontrol.QueueItemAccessor) bool {
+	if a == nil && b == nil {
+		return false
+	}
+	if a == nil { // Treat nil as lowest priority
+		return false
+	}
+	if b == nil { // Treat non-nil 'a' as higher priority than nil 'b'
+		return true
+	}
+	keyA, keyB := p.agedSize(a), p.agedSize(b)
+	if keyA != keyB {
+		return keyA < keyB
+	}
+	return a.EnqueueTime().Before(b.EnqueueTime())
+}
+
+// agedSize returns the job size of the item plus the aging accrued at its enqueue time. Comparing aged sizes is
+// equivalent to comparing job sizes reduced by the time waited so far.
+func (p *SJFPolicy) agedSize(item flowcontrol.QueueItemAccessor) float64 {
+	return p.jobSize(item.OriginalRequest()) + p.agingTokensPerSecond*item.EnqueueTime().Sub(p.epoch).Seconds()
+}
+
+// jobSize returns the estimated job size of the request, computing and pinning it on first use.
+func (p *SJFPolicy) jobSize(req flowcontrol.FlowControlRequest) float64 {
+	if req == nil {
+		return math.MaxFloat64
+	}
+	infReq := req.InferenceRequest()
+	if infReq != nil {
+		if size, ok := scheduling.ReadRequestAttribute[jobSize](infReq, p.sizeAttributeKey); ok {
+			return float64(size)
+		}
+	}
+	size := p.promptTokens(req) + p.outputTokens(req)
+	if infReq != nil {
+		infReq.PutAttribute(p.sizeAttributeKey, jobSize(size))
+	}
+	return size
+}
+
+// promptTokens returns the prompt tokens of the tokenized prompt, or estimates them from the request size.
+func (p *SJFPolicy) promptTokens(req flowcontrol.FlowControlRequest) float64 {
+	if infReq := req.InferenceRequest(); infReq != nil && infReq.Body != nil && infReq.Body.TokenizedPrompt != nil {
+		return float64(len(infReq.Body.TokenizedPrompt.TokenIDs))
+	}
+	return float64(req.ByteSize()) / p.bytesPerToken
+}
+
+// outputTokens returns the learned output length, capped by max_tokens, falling back to max_tokens and then to the
+// configured default.
+func (p *SJFPolicy) outputTokens(req flowcontrol.FlowControlRequest) float64 {
+	maxTokens, hasMaxTokens := requestMaxTokens(req.InferenceRequest())
+	if learned, ok := p.learnedOutputTokens(req.TargetModelName(), req.FlowKey().ID); ok {
+		if hasMaxTokens {
+			return math.Min(learned, maxTokens)
+		}
+		return learned
+	}
+	if hasMaxTokens {
+		return maxTokens
+	}
+	return p.defaultOutputTokens
+}
+
+// learnedOutputTokens returns the estimate for the model and fairness ID, falling back to the estimate of the model.
+func (p *SJFPolicy) learnedOutputTokens(model, fairnessID string) (float64, bool) {
+	if p.outputLengths == nil {
+		return 0, false
+	}
+	if estimate, ok := p.outputLengths.Peek(outputLengthKey{model: model, fairnessID: fairnessID}); ok {
+		return estimate, true
+	}
+	return p.outputLengths.Peek(outputLengthKey{model: model})
+}
+
+// requestMaxTokens returns the output token limit set in the request body, if any.
+func requestMaxTokens(infReq *scheduling.InferenceRequest) (float64, bool) {
+	if infReq == nil || infReq.Body == nil || infReq.Body.Payload == nil {
+		return 0, false
+	}
+	payload, ok := infReq.Body.Payload.AsMap()
+	if !ok {
+		return 0, false
+	}
+	for _, field := range maxTokensFields {
+		if value, ok := payload[field].(float64); ok && value > 0 {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// ResponseBody learns the output length of the model and fairness ID from the token usage reported at the end of
+// the response. This is a no-op unless output length estimation is enabled.
+func (p *SJFPolicy) ResponseBody(_ context.Context, request *scheduling.InferenceRequest, response *requestcontrol.Response,
+	_ *fwkdl.EndpointMetadata) {
+	if p.outputLengths == nil || request == nil || response == nil || !response.EndOfStream {
+		return
+	}
+	completionTokens := float64(response.Usage.CompletionTokens)
+	if completionTokens <= 0 {
+		return
+	}
+	keys := []outputLengthKey{{model: request.TargetModel}}
+	if request.FairnessID != "" {
+		keys = append(keys, outputLengthKey{model: request.TargetModel, fairnessID: request.FairnessID})
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, key := range keys {
+		estimate := completionTokens
+		if previous, ok := p.outputLengths.Peek(key); ok {
+			estimate = previous + p.smoothing*(completionTokens-previous)
+		}
+		p.outputLengths.Add(key, estimate)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sjf
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol/mocks"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+var testFlowKey = flowcontrol.FlowKey{ID: "test-flow", Priority: 0}
+
+// newItem creates a queue item for a request with the given number of prompt tokens and body payload, enqueued at
+// enqueueTime.
+func newItem(id string, promptTokens int, payload fwkrh.PayloadMap, enqueueTime time.Time) *mocks.MockQueueItemAccessor {
+	infReq := &scheduling.InferenceRequest{
+		RequestID:   id,
+		TargetModel: "model-a",
+		FairnessID:  testFlowKey.ID,
+		Body: &fwkrh.InferenceRequestBody{
+			Payload:         payload,
+			TokenizedPrompt: &fwkrh.TokenizedPrompt{TokenIDs: make([]uint32, promptTokens)},
+		},
+	}
+	item := mocks.NewMockQueueItemAccessor(0, id, testFlowKey, func(r *mocks.MockFlowControlRequest) {
+		r.InferenceRequestV = infReq
+		r.TargetModelNameV = infReq.TargetModel
+	})
+	item.EnqueueTimeV = enqueueTime
+	return item
+}
+
+func newTestPolicy(t *testing.T, parameters Parameters) *SJFPolicy {
+	t.Helper()
+	policy, err := newSJFPolicy(parameters)
+	require.NoError(t, err)
+	return policy
+}
+
+func TestSJFPolicy_Factory(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		jsonParams string
+		expectErr  bool
+	}{
+		{name: "defaults", jsonParams: `{}`},
+		{
+			name:       "all parameters",
+			jsonParams: `{"agingTokensPerSecond": 50, "defaultOutputTokens": 100, "bytesPerToken": 3, "outputLengthEstimation": {"smoothing": 0.5, "maxTrackedFlows": 10}}`,
+		},
+		{name: "negative aging", jsonParams: `{"agingTokensPerSecond": -1}`, expectErr: true},
+		{name: "smoothing above one", jsonParams: `{"outputLengthEstimation": {"smoothing": 2}}`, expectErr: true},
+		{name: "negative max tracked flows", jsonParams: `{"outputLengthEstimation": {"maxTrackedFlows": -1}}`, expectErr: true},
+		{name: "malformed JSON", jsonParams: `{"agingTokensPerSecond": `, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plugin, err := SJFOrderingPolicyFactory("sjf", json.NewDecoder(strings.NewReader(tt.jsonParams)), nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, plugin.TypedName(), plugin.(*SJFPolicy).TypedName())
+			assert.Equal(t, "sjf", plugin.TypedName().Name)
+		})
+	}
+}
+
+func TestSJFPolicy_RequiredQueueCapabilities(t *testing.T) {
+	t.Parallel()
+	policy := newTestPolicy(t, Parameters{})
+	assert.Equal(t, []flowcontrol.QueueCapability{flowcontrol.CapabilityPriorityConfigurable}, policy.RequiredQueueCapabilities())
+}
+
+func TestSJFPolicy_Less(t *testing.T) {
+	t.Parallel()
+	policy := newTestPolicy(t, Parameters{AgingTokensPerSecond: 100, DefaultOutputTokens: 50})
+	now := time.Now()
+
+	short := newItem("short", 100, fwkrh.PayloadMap{"max_tokens": float64(100)}, now)
+	long := newItem("long", 30000, fwkrh.PayloadMap{"max_tokens": float64(1000)}, now)
+	// default output tokens: 100 + 50.
+	noLimit := newItem("no-limit", 100, fwkrh.PayloadMap{}, now)
+	// aged by 2s * 100 tokens/s: 350 - 200.
+	older := newItem("older", 250, fwkrh.PayloadMap{"max_completion_tokens": float64(100)}, now.Add(-2*time.Second))
+	// 30000 + 1000 - 310s * 100 tokens/s, overtakes everything else.
+	starved := newItem("starved", 30000, fwkrh.PayloadMap{"max_tokens": float64(1000)}, now.Add(-310*time.Second))
+	sameSizeLater := newItem("same-size-later", 100, fwkrh.PayloadMap{"max_tokens": float64(100)}, now)
+	sameSizeLater.EnqueueTimeV = now.Add(time.Nanosecond)
+
+	testCases := []struct {
+		name     string
+		a, b     flowcontrol.QueueItemAccessor
+		expected bool
+	}{
+		{name: "shorter job first", a: short, b: long, expected: true},
+		{name: "longer job later", a: long, b: short, expected: false},
+		{name: "max tokens bound the output", a: noLimit, b: short, expected: true},
+		{name: "aging lowers the job size", a: older, b: short, expected: true},
+		{name: "aging prevents starvation", a: starved, b: noLimit, expected: true},
+		{name: "FCFS breaks ties", a: short, b: sameSizeLater, expected: true},
+		{name: "FCFS breaks ties (reversed)", a: sameSizeLater, b: short, expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.Less(tc.a, tc.b))
+		})
+	}
+}
+
+func TestSJFPolicy_LessWithoutTokenizedPrompt(t *testing.T) {
+	t.Parallel()
+	policy := newTestPolicy(t, Parameters{BytesPerToken: 2})
+	now := time.Now()
+
+	// 400 bytes at 2 bytes per token is 200 prompt tokens, shorter than 300 tokenized prompt tokens.
+	small := mocks.NewMockQueueItemAccessor(400, "small", testFlowKey)
+	small.EnqueueTimeV = now
+	tokenized := newItem("tokenized", 300, nil, now)
+	assert.True(t, policy.Less(small, tokenized))
+	assert.False(t, policy.Less(tokenized, small))
+}
+
+func TestSJFPolicy_OutputLengthEstimation(t *testing.T) {
+	t.Parallel()
+	policy := newTestPolicy(t, Parameters{
+		AgingTokensPerSecond:   1,
+		DefaultOutputTokens:    10,
+		OutputLengthEstimation: &OutputLengthEstimationParameters{Smoothing: 0.5},
+	})
+	now := time.Now()
+	request := &scheduling.InferenceRequest{TargetModel: "model-a", FairnessID: testFlowKey.ID}
+	respond := func(completionTokens int, endOfStream bool) {
+		policy.ResponseBody(context.Background(), request, &requestcontrol.Response{
+			EndOfStream: endOfStream,
+			Usage:       fwkrh.Usage{CompletionTokens: completionTokens},
+		}, nil)
+	}
+
+	before := newItem("before", 100, nil, now)
+	assert.Equal(t, 110.0, policy.jobSize(before.OriginalRequest()), "without observations the default is used")
+
+	respond(1000, false)
+	assert.Equal(t, 110.0, policy.jobSize(newItem("mid-stream", 100, nil, now).OriginalRequest()),
+		"only the end of the stream is observed")
+
+	respond(1000, true)
+	respond(2000, true)
+	assert.Equal(t, 1600.0, policy.jobSize(newItem("learned", 100, nil, now).OriginalRequest()))
+	assert.Equal(t, 600.0, policy.jobSize(newItem("capped", 100, fwkrh.PayloadMap{"max_tokens": float64(500)}, now).OriginalRequest()),
+		"max_tokens caps the learned estimate")
+	assert.Equal(t, 110.0, policy.jobSize(before.OriginalRequest()), "the size of a request is pinned once computed")
+
+	otherFlow := mocks.NewMockQueueItemAccessor(0, "other-flow", flowcontrol.FlowKey{ID: "other"},
+		func(r *mocks.MockFlowControlRequest) {
+			r.TargetModelNameV = "model-a"
+			r.InferenceRequestV = &scheduling.InferenceRequest{
+				TargetModel: "model-a",
+				Body:        &fwkrh.InferenceRequestBody{TokenizedPrompt: &fwkrh.TokenizedPrompt{}},
+			}
+		})
+	assert.Equal(t, 1500.0, policy.jobSize(otherFlow.OriginalRequest()), "unknown flows use the estimate of the model")
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/utils/ptr"
+)
+
+// apiConfig represents the external configuration schema for the composite detector.
+//
+// It is designed to be deserialized from JSON via the plugin's raw parameters.
+type apiConfig struct {
+	// Aggregation defines how the saturation of the component detectors is combined.
+	//
+	// Valid values are:
+	// - "max": the pool is as saturated as its most saturated resource.
+	// - "weightedAverage": the weighted mean of the component saturations.
+	//
+	// Defaults to "max" if unset.
+	Aggregation *aggregation `json:"aggregation,omitempty"`
+
+	// Detectors lists the component saturation detectors. Each entry references a saturation detector plugin
+	// declared earlier in the plugins section of the configuration.
+	Detectors []detectorRef `json:"detectors"`
+}
+
+// detectorRef references a component saturation detector.
+type detectorRef struct {
+	// PluginRef is the name of the referenced saturation detector plugin.
+	PluginRef string `json:"pluginRef"`
+	// Weight is the relative weight of the component for the "weightedAverage" aggregation.
+	// Ignored by the "max" aggregation. Defaults to 1.0 if unset.
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// aggregation is the function used to combine component saturations.
+type aggregation string
+
+const (
+	// aggregationMax reports the highest component saturation.
+	aggregationMax aggregation = "max"
+	// aggregationWeightedAverage reports the weighted mean of the component saturations.
+	aggregationWeightedAverage aggregation = "weightedAverage"
+)
+
+const (
+	// defaultAggregation is used when Aggregation is unset.
+	defaultAggregation = aggregationMax
+	// defaultWeight is used when a component weight is unset.
+	defaultWeight = 1.0
+)
+
+// component is the internal, validated form of a detectorRef.
+type component struct {
+	pluginRef string
+	weight    float64
+}
+
+// config is the internal, fully-validated configuration used by the detector.
+type config struct {
+	aggregation aggregation
+	components  []component
+}
+
+// buildConfig applies the configuration lifecycle (defaulting and validation) and translates the
+// external schema into the internal domain model.
+// The provided apiConfig is copied to prevent mutation side-effects.
+func buildConfig(name string, apiCfg *apiConfig) (*config, error) {
+	var safeCfg apiConfig
+	if apiCfg != nil {
+		safeCfg = *apiCfg
+	}
+
+	if safeCfg.Aggregation == nil {
+		safeCfg.Aggregation = ptr.To(defaultAggregation)
+	}
+
+	if err := validateConfig(name, &safeCfg); err != nil {
+		return nil, fmt.Errorf("invalid composite saturation detector configuration: %w", err)
+	}
+
+	cfg := &config{
+		aggregation: *safeCfg.Aggregation,
+		components:  make([]component, 0, len(safeCfg.Detectors)),
+	}
+	for _, ref := range safeCfg.Detectors {
+		weight := defaultWeight
+		if ref.Weight != nil {
+			weight = *ref.Weight
+		}
+		cfg.components = append(cfg.components, component{pluginRef: ref.PluginRef, weight: weight})
+	}
+	return cfg, nil
+}
+
+// validateConfig checks the constraints of the defaulted configuration.
+// It aggregates all validation failures.
+func validateConfig(name string, cfg *apiConfig) error {
+	var errs []error
+
+	switch *cfg.Aggregation {
+	case aggregationMax, aggregationWeightedAverage:
+		// Valid
+	default:
+		errs = append(errs, fmt.Errorf("unsupported aggregation: %q", *cfg.Aggregation))
+	}
+
+	if len(cfg.Detectors) == 0 {
+		errs = append(errs, errors.New("at least one detector must be referenced"))
+	}
+
+	seen := make(map[string]struct{}, len(cfg.Detectors))
+	for i, ref := range cfg.Detectors {
+		if ref.PluginRef == "" {
+			errs = append(errs, fmt.Errorf("detectors[%d].pluginRef must not be empty", i))
+			continue
+		}
+		if ref.PluginRef == name {
+			errs = append(errs, fmt.Errorf("detectors[%d] must not reference the composite detector itself", i))
+		}
+		if _, dup := seen[ref.PluginRef]; dup {
+			errs = append(errs, fmt.Errorf("detector %q is referenced more than once", ref.PluginRef))
+		}
+		seen[ref.PluginRef] = struct{}{}
+		if ref.Weight != nil && *ref.Weight <= 0 {
+			errs = append(errs, fmt.Errorf("detectors[%d].weight must be strictly positive, got %f", i, *ref.Weight))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package composite implements a saturation detector that combines the saturation signals of other
+// detectors. Pools saturate on different resources at different times (request concurrency, KV cache,
+// latency), so a single detector rarely captures every bottleneck.
+//
+// For configuration details, see the package README.
+package composite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	// CompositeDetectorType is the unique identifier for this plugin.
+	CompositeDetectorType = "composite-saturation-detector"
+)
+
+// CompositeDetectorFactory instantiates the detector plugin using the provided JSON parameters.
+// The referenced component detectors must be declared before the composite detector.
+func CompositeDetectorFactory(
+	name string,
+	params *json.Decoder,
+	handle fwkplugin.Handle,
+) (fwkplugin.Plugin, error) {
+	var apiCfg apiConfig
+	if params != nil {
+		if err := params.Decode(&apiCfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal composite saturation detector config: %w", err)
+		}
+	}
+	cfg, err := buildConfig(name, &apiCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	detectors := make([]flowcontrol.SaturationDetector, 0, len(cfg.components))
+	for _, c := range cfg.components {
+		d, err := fwkplugin.PluginByType[flowcontrol.SaturationDetector](handle, c.pluginRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve component detector for '%s' plugin: %w", name, err)
+		}
+		detectors = append(detectors, d)
+	}
+	return newDetector(name, *cfg, detectors, log.FromContext(handle.Context())), nil
+}
+
+var _ flowcontrol.SaturationDetector = &Detector{}
+
+// Detector combines the saturation reported by a set of component detectors.
+type Detector struct {
+	config     config
+	typedName  fwkplugin.TypedName
+	components []flowcontrol.SaturationDetector
+}
+
+// newDetector creates a new instance of the Composite Detector. The detectors slice must be aligned with
+// cfg.components.
+func newDetector(name string, cfg config, detectors []flowcontrol.SaturationDetector, logger logr.Logger) *Detector {
+	typedName := fwkplugin.TypedName{
+		Type: CompositeDetectorType,
+		Name: name,
+	}
+
+	componentNames := make([]string, 0, len(detectors))
+	for _, d := range detectors {
+		componentNames = append(componentNames, d.TypedName().String())
+	}
+	logger.WithName(typedName.String()).V(logutil.DEFAULT).Info("Creating new CompositeDetector",
+		"aggregation", cfg.aggregation,
+		"components", componentNames)
+
+	return &Detector{
+		config:     cfg,
+		typedName:  typedName,
+		components: detectors,
+	}
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (d *Detector) TypedName() fwkplugin.TypedName {
+	return d.typedName
+}
+
+// Saturation evaluates every component detector and combines the results.
+//
+// With the "max" aggregation the pool is as saturated as its most saturated resource:
+//
+//	Saturation = max(Saturation_i)
+//
+// With the "weightedAverage" aggregation:
+//
+//	Saturation = Σ(Weight_i * Saturation_i) / Σ(Weight_i)
+//
+// The saturation of each component is exported individually so operators can see which resource is
+// driving backpressure.
+func (d *Detector) Saturation(ctx context.Context, endpoints []datalayer.Endpoint) float64 {
+	if len(endpoints) == 0 {
+		return 1.0
+	}
+
+	var maxSaturation, weightedSum, totalWeight float64
+	for i, detector := range d.components {
+		saturation := detector.Saturation(ctx, endpoints)
+		typedName := detector.TypedName()
+		metrics.RecordFlowControlDetectorSaturation(typedName.Type, typedName.Name, saturation)
+
+		if i == 0 || saturation > maxSaturation {
+			maxSaturation = saturation
+		}
+		weight := d.config.components[i].weight
+		weightedSum += weight * saturation
+		totalWeight += weight
+	}
+
+	if d.config.aggregation == aggregationWeightedAverage {
+		if totalWeight == 0 {
+			return 1.0
+		}
+		return weightedSum / totalWeight
+	}
+	return maxSaturation
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+// fixedDetector is a SaturationDetector stub that reports a constant saturation for non-empty pools.
+type fixedDetector struct {
+	name       string
+	saturation float64
+}
+
+func (f *fixedDetector) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "fixed-detector", Name: f.name}
+}
+
+func (f *fixedDetector) Saturation(_ context.Context, endpoints []fwkdl.Endpoint) float64 {
+	if len(endpoints) == 0 {
+		return 1.0
+	}
+	return f.saturation
+}
+
+// notADetector is a plugin that does not implement SaturationDetector.
+type notADetector struct{}
+
+func (notADetector) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "not-a-detector", Name: "not-a-detector"}
+}
+
+func newTestHandle(t *testing.T) fwkplugin.Handle {
+	t.Helper()
+	handle := fwkplugin.NewEppHandle(t.Context(), func() []types.NamespacedName { return nil })
+	handle.AddPlugin("concurrency", &fixedDetector{name: "concurrency", saturation: 0.4})
+	handle.AddPlugin("utilization", &fixedDetector{name: "utilization", saturation: 0.8})
+	handle.AddPlugin("not-a-detector", notADetector{})
+	return handle
+}
+
+func makeEndpoints(n int) []fwkdl.Endpoint {
+	endpoints := make([]fwkdl.Endpoint, 0, n)
+	for i := range n {
+		meta := &fwkdl.EndpointMetadata{
+			NamespacedName: types.NamespacedName{Name: string(rune('a' + i)), Namespace: "ns1"},
+		}
+		endpoints = append(endpoints, fwkdl.NewEndpoint(meta, fwkdl.NewMetrics()))
+	}
+	return endpoints
+}
+
+// TestCompositeDetectorFactory evaluates config parsing, validation and component resolution.
+func TestCompositeDetectorFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configJSON []byte
+		wantError  string
+	}{
+		{
+			name:       "valid max configuration",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "concurrency"}, {"pluginRef": "utilization"}]}`),
+		},
+		{
+			name:       "valid weighted average configuration",
+			configJSON: []byte(`{"aggregation": "weightedAverage", "detectors": [{"pluginRef": "concurrency", "weight": 2}, {"pluginRef": "utilization"}]}`),
+		},
+		{
+			name:       "invalid schema",
+			configJSON: []byte(`{"detectors": "concurrency"}`),
+			wantError:  "failed to unmarshal",
+		},
+		{
+			name:       "no detectors",
+			configJSON: []byte(`{}`),
+			wantError:  "at least one detector",
+		},
+		{
+			name:       "unsupported aggregation",
+			configJSON: []byte(`{"aggregation": "min", "detectors": [{"pluginRef": "concurrency"}]}`),
+			wantError:  "unsupported aggregation",
+		},
+		{
+			name:       "empty plugin reference",
+			configJSON: []byte(`{"detectors": [{"pluginRef": ""}]}`),
+			wantError:  "pluginRef must not be empty",
+		},
+		{
+			name:       "duplicate plugin reference",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "concurrency"}, {"pluginRef": "concurrency"}]}`),
+			wantError:  "referenced more than once",
+		},
+		{
+			name:       "self reference",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "test-composite"}]}`),
+			wantError:  "must not reference the composite detector itself",
+		},
+		{
+			name:       "non-positive weight",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "concurrency", "weight": 0}]}`),
+			wantError:  "weight must be strictly positive",
+		},
+		{
+			name:       "undefined plugin",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "missing"}]}`),
+			wantError:  "there is no plugin with the name 'missing' defined",
+		},
+		{
+			name:       "plugin is not a saturation detector",
+			configJSON: []byte(`{"detectors": [{"pluginRef": "not-a-detector"}]}`),
+			wantError:  "is not an instance of",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			plugin, err := CompositeDetectorFactory("test-composite", fwkplugin.StrictDecoder(tc.configJSON), newTestHandle(t))
+			if tc.wantError != "" {
+				require.ErrorContains(t, err, tc.wantError)
+				require.Nil(t, plugin, "Plugin must be nil when initialization fails")
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, plugin)
+			require.Equal(t, fwkplugin.TypedName{Type: CompositeDetectorType, Name: "test-composite"}, plugin.TypedName())
+		})
+	}
+}
+
+// TestDetector_Saturation verifies the aggregation of component saturations.
+func TestDetector_Saturation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		aggregation aggregation
+		components  []component
+		detectors   []flowcontrol.SaturationDetector
+		endpoints   []fwkdl.Endpoint
+		expected    float64
+	}{
+		{
+			name:        "max picks the most saturated component",
+			aggregation: aggregationMax,
+			components:  []component{{pluginRef: "a", weight: 1}, {pluginRef: "b", weight: 1}},
+			detectors:   []flowcontrol.SaturationDetector{&fixedDetector{name: "a", saturation: 0.3}, &fixedDetector{name: "b", saturation: 0.9}},
+			endpoints:   makeEndpoints(2),
+			expected:    0.9,
+		},
+		{
+			name:        "max preserves overload depth",
+			aggregation: aggregationMax,
+			components:  []component{{pluginRef: "a", weight: 1}, {pluginRef: "b", weight: 1}},
+			detectors:   []flowcontrol.SaturationDetector{&fixedDetector{name: "a", saturation: 1.5}, &fixedDetector{name: "b", saturation: 0.2}},
+			endpoints:   makeEndpoints(1),
+			expected:    1.5,
+		},
+		{
+			name:        "weighted average with equal weights",
+			aggregation: aggregationWeightedAverage,
+			components:  []component{{pluginRef: "a", weight: 1}, {pluginRef: "b", weight: 1}},
+			detectors:   []flowcontrol.SaturationDetector{&fixedDetector{name: "a", saturation: 0.2}, &fixedDetector{name: "b", saturation: 0.6}},
+			endpoints:   makeEndpoints(2),
+			expected:    0.4,
+		},
+		{
+			name:        "weighted average with skewed weights",
+			aggregation: aggregationWeightedAverage,
+			components:  []component{{pluginRef: "a", weight: 3}, {pluginRef: "b", weight: 1}},
+			detectors:   []flowcontrol.SaturationDetector{&fixedDetector{name: "a", saturation: 0.2}, &fixedDetector{name: "b", saturation: 0.6}},
+			endpoints:   makeEndpoints(2),
+			expected:    0.3,
+		},
+		{
+			name:        "empty pool is fully saturated",
+			aggregation: aggregationWeightedAverage,
+			components:  []component{{pluginRef: "a", weight: 1}},
+			detectors:   []flowcontrol.SaturationDetector{&fixedDetector{name: "a", saturation: 0.1}},
+			endpoints:   nil,
+			expected:    1.0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config{aggregation: tc.aggregation, components: tc.components}
+			detector := newDetector("test-composite", cfg, tc.detectors, logr.Discard())
+			require.InDelta(t, tc.expected, detector.Saturation(context.Background(), tc.endpoints), 1e-9)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// apiConfig represents the external configuration schema for the latency detector.
+//
+// It is designed to be deserialized from JSON via the plugin's raw parameters.
+type apiConfig struct {
+	// TargetTTFT is the time to first token the pool is expected to sustain. An endpoint whose TTFT equals the
+	// target contributes a saturation of 1.0; an endpoint at twice the target contributes 2.0.
+	//
+	// Defaults to 1s if unset.
+	TargetTTFT *metav1.Duration `json:"targetTTFT,omitempty"`
+
+	// Source selects where the TTFT signal is read from.
+	//
+	// Valid values are:
+	// - "observed": TTFT measured by the detector from dispatch to the first response chunk.
+	// - "predicted": TTFT predicted by the latency predictor (LatencyPredictionInfo endpoint attribute).
+	//
+	// Defaults to "observed" if unset.
+	Source *latencySource `json:"source,omitempty"`
+
+	// Smoothing is the weight given to each new observation in the per-endpoint exponentially weighted
+	// moving average of the observed TTFT. Must be in (0, 1]. Only used by the "observed" source.
+	//
+	// Defaults to 0.2 if unset.
+	Smoothing *float64 `json:"smoothing,omitempty"`
+
+	// SampleTTL defines how long an endpoint's observed TTFT remains valid without new observations. Once
+	// expired, the endpoint is considered idle. This prevents a pool that stopped receiving traffic because it
+	// was saturated from staying saturated forever. Only used by the "observed" source.
+	//
+	// Defaults to 30s if unset.
+	SampleTTL *metav1.Duration `json:"sampleTTL,omitempty"`
+
+	// LatencyPredictionInfoProducerName is the name of the producer of the LatencyPredictionInfo attribute.
+	// Only used by the "predicted" source.
+	LatencyPredictionInfoProducerName string `json:"latencyPredictionInfoProducerName,omitempty"`
+}
+
+// latencySource is the origin of the TTFT signal.
+type latencySource string
+
+const (
+	// sourceObserved uses TTFT measured from the request lifecycle.
+	sourceObserved latencySource = "observed"
+	// sourcePredicted uses TTFT predicted by the latency predictor.
+	sourcePredicted latencySource = "predicted"
+)
+
+const (
+	// defaultTargetTTFT is the default expected time to first token.
+	defaultTargetTTFT = time.Second
+	// defaultSource is used when Source is unset.
+	defaultSource = sourceObserved
+	// defaultSmoothing is the default EWMA weight of new observations.
+	defaultSmoothing = 0.2
+	// defaultSampleTTL is the default validity of an observed TTFT.
+	defaultSampleTTL = 30 * time.Second
+)
+
+// config is the internal, fully-validated configuration used by the detector.
+type config struct {
+	targetTTFT                        time.Duration
+	source                            latencySource
+	smoothing                         float64
+	sampleTTL                         time.Duration
+	latencyPredictionInfoProducerName string
+}
+
+// buildConfig applies the configuration lifecycle (defaulting and validation) and translates the
+// external schema into the internal domain model.
+// The provided apiConfig is copied to prevent mutation side-effects.
+func buildConfig(apiCfg *apiConfig) (*config, error) {
+	var safeCfg apiConfig
+	if apiCfg != nil {
+		safeCfg = *apiCfg
+	}
+
+	applyDefaults(&safeCfg)
+
+	if err := validateConfig(&safeCfg); err != nil {
+		return nil, fmt.Errorf("invalid latency saturation detector configuration: %w", err)
+	}
+
+	return &config{
+		targetTTFT:                        safeCfg.TargetTTFT.Duration,
+		source:                            *safeCfg.Source,
+		smoothing:                         *safeCfg.Smoothing,
+		sampleTTL:                         safeCfg.SampleTTL.Duration,
+		latencyPredictionInfoProducerName: safeCfg.LatencyPredictionInfoProducerName,
+	}, nil
+}
+
+// applyDefaults populates unset fields in the external configuration with their standard defaults.
+func applyDefaults(cfg *apiConfig) {
+	if cfg.TargetTTFT == nil {
+		cfg.TargetTTFT = &metav1.Duration{Duration: defaultTargetTTFT}
+	}
+	if cfg.Source == nil {
+		cfg.Source = ptr.To(defaultSource)
+	}
+	if cfg.Smoothing == nil {
+		cfg.Smoothing = ptr.To(defaultSmoothing)
+	}
+	if cfg.SampleTTL == nil {
+		cfg.SampleTTL = &metav1.Duration{Duration: defaultSampleTTL}
+	}
+}
+
+// validateConfig checks the constraints of the fully defaulted configuration.
+// It aggregates all validation failures.
+func validateConfig(cfg *apiConfig) error {
+	var errs []error
+
+	if cfg.TargetTTFT.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("targetTTFT must be strictly positive, got %v", cfg.TargetTTFT.Duration))
+	}
+	switch *cfg.Source {
+	case sourceObserved, sourcePredicted:
+		// Valid
+	default:
+		errs = append(errs, fmt.Errorf("unsupported source: %q", *cfg.Source))
+	}
+	if s := *cfg.Smoothing; math.IsNaN(s) || s <= 0 || s > 1 {
+		errs = append(errs, fmt.Errorf("smoothing must be in (0, 1], got %f", s))
+	}
+	if cfg.SampleTTL.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("sampleTTL must be strictly positive, got %v", cfg.SampleTTL.Duration))
+	}
+
+	return errors.Join(errs...)
+}
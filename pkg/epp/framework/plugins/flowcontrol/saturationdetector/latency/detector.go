@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package latency implements a saturation detector that derives pool saturation from the time to first
+// token (TTFT) relative to a target. Latency is the symptom users experience, so it captures bottlenecks
+// that resource-based detectors miss (e.g. prefill contention or preemption).
+//
+// For configuration details, see the package README.
+package latency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrlatency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/latency"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	// LatencyDetectorType is the unique identifier for this plugin.
+	LatencyDetectorType = "latency-saturation-detector"
+)
+
+// LatencyDetectorFactory instantiates the detector plugin using the provided JSON parameters.
+func LatencyDetectorFactory(
+	name string,
+	params *json.Decoder,
+	handle fwkplugin.Handle,
+) (fwkplugin.Plugin, error) {
+	var apiCfg apiConfig
+	if params != nil {
+		if err := params.Decode(&apiCfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal latency saturation detector config: %w", err)
+		}
+	}
+	cfg, err := buildConfig(&apiCfg)
+	if err != nil {
+		return nil, err
+	}
+	return newDetector(name, *cfg, log.FromContext(handle.Context())), nil
+}
+
+var (
+	_ flowcontrol.SaturationDetector       = &Detector{}
+	_ fwkplugin.ConsumerPlugin             = &Detector{}
+	_ requestcontrol.PreRequest            = &Detector{}
+	_ requestcontrol.ResponseBodyProcessor = &Detector{}
+)
+
+// ttftSample is the smoothed observed TTFT of a single endpoint.
+type ttftSample struct {
+	// ewmaMs is the exponentially weighted moving average of the observed TTFT in milliseconds.
+	ewmaMs float64
+	// updated is the time of the most recent observation.
+	updated time.Time
+}
+
+// Detector determines pool saturation from the TTFT of the candidate endpoints.
+type Detector struct {
+	config                       config
+	typedName                    fwkplugin.TypedName
+	latencyPredictionInfoDataKey fwkplugin.DataKey
+	dispatchTimeAttributeKey     string
+	now                          func() time.Time
+
+	mu       sync.Mutex
+	observed map[types.NamespacedName]*ttftSample
+}
+
+// newDetector creates a new instance of the Latency Detector.
+func newDetector(name string, cfg config, logger logr.Logger) *Detector {
+	typedName := fwkplugin.TypedName{
+		Type: LatencyDetectorType,
+		Name: name,
+	}
+
+	logger.WithName(typedName.String()).V(logutil.DEFAULT).Info("Creating new LatencyDetector",
+		"source", cfg.source,
+		"targetTTFT", cfg.targetTTFT,
+		"smoothing", cfg.smoothing,
+		"sampleTTL", cfg.sampleTTL)
+
+	return &Detector{
+		config:    cfg,
+		typedName: typedName,
+		latencyPredictionInfoDataKey: attrlatency.LatencyPredictionInfoDataKey.WithNonEmptyProducerName(
+			cfg.latencyPredictionInfoProducerName),
+		dispatchTimeAttributeKey: name + "/dispatch-time",
+		now:                      time.Now,
+		observed:                 make(map[types.NamespacedName]*ttftSample),
+	}
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (d *Detector) TypedName() fwkplugin.TypedName {
+	return d.typedName
+}
+
+// Consumes declares the LatencyPredictionInfo dependency when the predicted source is used.
+func (d *Detector) Consumes() fwkplugin.DataDependencies {
+	if d.config.source != sourcePredicted {
+		return fwkplugin.DataDependencies{}
+	}
+	return fwkplugin.DataDependencies{
+		Required: map[fwkplugin.DataKey]any{d.latencyPredictionInfoDataKey: attrlatency.LatencyPredictionInfo{}},
+	}
+}
+
+// Saturation calculates the saturation level of the pool as the unweighted average of per-endpoint
+// saturation:
+//
+//	EndpointSaturation = TTFT / TargetTTFT
+//	PoolSaturation = Σ(EndpointSaturation) / EndpointCount
+//
+// Endpoints without a TTFT signal (no prediction, or no observation within the sample TTL) are considered
+// idle and contribute 0.
+func (d *Detector) Saturation(_ context.Context, endpoints []datalayer.Endpoint) float64 {
+	if len(endpoints) == 0 {
+		return 1.0
+	}
+
+	var getTTFT func(datalayer.Endpoint) (float64, bool)
+	if d.config.source == sourcePredicted {
+		getTTFT = d.predictedTTFT
+	} else {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.evictExpiredLocked()
+		getTTFT = d.observedTTFTLocked
+	}
+
+	targetMs := float64(d.config.targetTTFT) / float64(time.Millisecond)
+	var total float64
+	var count int
+	for _, e := range endpoints {
+		if e.GetMetadata() == nil {
+			continue
+		}
+		count++
+		if ttftMs, ok := getTTFT(e); ok {
+			total += ttftMs / targetMs
+		}
+	}
+
+	if count == 0 {
+		return 1.0
+	}
+
+	saturation := total / float64(count)
+	metrics.RecordFlowControlDetectorSaturation(d.typedName.Type, d.typedName.Name, saturation)
+	return saturation
+}
+
+// predictedTTFT reads the TTFT predicted for the endpoint, in milliseconds.
+func (d *Detector) predictedTTFT(e datalayer.Endpoint) (float64, bool) {
+	val, ok := e.GetAttributes().Get(d.latencyPredictionInfoDataKey.String())
+	if !ok {
+		return 0, false
+	}
+	info, ok := val.(*attrlatency.LatencyPredictionInfo)
+	if !ok || info == nil || info.TTFT() <= 0 {
+		return 0, false
+	}
+	return info.TTFT(), true
+}
+
+// observedTTFTLocked returns the smoothed observed TTFT of the endpoint, in milliseconds.
+// The caller must hold d.mu.
+func (d *Detector) observedTTFTLocked(e datalayer.Endpoint) (float64, bool) {
+	sample, ok := d.observed[e.GetMetadata().NamespacedName]
+	if !ok {
+		return 0, false
+	}
+	return sample.ewmaMs, true
+}
+
+// evictExpiredLocked drops observations older than the sample TTL, which also bounds the state to endpoints that
+// recently served traffic. The caller must hold d.mu.
+func (d *Detector) evictExpiredLocked() {
+	now := d.now()
+	for name, sample := range d.observed {
+		if now.Sub(sample.updated) > d.config.sampleTTL {
+			delete(d.observed, name)
+		}
+	}
+}
+
+// PreRequest records the dispatch time of the request for TTFT measurement.
+func (d *Detector) PreRequest(_ context.Context, request *fwksched.InferenceRequest, _ *fwksched.SchedulingResult) {
+	if d.config.source != sourceObserved || request == nil {
+		return
+	}
+	request.PutAttribute(d.dispatchTimeAttributeKey, d.now())
+}
+
+// ResponseBody measures the TTFT on the first response chunk and folds it into the serving endpoint's
+// moving average.
+func (d *Detector) ResponseBody(
+	_ context.Context,
+	request *fwksched.InferenceRequest,
+	response *requestcontrol.Response,
+	targetEndpoint *datalayer.EndpointMetadata,
+) {
+	if d.config.source != sourceObserved || request == nil || response == nil || !response.StartOfStream ||
+		targetEndpoint == nil {
+		return
+	}
+	dispatched, ok := fwksched.ReadRequestAttribute[time.Time](request, d.dispatchTimeAttributeKey)
+	if !ok {
+		return
+	}
+	now := d.now()
+	ttftMs := float64(now.Sub(dispatched)) / float64(time.Millisecond)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	sample, ok := d.observed[targetEndpoint.NamespacedName]
+	if !ok || now.Sub(sample.updated) > d.config.sampleTTL {
+		d.observed[targetEndpoint.NamespacedName] = &ttftSample{ewmaMs: ttftMs, updated: now}
+		return
+	}
+	sample.ewmaMs += d.config.smoothing * (ttftMs - sample.ewmaMs)
+	sample.updated = now
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrlatency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/latency"
+)
+
+func makeEndpoint(name string, predictedTTFTMs float64) fwkdl.Endpoint {
+	meta := &fwkdl.EndpointMetadata{
+		NamespacedName: types.NamespacedName{Name: name, Namespace: "ns1"},
+	}
+	endpoint := fwkdl.NewEndpoint(meta, fwkdl.NewMetrics())
+	if predictedTTFTMs > 0 {
+		endpoint.GetAttributes().Put(attrlatency.LatencyPredictionInfoDataKey.String(),
+			attrlatency.NewLatencyPredictionInfo(true, true, 0, 0, predictedTTFTMs, 0, 0))
+	}
+	return endpoint
+}
+
+// fakeClock is a manually advanced clock for deterministic TTFT measurement.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestDetector(t *testing.T, cfg config) (*Detector, *fakeClock) {
+	t.Helper()
+	d := newDetector("test-latency", cfg, logr.Discard())
+	clock := &fakeClock{t: time.Unix(1000, 0)}
+	d.now = clock.now
+	return d, clock
+}
+
+// serve simulates a request dispatched to the endpoint whose first chunk arrives after ttft.
+func serve(d *Detector, clock *fakeClock, endpoint string, ttft time.Duration) {
+	request := &fwksched.InferenceRequest{RequestID: "req"}
+	d.PreRequest(context.Background(), request, nil)
+	clock.advance(ttft)
+	meta := &fwkdl.EndpointMetadata{NamespacedName: types.NamespacedName{Name: endpoint, Namespace: "ns1"}}
+	d.ResponseBody(context.Background(), request, &requestcontrol.Response{StartOfStream: true}, meta)
+	// Subsequent chunks must not be treated as the first token.
+	clock.advance(ttft)
+	d.ResponseBody(context.Background(), request, &requestcontrol.Response{EndOfStream: true}, meta)
+}
+
+// TestLatencyDetectorFactory evaluates instantiation properties and config parsing constraints.
+func TestLatencyDetectorFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		configJSON []byte
+		wantError  bool
+	}{
+		{
+			name:       "empty config applies defaults",
+			configJSON: []byte(`{}`),
+		},
+		{
+			name:       "valid predicted configuration",
+			configJSON: []byte(`{"source": "predicted", "targetTTFT": "500ms"}`),
+		},
+		{
+			name:       "valid observed configuration",
+			configJSON: []byte(`{"source": "observed", "targetTTFT": "2s", "smoothing": 0.5, "sampleTTL": "10s"}`),
+		},
+		{
+			name:       "invalid schema",
+			configJSON: []byte(`{"smoothing": "high"}`),
+			wantError:  true,
+		},
+		{
+			name:       "invalid target",
+			configJSON: []byte(`{"targetTTFT": "0s"}`),
+			wantError:  true,
+		},
+		{
+			name:       "invalid source",
+			configJSON: []byte(`{"source": "measured"}`),
+			wantError:  true,
+		},
+		{
+			name:       "invalid smoothing",
+			configJSON: []byte(`{"smoothing": 1.5}`),
+			wantError:  true,
+		},
+		{
+			name:       "invalid sample TTL",
+			configJSON: []byte(`{"sampleTTL": "-1s"}`),
+			wantError:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			plugin, err := LatencyDetectorFactory("test-latency", fwkplugin.StrictDecoder(tc.configJSON), fwkplugin.NewEppHandle(t.Context(), func() []types.NamespacedName { return nil }))
+			if tc.wantError {
+				require.Error(t, err, "Expected initialization to fail on invalid configuration")
+				require.Nil(t, plugin, "Plugin must be nil when initialization fails")
+			} else {
+				require.NoError(t, err, "Expected initialization to succeed with valid configuration")
+				require.NotNil(t, plugin, "Plugin must not be nil on success")
+			}
+		})
+	}
+}
+
+// TestDetector_Consumes verifies the prediction dependency is only declared for the predicted source.
+func TestDetector_Consumes(t *testing.T) {
+	t.Parallel()
+
+	observed := newDetector("observed", config{source: sourceObserved}, logr.Discard())
+	require.Empty(t, observed.Consumes().Required)
+
+	predicted := newDetector("predicted", config{source: sourcePredicted}, logr.Discard())
+	require.Contains(t, predicted.Consumes().Required, attrlatency.LatencyPredictionInfoDataKey)
+}
+
+// TestDetector_Saturation_Predicted verifies saturation derived from predicted TTFT.
+func TestDetector_Saturation_Predicted(t *testing.T) {
+	t.Parallel()
+
+	cfg := config{
+		targetTTFT: 200 * time.Millisecond,
+		source:     sourcePredicted,
+		smoothing:  defaultSmoothing,
+		sampleTTL:  defaultSampleTTL,
+	}
+
+	tests := []struct {
+		name      string
+		endpoints []fwkdl.Endpoint
+		expected  float64
+	}{
+		{
+			name:      "empty pool is fully saturated",
+			endpoints: nil,
+			expected:  1.0,
+		},
+		{
+			name:      "single endpoint at half the target",
+			endpoints: []fwkdl.Endpoint{makeEndpoint("a", 100)},
+			expected:  0.5,
+		},
+		{
+			name:      "average across endpoints",
+			endpoints: []fwkdl.Endpoint{makeEndpoint("a", 100), makeEndpoint("b", 300)},
+			expected:  1.0,
+		},
+		{
+			name:      "endpoint without prediction is idle",
+			endpoints: []fwkdl.Endpoint{makeEndpoint("a", 400), makeEndpoint("b", 0)},
+			expected:  1.0,
+		},
+		{
+			name:      "overload depth is preserved",
+			endpoints: []fwkdl.Endpoint{makeEndpoint("a", 600)},
+			expected:  3.0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			d, _ := newTestDetector(t, cfg)
+			require.InDelta(t, tc.expected, d.Saturation(context.Background(), tc.endpoints), 1e-9)
+		})
+	}
+}
+
+// TestDetector_Saturation_Observed verifies TTFT measurement, smoothing and expiry.
+func TestDetector_Saturation_Observed(t *testing.T) {
+	t.Parallel()
+
+	cfg := config{
+		targetTTFT: time.Second,
+		source:     sourceObserved,
+		smoothing:  0.5,
+		sampleTTL:  10 * time.Second,
+	}
+	endpoints := []fwkdl.Endpoint{makeEndpoint("a", 0), makeEndpoint("b", 0)}
+
+	t.Run("no observations", func(t *testing.T) {
+		t.Parallel()
+		d, _ := newTestDetector(t, cfg)
+		require.InDelta(t, 0.0, d.Saturation(context.Background(), endpoints), 1e-9)
+	})
+
+	t.Run("first observation seeds the average", func(t *testing.T) {
+		t.Parallel()
+		d, clock := newTestDetector(t, cfg)
+		serve(d, clock, "a", 2*time.Second)
+		// a = 2.0, b idle.
+		require.InDelta(t, 1.0, d.Saturation(context.Background(), endpoints), 1e-9)
+	})
+
+	t.Run("subsequent observations are smoothed", func(t *testing.T) {
+		t.Parallel()
+		d, clock := newTestDetector(t, cfg)
+		serve(d, clock, "a", 2*time.Second)
+		serve(d, clock, "a", 1*time.Second)
+		serve(d, clock, "b", 500*time.Millisecond)
+		// a = 2 + 0.5*(1-2) = 1.5, b = 0.5.
+		require.InDelta(t, 1.0, d.Saturation(context.Background(), endpoints), 1e-9)
+	})
+
+	t.Run("expired observations are dropped", func(t *testing.T) {
+		t.Parallel()
+		d, clock := newTestDetector(t, cfg)
+		serve(d, clock, "a", 4*time.Second)
+		clock.advance(11 * time.Second)
+		require.InDelta(t, 0.0, d.Saturation(context.Background(), endpoints), 1e-9)
+		require.Empty(t, d.observed)
+	})
+
+	t.Run("response without dispatch time is ignored", func(t *testing.T) {
+		t.Parallel()
+		d, _ := newTestDetector(t, cfg)
+		meta := &fwkdl.EndpointMetadata{NamespacedName: types.NamespacedName{Name: "a", Namespace: "ns1"}}
+		d.ResponseBody(context.Background(), &fwksched.InferenceRequest{}, &requestcontrol.Response{StartOfStream: true}, meta)
+		require.Empty(t, d.observed)
+	})
+}
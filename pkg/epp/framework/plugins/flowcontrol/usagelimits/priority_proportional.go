@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usagelimits
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+const PriorityProportionalUsageLimitPolicyType = "priority-proportional-usage-limit-policy"
+
+// Spacing selects how ceilings are distributed between the floor and 1.0 across the active priorities.
+type Spacing string
+
+const (
+	// SpacingLinear spaces ceilings evenly between the floor (lowest priority) and 1.0 (highest priority).
+	SpacingLinear Spacing = "linear"
+	// SpacingGeometric shrinks the reserved headroom (1.0 - ceiling) by a constant ratio at each step up
+	// in rank, so the ceilings converge towards 1.0 quickly and lower priorities absorb most of the reserve.
+	SpacingGeometric Spacing = "geometric"
+)
+
+// The defaults gate sheddable traffic at 70% saturation, standard traffic at 90% and critical traffic never when
+// those three priorities are active.
+const (
+	defaultProportionalFloor   = 0.7
+	defaultProportionalSpacing = SpacingGeometric
+	defaultGeometricRatio      = 1.0 / 3.0
+)
+
+// priorityProportionalPolicyConfig is the JSON configuration for the priority-proportional usage limit policy.
+type priorityProportionalPolicyConfig struct {
+	// Floor is the ceiling assigned to the lowest active priority. Must be in (0.0, 1.0]. Defaults to 0.7.
+	Floor *float64 `json:"floor,omitempty"`
+	// Spacing is either "linear" or "geometric" (default).
+	Spacing Spacing `json:"spacing,omitempty"`
+	// Ratio is the factor by which the reserved headroom shrinks per rank with geometric spacing.
+	// Must be in (0.0, 1.0]. Defaults to 1/3. Ignored with linear spacing.
+	Ratio *float64 `json:"ratio,omitempty"`
+}
+
+// PriorityProportionalPolicyFactory creates a priority-proportional UsageLimitPolicy from JSON config.
+func PriorityProportionalPolicyFactory(name string, rawConfig *json.Decoder, _ plugin.Handle) (plugin.Plugin, error) {
+	cfg := priorityProportionalPolicyConfig{}
+	if rawConfig != nil {
+		if err := rawConfig.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse priority-proportional usage limit policy config: %w", err)
+		}
+	}
+
+	floor := defaultProportionalFloor
+	if cfg.Floor != nil {
+		floor = *cfg.Floor
+	}
+	ratio := defaultGeometricRatio
+	if cfg.Ratio != nil {
+		ratio = *cfg.Ratio
+	}
+	spacing := cfg.Spacing
+	if spacing == "" {
+		spacing = defaultProportionalSpacing
+	}
+
+	policy, err := NewPriorityProportionalPolicy(name, floor, spacing, ratio)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", name, err)
+	}
+	return policy, nil
+}
+
+// NewPriorityProportionalPolicy returns a UsageLimitPolicy that derives each active priority's ceiling from its
+// rank within the active priority domain. The highest active priority always receives 1.0 (never gated) and the
+// lowest receives floor; priorities in between are spaced according to spacing. When only one priority is active
+// it receives 1.0, since there is no lower priority to reserve headroom from.
+//
+// The ceilings depend only on the rank, not on the absolute priority values, so the policy adapts as workloads
+// come and go.
+func NewPriorityProportionalPolicy(name string, floor float64, spacing Spacing, ratio float64) (flowcontrol.UsageLimitPolicy, error) {
+	if math.IsNaN(floor) || floor <= 0 || floor > 1 {
+		return nil, fmt.Errorf("floor must be in (0.0, 1.0], got %v", floor)
+	}
+	switch spacing {
+	case SpacingLinear:
+	case SpacingGeometric:
+		if math.IsNaN(ratio) || ratio <= 0 || ratio > 1 {
+			return nil, fmt.Errorf("ratio must be in (0.0, 1.0], got %v", ratio)
+		}
+	default:
+		return nil, fmt.Errorf("unknown spacing %q, must be one of %q or %q", spacing, SpacingLinear, SpacingGeometric)
+	}
+
+	return &priorityProportionalPolicy{
+		name:    name,
+		floor:   floor,
+		spacing: spacing,
+		ratio:   ratio,
+	}, nil
+}
+
+type priorityProportionalPolicy struct {
+	name    string
+	floor   float64
+	spacing Spacing
+	ratio   float64
+}
+
+var _ flowcontrol.UsageLimitPolicy = &priorityProportionalPolicy{}
+
+func (p *priorityProportionalPolicy) TypedName() plugin.TypedName {
+	return plugin.TypedName{
+		Type: PriorityProportionalUsageLimitPolicyType,
+		Name: p.name,
+	}
+}
+
+// ComputeLimit assigns ceilings by rank. Priorities are ordered highest first, so index 0 receives 1.0 and the
+// last index receives the floor. Saturation does not affect the ceilings; it is compared against them by the caller.
+func (p *priorityProportionalPolicy) ComputeLimit(_ context.Context, _ float64, priorities []int) []float64 {
+	n := len(priorities)
+	ceilings := make([]float64, n)
+	if n == 0 {
+		return ceilings
+	}
+	ceilings[0] = 1.0
+	headroom := 1.0 - p.floor
+	for i := 1; i < n; i++ {
+		// stepsFromBottom is 0 for the lowest priority and n-1 for the highest.
+		stepsFromBottom := n - 1 - i
+		switch p.spacing {
+		case SpacingGeometric:
+			ceilings[i] = 1.0 - headroom*math.Pow(p.ratio, float64(stepsFromBottom))
+		default:
+			ceilings[i] = p.floor + headroom*float64(stepsFromBottom)/float64(n-1)
+		}
+	}
+	return ceilings
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usagelimits
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+)
+
+func TestPriorityProportionalPolicy_ComputeLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		floor      float64
+		spacing    Spacing
+		ratio      float64
+		priorities []int
+		expected   []float64
+	}{
+		{
+			name:       "no active priorities",
+			floor:      0.7,
+			spacing:    SpacingLinear,
+			priorities: []int{},
+			expected:   []float64{},
+		},
+		{
+			name:       "single priority is never gated",
+			floor:      0.7,
+			spacing:    SpacingLinear,
+			priorities: []int{0},
+			expected:   []float64{1.0},
+		},
+		{
+			name:       "linear two priorities",
+			floor:      0.7,
+			spacing:    SpacingLinear,
+			priorities: []int{10, -10},
+			expected:   []float64{1.0, 0.7},
+		},
+		{
+			name:       "linear three priorities",
+			floor:      0.7,
+			spacing:    SpacingLinear,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 0.85, 0.7},
+		},
+		{
+			name:       "linear five priorities",
+			floor:      0.6,
+			spacing:    SpacingLinear,
+			priorities: []int{100, 50, 0, -50, -100},
+			expected:   []float64{1.0, 0.9, 0.8, 0.7, 0.6},
+		},
+		{
+			name:       "geometric sheddable, standard, critical",
+			floor:      0.7,
+			spacing:    SpacingGeometric,
+			ratio:      1.0 / 3.0,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 0.9, 0.7},
+		},
+		{
+			name:       "geometric four priorities",
+			floor:      0.6,
+			spacing:    SpacingGeometric,
+			ratio:      0.5,
+			priorities: []int{3, 2, 1, 0},
+			expected:   []float64{1.0, 0.9, 0.8, 0.6},
+		},
+		{
+			name:       "floor of one disables gating",
+			floor:      1.0,
+			spacing:    SpacingGeometric,
+			ratio:      0.5,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 1.0, 1.0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := NewPriorityProportionalPolicy("test", tc.floor, tc.spacing, tc.ratio)
+			require.NoError(t, err)
+
+			// Ceilings depend only on rank, so they must be identical across saturation levels.
+			for _, saturation := range []float64{0.0, 0.5, 1.0} {
+				ceilings := policy.ComputeLimit(context.Background(), saturation, tc.priorities)
+				require.Len(t, ceilings, len(tc.expected))
+				for i := range tc.expected {
+					assert.InDelta(t, tc.expected[i], ceilings[i], 1e-9,
+						"ceiling for priority %d at saturation %v", tc.priorities[i], saturation)
+				}
+			}
+		})
+	}
+}
+
+func TestPriorityProportionalPolicyFactory(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		params      string
+		expectedErr string
+		priorities  []int
+		expected    []float64
+	}{
+		{
+			name:       "defaults",
+			params:     `{}`,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 0.9, 0.7},
+		},
+		{
+			name:       "explicit linear",
+			params:     `{"spacing": "linear"}`,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 0.85, 0.7},
+		},
+		{
+			name:       "explicit geometric",
+			params:     `{"floor": 0.5, "spacing": "geometric", "ratio": 0.5}`,
+			priorities: []int{1, 0, -1},
+			expected:   []float64{1.0, 0.75, 0.5},
+		},
+		{
+			name:        "floor of zero",
+			params:      `{"floor": 0}`,
+			expectedErr: "floor must be in (0.0, 1.0]",
+		},
+		{
+			name:        "floor above one",
+			params:      `{"floor": 1.2}`,
+			expectedErr: "floor must be in (0.0, 1.0]",
+		},
+		{
+			name:        "unknown spacing",
+			params:      `{"spacing": "cubic"}`,
+			expectedErr: "unknown spacing",
+		},
+		{
+			name:        "geometric ratio out of range",
+			params:      `{"spacing": "geometric", "ratio": 1.5}`,
+			expectedErr: "ratio must be in (0.0, 1.0]",
+		},
+		{
+			name:       "ratio ignored with linear spacing",
+			params:     `{"spacing": "linear", "ratio": 1.5}`,
+			priorities: []int{1, 0},
+			expected:   []float64{1.0, 0.7},
+		},
+		{
+			name:        "malformed parameters",
+			params:      `{"floor": "high"}`,
+			expectedErr: "failed to parse",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := PriorityProportionalPolicyFactory("my-policy", json.NewDecoder(strings.NewReader(tc.params)), nil)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, PriorityProportionalUsageLimitPolicyType, p.TypedName().Type)
+			assert.Equal(t, "my-policy", p.TypedName().Name)
+
+			policy, ok := p.(flowcontrol.UsageLimitPolicy)
+			require.True(t, ok)
+			ceilings := policy.ComputeLimit(context.Background(), 0.5, tc.priorities)
+			assert.InDeltaSlice(t, tc.expected, ceilings, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adaptiveconcurrency
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+const (
+	// minGradient and maxGradient bound the multiplicative change of a single gradient update, so one
+	// outlier sample can at most halve the limit.
+	minGradient = 0.5
+	maxGradient = 1.0
+)
+
+// limiter learns the concurrency limit of a single scope (the pool, or one model) from latency samples.
+// All methods are safe for concurrent use.
+type limiter struct {
+	typedName fwkplugin.TypedName
+	scope     string
+	params    *Parameters
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	// baseline is the minimum latency over the last BaselineWindow samples, in seconds.
+	baseline float64
+	window   windowedMin
+	// lastRTT is the most recent latency sample, in seconds.
+	lastRTT float64
+	samples uint64
+	// released is closed and replaced whenever a request completes, waking deferred admissions.
+	released chan struct{}
+}
+
+func newLimiter(typedName fwkplugin.TypedName, scope string, params *Parameters) *limiter {
+	l := &limiter{
+		typedName: typedName,
+		scope:     scope,
+		params:    params,
+		limit:     float64(params.InitialLimit),
+		window:    windowedMin{size: uint64(params.BaselineWindow)},
+		released:  make(chan struct{}),
+	}
+	recordLimit(typedName, scope, l.limit, 0)
+	recordInFlight(typedName, scope, 0)
+	return l
+}
+
+// hasCapacity reports whether another request fits under the current limit, without counting it.
+func (l *limiter) hasCapacity() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return float64(l.inFlight) < math.Floor(l.limit)
+}
+
+// tryAcquire counts a request if it fits under the current limit, and reports whether it did.
+func (l *limiter) tryAcquire() bool {
+	l.mu.Lock()
+	if float64(l.inFlight) >= math.Floor(l.limit) {
+		l.mu.Unlock()
+		return false
+	}
+	l.inFlight++
+	inFlight := l.inFlight
+	l.mu.Unlock()
+	recordInFlight(l.typedName, l.scope, inFlight)
+	return true
+}
+
+// acquireWithin waits until a request fits under the current limit and counts it, giving up when the timeout
+// elapses or the context is done. It reports whether the request was counted. The slot is taken under the lock,
+// so a released slot admits a single waiter.
+func (l *limiter) acquireWithin(ctx context.Context, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		l.mu.Lock()
+		if float64(l.inFlight) < math.Floor(l.limit) {
+			l.inFlight++
+			inFlight := l.inFlight
+			l.mu.Unlock()
+			recordInFlight(l.typedName, l.scope, inFlight)
+			return true
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-timer.C:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// acquire counts a dispatched request.
+func (l *limiter) acquire() {
+	l.mu.Lock()
+	l.inFlight++
+	inFlight := l.inFlight
+	l.mu.Unlock()
+	recordInFlight(l.typedName, l.scope, inFlight)
+}
+
+// release uncounts a completed request and wakes deferred admissions.
+func (l *limiter) release() {
+	l.mu.Lock()
+	if l.inFlight > 0 {
+		l.inFlight--
+	}
+	inFlight := l.inFlight
+	close(l.released)
+	l.released = make(chan struct{})
+	l.mu.Unlock()
+	recordInFlight(l.typedName, l.scope, inFlight)
+}
+
+// onSample folds a latency sample into the baseline and updates the limit with the configured algorithm.
+func (l *limiter) onSample(rtt time.Duration) {
+	sample := rtt.Seconds()
+	if sample <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	l.samples++
+	l.lastRTT = sample
+	l.baseline = l.window.add(sample)
+
+	switch l.params.Algorithm {
+	case AlgorithmGradient:
+		l.updateGradientLocked(sample)
+	default:
+		l.updateAIMDLocked(sample)
+	}
+	limit, baseline := l.limit, l.baseline
+	l.mu.Unlock()
+
+	recordLimit(l.typedName, l.scope, limit, baseline)
+}
+
+// updateAIMDLocked applies additive increase / multiplicative decrease: the limit backs off multiplicatively
+// when the sample exceeds the tolerated latency, and grows by one when the limit is actually being used.
+// The caller must hold l.mu.
+func (l *limiter) updateAIMDLocked(sample float64) {
+	if sample > l.params.Tolerance*l.baseline {
+		l.limit = l.clamp(l.limit * l.params.BackoffRatio)
+		return
+	}
+	if float64(l.inFlight)*2 >= l.limit {
+		l.limit = l.clamp(l.limit + 1)
+	}
+}
+
+// updateGradientLocked scales the limit by the ratio of the tolerated baseline latency to the sample, plus a
+// queue allowance of sqrt(limit) that lets the limit probe for more capacity while latency is healthy.
+// The caller must hold l.mu.
+func (l *limiter) updateGradientLocked(sample float64) {
+	// The limit is not being exercised, so the sample says nothing about it.
+	if float64(l.inFlight)*2 < l.limit {
+		return
+	}
+	gradient := math.Max(minGradient, math.Min(maxGradient, l.params.Tolerance*l.baseline/sample))
+	newLimit := l.limit*gradient + math.Sqrt(l.limit)
+	l.limit = l.clamp(l.limit*(1-l.params.Smoothing) + newLimit*l.params.Smoothing)
+}
+
+func (l *limiter) clamp(limit float64) float64 {
+	return math.Max(float64(l.params.MinLimit), math.Min(float64(l.params.MaxLimit), limit))
+}
+
+// windowedMin tracks the minimum of the last size samples. It keeps the samples that can still become the minimum,
+// in increasing order of both sequence number and value.
+type windowedMin struct {
+	size    uint64
+	next    uint64
+	samples []windowSample
+}
+
+type windowSample struct {
+	seq   uint64
+	value float64
+}
+
+// add records a sample and returns the minimum of the window.
+func (w *windowedMin) add(value float64) float64 {
+	seq := w.next
+	w.next++
+	for len(w.samples) > 0 && w.samples[len(w.samples)-1].value >= value {
+		w.samples = w.samples[:len(w.samples)-1]
+	}
+	w.samples = append(w.samples, windowSample{seq: seq, value: value})
+	for w.samples[0].seq+w.size <= seq {
+		w.samples = w.samples[1:]
+	}
+	return w.samples[0].value
+}
+
+// limiterState is the debug representation of a limiter.
+type limiterState struct {
+	Scope              string  `json:"scope"`
+	Limit              float64 `json:"limit"`
+	InFlight           int     `json:"inFlight"`
+	RTTBaselineSeconds float64 `json:"rttBaselineSeconds"`
+	LastRTTSeconds     float64 `json:"lastRttSeconds"`
+	Samples            uint64  `json:"samples"`
+}
+
+func (l *limiter) state() limiterState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return limiterState{
+		Scope:              l.scope,
+		Limit:              l.limit,
+		InFlight:           l.inFlight,
+		RTTBaselineSeconds: l.baseline,
+		LastRTTSeconds:     l.lastRTT,
+		Samples:            l.samples,
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adaptiveconcurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+)
+
+func newTestLimiter(mutate func(*Parameters)) *limiter {
+	params := defaultParameters()
+	if mutate != nil {
+		mutate(&params)
+	}
+	return newLimiter(fwkplugin.TypedName{Type: Type, Name: "test"}, ScopePool, &params)
+}
+
+// fill acquires n in-flight slots.
+func fill(l *limiter, n int) {
+	for range n {
+		l.acquire()
+	}
+}
+
+func TestLimiter_AIMD(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		inFlight int
+		samples  []time.Duration
+		expected float64
+	}{
+		{
+			name:     "healthy latency with the limit in use increases additively",
+			inFlight: 15,
+			samples:  []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+			expected: 23,
+		},
+		{
+			name:     "healthy latency with the limit unused leaves the limit unchanged",
+			inFlight: 2,
+			samples:  []time.Duration{100 * time.Millisecond, 100 * time.Millisecond},
+			expected: 20,
+		},
+		{
+			name:     "latency above tolerance backs off multiplicatively",
+			inFlight: 10,
+			samples:  []time.Duration{100 * time.Millisecond, time.Second},
+			expected: 21 * 0.9,
+		},
+		{
+			name:     "zero latency sample is ignored",
+			inFlight: 10,
+			samples:  []time.Duration{0},
+			expected: 20,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			l := newTestLimiter(nil)
+			fill(l, tc.inFlight)
+			for _, s := range tc.samples {
+				l.onSample(s)
+			}
+			assert.InDelta(t, tc.expected, l.state().Limit, 1e-9)
+		})
+	}
+}
+
+func TestLimiter_AIMD_RespectsBounds(t *testing.T) {
+	t.Parallel()
+
+	l := newTestLimiter(func(p *Parameters) {
+		p.InitialLimit = 2
+		p.MinLimit = 2
+		p.MaxLimit = 3
+	})
+	fill(l, 2)
+	for range 5 {
+		l.onSample(100 * time.Millisecond)
+	}
+	assert.InDelta(t, 3, l.state().Limit, 1e-9, "limit must not exceed maxLimit")
+
+	for range 20 {
+		l.onSample(10 * time.Second)
+	}
+	assert.InDelta(t, 2, l.state().Limit, 1e-9, "limit must not drop below minLimit")
+}
+
+func TestLimiter_Gradient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("latency at baseline grows the limit by the queue allowance", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.Algorithm = AlgorithmGradient })
+		fill(l, 20)
+		l.onSample(100 * time.Millisecond)
+		// gradient = min(1, 1.5*0.1/0.1) = 1; new = 20 + sqrt(20); limit = 20*0.8 + new*0.2.
+		assert.InDelta(t, 20+0.2*4.47213595499958, l.state().Limit, 1e-9)
+	})
+
+	t.Run("latency far above baseline shrinks the limit", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) {
+			p.Algorithm = AlgorithmGradient
+			p.Smoothing = 1
+		})
+		fill(l, 20)
+		l.onSample(100 * time.Millisecond)
+		before := l.state().Limit
+		for range 10 {
+			l.onSample(2 * time.Second)
+		}
+		assert.Less(t, l.state().Limit, before)
+	})
+
+	t.Run("unused limit is left unchanged", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.Algorithm = AlgorithmGradient })
+		fill(l, 1)
+		l.onSample(100 * time.Millisecond)
+		l.onSample(5 * time.Second)
+		assert.InDelta(t, 20, l.state().Limit, 1e-9)
+	})
+}
+
+func TestLimiter_Baseline(t *testing.T) {
+	t.Parallel()
+
+	l := newTestLimiter(func(p *Parameters) { p.BaselineWindow = 3 })
+	samples := []struct {
+		rtt      time.Duration
+		baseline float64
+	}{
+		{rtt: 100 * time.Millisecond, baseline: 0.1},
+		{rtt: 300 * time.Millisecond, baseline: 0.1},
+		{rtt: 200 * time.Millisecond, baseline: 0.1},
+		// The 100ms sample leaves the window of the last 3 samples.
+		{rtt: 400 * time.Millisecond, baseline: 0.2},
+		{rtt: 500 * time.Millisecond, baseline: 0.2},
+		{rtt: 600 * time.Millisecond, baseline: 0.4},
+		{rtt: 50 * time.Millisecond, baseline: 0.05},
+	}
+	for i, s := range samples {
+		l.onSample(s.rtt)
+		assert.InDelta(t, s.baseline, l.state().RTTBaselineSeconds, 1e-9, "after sample %d", i)
+	}
+	assert.InDelta(t, 0.05, l.state().LastRTTSeconds, 1e-9)
+	assert.Equal(t, uint64(len(samples)), l.state().Samples)
+}
+
+func TestLimiter_AcquireWithin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("wakes up when a request is released", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.InitialLimit = 1 })
+		l.acquire()
+		require.False(t, l.tryAcquire())
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			l.release()
+		}()
+		assert.True(t, l.acquireWithin(context.Background(), 5*time.Second))
+		assert.Equal(t, 1, l.state().InFlight)
+	})
+
+	t.Run("a release admits a single waiter", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.InitialLimit = 1 })
+		l.acquire()
+
+		const waiters = 5
+		var admitted atomic.Int32
+		var wg sync.WaitGroup
+		for range waiters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if l.acquireWithin(context.Background(), 200*time.Millisecond) {
+					admitted.Add(1)
+				}
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		l.release()
+		wg.Wait()
+		assert.Equal(t, int32(1), admitted.Load())
+		assert.Equal(t, 1, l.state().InFlight)
+	})
+
+	t.Run("times out without capacity", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.InitialLimit = 1 })
+		l.acquire()
+		assert.False(t, l.acquireWithin(context.Background(), 10*time.Millisecond))
+		assert.Equal(t, 1, l.state().InFlight)
+	})
+
+	t.Run("returns when the context is done", func(t *testing.T) {
+		t.Parallel()
+		l := newTestLimiter(func(p *Parameters) { p.InitialLimit = 1 })
+		l.acquire()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.False(t, l.acquireWithin(ctx, 5*time.Second))
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adaptiveconcurrency
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	metricsutil "github.com/llm-d/llm-d-router/pkg/common/observability/metrics"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	eppmetrics "github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+var (
+	concurrencyLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "adaptive_concurrency_limit",
+			Help:      metricsutil.HelpMsgWithStability("Current concurrency limit learned by the adaptive concurrency admitter.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "scope"},
+	)
+
+	rttBaseline = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "adaptive_concurrency_rtt_baseline_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Latency baseline the adaptive concurrency admitter compares new samples against.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "scope"},
+	)
+
+	inFlightRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "adaptive_concurrency_inflight_requests",
+			Help:      metricsutil.HelpMsgWithStability("Number of in-flight requests counted by the adaptive concurrency admitter.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "scope"},
+	)
+
+	rejectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "adaptive_concurrency_rejections_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of sheddable requests rejected by the adaptive concurrency admitter.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "scope"},
+	)
+)
+
+func registerMetrics(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		return errors.New("adaptive concurrency metrics registerer is required")
+	}
+	for _, collector := range []prometheus.Collector{concurrencyLimit, rttBaseline, inFlightRequests, rejectionsTotal} {
+		if err := registerer.Register(collector); err != nil {
+			var alreadyRegistered prometheus.AlreadyRegisteredError
+			if errors.As(err, &alreadyRegistered) && alreadyRegistered.ExistingCollector == collector {
+				continue
+			}
+			return fmt.Errorf("register adaptive concurrency metric: %w", err)
+		}
+	}
+	return nil
+}
+
+func recordLimit(typedName fwkplugin.TypedName, scope string, limit, baselineSeconds float64) {
+	concurrencyLimit.WithLabelValues(typedName.Type, typedName.Name, scope).Set(limit)
+	rttBaseline.WithLabelValues(typedName.Type, typedName.Name, scope).Set(baselineSeconds)
+}
+
+func recordInFlight(typedName fwkplugin.TypedName, scope string, inFlight int) {
+	inFlightRequests.WithLabelValues(typedName.Type, typedName.Name, scope).Set(float64(inFlight))
+}
+
+func recordRejection(typedName fwkplugin.TypedName, scope string) {
+	rejectionsTotal.WithLabelValues(typedName.Type, typedName.Name, scope).Inc()
+}
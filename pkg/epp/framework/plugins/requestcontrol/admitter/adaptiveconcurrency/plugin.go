@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package adaptiveconcurrency implements an admission control plugin that learns a concurrency limit from
+// observed request latency, in the spirit of Netflix concurrency-limits. Protected requests (priority >= 0)
+// are always admitted; sheddable requests (priority < 0) are rejected, or deferred for a bounded time, while
+// the number of in-flight requests is at or above the learned limit.
+package adaptiveconcurrency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const (
+	// Type is the registered plugin type for the adaptive concurrency admitter.
+	Type = "adaptive-concurrency-admitter"
+
+	// AlgorithmAIMD selects additive increase / multiplicative decrease.
+	AlgorithmAIMD = "aimd"
+	// AlgorithmGradient selects the gradient algorithm.
+	AlgorithmGradient = "gradient"
+
+	// ScopePool learns a single limit for the whole pool.
+	ScopePool = "pool"
+	// ScopeModel learns one limit per target model.
+	ScopeModel = "model"
+
+	// SignalTTFT samples the time from dispatch to the first response chunk.
+	SignalTTFT = "ttft"
+	// SignalE2E samples the time from dispatch to the end of the response.
+	SignalE2E = "e2e"
+
+	defaultInitialLimit   = 20
+	defaultMinLimit       = 1
+	defaultMaxLimit       = 1000
+	defaultTolerance      = 1.5
+	defaultBackoffRatio   = 0.9
+	defaultSmoothing      = 0.2
+	defaultBaselineWindow = 600
+
+	inFlightStateKey = fwkplugin.StateKey("adaptive-concurrency/in-flight")
+)
+
+// Parameters defines the JSON-configurable fields for the plugin.
+type Parameters struct {
+	// Algorithm is the limit algorithm, "aimd" or "gradient".
+	Algorithm string `json:"algorithm"`
+	// Scope selects whether a single limit is learned for the pool or one per target model.
+	Scope string `json:"scope"`
+	// LatencySignal is the latency sampled for every request, "ttft" or "e2e".
+	LatencySignal string `json:"latencySignal"`
+	// InitialLimit is the limit before any latency has been observed.
+	InitialLimit int `json:"initialLimit"`
+	// MinLimit and MaxLimit bound the learned limit.
+	MinLimit int `json:"minLimit"`
+	MaxLimit int `json:"maxLimit"`
+	// Tolerance is the multiple of the latency baseline considered healthy.
+	Tolerance float64 `json:"tolerance"`
+	// BackoffRatio is the multiplicative decrease applied by AIMD when latency exceeds the tolerance.
+	BackoffRatio float64 `json:"backoffRatio"`
+	// Smoothing is the weight of each gradient update.
+	Smoothing float64 `json:"smoothing"`
+	// BaselineWindow is the number of most recent samples the latency baseline is the minimum of.
+	BaselineWindow int `json:"baselineWindow"`
+	// DeferTimeout is how long a sheddable request above the limit waits for capacity before it is
+	// rejected. Zero rejects immediately.
+	DeferTimeout metav1.Duration `json:"deferTimeout"`
+}
+
+func defaultParameters() Parameters {
+	return Parameters{
+		Algorithm:      AlgorithmAIMD,
+		Scope:          ScopePool,
+		LatencySignal:  SignalTTFT,
+		InitialLimit:   defaultInitialLimit,
+		MinLimit:       defaultMinLimit,
+		MaxLimit:       defaultMaxLimit,
+		Tolerance:      defaultTolerance,
+		BackoffRatio:   defaultBackoffRatio,
+		Smoothing:      defaultSmoothing,
+		BaselineWindow: defaultBaselineWindow,
+	}
+}
+
+func (p *Parameters) validate() error {
+	switch p.Algorithm {
+	case AlgorithmAIMD, AlgorithmGradient:
+	default:
+		return fmt.Errorf("algorithm must be one of %q or %q, got %q", AlgorithmAIMD, AlgorithmGradient, p.Algorithm)
+	}
+	switch p.Scope {
+	case ScopePool, ScopeModel:
+	default:
+		return fmt.Errorf("scope must be one of %q or %q, got %q", ScopePool, ScopeModel, p.Scope)
+	}
+	switch p.LatencySignal {
+	case SignalTTFT, SignalE2E:
+	default:
+		return fmt.Errorf("latencySignal must be one of %q or %q, got %q", SignalTTFT, SignalE2E, p.LatencySignal)
+	}
+	if p.MinLimit <= 0 {
+		return fmt.Errorf("minLimit must be > 0, got %d", p.MinLimit)
+	}
+	if p.MaxLimit < p.MinLimit {
+		return fmt.Errorf("maxLimit must be >= minLimit (%d), got %d", p.MinLimit, p.MaxLimit)
+	}
+	if p.InitialLimit < p.MinLimit || p.InitialLimit > p.MaxLimit {
+		return fmt.Errorf("initialLimit must be in [%d, %d], got %d", p.MinLimit, p.MaxLimit, p.InitialLimit)
+	}
+	if p.Tolerance < 1 {
+		return fmt.Errorf("tolerance must be >= 1, got %g", p.Tolerance)
+	}
+	if p.BackoffRatio <= 0 || p.BackoffRatio >= 1 {
+		return fmt.Errorf("backoffRatio must be in (0, 1), got %g", p.BackoffRatio)
+	}
+	if p.Smoothing <= 0 || p.Smoothing > 1 {
+		return fmt.Errorf("smoothing must be in (0, 1], got %g", p.Smoothing)
+	}
+	if p.BaselineWindow <= 0 {
+		return fmt.Errorf("baselineWindow must be > 0, got %d", p.BaselineWindow)
+	}
+	if p.DeferTimeout.Duration < 0 {
+		return fmt.Errorf("deferTimeout must be >= 0, got %v", p.DeferTimeout.Duration)
+	}
+	return nil
+}
+
+// compile-time interface assertions
+var (
+	_ requestcontrol.Admitter              = &Admitter{}
+	_ requestcontrol.PreRequest            = &Admitter{}
+	_ requestcontrol.ResponseBodyProcessor = &Admitter{}
+	_ fwkplugin.StateDumper                = &Admitter{}
+)
+
+// Admitter rejects or defers sheddable requests while the in-flight count of their scope is at or above a
+// concurrency limit learned from observed latency.
+type Admitter struct {
+	typedName   fwkplugin.TypedName
+	params      Parameters
+	pluginState *fwkplugin.PluginState
+	now         func() time.Time
+
+	// limiters maps the scope key to its *limiter.
+	limiters sync.Map
+}
+
+// Factory creates an Admitter from plugin configuration.
+func Factory(name string, rawParameters *json.Decoder, handle fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	params := defaultParameters()
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&params); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", Type, err)
+		}
+	}
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", Type, err)
+	}
+	if err := registerMetrics(handle.Metrics()); err != nil {
+		return nil, err
+	}
+	return newAdmitter(name, params, fwkplugin.NewPluginState(handle.Context())), nil
+}
+
+// newAdmitter creates an Admitter with the given, already validated, parameters.
+func newAdmitter(name string, params Parameters, pluginState *fwkplugin.PluginState) *Admitter {
+	return &Admitter{
+		typedName:   fwkplugin.TypedName{Type: Type, Name: name},
+		params:      params,
+		pluginState: pluginState,
+		now:         time.Now,
+	}
+}
+
+// TypedName returns the plugin type and instance name.
+func (a *Admitter) TypedName() fwkplugin.TypedName {
+	return a.typedName
+}
+
+func (a *Admitter) scopeKey(request *fwksched.InferenceRequest) string {
+	if a.params.Scope == ScopeModel {
+		return request.TargetModel
+	}
+	return ScopePool
+}
+
+func (a *Admitter) limiterFor(scope string) *limiter {
+	if l, ok := a.limiters.Load(scope); ok {
+		return l.(*limiter)
+	}
+	l, _ := a.limiters.LoadOrStore(scope, newLimiter(a.typedName, scope, &a.params))
+	return l.(*limiter)
+}
+
+// Admit implements requestcontrol.Admitter.
+// Returns nil to admit, or a ResourceExhausted error to reject.
+func (a *Admitter) Admit(ctx context.Context, request *fwksched.InferenceRequest, _ []fwksched.Endpoint) error {
+	if request == nil || request.Objectives.Priority >= 0 {
+		return nil
+	}
+
+	scope := a.scopeKey(request)
+	l := a.limiterFor(scope)
+	if request.RequestID == "" {
+		// Without a request ID the request cannot be tracked after admission, so there is no slot to reserve.
+		if l.hasCapacity() {
+			return nil
+		}
+	} else if l.tryAcquire() ||
+		(a.params.DeferTimeout.Duration > 0 && l.acquireWithin(ctx, a.params.DeferTimeout.Duration)) {
+		// The slot is reserved at admission, so concurrent admissions cannot exceed the limit between now and
+		// dispatch. A request that is admitted but never dispatched is released by the PluginState janitor.
+		a.pluginState.Write(request.RequestID, inFlightStateKey, &inFlightEntry{limiter: l})
+		return nil
+	}
+
+	recordRejection(a.typedName, scope)
+	st := l.state()
+	return errcommon.Error{
+		Code: errcommon.ResourceExhausted,
+		Msg:  fmt.Sprintf("%s: rejected, scope=%s inFlight=%d limit=%.1f", Type, scope, st.InFlight, st.Limit),
+	}
+}
+
+// inFlightEntry tracks a request holding a slot of its limiter, from admission (sheddable requests) or dispatch
+// (protected requests) until the end of the response. Evicting it releases the slot, so requests that never
+// complete (e.g. client disconnects) are eventually released by the PluginState janitor.
+type inFlightEntry struct {
+	limiter *limiter
+	// dispatched is the time the request was dispatched, nil while it has only been admitted.
+	dispatched atomic.Pointer[time.Time]
+	sampled    atomic.Bool
+	released   atomic.Bool
+}
+
+var _ fwkplugin.EvictableStateData = (*inFlightEntry)(nil)
+
+// Clone returns the entry itself: the entry represents a single in-flight slot, which must be released at
+// most once regardless of how many references exist.
+func (e *inFlightEntry) Clone() fwkplugin.StateData {
+	return e
+}
+
+// OnEvicted releases the in-flight slot.
+func (e *inFlightEntry) OnEvicted(_ string, _ fwkplugin.StateKey) {
+	if e.released.CompareAndSwap(false, true) {
+		e.limiter.release()
+	}
+}
+
+// PreRequest counts the dispatched request against the limiter of its scope, unless it already reserved a slot
+// at admission, and records the dispatch time latency is sampled from.
+func (a *Admitter) PreRequest(_ context.Context, request *fwksched.InferenceRequest, _ *fwksched.SchedulingResult) {
+	if request == nil || request.RequestID == "" {
+		return
+	}
+	dispatched := a.now()
+	if entry, err := fwkplugin.ReadPluginStateKey[*inFlightEntry](a.pluginState, request.RequestID, inFlightStateKey); err == nil {
+		entry.dispatched.Store(&dispatched)
+		return
+	}
+	l := a.limiterFor(a.scopeKey(request))
+	l.acquire()
+	entry := &inFlightEntry{limiter: l}
+	entry.dispatched.Store(&dispatched)
+	a.pluginState.Write(request.RequestID, inFlightStateKey, entry)
+}
+
+// ResponseBody samples the latency of the request and releases it from its limiter at the end of the stream.
+func (a *Admitter) ResponseBody(
+	_ context.Context,
+	request *fwksched.InferenceRequest,
+	response *requestcontrol.Response,
+	_ *datalayer.EndpointMetadata,
+) {
+	if request == nil || response == nil || request.RequestID == "" {
+		return
+	}
+	entry, err := fwkplugin.ReadPluginStateKey[*inFlightEntry](a.pluginState, request.RequestID, inFlightStateKey)
+	if err != nil {
+		return
+	}
+
+	if (response.StartOfStream && a.params.LatencySignal == SignalTTFT) || (response.EndOfStream && a.params.LatencySignal == SignalE2E) {
+		if dispatched := entry.dispatched.Load(); dispatched != nil && entry.sampled.CompareAndSwap(false, true) {
+			entry.limiter.onSample(a.now().Sub(*dispatched))
+		}
+	}
+
+	if response.EndOfStream {
+		a.pluginState.Delete(request.RequestID)
+	} else {
+		a.pluginState.Touch(request.RequestID)
+	}
+}
+
+type admitterState struct {
+	Algorithm string         `json:"algorithm"`
+	Scope     string         `json:"scope"`
+	Limiters  []limiterState `json:"limiters"`
+}
+
+// DumpState implements [fwkplugin.StateDumper] and exposes the learned limit, in-flight count and latency
+// baseline of every scope for the /debug/plugins/state endpoint.
+func (a *Admitter) DumpState() (json.RawMessage, error) {
+	state := admitterState{
+		Algorithm: a.params.Algorithm,
+		Scope:     a.params.Scope,
+		Limiters:  []limiterState{},
+	}
+	a.limiters.Range(func(_, value any) bool {
+		state.Limiters = append(state.Limiters, value.(*limiter).state())
+		return true
+	})
+	sort.Slice(state.Limiters, func(i, j int) bool {
+		return state.Limiters[i].Scope < state.Limiters[j].Scope
+	})
+	return json.Marshal(state)
+}
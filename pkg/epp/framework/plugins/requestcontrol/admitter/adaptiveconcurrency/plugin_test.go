@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adaptiveconcurrency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	testutils "github.com/llm-d/llm-d-router/test/utils"
+)
+
+func newTestAdmitter(t *testing.T, mutate func(*Parameters)) *Admitter {
+	t.Helper()
+	params := defaultParameters()
+	if mutate != nil {
+		mutate(&params)
+	}
+	require.NoError(t, params.validate())
+	return newAdmitter("test", params, fwkplugin.NewPluginState(t.Context()))
+}
+
+func newRequest(id, model string, priority int) *fwksched.InferenceRequest {
+	return &fwksched.InferenceRequest{
+		RequestID:   id,
+		TargetModel: model,
+		Objectives:  fwksched.RequestObjectives{Priority: priority},
+	}
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		params    string
+		wantError string
+	}{
+		{name: "defaults", params: `{}`},
+		{name: "gradient per model", params: `{"algorithm": "gradient", "scope": "model", "latencySignal": "e2e", "deferTimeout": "200ms"}`},
+		{name: "malformed", params: `{"initialLimit": "ten"}`, wantError: "failed to parse"},
+		{name: "unknown algorithm", params: `{"algorithm": "vegas"}`, wantError: "algorithm must be one of"},
+		{name: "unknown scope", params: `{"scope": "endpoint"}`, wantError: "scope must be one of"},
+		{name: "unknown signal", params: `{"latencySignal": "tpot"}`, wantError: "latencySignal must be one of"},
+		{name: "non-positive min limit", params: `{"minLimit": 0}`, wantError: "minLimit must be > 0"},
+		{name: "max below min", params: `{"minLimit": 10, "maxLimit": 5, "initialLimit": 10}`, wantError: "maxLimit must be >= minLimit"},
+		{name: "initial out of bounds", params: `{"initialLimit": 5000}`, wantError: "initialLimit must be in"},
+		{name: "tolerance below one", params: `{"tolerance": 0.5}`, wantError: "tolerance must be >= 1"},
+		{name: "backoff ratio of one", params: `{"backoffRatio": 1}`, wantError: "backoffRatio must be in (0, 1)"},
+		{name: "zero smoothing", params: `{"smoothing": 0}`, wantError: "smoothing must be in (0, 1]"},
+		{name: "zero baseline window", params: `{"baselineWindow": 0}`, wantError: "baselineWindow must be > 0"},
+		{name: "negative defer timeout", params: `{"deferTimeout": "-1s"}`, wantError: "deferTimeout must be >= 0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := Factory("test-admitter", fwkplugin.StrictDecoder(json.RawMessage(tc.params)), testutils.NewTestHandle(t.Context()))
+			if tc.wantError != "" {
+				require.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: Type, Name: "test-admitter"}, p.TypedName())
+		})
+	}
+}
+
+func TestAdmit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("protected requests are always admitted", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) { p.InitialLimit = 1 })
+		a.PreRequest(context.Background(), newRequest("r1", "m", 0), nil)
+		assert.NoError(t, a.Admit(context.Background(), newRequest("r2", "m", 0), nil))
+		assert.NoError(t, a.Admit(context.Background(), newRequest("r3", "m", 10), nil))
+	})
+
+	t.Run("sheddable requests are rejected at the limit", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) { p.InitialLimit = 2 })
+		require.NoError(t, a.Admit(context.Background(), newRequest("r1", "m", -1), nil))
+		a.PreRequest(context.Background(), newRequest("r1", "m", -1), nil)
+		require.NoError(t, a.Admit(context.Background(), newRequest("r2", "m", -1), nil))
+		a.PreRequest(context.Background(), newRequest("r2", "m", 0), nil)
+
+		err := a.Admit(context.Background(), newRequest("r3", "m", -1), nil)
+		require.Error(t, err)
+		var e errcommon.Error
+		require.True(t, errors.As(err, &e))
+		assert.Equal(t, errcommon.ResourceExhausted, e.Code)
+
+		// Completing a request frees a slot.
+		a.ResponseBody(context.Background(), newRequest("r1", "m", -1), &requestcontrol.Response{StartOfStream: true, EndOfStream: true}, nil)
+		assert.NoError(t, a.Admit(context.Background(), newRequest("r3", "m", -1), nil))
+	})
+
+	t.Run("deferred requests are admitted when capacity frees up", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) {
+			p.InitialLimit = 1
+			p.DeferTimeout.Duration = 5 * time.Second
+		})
+		a.PreRequest(context.Background(), newRequest("r1", "m", 0), nil)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			a.ResponseBody(context.Background(), newRequest("r1", "m", 0), &requestcontrol.Response{EndOfStream: true}, nil)
+		}()
+		assert.NoError(t, a.Admit(context.Background(), newRequest("r2", "m", -1), nil))
+	})
+
+	t.Run("deferred requests are rejected after the timeout", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) {
+			p.InitialLimit = 1
+			p.DeferTimeout.Duration = 10 * time.Millisecond
+		})
+		a.PreRequest(context.Background(), newRequest("r1", "m", 0), nil)
+		assert.Error(t, a.Admit(context.Background(), newRequest("r2", "m", -1), nil))
+	})
+
+	t.Run("admission reserves the slot", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) { p.InitialLimit = 2 })
+		require.NoError(t, a.Admit(context.Background(), newRequest("r1", "m", -1), nil))
+		require.NoError(t, a.Admit(context.Background(), newRequest("r2", "m", -1), nil))
+		// Neither admitted request has been dispatched yet, but both hold a slot.
+		assert.Error(t, a.Admit(context.Background(), newRequest("r3", "m", -1), nil))
+
+		// Dispatching an admitted request does not count it twice.
+		a.PreRequest(context.Background(), newRequest("r1", "m", -1), nil)
+		a.PreRequest(context.Background(), newRequest("r2", "m", -1), nil)
+		assert.Equal(t, 2, a.limiterFor(ScopePool).state().InFlight)
+	})
+
+	t.Run("deferred requests do not over-admit", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) {
+			p.InitialLimit = 1
+			p.DeferTimeout.Duration = 200 * time.Millisecond
+		})
+		a.PreRequest(context.Background(), newRequest("r0", "m", 0), nil)
+
+		const waiters = 5
+		var admitted atomic.Int32
+		var wg sync.WaitGroup
+		for i := range waiters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if a.Admit(context.Background(), newRequest(fmt.Sprintf("r%d", i+1), "m", -1), nil) == nil {
+					admitted.Add(1)
+				}
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		a.ResponseBody(context.Background(), newRequest("r0", "m", 0), &requestcontrol.Response{EndOfStream: true}, nil)
+		wg.Wait()
+		assert.Equal(t, int32(1), admitted.Load())
+		assert.Equal(t, 1, a.limiterFor(ScopePool).state().InFlight)
+	})
+
+	t.Run("model scope keeps independent limits", func(t *testing.T) {
+		t.Parallel()
+		a := newTestAdmitter(t, func(p *Parameters) {
+			p.InitialLimit = 1
+			p.Scope = ScopeModel
+		})
+		a.PreRequest(context.Background(), newRequest("r1", "model-a", 0), nil)
+		assert.Error(t, a.Admit(context.Background(), newRequest("r2", "model-a", -1), nil))
+		assert.NoError(t, a.Admit(context.Background(), newRequest("r3", "model-b", -1), nil))
+	})
+}
+
+func TestResponseBody_SamplesLatency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		signal   string
+		expected time.Duration
+	}{
+		{name: "ttft samples the first chunk", signal: SignalTTFT, expected: 100 * time.Millisecond},
+		{name: "e2e samples the last chunk", signal: SignalE2E, expected: 300 * time.Millisecond},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			a := newTestAdmitter(t, func(p *Parameters) { p.LatencySignal = tc.signal })
+			clock := time.Unix(1000, 0)
+			a.now = func() time.Time { return clock }
+
+			req := newRequest("r1", "m", 0)
+			a.PreRequest(context.Background(), req, nil)
+			clock = clock.Add(100 * time.Millisecond)
+			a.ResponseBody(context.Background(), req, &requestcontrol.Response{StartOfStream: true}, nil)
+			clock = clock.Add(100 * time.Millisecond)
+			a.ResponseBody(context.Background(), req, &requestcontrol.Response{}, nil)
+			clock = clock.Add(100 * time.Millisecond)
+			a.ResponseBody(context.Background(), req, &requestcontrol.Response{EndOfStream: true}, nil)
+
+			st := a.limiterFor(ScopePool).state()
+			assert.Equal(t, uint64(1), st.Samples)
+			assert.InDelta(t, tc.expected.Seconds(), st.LastRTTSeconds, 1e-9)
+			assert.Equal(t, 0, st.InFlight)
+		})
+	}
+}
+
+func TestResponseBody_AdmittedRequestIsSampledFromDispatch(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdmitter(t, nil)
+	clock := time.Unix(1000, 0)
+	a.now = func() time.Time { return clock }
+
+	req := newRequest("r1", "m", -1)
+	require.NoError(t, a.Admit(context.Background(), req, nil))
+	clock = clock.Add(time.Second)
+	a.PreRequest(context.Background(), req, nil)
+	clock = clock.Add(100 * time.Millisecond)
+	a.ResponseBody(context.Background(), req, &requestcontrol.Response{StartOfStream: true, EndOfStream: true}, nil)
+
+	st := a.limiterFor(ScopePool).state()
+	assert.Equal(t, uint64(1), st.Samples)
+	assert.InDelta(t, 0.1, st.LastRTTSeconds, 1e-9)
+	assert.Equal(t, 0, st.InFlight)
+}
+
+func TestInFlightEntry_EvictionReleasesOnce(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdmitter(t, nil)
+	req := newRequest("r1", "m", 0)
+	a.PreRequest(context.Background(), req, nil)
+	a.PreRequest(context.Background(), newRequest("r2", "m", 0), nil)
+	require.Equal(t, 2, a.limiterFor(ScopePool).state().InFlight)
+
+	entry, err := fwkplugin.ReadPluginStateKey[*inFlightEntry](a.pluginState, req.RequestID, inFlightStateKey)
+	require.NoError(t, err)
+	entry.OnEvicted(req.RequestID, inFlightStateKey)
+	entry.OnEvicted(req.RequestID, inFlightStateKey)
+	assert.Equal(t, 1, a.limiterFor(ScopePool).state().InFlight)
+}
+
+func TestDumpState(t *testing.T) {
+	t.Parallel()
+
+	a := newTestAdmitter(t, func(p *Parameters) { p.Scope = ScopeModel })
+	a.PreRequest(context.Background(), newRequest("r1", "model-b", 0), nil)
+	a.PreRequest(context.Background(), newRequest("r2", "model-a", 0), nil)
+
+	raw, err := a.DumpState()
+	require.NoError(t, err)
+	var state admitterState
+	require.NoError(t, json.Unmarshal(raw, &state))
+	assert.Equal(t, AlgorithmAIMD, state.Algorithm)
+	assert.Equal(t, ScopeModel, state.Scope)
+	require.Len(t, state.Limiters, 2)
+	assert.Equal(t, "model-a", state.Limiters[0].Scope)
+	assert.Equal(t, "model-b", state.Limiters[1].Scope)
+	assert.InDelta(t, float64(defaultInitialLimit), state.Limiters[0].Limit, 1e-9)
+	assert.Equal(t, 1, state.Limiters[0].InFlight)
+}
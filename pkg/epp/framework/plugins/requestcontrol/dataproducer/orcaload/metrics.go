@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orcaload
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	metricsutil "github.com/llm-d/llm-d-router/pkg/common/observability/metrics"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	eppmetrics "github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	outcomeSuccess         = "success"
+	outcomeError           = "error"
+	outcomeUnknownEndpoint = "unknown_endpoint"
+)
+
+var loadReportsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+		Name:      "orca_load_reports_total",
+		Help:      metricsutil.HelpMsgWithStability("Total number of ORCA load reports received in response headers, by encoding and outcome.", compbasemetrics.ALPHA),
+	},
+	[]string{"plugin_type", "plugin_name", "encoding", "outcome"},
+)
+
+func registerMetrics(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		return errors.New("orca load metrics registerer is required")
+	}
+	if err := registerer.Register(loadReportsTotal); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) && alreadyRegistered.ExistingCollector == loadReportsTotal {
+			return nil
+		}
+		return fmt.Errorf("register orca load metric: %w", err)
+	}
+	return nil
+}
+
+func recordLoadReport(typedName fwkplugin.TypedName, encoding, outcome string) {
+	loadReportsTotal.WithLabelValues(typedName.Type, typedName.Name, encoding, outcome).Inc()
+}
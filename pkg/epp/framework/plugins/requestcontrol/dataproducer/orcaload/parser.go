@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orcaload
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// loadMetricsHeader is the ORCA response header carrying a load report in
+	// one of the TEXT, JSON or BIN encodings, selected by a value prefix.
+	loadMetricsHeader = "endpoint-load-metrics"
+	// loadMetricsBinHeader is the gRPC-style binary header carrying a base64
+	// encoded serialized OrcaLoadReport.
+	loadMetricsBinHeader = "endpoint-load-metrics-bin"
+
+	encodingText = "TEXT"
+	encodingJSON = "JSON"
+	encodingBin  = "BIN"
+
+	namedMetricsPrefix = "named_metrics."
+	utilizationPrefix  = "utilization."
+	requestCostPrefix  = "request_cost."
+)
+
+var errNoLoadReport = errors.New("no ORCA load report header present")
+
+// parseLoadReport extracts an ORCA load report from response headers. Header
+// names are expected to be lowercase, as delivered by Envoy. It returns the
+// encoding that was parsed alongside the report, and errNoLoadReport when the
+// response carries no ORCA header.
+func parseLoadReport(headers map[string]string) (*orcav3.OrcaLoadReport, string, error) {
+	if value := strings.TrimSpace(headers[loadMetricsBinHeader]); value != "" {
+		report, err := parseBinary(value)
+		return report, encodingBin, err
+	}
+
+	value := strings.TrimSpace(headers[loadMetricsHeader])
+	if value == "" {
+		return nil, "", errNoLoadReport
+	}
+	encoding, payload, _ := strings.Cut(value, " ")
+	payload = strings.TrimSpace(payload)
+	switch strings.ToUpper(encoding) {
+	case encodingText:
+		report, err := parseText(payload)
+		return report, encodingText, err
+	case encodingJSON:
+		report, err := parseJSON(payload)
+		return report, encodingJSON, err
+	case encodingBin:
+		report, err := parseBinary(payload)
+		return report, encodingBin, err
+	default:
+		return nil, "", fmt.Errorf("unsupported ORCA encoding %q", encoding)
+	}
+}
+
+// parseText parses the native HTTP encoding of a load report, a comma
+// separated list of key=value pairs such as
+// "cpu_utilization=0.3, named_metrics.kv_cache_usage_perc=0.5".
+func parseText(payload string) (*orcav3.OrcaLoadReport, error) {
+	report := &orcav3.OrcaLoadReport{}
+	for pair := range strings.SplitSeq(payload, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed ORCA TEXT entry %q", pair)
+		}
+		key = strings.TrimSpace(key)
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed ORCA TEXT value for %q: %w", key, err)
+		}
+		if err := setTextField(report, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func setTextField(report *orcav3.OrcaLoadReport, key string, value float64) error {
+	switch {
+	case key == "cpu_utilization":
+		report.CpuUtilization = value
+	case key == "mem_utilization":
+		report.MemUtilization = value
+	case key == "application_utilization":
+		report.ApplicationUtilization = value
+	case key == "rps_fractional":
+		report.RpsFractional = value
+	case key == "eps":
+		report.Eps = value
+	case strings.HasPrefix(key, namedMetricsPrefix):
+		report.NamedMetrics = putNamed(report.NamedMetrics, strings.TrimPrefix(key, namedMetricsPrefix), value)
+	case strings.HasPrefix(key, utilizationPrefix):
+		report.Utilization = putNamed(report.Utilization, strings.TrimPrefix(key, utilizationPrefix), value)
+	case strings.HasPrefix(key, requestCostPrefix):
+		report.RequestCost = putNamed(report.RequestCost, strings.TrimPrefix(key, requestCostPrefix), value)
+	default:
+		return fmt.Errorf("unknown ORCA TEXT key %q", key)
+	}
+	return nil
+}
+
+func putNamed(m map[string]float64, name string, value float64) map[string]float64 {
+	if m == nil {
+		m = map[string]float64{}
+	}
+	m[name] = value
+	return m
+}
+
+func parseJSON(payload string) (*orcav3.OrcaLoadReport, error) {
+	report := &orcav3.OrcaLoadReport{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(payload), report); err != nil {
+		return nil, fmt.Errorf("malformed ORCA JSON payload: %w", err)
+	}
+	return report, nil
+}
+
+func parseBinary(payload string) (*orcav3.OrcaLoadReport, error) {
+	raw, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		// gRPC binary headers may omit padding.
+		if raw, err = base64.RawStdEncoding.DecodeString(payload); err != nil {
+			return nil, fmt.Errorf("malformed ORCA BIN payload: %w", err)
+		}
+	}
+	report := &orcav3.OrcaLoadReport{}
+	if err := proto.Unmarshal(raw, report); err != nil {
+		return nil, fmt.Errorf("malformed ORCA BIN payload: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orcaload
+
+import (
+	"encoding/base64"
+	"testing"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseLoadReport(t *testing.T) {
+	t.Parallel()
+
+	binary, err := proto.Marshal(&orcav3.OrcaLoadReport{
+		CpuUtilization: 0.25,
+		NamedMetrics:   map[string]float64{"kv_cache_usage_perc": 0.5},
+	})
+	require.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(binary)
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		wantEncoding string
+		wantCPU      float64
+		wantNamed    map[string]float64
+		wantErr      bool
+	}{
+		{
+			name: "text",
+			headers: map[string]string{
+				loadMetricsHeader: "TEXT cpu_utilization=0.25, named_metrics.kv_cache_usage_perc=0.5",
+			},
+			wantEncoding: encodingText,
+			wantCPU:      0.25,
+			wantNamed:    map[string]float64{"kv_cache_usage_perc": 0.5},
+		},
+		{
+			name: "json",
+			headers: map[string]string{
+				loadMetricsHeader: `JSON {"cpu_utilization": 0.25, "named_metrics": {"kv_cache_usage_perc": 0.5}}`,
+			},
+			wantEncoding: encodingJSON,
+			wantCPU:      0.25,
+			wantNamed:    map[string]float64{"kv_cache_usage_perc": 0.5},
+		},
+		{
+			name:         "bin prefix",
+			headers:      map[string]string{loadMetricsHeader: "BIN " + encoded},
+			wantEncoding: encodingBin,
+			wantCPU:      0.25,
+			wantNamed:    map[string]float64{"kv_cache_usage_perc": 0.5},
+		},
+		{
+			name:         "bin header",
+			headers:      map[string]string{loadMetricsBinHeader: encoded},
+			wantEncoding: encodingBin,
+			wantCPU:      0.25,
+			wantNamed:    map[string]float64{"kv_cache_usage_perc": 0.5},
+		},
+		{
+			name:         "bin header without padding",
+			headers:      map[string]string{loadMetricsBinHeader: base64.RawStdEncoding.EncodeToString(binary)},
+			wantEncoding: encodingBin,
+			wantCPU:      0.25,
+			wantNamed:    map[string]float64{"kv_cache_usage_perc": 0.5},
+		},
+		{
+			name:    "unknown encoding",
+			headers: map[string]string{loadMetricsHeader: "YAML cpu_utilization: 1"},
+			wantErr: true,
+		},
+		{
+			name:         "malformed text value",
+			headers:      map[string]string{loadMetricsHeader: "TEXT cpu_utilization=high"},
+			wantEncoding: encodingText,
+			wantErr:      true,
+		},
+		{
+			name:         "unknown text key",
+			headers:      map[string]string{loadMetricsHeader: "TEXT gpu_utilization=0.1"},
+			wantEncoding: encodingText,
+			wantErr:      true,
+		},
+		{
+			name:         "malformed json",
+			headers:      map[string]string{loadMetricsHeader: "JSON {"},
+			wantEncoding: encodingJSON,
+			wantErr:      true,
+		},
+		{
+			name:         "malformed bin",
+			headers:      map[string]string{loadMetricsBinHeader: "!!!"},
+			wantEncoding: encodingBin,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			report, encoding, err := parseLoadReport(tc.headers)
+			assert.Equal(t, tc.wantEncoding, encoding)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantCPU, report.GetCpuUtilization(), 1e-9)
+			assert.Equal(t, tc.wantNamed, report.GetNamedMetrics())
+		})
+	}
+}
+
+func TestParseLoadReport_NoHeader(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := parseLoadReport(map[string]string{"content-type": "application/json"})
+	require.ErrorIs(t, err, errNoLoadReport)
+	_, _, err = parseLoadReport(nil)
+	require.ErrorIs(t, err, errNoLoadReport)
+}
+
+func TestParseText_AllFields(t *testing.T) {
+	t.Parallel()
+
+	report, err := parseText("cpu_utilization=0.1, mem_utilization=0.2, application_utilization=0.3, " +
+		"rps_fractional=12.5, eps=0.5, utilization.gpu=0.9, request_cost.tokens=42, named_metrics.foo=7,")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.1, report.GetCpuUtilization(), 1e-9)
+	assert.InDelta(t, 0.2, report.GetMemUtilization(), 1e-9)
+	assert.InDelta(t, 0.3, report.GetApplicationUtilization(), 1e-9)
+	assert.InDelta(t, 12.5, report.GetRpsFractional(), 1e-9)
+	assert.InDelta(t, 0.5, report.GetEps(), 1e-9)
+	assert.Equal(t, map[string]float64{"gpu": 0.9}, report.GetUtilization())
+	assert.Equal(t, map[string]float64{"tokens": 42}, report.GetRequestCost())
+	assert.Equal(t, map[string]float64{"foo": 7}, report.GetNamedMetrics())
+}
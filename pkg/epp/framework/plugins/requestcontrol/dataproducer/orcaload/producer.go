@@ -0,0 +1,246 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orcaload provides a data producer that ingests ORCA load reports
+// attached by model servers (or Envoy) to response headers, and applies them
+// to the serving endpoint immediately instead of waiting for the next metrics
+// poll.
+package orcaload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrloadreport "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/loadreport"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+	sourcenotifications "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/notifications"
+	orcaloadconstants "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/orcaload/constants"
+)
+
+// OrcaLoadProducerType is the plugin type registered with the framework.
+const OrcaLoadProducerType = orcaloadconstants.OrcaLoadProducerType
+
+// Config maps ORCA named metrics onto the core endpoint Metrics fields. An
+// empty metric name disables the corresponding mapping.
+type Config struct {
+	// WaitingQueueSizeMetric is the ORCA named metric holding the number of
+	// waiting requests. Defaults to "num_requests_waiting".
+	WaitingQueueSizeMetric string `json:"waitingQueueSizeMetric"`
+	// RunningRequestsSizeMetric is the ORCA named metric holding the number of
+	// running requests. Defaults to "num_requests_running".
+	RunningRequestsSizeMetric string `json:"runningRequestsSizeMetric"`
+	// KVCacheUsageMetric is the ORCA named metric holding the KV cache
+	// utilization in [0, 1]. Defaults to "kv_cache_usage_perc".
+	KVCacheUsageMetric string `json:"kvCacheUsageMetric"`
+	// NamedMetricAttributePrefix is prepended to every ORCA named metric to
+	// form the endpoint attribute key under which the value is stored as a
+	// scalar metric. Defaults to "orca.".
+	NamedMetricAttributePrefix string `json:"namedMetricAttributePrefix"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		WaitingQueueSizeMetric:     "num_requests_waiting",
+		RunningRequestsSizeMetric:  "num_requests_running",
+		KVCacheUsageMetric:         "kv_cache_usage_perc",
+		NamedMetricAttributePrefix: "orca.",
+	}
+}
+
+var (
+	_ requestcontrol.ResponseHeaderProcessor = &Producer{}
+	_ fwkplugin.ProducerPlugin               = &Producer{}
+	_ fwkdl.EndpointExtractor                = &Producer{}
+	_ fwkdl.Registrant                       = &Producer{}
+)
+
+// Producer parses ORCA load reports from response headers and writes them to
+// the endpoint that served the request.
+type Producer struct {
+	typedName fwkplugin.TypedName
+	dk        fwkplugin.DataKey
+	config    Config
+	// endpoints maps an endpoint's NamespacedName to the live datastore
+	// endpoint, maintained from endpoint lifecycle events. Response hooks only
+	// receive endpoint metadata, so this is how a report reaches the endpoint's
+	// Metrics and attributes.
+	endpoints sync.Map
+	now       func() time.Time
+}
+
+// Factory builds a Producer from raw plugin parameters.
+func Factory(name string, rawParameters *json.Decoder, handle fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	cfg := defaultConfig()
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' producer: %w", OrcaLoadProducerType, err)
+		}
+	}
+	if handle == nil {
+		return nil, errors.New("plugin handle is required")
+	}
+	if err := registerMetrics(handle.Metrics()); err != nil {
+		return nil, err
+	}
+	return NewProducer(name, cfg), nil
+}
+
+// NewProducer returns a Producer with the given name and configuration.
+func NewProducer(name string, cfg Config) *Producer {
+	return &Producer{
+		typedName: fwkplugin.TypedName{Type: OrcaLoadProducerType, Name: name},
+		dk:        attrloadreport.LoadReportDataKey.WithNonEmptyProducerName(name),
+		config:    cfg,
+		now:       time.Now,
+	}
+}
+
+// TypedName returns the type and name of the plugin.
+func (p *Producer) TypedName() fwkplugin.TypedName {
+	return p.typedName
+}
+
+// Produces declares the LoadReport attribute key written by this producer.
+func (p *Producer) Produces() map[fwkplugin.DataKey]any {
+	return map[fwkplugin.DataKey]any{p.dk: attrloadreport.LoadReport{}}
+}
+
+// RegisterDependencies declares that this plugin needs an endpoint-notification-source to
+// resolve the live endpoint served by a response. The source is auto-created if not already
+// in the config.
+func (p *Producer) RegisterDependencies(r fwkdl.Registrar) error {
+	return r.Register(fwkdl.PendingRegistration{
+		Owner:         p.TypedName(),
+		SourceType:    sourcenotifications.EndpointNotificationSourceType,
+		Extractor:     p,
+		DefaultSource: sourcenotifications.NewEndpointDataSource(sourcenotifications.EndpointNotificationSourceType, sourcenotifications.EndpointNotificationSourceType),
+	})
+}
+
+// Extract tracks endpoint lifecycle events so load reports can be applied to the live endpoint.
+func (p *Producer) Extract(_ context.Context, event fwkdl.EndpointEvent) error {
+	if event.Endpoint == nil || event.Endpoint.GetMetadata() == nil {
+		return nil
+	}
+	id := event.Endpoint.GetMetadata().NamespacedName.String()
+	switch event.Type {
+	case fwkdl.EventDelete:
+		p.endpoints.Delete(id)
+	case fwkdl.EventAddOrUpdate:
+		p.endpoints.Store(id, event.Endpoint)
+	}
+	return nil
+}
+
+// ResponseHeader parses the ORCA load report of the response, if any, and
+// applies it to the endpoint that served the request.
+func (p *Producer) ResponseHeader(ctx context.Context, _ *fwksched.InferenceRequest, response *requestcontrol.Response,
+	targetEndpoint *fwkdl.EndpointMetadata) {
+	if response == nil || targetEndpoint == nil {
+		return
+	}
+	report, encoding, err := parseLoadReport(response.Headers)
+	if errors.Is(err, errNoLoadReport) {
+		return
+	}
+	logger := log.FromContext(ctx).WithValues("endpoint", targetEndpoint.NamespacedName)
+	if err != nil {
+		recordLoadReport(p.typedName, encoding, outcomeError)
+		logger.V(logutil.DEBUG).Info("Ignoring malformed ORCA load report", "error", err)
+		return
+	}
+	raw, ok := p.endpoints.Load(targetEndpoint.NamespacedName.String())
+	if !ok {
+		recordLoadReport(p.typedName, encoding, outcomeUnknownEndpoint)
+		logger.V(logutil.DEBUG).Info("Ignoring ORCA load report for untracked endpoint")
+		return
+	}
+	p.apply(raw.(fwkdl.Endpoint), report)
+	recordLoadReport(p.typedName, encoding, outcomeSuccess)
+	logger.V(logutil.TRACE).Info("Applied ORCA load report", "encoding", encoding, "report", report)
+}
+
+// apply writes the report onto the endpoint. Mapped named metrics update a
+// clone of the current Metrics, which is stored with a fresh UpdateTime so the
+// report supersedes the last polled snapshot until the next poll lands.
+func (p *Producer) apply(ep fwkdl.Endpoint, report *orcav3.OrcaLoadReport) {
+	receivedAt := p.now()
+	named := report.GetNamedMetrics()
+
+	if current := ep.GetMetrics(); current != nil {
+		clone := current.Clone()
+		updated := false
+		if v, ok := lookup(named, p.config.WaitingQueueSizeMetric); ok {
+			clone.WaitingQueueSize = int(v)
+			updated = true
+		}
+		if v, ok := lookup(named, p.config.RunningRequestsSizeMetric); ok {
+			clone.RunningRequestsSize = int(v)
+			updated = true
+		}
+		if v, ok := lookup(named, p.config.KVCacheUsageMetric); ok {
+			clone.KVCacheUsagePercent = v
+			updated = true
+		}
+		if updated {
+			clone.UpdateTime = receivedAt
+			ep.UpdateMetrics(clone)
+		}
+	}
+
+	attrs := ep.GetAttributes()
+	if p.config.NamedMetricAttributePrefix != "" {
+		for name, value := range named {
+			attrs.Put(p.config.NamedMetricAttributePrefix+name, attrmetrics.ScalarMetricValue(value))
+		}
+	}
+	rps := report.GetRpsFractional()
+	if rps == 0 {
+		rps = float64(report.GetRps()) //nolint:staticcheck // deprecated integer rps is still sent by older reporters
+	}
+	attrs.Put(p.dk.String(), &attrloadreport.LoadReport{
+		CPUUtilization:         report.GetCpuUtilization(),
+		MemUtilization:         report.GetMemUtilization(),
+		ApplicationUtilization: report.GetApplicationUtilization(),
+		RPS:                    rps,
+		EPS:                    report.GetEps(),
+		NamedMetrics:           maps.Clone(named),
+		Utilization:            maps.Clone(report.GetUtilization()),
+		RequestCost:            maps.Clone(report.GetRequestCost()),
+		ReceivedAt:             receivedAt,
+	})
+}
+
+func lookup(named map[string]float64, name string) (float64, bool) {
+	if name == "" {
+		return 0, false
+	}
+	v, ok := named[name]
+	return v, ok
+}
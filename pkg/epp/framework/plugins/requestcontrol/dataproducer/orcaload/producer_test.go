@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orcaload
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	attrloadreport "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/loadreport"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+	testutils "github.com/llm-d/llm-d-router/test/utils"
+)
+
+func newTestEndpoint(name string) fwkdl.Endpoint {
+	metrics := fwkdl.NewMetrics()
+	metrics.WaitingQueueSize = 10
+	metrics.RunningRequestsSize = 4
+	metrics.KVCacheUsagePercent = 0.9
+	metrics.UpdateTime = time.Unix(100, 0)
+	return fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: name},
+	}, metrics)
+}
+
+func newTrackedProducer(t *testing.T, ep fwkdl.Endpoint) *Producer {
+	t.Helper()
+	p := NewProducer("orca", defaultConfig())
+	p.now = func() time.Time { return time.Unix(200, 0) }
+	require.NoError(t, p.Extract(context.Background(), fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: ep}))
+	return p
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	handle := testutils.NewTestHandle(context.Background())
+
+	plg, err := Factory("orca", nil, handle)
+	require.NoError(t, err)
+	p := plg.(*Producer)
+	assert.Equal(t, fwkplugin.TypedName{Type: OrcaLoadProducerType, Name: "orca"}, p.TypedName())
+	assert.Equal(t, defaultConfig(), p.config)
+	assert.Contains(t, p.Produces(), attrloadreport.LoadReportDataKey.WithNonEmptyProducerName("orca"))
+
+	plg, err = Factory("orca", fwkplugin.StrictDecoder(json.RawMessage(`{"kvCacheUsageMetric":"kv"}`)), handle)
+	require.NoError(t, err)
+	assert.Equal(t, "kv", plg.(*Producer).config.KVCacheUsageMetric)
+	assert.Equal(t, "num_requests_waiting", plg.(*Producer).config.WaitingQueueSizeMetric)
+
+	_, err = Factory("orca", fwkplugin.StrictDecoder(json.RawMessage(`{"unknown":1}`)), handle)
+	require.Error(t, err)
+
+	_, err = Factory("orca", nil, nil)
+	require.Error(t, err)
+}
+
+func TestResponseHeader_AppliesReport(t *testing.T) {
+	t.Parallel()
+
+	ep := newTestEndpoint("pod-a")
+	p := newTrackedProducer(t, ep)
+
+	p.ResponseHeader(context.Background(), nil, &requestcontrol.Response{
+		Headers: map[string]string{
+			loadMetricsHeader: "TEXT cpu_utilization=0.4, named_metrics.num_requests_waiting=2, " +
+				"named_metrics.num_requests_running=3, named_metrics.kv_cache_usage_perc=0.25, named_metrics.spec_accept=0.7",
+		},
+	}, ep.GetMetadata())
+
+	metrics := ep.GetMetrics()
+	assert.Equal(t, 2, metrics.WaitingQueueSize)
+	assert.Equal(t, 3, metrics.RunningRequestsSize)
+	assert.InDelta(t, 0.25, metrics.KVCacheUsagePercent, 1e-9)
+	assert.Equal(t, time.Unix(200, 0), metrics.UpdateTime)
+
+	report, ok := attrloadreport.ReadLoadReport(ep.GetAttributes(), p.dk.String())
+	require.True(t, ok)
+	assert.InDelta(t, 0.4, report.CPUUtilization, 1e-9)
+	assert.Equal(t, time.Unix(200, 0), report.ReceivedAt)
+	assert.Len(t, report.NamedMetrics, 4)
+
+	custom, ok := attrmetrics.ReadScalarMetricValue(ep.GetAttributes(), "orca.spec_accept")
+	require.True(t, ok)
+	assert.InDelta(t, 0.7, float64(custom), 1e-9)
+}
+
+func TestResponseHeader_PartialReportKeepsPolledValues(t *testing.T) {
+	t.Parallel()
+
+	ep := newTestEndpoint("pod-a")
+	p := newTrackedProducer(t, ep)
+
+	p.ResponseHeader(context.Background(), nil, &requestcontrol.Response{
+		Headers: map[string]string{loadMetricsHeader: `JSON {"named_metrics": {"num_requests_waiting": 1}}`},
+	}, ep.GetMetadata())
+
+	metrics := ep.GetMetrics()
+	assert.Equal(t, 1, metrics.WaitingQueueSize)
+	assert.Equal(t, 4, metrics.RunningRequestsSize, "unreported fields keep their polled value")
+	assert.InDelta(t, 0.9, metrics.KVCacheUsagePercent, 1e-9)
+}
+
+func TestResponseHeader_Ignored(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		endpoint string
+	}{
+		{name: "no header", headers: map[string]string{"content-type": "application/json"}, endpoint: "pod-a"},
+		{name: "malformed header", headers: map[string]string{loadMetricsHeader: "TEXT broken"}, endpoint: "pod-a"},
+		{name: "untracked endpoint", headers: map[string]string{loadMetricsHeader: "TEXT named_metrics.num_requests_waiting=1"}, endpoint: "pod-b"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ep := newTestEndpoint("pod-a")
+			p := newTrackedProducer(t, ep)
+
+			target := newTestEndpoint(tc.endpoint).GetMetadata()
+			p.ResponseHeader(context.Background(), nil, &requestcontrol.Response{Headers: tc.headers}, target)
+
+			assert.Equal(t, 10, ep.GetMetrics().WaitingQueueSize)
+			assert.Equal(t, time.Unix(100, 0), ep.GetMetrics().UpdateTime)
+			_, ok := ep.GetAttributes().Get(p.dk.String())
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestExtract_DeleteStopsTracking(t *testing.T) {
+	t.Parallel()
+
+	ep := newTestEndpoint("pod-a")
+	p := newTrackedProducer(t, ep)
+	require.NoError(t, p.Extract(context.Background(), fwkdl.EndpointEvent{Type: fwkdl.EventDelete, Endpoint: ep}))
+
+	p.ResponseHeader(context.Background(), nil, &requestcontrol.Response{
+		Headers: map[string]string{loadMetricsHeader: "TEXT named_metrics.num_requests_waiting=1"},
+	}, ep.GetMetadata())
+	assert.Equal(t, 10, ep.GetMetrics().WaitingQueueSize)
+
+	// Nil events are tolerated.
+	require.NoError(t, p.Extract(context.Background(), fwkdl.EndpointEvent{Type: fwkdl.EventDelete}))
+}
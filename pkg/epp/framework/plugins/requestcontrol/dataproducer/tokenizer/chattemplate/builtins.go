@@ -0,0 +1,1044 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type filterFunc func(v any, args []any, kwargs *Dict) (any, error)
+
+type testFunc func(v any, args []any) (bool, error)
+
+// arg returns the positional argument i, else the keyword argument, else def.
+func arg(args []any, kwargs *Dict, i int, name string, def any) any {
+	if i < len(args) {
+		return args[i]
+	}
+	if kwargs != nil {
+		if v, ok := kwargs.Get(name); ok {
+			return v
+		}
+	}
+	return def
+}
+
+var filters map[string]filterFunc
+
+func init() {
+	filters = map[string]filterFunc{
+		"length":     filterLength,
+		"count":      filterLength,
+		"tojson":     filterToJSON,
+		"string":     func(v any, _ []any, _ *Dict) (any, error) { return toStr(v), nil },
+		"int":        filterInt,
+		"float":      filterFloat,
+		"upper":      stringFilter(strings.ToUpper),
+		"lower":      stringFilter(strings.ToLower),
+		"title":      stringFilter(pyTitle),
+		"capitalize": stringFilter(pyCapitalize),
+		"trim":       filterTrim,
+		"default":    filterDefault,
+		"d":          filterDefault,
+		"join":       filterJoin,
+		"first":      filterFirst,
+		"last":       filterLast,
+		"list":       filterList,
+		"items":      filterItems,
+		"selectattr": selectFilter(true, true),
+		"rejectattr": selectFilter(true, false),
+		"select":     selectFilter(false, true),
+		"reject":     selectFilter(false, false),
+		"map":        filterMap,
+		"replace":    filterReplace,
+		"reverse":    filterReverse,
+		"sort":       filterSort,
+		"unique":     filterUnique,
+		"abs":        filterAbs,
+		"round":      filterRound,
+		"safe":       func(v any, _ []any, _ *Dict) (any, error) { return v, nil },
+		"escape":     stringFilter(htmlEscape),
+		"e":          stringFilter(htmlEscape),
+		"indent":     filterIndent,
+		"dictsort":   filterDictSort,
+		"min":        extremeFilter(-1),
+		"max":        extremeFilter(1),
+		"sum":        filterSum,
+		"wordcount":  func(v any, _ []any, _ *Dict) (any, error) { return int64(len(strings.Fields(toStr(v)))), nil },
+	}
+}
+
+func filterLength(v any, _ []any, _ *Dict) (any, error) {
+	n, err := length(v)
+	return int64(n), err
+}
+
+// filterToJSON follows transformers' override of tojson, which calls
+// json.dumps(x, ensure_ascii=False, indent=indent) without HTML escaping.
+func filterToJSON(v any, args []any, kwargs *Dict) (any, error) {
+	indent := ""
+	switch x := arg(args, kwargs, 0, "indent", nil).(type) {
+	case int64:
+		indent = strings.Repeat(" ", int(x))
+	case string:
+		indent = x
+	}
+	if u, ok := v.(undefined); ok {
+		return nil, u.err()
+	}
+	return toJSON(v, indent)
+}
+
+func filterInt(v any, args []any, kwargs *Dict) (any, error) {
+	def := arg(args, kwargs, 0, "default", int64(0))
+	switch x := v.(type) {
+	case int64:
+		return x, nil
+	case bool:
+		n, _ := toInt(x)
+		return n, nil
+	case float64:
+		return int64(x), nil
+	case string:
+		s := strings.TrimSpace(x)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return int64(f), nil
+		}
+	}
+	return def, nil
+}
+
+func filterFloat(v any, args []any, kwargs *Dict) (any, error) {
+	def := arg(args, kwargs, 0, "default", 0.0)
+	if f, ok := toFloat(v); ok {
+		return f, nil
+	}
+	if s, ok := v.(string); ok {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			return f, nil
+		}
+	}
+	return def, nil
+}
+
+func stringFilter(fn func(string) string) filterFunc {
+	return func(v any, _ []any, _ *Dict) (any, error) {
+		return fn(toStr(v)), nil
+	}
+}
+
+func filterTrim(v any, args []any, kwargs *Dict) (any, error) {
+	chars := arg(args, kwargs, 0, "chars", nil)
+	return pyStrip(toStr(v), chars, true, true), nil
+}
+
+func filterDefault(v any, args []any, kwargs *Dict) (any, error) {
+	def := arg(args, kwargs, 0, "default_value", "")
+	boolean := truthy(arg(args, kwargs, 1, "boolean", false))
+	if _, ok := v.(undefined); ok || (boolean && !truthy(v)) {
+		return def, nil
+	}
+	return v, nil
+}
+
+func filterJoin(v any, args []any, kwargs *Dict) (any, error) {
+	sep := toStr(arg(args, kwargs, 0, "d", ""))
+	attr := arg(args, kwargs, 1, "attribute", nil)
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]string, len(items))
+	for i, item := range items {
+		if attr != nil {
+			if item, err = lookupPath(item, attr); err != nil {
+				return nil, err
+			}
+		}
+		parts[i] = toStr(item)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+func filterFirst(v any, _ []any, _ *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return undefined{hint: "No first item, sequence was empty."}, nil
+	}
+	return items[0], nil
+}
+
+func filterLast(v any, _ []any, _ *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return undefined{hint: "No last item, sequence was empty."}, nil
+	}
+	return items[len(items)-1], nil
+}
+
+func filterList(v any, _ []any, _ *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]any(nil), items...), nil
+}
+
+func filterItems(v any, _ []any, _ *Dict) (any, error) {
+	switch x := v.(type) {
+	case *Dict:
+		return dictItems(x), nil
+	case undefined:
+		return []any{}, nil
+	}
+	return nil, fmt.Errorf("can only get item pairs from a mapping, not %s", typeName(v))
+}
+
+func dictItems(d *Dict) []any {
+	out := make([]any, len(d.keys))
+	for i, k := range d.keys {
+		out[i] = []any{k, d.m[k]}
+	}
+	return out
+}
+
+// lookupPath resolves a dotted attribute path, or an integer index.
+func lookupPath(v any, attr any) (any, error) {
+	if n, ok := attr.(int64); ok {
+		return getItem(v, n)
+	}
+	for _, part := range strings.Split(toStr(attr), ".") {
+		var err error
+		if n, convErr := strconv.ParseInt(part, 10, 64); convErr == nil {
+			v, err = getItem(v, n)
+		} else {
+			v, err = getItem(v, part)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func selectFilter(byAttr, keep bool) filterFunc {
+	return func(v any, args []any, _ *Dict) (any, error) {
+		items, err := iterate(v)
+		if err != nil {
+			return nil, err
+		}
+		var attr any
+		if byAttr {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("missing attribute for selectattr/rejectattr")
+			}
+			attr, args = args[0], args[1:]
+		}
+		var test testFunc
+		var testArgs []any
+		if len(args) > 0 {
+			name := toStr(args[0])
+			t, ok := tests[name]
+			if !ok {
+				return nil, fmt.Errorf("no test named %q", name)
+			}
+			test, testArgs = t, args[1:]
+		}
+		out := []any{}
+		for _, item := range items {
+			subject := item
+			if byAttr {
+				if subject, err = lookupPath(item, attr); err != nil {
+					return nil, err
+				}
+			}
+			ok := truthy(subject)
+			if test != nil {
+				if ok, err = test(subject, testArgs); err != nil {
+					return nil, err
+				}
+			}
+			if ok == keep {
+				out = append(out, item)
+			}
+		}
+		return out, nil
+	}
+}
+
+func filterMap(v any, args []any, kwargs *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, len(items))
+	if attr, ok := kwargs.Get("attribute"); ok {
+		def, hasDef := kwargs.Get("default")
+		for i, item := range items {
+			val, err := lookupPath(item, attr)
+			if err != nil {
+				return nil, err
+			}
+			if _, isUndef := val.(undefined); isUndef && hasDef {
+				val = def
+			}
+			out[i] = val
+		}
+		return out, nil
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("map requires a filter name or attribute")
+	}
+	name := toStr(args[0])
+	fn, ok := filters[name]
+	if !ok {
+		return nil, fmt.Errorf("no filter named %q", name)
+	}
+	for i, item := range items {
+		if out[i], err = fn(item, args[1:], kwargs); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func filterReplace(v any, args []any, kwargs *Dict) (any, error) {
+	s := toStr(v)
+	old := toStr(arg(args, kwargs, 0, "old", ""))
+	repl := toStr(arg(args, kwargs, 1, "new", ""))
+	n := -1
+	if c, ok := toInt(arg(args, kwargs, 2, "count", nil)); ok {
+		n = int(c)
+	}
+	return strings.Replace(s, old, repl, n), nil
+}
+
+func filterReverse(v any, _ []any, _ *Dict) (any, error) {
+	if s, ok := v.(string); ok {
+		runes := []rune(s)
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	}
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]any, len(items))
+	for i, item := range items {
+		out[len(items)-1-i] = item
+	}
+	return out, nil
+}
+
+func sortKey(v any, caseSensitive bool) any {
+	if s, ok := v.(string); ok && !caseSensitive {
+		return strings.ToLower(s)
+	}
+	return v
+}
+
+func filterSort(v any, args []any, kwargs *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	reverse := truthy(arg(args, kwargs, 0, "reverse", false))
+	caseSensitive := truthy(arg(args, kwargs, 1, "case_sensitive", false))
+	attr := arg(args, kwargs, 2, "attribute", nil)
+	keys := make([]any, len(items))
+	for i, item := range items {
+		k := item
+		if attr != nil {
+			if k, err = lookupPath(item, attr); err != nil {
+				return nil, err
+			}
+		}
+		keys[i] = sortKey(k, caseSensitive)
+	}
+	return sortBy(items, keys, reverse)
+}
+
+func sortBy(items, keys []any, reverse bool) ([]any, error) {
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(idx, func(a, b int) bool {
+		c, err := compare(keys[idx[a]], keys[idx[b]])
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		if reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	out := make([]any, len(items))
+	for i, j := range idx {
+		out[i] = items[j]
+	}
+	return out, nil
+}
+
+func filterUnique(v any, args []any, kwargs *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	caseSensitive := truthy(arg(args, kwargs, 0, "case_sensitive", false))
+	attr := arg(args, kwargs, 1, "attribute", nil)
+	var seen []any
+	out := []any{}
+	for _, item := range items {
+		k := item
+		if attr != nil {
+			if k, err = lookupPath(item, attr); err != nil {
+				return nil, err
+			}
+		}
+		k = sortKey(k, caseSensitive)
+		dup := false
+		for _, s := range seen {
+			if equal(s, k) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			seen = append(seen, k)
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func filterAbs(v any, _ []any, _ *Dict) (any, error) {
+	switch x := v.(type) {
+	case int64:
+		if x < 0 {
+			return -x, nil
+		}
+		return x, nil
+	case float64:
+		return math.Abs(x), nil
+	}
+	return nil, fmt.Errorf("bad operand type for abs(): '%s'", typeName(v))
+}
+
+func filterRound(v any, args []any, kwargs *Dict) (any, error) {
+	f, ok := toFloat(v)
+	if !ok {
+		return nil, fmt.Errorf("round expects a number, got %s", typeName(v))
+	}
+	precision, _ := toInt(arg(args, kwargs, 0, "precision", int64(0)))
+	method := toStr(arg(args, kwargs, 1, "method", "common"))
+	scale := math.Pow(10, float64(precision))
+	switch method {
+	case "common":
+		return math.Round(f*scale) / scale, nil
+	case "ceil":
+		return math.Ceil(f*scale) / scale, nil
+	case "floor":
+		return math.Floor(f*scale) / scale, nil
+	}
+	return nil, fmt.Errorf("method must be common, ceil or floor")
+}
+
+func htmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&#34;", "'", "&#39;").Replace(s)
+}
+
+func filterIndent(v any, args []any, kwargs *Dict) (any, error) {
+	width := arg(args, kwargs, 0, "width", int64(4))
+	first := truthy(arg(args, kwargs, 1, "first", false))
+	blank := truthy(arg(args, kwargs, 2, "blank", false))
+	pad := ""
+	if n, ok := width.(int64); ok {
+		pad = strings.Repeat(" ", int(n))
+	} else {
+		pad = toStr(width)
+	}
+	lines := strings.Split(toStr(v), "\n")
+	for i, line := range lines {
+		if i == 0 && !first {
+			continue
+		}
+		if line == "" && !blank {
+			continue
+		}
+		lines[i] = pad + line
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func filterDictSort(v any, args []any, kwargs *Dict) (any, error) {
+	d, ok := v.(*Dict)
+	if !ok {
+		return nil, fmt.Errorf("dictsort expects a mapping, got %s", typeName(v))
+	}
+	caseSensitive := truthy(arg(args, kwargs, 0, "case_sensitive", false))
+	byValue := toStr(arg(args, kwargs, 1, "by", "key")) == "value"
+	reverse := truthy(arg(args, kwargs, 2, "reverse", false))
+	items := dictItems(d)
+	keys := make([]any, len(items))
+	for i, item := range items {
+		pair := item.([]any)
+		k := pair[0]
+		if byValue {
+			k = pair[1]
+		}
+		keys[i] = sortKey(k, caseSensitive)
+	}
+	return sortBy(items, keys, reverse)
+}
+
+func extremeFilter(sign int) filterFunc {
+	return func(v any, args []any, kwargs *Dict) (any, error) {
+		items, err := iterate(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			return undefined{hint: "No aggregated item, sequence was empty."}, nil
+		}
+		caseSensitive := truthy(arg(args, kwargs, 0, "case_sensitive", false))
+		attr := arg(args, kwargs, 1, "attribute", nil)
+		var best, bestKey any
+		for i, item := range items {
+			k := item
+			if attr != nil {
+				if k, err = lookupPath(item, attr); err != nil {
+					return nil, err
+				}
+			}
+			k = sortKey(k, caseSensitive)
+			if i == 0 {
+				best, bestKey = item, k
+				continue
+			}
+			c, err := compare(k, bestKey)
+			if err != nil {
+				return nil, err
+			}
+			if c*sign > 0 {
+				best, bestKey = item, k
+			}
+		}
+		return best, nil
+	}
+}
+
+func filterSum(v any, args []any, kwargs *Dict) (any, error) {
+	items, err := iterate(v)
+	if err != nil {
+		return nil, err
+	}
+	attr := arg(args, kwargs, 0, "attribute", nil)
+	total := arg(args, kwargs, 1, "start", int64(0))
+	for _, item := range items {
+		if attr != nil {
+			if item, err = lookupPath(item, attr); err != nil {
+				return nil, err
+			}
+		}
+		if total, err = arith("+", total, item); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+var tests map[string]testFunc
+
+func init() {
+	isType := func(pred func(any) bool) testFunc {
+		return func(v any, _ []any) (bool, error) { return pred(v), nil }
+	}
+	cmp := func(ok func(int) bool) testFunc {
+		return func(v any, args []any) (bool, error) {
+			if len(args) != 1 {
+				return false, fmt.Errorf("comparison test takes one argument")
+			}
+			c, err := compare(v, args[0])
+			return ok(c), err
+		}
+	}
+	eq := func(v any, args []any) (bool, error) {
+		if len(args) != 1 {
+			return false, fmt.Errorf("comparison test takes one argument")
+		}
+		return equal(v, args[0]), nil
+	}
+	tests = map[string]testFunc{
+		"defined":   isType(func(v any) bool { _, ok := v.(undefined); return !ok }),
+		"undefined": isType(func(v any) bool { _, ok := v.(undefined); return ok }),
+		"none":      isType(func(v any) bool { return v == nil }),
+		"boolean":   isType(func(v any) bool { _, ok := v.(bool); return ok }),
+		"true":      isType(func(v any) bool { b, ok := v.(bool); return ok && b }),
+		"false":     isType(func(v any) bool { b, ok := v.(bool); return ok && !b }),
+		"integer":   isType(func(v any) bool { _, ok := v.(int64); return ok }),
+		"float":     isType(func(v any) bool { _, ok := v.(float64); return ok }),
+		"number":    isType(func(v any) bool { _, ok := toFloat(v); return ok }),
+		"string":    isType(func(v any) bool { _, ok := v.(string); return ok }),
+		"mapping":   isType(func(v any) bool { _, ok := v.(*Dict); return ok }),
+		"sequence": isType(func(v any) bool {
+			switch v.(type) {
+			case []any, string, *Dict:
+				return true
+			}
+			return false
+		}),
+		"iterable": isType(func(v any) bool {
+			switch v.(type) {
+			case []any, string, *Dict, undefined:
+				return true
+			}
+			return false
+		}),
+		"callable": isType(func(v any) bool {
+			switch v.(type) {
+			case callable, boundMethod:
+				return true
+			}
+			return false
+		}),
+		"odd": func(v any, _ []any) (bool, error) {
+			n, ok := toInt(v)
+			return ok && n%2 != 0, nil
+		},
+		"even": func(v any, _ []any) (bool, error) {
+			n, ok := toInt(v)
+			return ok && n%2 == 0, nil
+		},
+		"divisibleby": func(v any, args []any) (bool, error) {
+			if len(args) != 1 {
+				return false, fmt.Errorf("divisibleby takes one argument")
+			}
+			n, ok1 := toInt(v)
+			d, ok2 := toInt(args[0])
+			if !ok1 || !ok2 || d == 0 {
+				return false, fmt.Errorf("divisibleby expects non-zero integers")
+			}
+			return n%d == 0, nil
+		},
+		"eq":          eq,
+		"equalto":     eq,
+		"==":          eq,
+		"ne":          func(v any, args []any) (bool, error) { ok, err := eq(v, args); return !ok, err },
+		"!=":          func(v any, args []any) (bool, error) { ok, err := eq(v, args); return !ok, err },
+		"lt":          cmp(func(c int) bool { return c < 0 }),
+		"lessthan":    cmp(func(c int) bool { return c < 0 }),
+		"le":          cmp(func(c int) bool { return c <= 0 }),
+		"gt":          cmp(func(c int) bool { return c > 0 }),
+		"greaterthan": cmp(func(c int) bool { return c > 0 }),
+		"ge":          cmp(func(c int) bool { return c >= 0 }),
+		"in": func(v any, args []any) (bool, error) {
+			if len(args) != 1 {
+				return false, fmt.Errorf("in test takes one argument")
+			}
+			return contains(args[0], v)
+		},
+		"sameas": func(v any, args []any) (bool, error) {
+			if len(args) != 1 {
+				return false, fmt.Errorf("sameas takes one argument")
+			}
+			switch v.(type) {
+			case nil, bool:
+				return v == args[0], nil
+			}
+			return equal(v, args[0]), nil
+		},
+		"lower": isType(func(v any) bool { s, ok := v.(string); return ok && s == strings.ToLower(s) }),
+		"upper": isType(func(v any) bool { s, ok := v.(string); return ok && s == strings.ToUpper(s) }),
+	}
+}
+
+// globals are the functions available to every template, including the
+// helpers transformers adds for chat templates.
+var globals = map[string]any{
+	"raise_exception": callable(func(args []any, _ *Dict) (any, error) {
+		msg := ""
+		if len(args) > 0 {
+			msg = toStr(args[0])
+		}
+		return nil, &TemplateError{Message: msg}
+	}),
+	"namespace": callable(func(args []any, kwargs *Dict) (any, error) {
+		attrs := NewDict()
+		if len(args) > 0 {
+			if d, ok := args[0].(*Dict); ok {
+				for _, k := range d.keys {
+					attrs.Set(k, d.m[k])
+				}
+			}
+		}
+		for _, k := range kwargs.keys {
+			attrs.Set(k, kwargs.m[k])
+		}
+		return &namespace{attrs: attrs}, nil
+	}),
+	"range": callable(func(args []any, _ *Dict) (any, error) {
+		bounds := make([]int64, len(args))
+		for i, a := range args {
+			n, ok := toInt(a)
+			if !ok {
+				return nil, fmt.Errorf("range() arguments must be integers, got %s", typeName(a))
+			}
+			bounds[i] = n
+		}
+		start, stop, step := int64(0), int64(0), int64(1)
+		switch len(bounds) {
+		case 1:
+			stop = bounds[0]
+		case 2:
+			start, stop = bounds[0], bounds[1]
+		case 3:
+			start, stop, step = bounds[0], bounds[1], bounds[2]
+		default:
+			return nil, fmt.Errorf("range expected 1 to 3 arguments, got %d", len(bounds))
+		}
+		if step == 0 {
+			return nil, fmt.Errorf("range() arg 3 must not be zero")
+		}
+		out := []any{}
+		for i := start; (step > 0 && i < stop) || (step < 0 && i > stop); i += step {
+			if len(out) >= maxSteps {
+				return nil, fmt.Errorf("range too large")
+			}
+			out = append(out, i)
+		}
+		return out, nil
+	}),
+	"dict": callable(func(args []any, kwargs *Dict) (any, error) {
+		d := NewDict()
+		if len(args) > 0 {
+			if src, ok := args[0].(*Dict); ok {
+				for _, k := range src.keys {
+					d.Set(k, src.m[k])
+				}
+			}
+		}
+		for _, k := range kwargs.keys {
+			d.Set(k, kwargs.m[k])
+		}
+		return d, nil
+	}),
+	"strftime_now": callable(func(args []any, _ *Dict) (any, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("strftime_now takes one argument")
+		}
+		return strftime(now(), toStr(args[0])), nil
+	}),
+}
+
+// now is swapped out in tests.
+var now = time.Now
+
+// strftime supports the directives chat templates use for dates.
+func strftime(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case 'b':
+			b.WriteString(t.Month().String()[:3])
+		case 'B':
+			b.WriteString(t.Month().String())
+		case 'a':
+			b.WriteString(t.Weekday().String()[:3])
+		case 'A':
+			b.WriteString(t.Weekday().String())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+var unsafeListMethods = map[string]bool{
+	"append": true, "extend": true, "insert": true, "pop": true, "remove": true, "clear": true, "sort": true, "reverse": true,
+}
+
+var stringMethods = map[string]bool{
+	"strip": true, "lstrip": true, "rstrip": true, "split": true, "rsplit": true, "startswith": true, "endswith": true,
+	"upper": true, "lower": true, "title": true, "capitalize": true, "replace": true, "find": true, "rfind": true,
+	"count": true, "join": true, "isdigit": true, "isspace": true, "isalpha": true, "isalnum": true,
+}
+
+var dictMethods = map[string]bool{"items": true, "keys": true, "values": true, "get": true}
+
+func isMethod(v any, name string) bool {
+	switch v.(type) {
+	case string:
+		return stringMethods[name]
+	case *Dict:
+		return dictMethods[name]
+	}
+	return false
+}
+
+func callMethod(m boundMethod, args []any, kwargs *Dict) (any, error) {
+	switch recv := m.recv.(type) {
+	case *Dict:
+		switch m.name {
+		case "items":
+			return dictItems(recv), nil
+		case "keys":
+			out := make([]any, len(recv.keys))
+			for i, k := range recv.keys {
+				out[i] = k
+			}
+			return out, nil
+		case "values":
+			out := make([]any, len(recv.keys))
+			for i, k := range recv.keys {
+				out[i] = recv.m[k]
+			}
+			return out, nil
+		case "get":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("get expected at least 1 argument")
+			}
+			if v, ok := recv.m[toStr(args[0])]; ok {
+				return v, nil
+			}
+			return arg(args, kwargs, 1, "default", nil), nil
+		}
+	case string:
+		return callStringMethod(recv, m.name, args, kwargs)
+	}
+	return nil, fmt.Errorf("'%s' object has no method '%s'", typeName(m.recv), m.name)
+}
+
+func callStringMethod(s, name string, args []any, kwargs *Dict) (any, error) {
+	switch name {
+	case "strip":
+		return pyStrip(s, arg(args, kwargs, 0, "chars", nil), true, true), nil
+	case "lstrip":
+		return pyStrip(s, arg(args, kwargs, 0, "chars", nil), true, false), nil
+	case "rstrip":
+		return pyStrip(s, arg(args, kwargs, 0, "chars", nil), false, true), nil
+	case "split", "rsplit":
+		sep := arg(args, kwargs, 0, "sep", nil)
+		maxSplit, ok := toInt(arg(args, kwargs, 1, "maxsplit", int64(-1)))
+		if !ok {
+			maxSplit = -1
+		}
+		return pySplit(s, sep, int(maxSplit), name == "rsplit")
+	case "startswith", "endswith":
+		check := strings.HasPrefix
+		if name == "endswith" {
+			check = strings.HasSuffix
+		}
+		switch p := arg(args, kwargs, 0, "prefix", nil).(type) {
+		case string:
+			return check(s, p), nil
+		case []any:
+			for _, e := range p {
+				if check(s, toStr(e)) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return nil, fmt.Errorf("%s arg must be str or a tuple of str", name)
+	case "upper":
+		return strings.ToUpper(s), nil
+	case "lower":
+		return strings.ToLower(s), nil
+	case "title":
+		return pyTitle(s), nil
+	case "capitalize":
+		return pyCapitalize(s), nil
+	case "replace":
+		n := -1
+		if c, ok := toInt(arg(args, kwargs, 2, "count", nil)); ok {
+			n = int(c)
+		}
+		return strings.Replace(s, toStr(arg(args, kwargs, 0, "old", "")), toStr(arg(args, kwargs, 1, "new", "")), n), nil
+	case "find", "rfind":
+		sub := toStr(arg(args, kwargs, 0, "sub", ""))
+		idx := strings.Index(s, sub)
+		if name == "rfind" {
+			idx = strings.LastIndex(s, sub)
+		}
+		if idx < 0 {
+			return int64(-1), nil
+		}
+		return int64(len([]rune(s[:idx]))), nil
+	case "count":
+		return int64(strings.Count(s, toStr(arg(args, kwargs, 0, "sub", "")))), nil
+	case "join":
+		items, err := iterate(arg(args, kwargs, 0, "iterable", nil))
+		if err != nil {
+			return nil, err
+		}
+		parts := make([]string, len(items))
+		for i, item := range items {
+			parts[i] = toStr(item)
+		}
+		return strings.Join(parts, s), nil
+	case "isdigit":
+		return s != "" && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }) < 0, nil
+	case "isspace":
+		return s != "" && strings.TrimSpace(s) == "", nil
+	case "isalpha":
+		return s != "" && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) < 0, nil
+	case "isalnum":
+		return s != "" && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) < 0, nil
+	}
+	return nil, fmt.Errorf("'str' object has no method '%s'", name)
+}
+
+func pyStrip(s string, chars any, left, right bool) string {
+	pred := unicode.IsSpace
+	if chars != nil {
+		set := toStr(chars)
+		pred = func(r rune) bool { return strings.ContainsRune(set, r) }
+	}
+	if left {
+		s = strings.TrimLeftFunc(s, pred)
+	}
+	if right {
+		s = strings.TrimRightFunc(s, pred)
+	}
+	return s
+}
+
+func pySplit(s string, sep any, maxSplit int, fromRight bool) (any, error) {
+	var parts []string
+	if sep == nil {
+		fields := strings.Fields(s)
+		if maxSplit >= 0 && len(fields) > maxSplit+1 {
+			// Rejoin the tail on the original text to keep its spacing.
+			if fromRight {
+				head := s
+				for i := 0; i < maxSplit; i++ {
+					head = strings.TrimRightFunc(head, unicode.IsSpace)
+					head = head[:strings.LastIndexFunc(head, unicode.IsSpace)+1]
+				}
+				fields = append([]string{strings.TrimSpace(head)}, fields[len(fields)-maxSplit:]...)
+			} else {
+				rest := s
+				for i := 0; i < maxSplit; i++ {
+					rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+					rest = rest[strings.IndexFunc(rest, unicode.IsSpace):]
+				}
+				fields = append(fields[:maxSplit:maxSplit], strings.TrimSpace(rest))
+			}
+		}
+		parts = fields
+	} else {
+		sepStr := toStr(sep)
+		if sepStr == "" {
+			return nil, fmt.Errorf("empty separator")
+		}
+		switch {
+		case maxSplit < 0:
+			parts = strings.Split(s, sepStr)
+		case fromRight:
+			parts = nil
+			rest := s
+			for i := 0; i < maxSplit; i++ {
+				idx := strings.LastIndex(rest, sepStr)
+				if idx < 0 {
+					break
+				}
+				parts = append([]string{rest[idx+len(sepStr):]}, parts...)
+				rest = rest[:idx]
+			}
+			parts = append([]string{rest}, parts...)
+		default:
+			parts = strings.SplitN(s, sepStr, maxSplit+1)
+		}
+	}
+	out := make([]any, len(parts))
+	for i, p := range parts {
+		out[i] = p
+	}
+	return out, nil
+}
+
+func pyTitle(s string) string {
+	var b strings.Builder
+	prevCased := false
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			if prevCased {
+				b.WriteRune(unicode.ToLower(r))
+			} else {
+				b.WriteRune(unicode.ToTitle(r))
+			}
+			prevCased = true
+			continue
+		}
+		b.WriteRune(r)
+		prevCased = false
+	}
+	return b.String()
+}
+
+func pyCapitalize(s string) string {
+	for i, r := range s {
+		return string(unicode.ToTitle(r)) + strings.ToLower(s[i+len(string(r)):])
+	}
+	return s
+}
@@ -0,0 +1,815 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	errBreak    = errors.New("break outside of loop")
+	errContinue = errors.New("continue outside of loop")
+)
+
+// frame is a variable scope. Loops and macro calls push a frame; if blocks
+// do not, matching Jinja's scoping rules.
+type frame struct {
+	vars   map[string]any
+	parent *frame
+}
+
+func newFrame(parent *frame) *frame {
+	return &frame{vars: map[string]any{}, parent: parent}
+}
+
+func (f *frame) lookup(name string) (any, bool) {
+	for cur := f; cur != nil; cur = cur.parent {
+		if v, ok := cur.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+type renderer struct {
+	out   strings.Builder
+	steps int
+}
+
+// maxSteps bounds the work of a single render so a hostile template cannot
+// spin forever.
+const maxSteps = 1_000_000
+
+func (r *renderer) exec(nodes []node, f *frame) error {
+	for _, n := range nodes {
+		r.steps++
+		if r.steps > maxSteps {
+			return fmt.Errorf("template exceeded %d evaluation steps", maxSteps)
+		}
+		if err := r.execNode(n, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) execNode(n node, f *frame) error {
+	switch n := n.(type) {
+	case *textNode:
+		r.out.WriteString(n.text)
+	case *outputNode:
+		v, err := r.eval(n.expr, f)
+		if err != nil {
+			return err
+		}
+		r.out.WriteString(toStr(v))
+	case *ifNode:
+		for i, cond := range n.conds {
+			v, err := r.eval(cond, f)
+			if err != nil {
+				return err
+			}
+			if truthy(v) {
+				return r.exec(n.bodies[i], f)
+			}
+		}
+		return r.exec(n.orelse, f)
+	case *forNode:
+		return r.execFor(n, f)
+	case *setNode:
+		return r.execSet(n, f)
+	case *macroNode:
+		f.vars[n.name] = r.makeMacro(n, f)
+	case *filterBlockNode:
+		body, err := r.capture(n.body, f)
+		if err != nil {
+			return err
+		}
+		v, err := r.applyFilter(n.filter, body, f)
+		if err != nil {
+			return err
+		}
+		r.out.WriteString(toStr(v))
+	case *generationNode:
+		return r.exec(n.body, f)
+	case *breakNode:
+		return errBreak
+	case *continueNode:
+		return errContinue
+	default:
+		return fmt.Errorf("unknown node %T", n)
+	}
+	return nil
+}
+
+// capture renders nodes into a string instead of the output.
+func (r *renderer) capture(nodes []node, f *frame) (string, error) {
+	saved := r.out
+	r.out = strings.Builder{}
+	err := r.exec(nodes, f)
+	body := r.out.String()
+	r.out = saved
+	return body, err
+}
+
+func (r *renderer) execFor(n *forNode, f *frame) error {
+	iterable, err := r.eval(n.iter, f)
+	if err != nil {
+		return err
+	}
+	items, err := iterate(iterable)
+	if err != nil {
+		return err
+	}
+	inner := newFrame(f)
+	if n.filter != nil {
+		kept := make([]any, 0, len(items))
+		for _, item := range items {
+			if err := bindTargets(inner, n.targets, item); err != nil {
+				return err
+			}
+			v, err := r.eval(n.filter, inner)
+			if err != nil {
+				return err
+			}
+			if truthy(v) {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+	}
+	if len(items) == 0 {
+		return r.exec(n.orelse, f)
+	}
+	loop := &loopState{items: items}
+	for i, item := range items {
+		inner = newFrame(f)
+		loop.index = i
+		inner.vars["loop"] = loop
+		if err := bindTargets(inner, n.targets, item); err != nil {
+			return err
+		}
+		err := r.exec(n.body, inner)
+		switch {
+		case errors.Is(err, errBreak):
+			return nil
+		case errors.Is(err, errContinue):
+		case err != nil:
+			return err
+		}
+	}
+	return nil
+}
+
+func bindTargets(f *frame, targets []string, v any) error {
+	if len(targets) == 1 {
+		f.vars[targets[0]] = v
+		return nil
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return fmt.Errorf("cannot unpack %s into %d names", typeName(v), len(targets))
+	}
+	if len(items) != len(targets) {
+		return fmt.Errorf("expected %d values to unpack, got %d", len(targets), len(items))
+	}
+	for i, name := range targets {
+		f.vars[name] = items[i]
+	}
+	return nil
+}
+
+func (r *renderer) execSet(n *setNode, f *frame) error {
+	var v any
+	if n.value == nil {
+		body, err := r.capture(n.body, f)
+		if err != nil {
+			return err
+		}
+		v = body
+	} else {
+		var err error
+		if v, err = r.eval(n.value, f); err != nil {
+			return err
+		}
+	}
+	if n.attr != "" {
+		target, _ := f.lookup(n.targets[0])
+		ns, ok := target.(*namespace)
+		if !ok {
+			return fmt.Errorf("cannot assign attribute on non-namespace object %q", n.targets[0])
+		}
+		ns.attrs.Set(n.attr, v)
+		return nil
+	}
+	return bindTargets(f, n.targets, v)
+}
+
+func (r *renderer) makeMacro(n *macroNode, def *frame) callable {
+	return func(args []any, kwargs *Dict) (any, error) {
+		if len(args) > len(n.params) {
+			return nil, fmt.Errorf("macro %q takes %d arguments, got %d", n.name, len(n.params), len(args))
+		}
+		call := newFrame(def)
+		for i, name := range n.params {
+			switch {
+			case i < len(args):
+				call.vars[name] = args[i]
+			case kwargs != nil && hasKey(kwargs, name):
+				call.vars[name], _ = kwargs.Get(name)
+			case n.defaults[i] != nil:
+				v, err := r.eval(n.defaults[i], call)
+				if err != nil {
+					return nil, err
+				}
+				call.vars[name] = v
+			default:
+				call.vars[name] = undefined{hint: fmt.Sprintf("parameter %q was not provided", name)}
+			}
+		}
+		return r.capture(n.body, call)
+	}
+}
+
+func hasKey(d *Dict, key string) bool {
+	_, ok := d.Get(key)
+	return ok
+}
+
+func (r *renderer) eval(e expr, f *frame) (any, error) {
+	switch e := e.(type) {
+	case *literal:
+		return e.val, nil
+	case *nameExpr:
+		if v, ok := f.lookup(e.name); ok {
+			return v, nil
+		}
+		if g, ok := globals[e.name]; ok {
+			return g, nil
+		}
+		return undefined{hint: fmt.Sprintf("'%s' is undefined", e.name)}, nil
+	case *attrExpr:
+		obj, err := r.eval(e.obj, f)
+		if err != nil {
+			return nil, err
+		}
+		return getAttr(obj, e.name)
+	case *itemExpr:
+		obj, err := r.eval(e.obj, f)
+		if err != nil {
+			return nil, err
+		}
+		key, err := r.eval(e.key, f)
+		if err != nil {
+			return nil, err
+		}
+		return getItem(obj, key)
+	case *sliceExpr:
+		return r.evalSlice(e, f)
+	case *callExpr:
+		return r.evalCall(e, f)
+	case *filterExpr:
+		target, err := r.eval(e.target, f)
+		if err != nil {
+			return nil, err
+		}
+		return r.applyFilter(e, target, f)
+	case *testExpr:
+		return r.evalTest(e, f)
+	case *unaryExpr:
+		x, err := r.eval(e.x, f)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "not":
+			return !truthy(x), nil
+		case "-":
+			switch n := x.(type) {
+			case int64:
+				return -n, nil
+			case float64:
+				return -n, nil
+			case bool:
+				n2, _ := toInt(n)
+				return -n2, nil
+			}
+			return nil, fmt.Errorf("bad operand type for unary -: '%s'", typeName(x))
+		default:
+			if _, ok := toFloat(x); !ok {
+				return nil, fmt.Errorf("bad operand type for unary +: '%s'", typeName(x))
+			}
+			return x, nil
+		}
+	case *binaryExpr:
+		return r.evalBinary(e, f)
+	case *condExpr:
+		cond, err := r.eval(e.cond, f)
+		if err != nil {
+			return nil, err
+		}
+		if truthy(cond) {
+			return r.eval(e.then, f)
+		}
+		if e.orelse == nil {
+			return undefined{hint: "the inline if-expression evaluated to false and no else section was defined"}, nil
+		}
+		return r.eval(e.orelse, f)
+	case *listExpr:
+		out := make([]any, len(e.items))
+		for i, item := range e.items {
+			v, err := r.eval(item, f)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = v
+		}
+		return out, nil
+	case *dictExpr:
+		d := NewDict()
+		for i := range e.keys {
+			k, err := r.eval(e.keys[i], f)
+			if err != nil {
+				return nil, err
+			}
+			v, err := r.eval(e.values[i], f)
+			if err != nil {
+				return nil, err
+			}
+			d.Set(toStr(k), v)
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("unknown expression %T", e)
+	}
+}
+
+func (r *renderer) evalBinary(e *binaryExpr, f *frame) (any, error) {
+	l, err := r.eval(e.l, f)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "and":
+		if !truthy(l) {
+			return l, nil
+		}
+		return r.eval(e.r, f)
+	case "or":
+		if truthy(l) {
+			return l, nil
+		}
+		return r.eval(e.r, f)
+	}
+	rv, err := r.eval(e.r, f)
+	if err != nil {
+		return nil, err
+	}
+	switch e.op {
+	case "==":
+		return equal(l, rv), nil
+	case "!=":
+		return !equal(l, rv), nil
+	case "<", "<=", ">", ">=":
+		c, err := compare(l, rv)
+		if err != nil {
+			return nil, err
+		}
+		switch e.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "in":
+		return contains(rv, l)
+	case "not in":
+		found, err := contains(rv, l)
+		return !found, err
+	case "~":
+		return toStr(l) + toStr(rv), nil
+	}
+	return arith(e.op, l, rv)
+}
+
+func arith(op string, l, r any) (any, error) {
+	if u, ok := l.(undefined); ok {
+		return nil, u.err()
+	}
+	if u, ok := r.(undefined); ok {
+		return nil, u.err()
+	}
+	li, lInt := toInt(l)
+	ri, rInt := toInt(r)
+	lf, lNum := toFloat(l)
+	rf, rNum := toFloat(r)
+	if lNum && rNum {
+		bothInt := lInt && rInt
+		switch op {
+		case "+":
+			if bothInt {
+				return li + ri, nil
+			}
+			return lf + rf, nil
+		case "-":
+			if bothInt {
+				return li - ri, nil
+			}
+			return lf - rf, nil
+		case "*":
+			if bothInt {
+				return li * ri, nil
+			}
+			return lf * rf, nil
+		case "/":
+			if rf == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return lf / rf, nil
+		case "//":
+			if rf == 0 {
+				return nil, fmt.Errorf("integer division or modulo by zero")
+			}
+			if bothInt {
+				q := li / ri
+				if (li%ri != 0) && ((li < 0) != (ri < 0)) {
+					q--
+				}
+				return q, nil
+			}
+			return math.Floor(lf / rf), nil
+		case "%":
+			if rf == 0 {
+				return nil, fmt.Errorf("integer division or modulo by zero")
+			}
+			if bothInt {
+				m := li % ri
+				if m != 0 && ((m < 0) != (ri < 0)) {
+					m += ri
+				}
+				return m, nil
+			}
+			m := math.Mod(lf, rf)
+			if m != 0 && ((m < 0) != (rf < 0)) {
+				m += rf
+			}
+			return m, nil
+		case "**":
+			if bothInt && ri >= 0 {
+				out := int64(1)
+				for i := int64(0); i < ri; i++ {
+					out *= li
+				}
+				return out, nil
+			}
+			return math.Pow(lf, rf), nil
+		}
+	}
+	switch op {
+	case "+":
+		if ls, ok := l.(string); ok {
+			if rs, ok := r.(string); ok {
+				return ls + rs, nil
+			}
+		}
+		if ll, ok := l.([]any); ok {
+			if rl, ok := r.([]any); ok {
+				out := make([]any, 0, len(ll)+len(rl))
+				return append(append(out, ll...), rl...), nil
+			}
+		}
+	case "*":
+		if s, ok := l.(string); ok && rInt {
+			return strings.Repeat(s, int(max(ri, 0))), nil
+		}
+		if list, ok := l.([]any); ok && rInt {
+			var out []any
+			for i := int64(0); i < ri; i++ {
+				out = append(out, list...)
+			}
+			return out, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported operand type(s) for %s: '%s' and '%s'", op, typeName(l), typeName(r))
+}
+
+func (r *renderer) evalSlice(e *sliceExpr, f *frame) (any, error) {
+	obj, err := r.eval(e.obj, f)
+	if err != nil {
+		return nil, err
+	}
+	bound := func(x expr) (*int64, error) {
+		if x == nil {
+			return nil, nil
+		}
+		v, err := r.eval(x, f)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		n, ok := toInt(v)
+		if !ok {
+			return nil, fmt.Errorf("slice indices must be integers or None")
+		}
+		return &n, nil
+	}
+	start, err := bound(e.start)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := bound(e.stop)
+	if err != nil {
+		return nil, err
+	}
+	step, err := bound(e.step)
+	if err != nil {
+		return nil, err
+	}
+	switch x := obj.(type) {
+	case []any:
+		idx, err := sliceIndices(len(x), start, stop, step)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]any, len(idx))
+		for i, j := range idx {
+			out[i] = x[j]
+		}
+		return out, nil
+	case string:
+		runes := []rune(x)
+		idx, err := sliceIndices(len(runes), start, stop, step)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]rune, len(idx))
+		for i, j := range idx {
+			out[i] = runes[j]
+		}
+		return string(out), nil
+	case undefined:
+		return nil, x.err()
+	default:
+		return nil, fmt.Errorf("'%s' object is not subscriptable", typeName(obj))
+	}
+}
+
+// sliceIndices resolves Python slice bounds into the selected indices.
+func sliceIndices(n int, start, stop, step *int64) ([]int, error) {
+	st := int64(1)
+	if step != nil {
+		st = *step
+	}
+	if st == 0 {
+		return nil, fmt.Errorf("slice step cannot be zero")
+	}
+	clamp := func(p *int64, def int64) int64 {
+		if p == nil {
+			return def
+		}
+		v := *p
+		if v < 0 {
+			v += int64(n)
+		}
+		if st > 0 {
+			return min(max(v, 0), int64(n))
+		}
+		return min(max(v, -1), int64(n)-1)
+	}
+	var out []int
+	if st > 0 {
+		for i := clamp(start, 0); i < clamp(stop, int64(n)); i += st {
+			out = append(out, int(i))
+		}
+	} else {
+		for i := clamp(start, int64(n)-1); i > clamp(stop, -1); i += st {
+			out = append(out, int(i))
+		}
+	}
+	return out, nil
+}
+
+func (r *renderer) evalArgs(args []expr, kwargs []kwarg, f *frame) ([]any, *Dict, error) {
+	vals := make([]any, len(args))
+	for i, a := range args {
+		v, err := r.eval(a, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		vals[i] = v
+	}
+	kw := NewDict()
+	for _, k := range kwargs {
+		v, err := r.eval(k.value, f)
+		if err != nil {
+			return nil, nil, err
+		}
+		kw.Set(k.name, v)
+	}
+	return vals, kw, nil
+}
+
+func (r *renderer) evalCall(e *callExpr, f *frame) (any, error) {
+	fn, err := r.eval(e.fn, f)
+	if err != nil {
+		return nil, err
+	}
+	args, kwargs, err := r.evalArgs(e.args, e.kwargs, f)
+	if err != nil {
+		return nil, err
+	}
+	switch fn := fn.(type) {
+	case callable:
+		return fn(args, kwargs)
+	case boundMethod:
+		return callMethod(fn, args, kwargs)
+	case undefined:
+		return nil, fn.err()
+	default:
+		return nil, fmt.Errorf("'%s' object is not callable", typeName(fn))
+	}
+}
+
+func (r *renderer) applyFilter(e *filterExpr, target any, f *frame) (any, error) {
+	fn, ok := filters[e.name]
+	if !ok {
+		return nil, fmt.Errorf("no filter named %q", e.name)
+	}
+	args, kwargs, err := r.evalArgs(e.args, e.kwargs, f)
+	if err != nil {
+		return nil, err
+	}
+	return fn(target, args, kwargs)
+}
+
+func (r *renderer) evalTest(e *testExpr, f *frame) (any, error) {
+	fn, ok := tests[e.name]
+	if !ok {
+		return nil, fmt.Errorf("no test named %q", e.name)
+	}
+	target, err := r.eval(e.target, f)
+	if err != nil {
+		return nil, err
+	}
+	args, _, err := r.evalArgs(e.args, nil, f)
+	if err != nil {
+		return nil, err
+	}
+	ok, err = fn(target, args)
+	if err != nil {
+		return nil, err
+	}
+	return ok != e.negate, nil
+}
+
+func getAttr(obj any, name string) (any, error) {
+	switch x := obj.(type) {
+	case undefined:
+		return nil, x.err()
+	case *Dict:
+		if v, ok := x.m[name]; ok {
+			return v, nil
+		}
+		if isMethod(obj, name) {
+			return boundMethod{recv: obj, name: name}, nil
+		}
+		return undefined{hint: fmt.Sprintf("'dict object' has no attribute '%s'", name)}, nil
+	case *namespace:
+		if v, ok := x.attrs.m[name]; ok {
+			return v, nil
+		}
+	case *loopState:
+		if v, ok := x.attr(name); ok {
+			return v, nil
+		}
+	default:
+		if isMethod(obj, name) {
+			return boundMethod{recv: obj, name: name}, nil
+		}
+		if _, isList := obj.([]any); isList && unsafeListMethods[name] {
+			return nil, fmt.Errorf("access to attribute '%s' of 'list' object is unsafe", name)
+		}
+	}
+	return undefined{hint: fmt.Sprintf("'%s' has no attribute '%s'", describeObj(obj), name)}, nil
+}
+
+func getItem(obj, key any) (any, error) {
+	switch x := obj.(type) {
+	case undefined:
+		return nil, x.err()
+	case *Dict:
+		if s, ok := key.(string); ok {
+			if v, ok := x.m[s]; ok {
+				return v, nil
+			}
+			return undefined{hint: fmt.Sprintf("'dict object' has no attribute '%s'", s)}, nil
+		}
+	case []any:
+		if i, ok := toInt(key); ok {
+			if i < 0 {
+				i += int64(len(x))
+			}
+			if i >= 0 && i < int64(len(x)) {
+				return x[i], nil
+			}
+			return undefined{hint: "list object has no element " + repr(key)}, nil
+		}
+	case string:
+		if i, ok := toInt(key); ok {
+			runes := []rune(x)
+			if i < 0 {
+				i += int64(len(runes))
+			}
+			if i >= 0 && i < int64(len(runes)) {
+				return string(runes[i]), nil
+			}
+			return undefined{hint: "str object has no element " + repr(key)}, nil
+		}
+	}
+	if s, ok := key.(string); ok {
+		return getAttr(obj, s)
+	}
+	return undefined{hint: fmt.Sprintf("'%s' has no attribute %s", describeObj(obj), repr(key))}, nil
+}
+
+func describeObj(v any) string {
+	switch v.(type) {
+	case nil:
+		return "None"
+	case *Dict:
+		return "dict object"
+	case []any:
+		return "list object"
+	case string:
+		return "str object"
+	}
+	return typeName(v) + " object"
+}
+
+// loopState backs the loop variable inside for loops.
+type loopState struct {
+	items []any
+	index int
+}
+
+func (l *loopState) attr(name string) (any, bool) {
+	n := len(l.items)
+	switch name {
+	case "index":
+		return int64(l.index + 1), true
+	case "index0":
+		return int64(l.index), true
+	case "revindex":
+		return int64(n - l.index), true
+	case "revindex0":
+		return int64(n - l.index - 1), true
+	case "first":
+		return l.index == 0, true
+	case "last":
+		return l.index == n-1, true
+	case "length":
+		return int64(n), true
+	case "depth":
+		return int64(1), true
+	case "depth0":
+		return int64(0), true
+	case "previtem":
+		if l.index == 0 {
+			return undefined{hint: "there is no previous item"}, true
+		}
+		return l.items[l.index-1], true
+	case "nextitem":
+		if l.index == n-1 {
+			return undefined{hint: "there is no next item"}, true
+		}
+		return l.items[l.index+1], true
+	case "cycle":
+		idx := l.index
+		return callable(func(args []any, _ *Dict) (any, error) {
+			if len(args) == 0 {
+				return nil, fmt.Errorf("no items for cycling given")
+			}
+			return args[idx%len(args)], nil
+		}), true
+	}
+	return nil, false
+}
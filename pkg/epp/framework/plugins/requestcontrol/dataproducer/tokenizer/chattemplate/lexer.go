@@ -0,0 +1,373 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type segmentKind int
+
+const (
+	segmentText segmentKind = iota
+	segmentOutput
+	segmentBlock
+)
+
+// segment is a run of literal text or the inside of a {{ }} / {% %} tag.
+type segment struct {
+	kind segmentKind
+	text string
+	line int
+}
+
+// lexTemplate splits a template into text and tag segments, dropping comments
+// and applying whitespace control the way transformers configures Jinja for
+// chat templates: trim_blocks and lstrip_blocks enabled, plus the explicit
+// "-" / "+" tag modifiers.
+func lexTemplate(src string) ([]segment, error) {
+	var segs []segment
+	pos, line := 0, 1
+	// lineStarting reports whether the previous tag consumed a newline, so
+	// text before the next block tag starts a line.
+	lineStarting := true
+	stripNext := false
+
+	for pos < len(src) {
+		start := nextTagStart(src, pos)
+		text := src[pos:start]
+		if stripNext {
+			text = strings.TrimLeftFunc(text, unicode.IsSpace)
+			stripNext = false
+		}
+		if start == len(src) {
+			segs = appendText(segs, text, line)
+			break
+		}
+
+		opener := src[start : start+2]
+		inner := start + 2
+		leftMod := byte(0)
+		if inner < len(src) && (src[inner] == '-' || src[inner] == '+') {
+			leftMod = src[inner]
+			inner++
+		}
+		switch {
+		case leftMod == '-':
+			text = strings.TrimRightFunc(text, unicode.IsSpace)
+		case opener != "{{" && leftMod != '+':
+			text = lstripLine(text, lineStarting)
+		}
+		segs = appendText(segs, text, line)
+		line += strings.Count(src[pos:start], "\n")
+
+		closer := map[string]string{"{{": "}}", "{%": "%}", "{#": "#}"}[opener]
+		end, err := findTagEnd(src, inner, closer, opener == "{#")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		body := src[inner:end]
+		rightMod := byte(0)
+		if n := len(body); n > 0 && (body[n-1] == '-' || body[n-1] == '+') {
+			rightMod = body[n-1]
+			body = body[:n-1]
+		}
+		line += strings.Count(src[start:end+2], "\n")
+		pos = end + 2
+
+		switch opener {
+		case "{{":
+			segs = append(segs, segment{kind: segmentOutput, text: strings.TrimSpace(body), line: line})
+		case "{%":
+			content := strings.TrimSpace(body)
+			if content == "raw" {
+				rawText, next, err := readRaw(src, pos)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				segs = appendText(segs, rawText, line)
+				line += strings.Count(src[pos:next], "\n")
+				pos = next
+				break
+			}
+			segs = append(segs, segment{kind: segmentBlock, text: content, line: line})
+		}
+
+		lineStarting = false
+		switch {
+		case rightMod == '-':
+			stripNext = true
+			rest := strings.TrimLeftFunc(src[pos:], unicode.IsSpace)
+			lineStarting = strings.HasSuffix(src[pos:len(src)-len(rest)], "\n")
+		case opener != "{{" && rightMod != '+':
+			// trim_blocks removes the first newline after a block or comment.
+			if strings.HasPrefix(src[pos:], "\n") {
+				pos++
+				line++
+				lineStarting = true
+			} else if strings.HasPrefix(src[pos:], "\r\n") {
+				pos += 2
+				line++
+				lineStarting = true
+			}
+		}
+	}
+	return segs, nil
+}
+
+func appendText(segs []segment, text string, line int) []segment {
+	if text == "" {
+		return segs
+	}
+	return append(segs, segment{kind: segmentText, text: text, line: line})
+}
+
+func nextTagStart(src string, pos int) int {
+	for i := pos; i+1 < len(src); i++ {
+		if src[i] == '{' && (src[i+1] == '{' || src[i+1] == '%' || src[i+1] == '#') {
+			return i
+		}
+	}
+	return len(src)
+}
+
+// lstripLine removes the spaces and tabs that precede a block tag on its
+// line (lstrip_blocks).
+func lstripLine(text string, lineStarting bool) string {
+	lineStart := strings.LastIndexByte(text, '\n') + 1
+	if lineStart == 0 && !lineStarting {
+		return text
+	}
+	if strings.TrimLeft(text[lineStart:], " \t") != "" {
+		return text
+	}
+	return text[:lineStart]
+}
+
+// findTagEnd returns the index of the closer, skipping over string literals
+// and bracketed sub-expressions such as nested dict literals.
+func findTagEnd(src string, pos int, closer string, comment bool) (int, error) {
+	if comment {
+		idx := strings.Index(src[pos:], closer)
+		if idx < 0 {
+			return 0, fmt.Errorf("unterminated comment")
+		}
+		return pos + idx, nil
+	}
+	var quote byte
+	depth := 0
+	for i := pos; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case depth == 0 && strings.HasPrefix(src[i:], closer):
+			return i, nil
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case (c == ')' || c == ']' || c == '}') && depth > 0:
+			depth--
+		}
+	}
+	return 0, fmt.Errorf("unterminated tag, expected %q", closer)
+}
+
+// readRaw returns the verbatim text up to the matching {% endraw %}.
+func readRaw(src string, pos int) (string, int, error) {
+	for i := pos; ; {
+		idx := strings.Index(src[i:], "{%")
+		if idx < 0 {
+			return "", 0, fmt.Errorf("unterminated raw block")
+		}
+		start := i + idx
+		end := strings.Index(src[start:], "%}")
+		if end < 0 {
+			return "", 0, fmt.Errorf("unterminated raw block")
+		}
+		body := strings.Trim(src[start+2:start+end], "-+ \t\r\n")
+		if body == "endraw" {
+			text := src[pos:start]
+			if src[start+2] == '-' {
+				text = strings.TrimRightFunc(text, unicode.IsSpace)
+			}
+			return text, start + end + 2, nil
+		}
+		i = start + 2
+	}
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokString
+	tokInt
+	tokFloat
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	num  float64
+	inum int64
+}
+
+// operators lists the expression operators, longest first.
+var operators = []string{
+	"**", "//", "==", "!=", "<=", ">=",
+	"+", "-", "*", "/", "%", "~", "<", ">", "=", "|", ".", ",", ":", "(", ")", "[", "]", "{", "}",
+}
+
+func lexExpr(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '_' || isLetter(c):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || isLetter(src[j]) || isDigit(src[j])) {
+				j++
+			}
+			toks = append(toks, token{kind: tokName, val: src[i:j]})
+			i = j
+		case isDigit(c):
+			j := i
+			isFloat := false
+			for j < len(src) && (isDigit(src[j]) || src[j] == '_') {
+				j++
+			}
+			if j+1 < len(src) && src[j] == '.' && isDigit(src[j+1]) {
+				isFloat = true
+				j++
+				for j < len(src) && (isDigit(src[j]) || src[j] == '_') {
+					j++
+				}
+			}
+			if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+				k := j + 1
+				if k < len(src) && (src[k] == '+' || src[k] == '-') {
+					k++
+				}
+				if k < len(src) && isDigit(src[k]) {
+					isFloat = true
+					for k < len(src) && isDigit(src[k]) {
+						k++
+					}
+					j = k
+				}
+			}
+			lit := strings.ReplaceAll(src[i:j], "_", "")
+			if isFloat {
+				f, err := strconv.ParseFloat(lit, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid number %q", lit)
+				}
+				toks = append(toks, token{kind: tokFloat, num: f})
+			} else {
+				n, err := strconv.ParseInt(lit, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid number %q", lit)
+				}
+				toks = append(toks, token{kind: tokInt, inum: n})
+			}
+			i = j
+		case c == '\'' || c == '"':
+			s, n, err := lexString(src[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokString, val: s})
+			i += n
+		default:
+			matched := false
+			for _, op := range operators {
+				if strings.HasPrefix(src[i:], op) {
+					toks = append(toks, token{kind: tokOp, val: op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+		}
+	}
+	return append(toks, token{kind: tokEOF}), nil
+}
+
+// lexString decodes a quoted string literal with Python escapes and returns
+// it with the number of bytes consumed.
+func lexString(src string) (string, int, error) {
+	quote := src[0]
+	var b strings.Builder
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		if c == quote {
+			return b.String(), i + 1, nil
+		}
+		if c != '\\' || i+1 >= len(src) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch e := src[i]; e {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '0':
+			b.WriteByte(0)
+		case '\\', '\'', '"':
+			b.WriteByte(e)
+		case 'x', 'u', 'U':
+			width := map[byte]int{'x': 2, 'u': 4, 'U': 8}[e]
+			if i+width >= len(src) {
+				return "", 0, fmt.Errorf("truncated \\%c escape", e)
+			}
+			r, err := strconv.ParseUint(src[i+1:i+1+width], 16, 32)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid \\%c escape", e)
+			}
+			b.WriteRune(rune(r))
+			i += width
+		case '\n':
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(e)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string literal")
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
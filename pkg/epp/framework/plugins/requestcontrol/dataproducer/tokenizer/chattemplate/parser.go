@@ -0,0 +1,963 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Statement nodes.
+type (
+	node any
+
+	textNode struct{ text string }
+
+	outputNode struct{ expr expr }
+
+	ifNode struct {
+		conds  []expr
+		bodies [][]node
+		orelse []node
+	}
+
+	forNode struct {
+		targets []string
+		iter    expr
+		filter  expr
+		body    []node
+		orelse  []node
+	}
+
+	// setNode assigns to names, a namespace attribute (attr != ""), or the
+	// rendered body of a block set when value is nil.
+	setNode struct {
+		targets []string
+		attr    string
+		value   expr
+		body    []node
+	}
+
+	macroNode struct {
+		name     string
+		params   []string
+		defaults []expr
+		body     []node
+	}
+
+	filterBlockNode struct {
+		filter *filterExpr
+		body   []node
+	}
+
+	// generationNode is transformers' {% generation %} block, which only
+	// marks assistant text and renders its body unchanged.
+	generationNode struct{ body []node }
+
+	breakNode    struct{}
+	continueNode struct{}
+)
+
+// Expression nodes.
+type (
+	expr any
+
+	literal  struct{ val any }
+	nameExpr struct{ name string }
+	attrExpr struct {
+		obj  expr
+		name string
+	}
+	itemExpr struct {
+		obj, key expr
+	}
+	sliceExpr struct {
+		obj, start, stop, step expr
+	}
+	callExpr struct {
+		fn     expr
+		args   []expr
+		kwargs []kwarg
+	}
+	filterExpr struct {
+		target expr
+		name   string
+		args   []expr
+		kwargs []kwarg
+	}
+	testExpr struct {
+		target expr
+		name   string
+		args   []expr
+		negate bool
+	}
+	unaryExpr struct {
+		op string
+		x  expr
+	}
+	binaryExpr struct {
+		op   string
+		l, r expr
+	}
+	condExpr struct {
+		cond, then, orelse expr
+	}
+	listExpr struct{ items []expr }
+	dictExpr struct{ keys, values []expr }
+
+	kwarg struct {
+		name  string
+		value expr
+	}
+)
+
+// parser turns lexed segments into a node tree.
+type parser struct {
+	segs []segment
+	pos  int
+}
+
+func parseTemplate(src string) ([]node, error) {
+	segs, err := lexTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{segs: segs}
+	nodes, end, err := p.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	if end != "" {
+		return nil, fmt.Errorf("line %d: unexpected {%% %s %%}", p.segs[p.pos-1].line, end)
+	}
+	return nodes, nil
+}
+
+// parseBody parses nodes until EOF or a block tag that closes or continues
+// an enclosing statement. It returns that tag's content, already consumed.
+func (p *parser) parseBody(stops ...string) ([]node, string, error) {
+	var nodes []node
+	for p.pos < len(p.segs) {
+		seg := p.segs[p.pos]
+		p.pos++
+		switch seg.kind {
+		case segmentText:
+			nodes = append(nodes, &textNode{text: seg.text})
+		case segmentOutput:
+			e, err := parseExprString(seg.text)
+			if err != nil {
+				return nil, "", fmt.Errorf("line %d: %w", seg.line, err)
+			}
+			nodes = append(nodes, &outputNode{expr: e})
+		case segmentBlock:
+			keyword, _, _ := strings.Cut(seg.text, " ")
+			for _, stop := range stops {
+				if keyword == stop {
+					return nodes, seg.text, nil
+				}
+			}
+			n, err := p.parseStatement(seg)
+			if err != nil {
+				return nil, "", fmt.Errorf("line %d: %w", seg.line, err)
+			}
+			nodes = append(nodes, n)
+		}
+	}
+	if len(stops) > 0 {
+		return nil, "", fmt.Errorf("unexpected end of template, expected one of %v", stops)
+	}
+	return nodes, "", nil
+}
+
+func (p *parser) parseStatement(seg segment) (node, error) {
+	toks, err := lexExpr(seg.text)
+	if err != nil {
+		return nil, err
+	}
+	ts := &tokenStream{toks: toks}
+	keyword := ts.next()
+	if keyword.kind != tokName {
+		return nil, fmt.Errorf("expected statement, got %q", seg.text)
+	}
+	switch keyword.val {
+	case "if":
+		return p.parseIf(ts)
+	case "for":
+		return p.parseFor(ts)
+	case "set":
+		return p.parseSet(ts)
+	case "macro":
+		return p.parseMacro(ts)
+	case "filter":
+		f, err := ts.parseFilter(nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := ts.expectEnd(); err != nil {
+			return nil, err
+		}
+		body, _, err := p.parseBody("endfilter")
+		if err != nil {
+			return nil, err
+		}
+		return &filterBlockNode{filter: f, body: body}, nil
+	case "generation":
+		body, _, err := p.parseBody("endgeneration")
+		if err != nil {
+			return nil, err
+		}
+		return &generationNode{body: body}, nil
+	case "break":
+		return &breakNode{}, ts.expectEnd()
+	case "continue":
+		return &continueNode{}, ts.expectEnd()
+	default:
+		return nil, fmt.Errorf("unknown statement %q", keyword.val)
+	}
+}
+
+func (p *parser) parseIf(ts *tokenStream) (node, error) {
+	n := &ifNode{}
+	for {
+		cond, err := ts.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := ts.expectEnd(); err != nil {
+			return nil, err
+		}
+		body, end, err := p.parseBody("elif", "else", "endif")
+		if err != nil {
+			return nil, err
+		}
+		n.conds = append(n.conds, cond)
+		n.bodies = append(n.bodies, body)
+		switch {
+		case end == "else":
+			if n.orelse, _, err = p.parseBody("endif"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case strings.HasPrefix(end, "elif"):
+			if ts, err = newTokenStream(strings.TrimPrefix(end, "elif")); err != nil {
+				return nil, err
+			}
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parseFor(ts *tokenStream) (node, error) {
+	n := &forNode{}
+	for {
+		name := ts.next()
+		if name.kind != tokName {
+			return nil, fmt.Errorf("expected loop variable")
+		}
+		n.targets = append(n.targets, name.val)
+		if !ts.skipOp(",") {
+			break
+		}
+	}
+	if !ts.skipName("in") {
+		return nil, fmt.Errorf("expected 'in' in for loop")
+	}
+	// The iterable stops before an inline "if" filter, so parse it without
+	// conditional expressions.
+	iter, err := ts.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	n.iter = iter
+	if ts.skipName("if") {
+		if n.filter, err = ts.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if ts.skipName("recursive") {
+		return nil, fmt.Errorf("recursive loops are not supported")
+	}
+	if err := ts.expectEnd(); err != nil {
+		return nil, err
+	}
+	body, end, err := p.parseBody("else", "endfor")
+	if err != nil {
+		return nil, err
+	}
+	n.body = body
+	if end == "else" {
+		if n.orelse, _, err = p.parseBody("endfor"); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) parseSet(ts *tokenStream) (node, error) {
+	n := &setNode{}
+	first := ts.next()
+	if first.kind != tokName {
+		return nil, fmt.Errorf("expected name after set")
+	}
+	n.targets = []string{first.val}
+	if ts.skipOp(".") {
+		attr := ts.next()
+		if attr.kind != tokName {
+			return nil, fmt.Errorf("expected attribute name")
+		}
+		n.attr = attr.val
+	} else {
+		for ts.skipOp(",") {
+			name := ts.next()
+			if name.kind != tokName {
+				return nil, fmt.Errorf("expected name in set")
+			}
+			n.targets = append(n.targets, name.val)
+		}
+	}
+	if ts.peek().kind == tokEOF {
+		if n.attr != "" || len(n.targets) != 1 {
+			return nil, fmt.Errorf("block set takes a single name")
+		}
+		body, _, err := p.parseBody("endset")
+		if err != nil {
+			return nil, err
+		}
+		n.body = body
+		return n, nil
+	}
+	if !ts.skipOp("=") {
+		return nil, fmt.Errorf("expected '=' in set")
+	}
+	value, err := ts.parseTuple()
+	if err != nil {
+		return nil, err
+	}
+	n.value = value
+	return n, ts.expectEnd()
+}
+
+func (p *parser) parseMacro(ts *tokenStream) (node, error) {
+	name := ts.next()
+	if name.kind != tokName {
+		return nil, fmt.Errorf("expected macro name")
+	}
+	n := &macroNode{name: name.val}
+	if !ts.skipOp("(") {
+		return nil, fmt.Errorf("expected '(' after macro name")
+	}
+	for !ts.skipOp(")") {
+		if len(n.params) > 0 && !ts.skipOp(",") {
+			return nil, fmt.Errorf("expected ',' in macro parameters")
+		}
+		param := ts.next()
+		if param.kind != tokName {
+			return nil, fmt.Errorf("expected parameter name")
+		}
+		n.params = append(n.params, param.val)
+		var def expr
+		if ts.skipOp("=") {
+			var err error
+			if def, err = ts.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		n.defaults = append(n.defaults, def)
+	}
+	if err := ts.expectEnd(); err != nil {
+		return nil, err
+	}
+	body, _, err := p.parseBody("endmacro")
+	if err != nil {
+		return nil, err
+	}
+	n.body = body
+	return n, nil
+}
+
+// tokenStream is a cursor over the tokens of one tag, with the expression
+// grammar following Jinja's operator precedence.
+type tokenStream struct {
+	toks []token
+	pos  int
+}
+
+func newTokenStream(src string) (*tokenStream, error) {
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenStream{toks: toks}, nil
+}
+
+func parseExprString(src string) (expr, error) {
+	ts, err := newTokenStream(src)
+	if err != nil {
+		return nil, err
+	}
+	e, err := ts.parseTuple()
+	if err != nil {
+		return nil, err
+	}
+	return e, ts.expectEnd()
+}
+
+func (ts *tokenStream) peek() token { return ts.toks[ts.pos] }
+
+func (ts *tokenStream) peekAt(n int) token {
+	if ts.pos+n < len(ts.toks) {
+		return ts.toks[ts.pos+n]
+	}
+	return token{kind: tokEOF}
+}
+
+func (ts *tokenStream) next() token {
+	t := ts.toks[ts.pos]
+	if t.kind != tokEOF {
+		ts.pos++
+	}
+	return t
+}
+
+func (ts *tokenStream) isOp(op string) bool {
+	t := ts.peek()
+	return t.kind == tokOp && t.val == op
+}
+
+func (ts *tokenStream) isName(name string) bool {
+	t := ts.peek()
+	return t.kind == tokName && t.val == name
+}
+
+func (ts *tokenStream) skipOp(op string) bool {
+	if ts.isOp(op) {
+		ts.pos++
+		return true
+	}
+	return false
+}
+
+func (ts *tokenStream) skipName(name string) bool {
+	if ts.isName(name) {
+		ts.pos++
+		return true
+	}
+	return false
+}
+
+func (ts *tokenStream) expectOp(op string) error {
+	if !ts.skipOp(op) {
+		return fmt.Errorf("expected %q, got %s", op, ts.peek().describe())
+	}
+	return nil
+}
+
+func (ts *tokenStream) expectEnd() error {
+	if t := ts.peek(); t.kind != tokEOF {
+		return fmt.Errorf("unexpected %s", t.describe())
+	}
+	return nil
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return fmt.Sprintf("string %q", t.val)
+	case tokInt:
+		return fmt.Sprintf("integer %d", t.inum)
+	case tokFloat:
+		return fmt.Sprintf("float %v", t.num)
+	default:
+		return fmt.Sprintf("%q", t.val)
+	}
+}
+
+// parseTuple parses an expression that may be an unparenthesized tuple.
+func (ts *tokenStream) parseTuple() (expr, error) {
+	first, err := ts.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if !ts.isOp(",") {
+		return first, nil
+	}
+	items := []expr{first}
+	for ts.skipOp(",") {
+		if ts.peek().kind == tokEOF {
+			break
+		}
+		item, err := ts.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &listExpr{items: items}, nil
+}
+
+func (ts *tokenStream) parseExpr() (expr, error) {
+	e, err := ts.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for ts.skipName("if") {
+		cond, err := ts.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		var orelse expr
+		if ts.skipName("else") {
+			if orelse, err = ts.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		e = &condExpr{cond: cond, then: e, orelse: orelse}
+	}
+	return e, nil
+}
+
+func (ts *tokenStream) parseOr() (expr, error) {
+	l, err := ts.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for ts.skipName("or") {
+		r, err := ts.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "or", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parseAnd() (expr, error) {
+	l, err := ts.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for ts.skipName("and") {
+		r, err := ts.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "and", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parseNot() (expr, error) {
+	if ts.skipName("not") {
+		x, err := ts.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "not", x: x}, nil
+	}
+	return ts.parseCompare()
+}
+
+func (ts *tokenStream) parseCompare() (expr, error) {
+	l, err := ts.parseMath1()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		t := ts.peek()
+		switch {
+		case t.kind == tokOp && (t.val == "==" || t.val == "!=" || t.val == "<" || t.val == "<=" || t.val == ">" || t.val == ">="):
+			op = t.val
+			ts.pos++
+		case ts.isName("in"):
+			op = "in"
+			ts.pos++
+		case ts.isName("not") && ts.peekAt(1).kind == tokName && ts.peekAt(1).val == "in":
+			op = "not in"
+			ts.pos += 2
+		default:
+			return l, nil
+		}
+		r, err := ts.parseMath1()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op, l: l, r: r}
+	}
+}
+
+func (ts *tokenStream) parseMath1() (expr, error) {
+	l, err := ts.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	for ts.isOp("+") || ts.isOp("-") {
+		op := ts.next().val
+		r, err := ts.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parseConcat() (expr, error) {
+	l, err := ts.parseMath2()
+	if err != nil {
+		return nil, err
+	}
+	for ts.skipOp("~") {
+		r, err := ts.parseMath2()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "~", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parseMath2() (expr, error) {
+	l, err := ts.parsePow()
+	if err != nil {
+		return nil, err
+	}
+	for ts.isOp("*") || ts.isOp("/") || ts.isOp("//") || ts.isOp("%") {
+		op := ts.next().val
+		r, err := ts.parsePow()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parsePow() (expr, error) {
+	l, err := ts.parseUnary(true)
+	if err != nil {
+		return nil, err
+	}
+	for ts.skipOp("**") {
+		r, err := ts.parseUnary(true)
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryExpr{op: "**", l: l, r: r}
+	}
+	return l, nil
+}
+
+func (ts *tokenStream) parseUnary(withFilter bool) (expr, error) {
+	var e expr
+	var err error
+	switch {
+	case ts.skipOp("-"):
+		x, err := ts.parseUnary(false)
+		if err != nil {
+			return nil, err
+		}
+		e = &unaryExpr{op: "-", x: x}
+	case ts.skipOp("+"):
+		x, err := ts.parseUnary(false)
+		if err != nil {
+			return nil, err
+		}
+		e = &unaryExpr{op: "+", x: x}
+	default:
+		if e, err = ts.parsePrimary(); err != nil {
+			return nil, err
+		}
+		if e, err = ts.parsePostfix(e); err != nil {
+			return nil, err
+		}
+	}
+	if withFilter {
+		return ts.parseFilterChain(e)
+	}
+	return e, nil
+}
+
+func (ts *tokenStream) parsePrimary() (expr, error) {
+	t := ts.next()
+	switch t.kind {
+	case tokName:
+		switch t.val {
+		case "true", "True":
+			return &literal{val: true}, nil
+		case "false", "False":
+			return &literal{val: false}, nil
+		case "none", "None":
+			return &literal{val: nil}, nil
+		}
+		return &nameExpr{name: t.val}, nil
+	case tokString:
+		s := t.val
+		// Adjacent string literals are concatenated.
+		for ts.peek().kind == tokString {
+			s += ts.next().val
+		}
+		return &literal{val: s}, nil
+	case tokInt:
+		return &literal{val: t.inum}, nil
+	case tokFloat:
+		return &literal{val: t.num}, nil
+	case tokOp:
+		switch t.val {
+		case "(":
+			if ts.skipOp(")") {
+				return &listExpr{}, nil
+			}
+			e, err := ts.parseTuple()
+			if err != nil {
+				return nil, err
+			}
+			return e, ts.expectOp(")")
+		case "[":
+			l := &listExpr{}
+			for !ts.skipOp("]") {
+				if len(l.items) > 0 {
+					if err := ts.expectOp(","); err != nil {
+						return nil, err
+					}
+					if ts.skipOp("]") {
+						break
+					}
+				}
+				item, err := ts.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				l.items = append(l.items, item)
+			}
+			return l, nil
+		case "{":
+			d := &dictExpr{}
+			for !ts.skipOp("}") {
+				if len(d.keys) > 0 {
+					if err := ts.expectOp(","); err != nil {
+						return nil, err
+					}
+					if ts.skipOp("}") {
+						break
+					}
+				}
+				k, err := ts.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				if err := ts.expectOp(":"); err != nil {
+					return nil, err
+				}
+				v, err := ts.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				d.keys = append(d.keys, k)
+				d.values = append(d.values, v)
+			}
+			return d, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", t.describe())
+}
+
+func (ts *tokenStream) parsePostfix(e expr) (expr, error) {
+	for {
+		switch {
+		case ts.skipOp("."):
+			t := ts.next()
+			switch t.kind {
+			case tokName:
+				e = &attrExpr{obj: e, name: t.val}
+			case tokInt:
+				e = &itemExpr{obj: e, key: &literal{val: t.inum}}
+			default:
+				return nil, fmt.Errorf("expected attribute name, got %s", t.describe())
+			}
+		case ts.skipOp("["):
+			sub, err := ts.parseSubscript(e)
+			if err != nil {
+				return nil, err
+			}
+			e = sub
+		case ts.isOp("("):
+			ts.pos++
+			args, kwargs, err := ts.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			e = &callExpr{fn: e, args: args, kwargs: kwargs}
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (ts *tokenStream) parseSubscript(obj expr) (expr, error) {
+	var parts [3]expr
+	idx := 0
+	isSlice := false
+	for {
+		if ts.skipOp("]") {
+			break
+		}
+		if ts.skipOp(":") {
+			isSlice = true
+			idx++
+			if idx > 2 {
+				return nil, fmt.Errorf("too many ':' in slice")
+			}
+			continue
+		}
+		e, err := ts.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		parts[idx] = e
+	}
+	if !isSlice {
+		if parts[0] == nil {
+			return nil, fmt.Errorf("empty subscript")
+		}
+		return &itemExpr{obj: obj, key: parts[0]}, nil
+	}
+	return &sliceExpr{obj: obj, start: parts[0], stop: parts[1], step: parts[2]}, nil
+}
+
+// parseArgs parses a call's arguments after the opening parenthesis.
+func (ts *tokenStream) parseArgs() ([]expr, []kwarg, error) {
+	var args []expr
+	var kwargs []kwarg
+	for !ts.skipOp(")") {
+		if len(args)+len(kwargs) > 0 {
+			if err := ts.expectOp(","); err != nil {
+				return nil, nil, err
+			}
+			if ts.skipOp(")") {
+				break
+			}
+		}
+		if t := ts.peek(); t.kind == tokName && ts.peekAt(1).kind == tokOp && ts.peekAt(1).val == "=" {
+			ts.pos += 2
+			v, err := ts.parseExpr()
+			if err != nil {
+				return nil, nil, err
+			}
+			kwargs = append(kwargs, kwarg{name: t.val, value: v})
+			continue
+		}
+		if len(kwargs) > 0 {
+			return nil, nil, fmt.Errorf("positional argument follows keyword argument")
+		}
+		v, err := ts.parseExpr()
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, v)
+	}
+	return args, kwargs, nil
+}
+
+func (ts *tokenStream) parseFilterChain(e expr) (expr, error) {
+	for {
+		switch {
+		case ts.skipOp("|"):
+			f, err := ts.parseFilter(e)
+			if err != nil {
+				return nil, err
+			}
+			e = f
+		case ts.skipName("is"):
+			t, err := ts.parseTest(e)
+			if err != nil {
+				return nil, err
+			}
+			e = t
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (ts *tokenStream) parseFilter(target expr) (*filterExpr, error) {
+	name := ts.next()
+	if name.kind != tokName {
+		return nil, fmt.Errorf("expected filter name, got %s", name.describe())
+	}
+	f := &filterExpr{target: target, name: name.val}
+	if ts.skipOp("(") {
+		var err error
+		if f.args, f.kwargs, err = ts.parseArgs(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (ts *tokenStream) parseTest(target expr) (expr, error) {
+	t := &testExpr{target: target, negate: ts.skipName("not")}
+	name := ts.next()
+	if name.kind != tokName {
+		return nil, fmt.Errorf("expected test name, got %s", name.describe())
+	}
+	t.name = name.val
+	// "none", "true" and "false" arrive as names, not literals.
+	switch {
+	case ts.skipOp("("):
+		args, kwargs, err := ts.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf("test %q does not take keyword arguments", t.name)
+		}
+		t.args = args
+	case ts.startsTestArg():
+		arg, err := ts.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if arg, err = ts.parsePostfix(arg); err != nil {
+			return nil, err
+		}
+		t.args = []expr{arg}
+	}
+	return t, nil
+}
+
+// startsTestArg reports whether a test is followed by a single bare argument,
+// as in "x is divisibleby 3".
+func (ts *tokenStream) startsTestArg() bool {
+	t := ts.peek()
+	switch t.kind {
+	case tokString, tokInt, tokFloat:
+		return true
+	case tokName:
+		switch t.val {
+		case "else", "or", "and", "if", "is", "in", "not":
+			return false
+		}
+		return true
+	case tokOp:
+		return t.val == "[" || t.val == "{"
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chattemplate renders Hugging Face chat templates. It implements the
+// subset of Jinja2 that chat templates use, with the environment settings and
+// helpers that transformers installs (trim_blocks, lstrip_blocks, loop
+// controls, tojson without HTML escaping, raise_exception, strftime_now and
+// the {% generation %} block). Autoescaping, template inheritance, includes
+// and recursive loops are not supported.
+//
+// The existing Go template engines do not render published chat templates:
+// gonja v1.5.3 fails to parse the Llama 3 template ({% set %} with a string
+// concatenation) and renders the Qwen 2.5 template without its messages, and
+// pongo2 implements Django syntax and rejects the escape sequences and
+// multi-line string literals both templates use. Rendering must match
+// transformers byte for byte for the token IDs to match vLLM, so the package
+// carries its own engine, limited to what chat templates need.
+package chattemplate
+
+import (
+	"fmt"
+)
+
+// Template is a parsed chat template. It is safe for concurrent use.
+type Template struct {
+	nodes []node
+}
+
+// TemplateError is raised by the raise_exception helper, typically to reject
+// a conversation the model does not support.
+type TemplateError struct {
+	Message string
+}
+
+func (e *TemplateError) Error() string {
+	return e.Message
+}
+
+// Parse compiles a template source.
+func Parse(src string) (*Template, error) {
+	nodes, err := parseTemplate(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse chat template: %w", err)
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// Render executes the template. Values in vars are converted with FromGo.
+func (t *Template) Render(vars map[string]any) (string, error) {
+	root := newFrame(nil)
+	for k, v := range vars {
+		root.vars[k] = FromGo(v)
+	}
+	r := &renderer{}
+	if err := r.exec(t.nodes, root); err != nil {
+		return "", fmt.Errorf("failed to render chat template: %w", err)
+	}
+	return r.out.String(), nil
+}
+
+// IteratesOverContent reports whether the template loops over a message's
+// "content" field, meaning it expects OpenAI-style lists of content parts
+// rather than plain strings. This mirrors the content-format detection vLLM
+// applies to chat templates.
+func (t *Template) IteratesOverContent() bool {
+	contentVars := map[string]bool{}
+	found := false
+	var walk func(nodes []node)
+	walk = func(nodes []node) {
+		for _, n := range nodes {
+			if found {
+				return
+			}
+			switch n := n.(type) {
+			case *setNode:
+				if n.value != nil && len(n.targets) == 1 && n.attr == "" && accessesContent(n.value, contentVars) {
+					contentVars[n.targets[0]] = true
+				}
+			case *forNode:
+				if accessesContent(n.iter, contentVars) {
+					found = true
+					return
+				}
+				walk(n.body)
+				walk(n.orelse)
+			case *ifNode:
+				for _, body := range n.bodies {
+					walk(body)
+				}
+				walk(n.orelse)
+			case *macroNode:
+				walk(n.body)
+			case *filterBlockNode:
+				walk(n.body)
+			case *generationNode:
+				walk(n.body)
+			}
+		}
+	}
+	walk(t.nodes)
+	return found
+}
+
+// accessesContent reports whether e reads a "content" field directly or
+// through filters, or names a variable previously assigned from one.
+func accessesContent(e expr, contentVars map[string]bool) bool {
+	switch e := e.(type) {
+	case *attrExpr:
+		return e.name == "content"
+	case *itemExpr:
+		lit, ok := e.key.(*literal)
+		return ok && lit.val == "content"
+	case *filterExpr:
+		return accessesContent(e.target, contentVars)
+	case *nameExpr:
+		return contentVars[e.name]
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, src string, vars map[string]any) string {
+	t.Helper()
+	tpl, err := Parse(src)
+	require.NoError(t, err)
+	out, err := tpl.Render(vars)
+	require.NoError(t, err)
+	return out
+}
+
+func loadTemplate(t *testing.T, name string) *Template {
+	t.Helper()
+	src, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	tpl, err := Parse(string(src))
+	require.NoError(t, err)
+	return tpl
+}
+
+func TestRender(t *testing.T) {
+	messages := []any{
+		map[string]any{"role": "user", "content": "hi"},
+		map[string]any{"role": "assistant", "content": "yo"},
+	}
+	tests := []struct {
+		name string
+		src  string
+		vars map[string]any
+		want string
+	}{
+		{
+			name: "trim and lstrip blocks",
+			src:  "{% for m in messages %}\n  {{ m.role }}: {{ m.content }}\n{% endfor %}\nend",
+			vars: map[string]any{"messages": messages},
+			want: "  user: hi\n  assistant: yo\nend",
+		},
+		{
+			name: "indented block tags",
+			src:  "  {% if true %}\n    yes\n  {% endif %}\n",
+			want: "    yes\n",
+		},
+		{
+			name: "explicit whitespace control",
+			src:  "a  {%- if true -%}  b  {%- endif -%}  \n c",
+			want: "abc",
+		},
+		{
+			name: "plus disables lstrip",
+			src:  "x\n  {%+ if true %}y{% endif %}",
+			want: "x\n  y",
+		},
+		{
+			name: "comments and raw",
+			src:  "{# note #}\n{% raw %}{{ not evaluated }}{% endraw %}",
+			want: "{{ not evaluated }}",
+		},
+		{
+			name: "namespace and loop filter",
+			src:  "{%- set ns = namespace(n=0) -%}{%- for m in messages if m.role == 'user' -%}{% set ns.n = ns.n + 1 %}{%- endfor -%}{{ ns.n }}",
+			vars: map[string]any{"messages": messages},
+			want: "1",
+		},
+		{
+			name: "loop variables and controls",
+			src:  "{% for x in [1, 2, 3, 4] %}{% if x == 2 %}{% continue %}{% elif x == 4 %}{% break %}{% endif %}{{ loop.index }}{{ loop.cycle('a', 'b') }}{{ ',' if not loop.last }}{% endfor %}",
+			want: "1a,3a,",
+		},
+		{
+			name: "for else",
+			src:  "{% for x in [] %}{{ x }}{% else %}empty{% endfor %}",
+			want: "empty",
+		},
+		{
+			name: "tuple unpacking",
+			src:  "{% for k, v in d.items() %}{{ k }}={{ v }};{% endfor %}{{ d.missing is undefined }}",
+			vars: map[string]any{"d": map[string]any{"b": 2, "a": "x"}},
+			want: "a=x;b=2;True",
+		},
+		{
+			name: "arithmetic",
+			src:  "{{ 7 // 2 }} {{ -7 // 2 }} {{ -7 % 3 }} {{ 1 / 2 }} {{ 2 ** 10 }} {{ (messages | length) - 1 }} {{ 'ab' * 2 }}",
+			vars: map[string]any{"messages": messages},
+			want: "3 -4 2 0.5 1024 1 abab",
+		},
+		{
+			name: "python reprs",
+			src:  "{{ [1, 'a', none, true, 1.5] }} {{ {'k': \"it's\"} }} {{ 3.0 }} {{ 1e20 }}",
+			want: "[1, 'a', None, True, 1.5] {'k': \"it's\"} 3.0 1e+20",
+		},
+		{
+			name: "tojson keeps literal order",
+			src:  "{{ {'name': 'f', 'arguments': {'b': 1, 'a': [true, none, 'é\"']}} | tojson }}",
+			want: `{"name": "f", "arguments": {"b": 1, "a": [true, null, "é\""]}}`,
+		},
+		{
+			name: "tojson indent",
+			src:  "{{ {'a': [1], 'b': {}} | tojson(indent=2) }}",
+			want: "{\n  \"a\": [\n    1\n  ],\n  \"b\": {}\n}",
+		},
+		{
+			name: "slices and string methods",
+			src:  "{{ messages[::-1] | map(attribute='role') | join(',') }}|{{ 'a</think>b'.split('</think>')[-1] }}|{{ '\\n x \\n'.strip() }}|{{ 'abc'[1:] }}|{{ 'Hi'.startswith(('x', 'H')) }}",
+			vars: map[string]any{"messages": messages},
+			want: "assistant,user|b|x|bc|True",
+		},
+		{
+			name: "conditional expressions and tests",
+			src:  "{{ 'x' if false }}|{{ messages[5] is defined }}|{{ none is none }}|{{ 'a' is string }}|{{ 4 is divisibleby 2 }}|{{ 3 is not even }}",
+			vars: map[string]any{"messages": messages},
+			want: "|False|True|True|True|True",
+		},
+		{
+			name: "filters",
+			src:  "{{ missing | default('d') }}|{{ '' | default('e', true) }}|{{ [3, 1, 2] | sort | join }}|{{ [1, 1, 2] | unique | list | length }}|{{ ' a ' | trim | upper }}|{{ messages | selectattr('role', 'equalto', 'user') | list | length }}|{{ messages | rejectattr('content') | list | length }}",
+			vars: map[string]any{"messages": messages},
+			want: "d|e|123|2|A|1|0",
+		},
+		{
+			name: "macros",
+			src:  "{% macro f(x, y='d') %}[{{ x }}{{ y }}]{% endmacro %}{{ f(1) }}{{ f(2, y=3) }}",
+			want: "[1d][23]",
+		},
+		{
+			name: "block set and filter block",
+			src:  "{% set greeting %}hello {{ name }}{% endset %}{{ greeting }}|{% filter upper %}{{ greeting }}{% endfilter %}",
+			vars: map[string]any{"name": "bob"},
+			want: "hello bob|HELLO BOB",
+		},
+		{
+			name: "loop scoping",
+			src:  "{% set x = 1 %}{% for i in range(3) %}{% set x = i %}{% endfor %}{{ x }}",
+			want: "1",
+		},
+		{
+			name: "generation block",
+			src:  "{% generation %}text{% endgeneration %}",
+			want: "text",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, render(t, tt.src, tt.vars))
+		})
+	}
+}
+
+func TestRender_Llama3(t *testing.T) {
+	out, err := loadTemplate(t, "llama3.jinja").Render(map[string]any{
+		"messages": []any{
+			map[string]any{"role": "system", "content": "You are helpful. "},
+			map[string]any{"role": "user", "content": " Hi"},
+		},
+		"bos_token":             "<|begin_of_text|>",
+		"add_generation_prompt": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "<|begin_of_text|><|start_header_id|>system<|end_header_id|>\n\nYou are helpful.<|eot_id|>"+
+		"<|start_header_id|>user<|end_header_id|>\n\nHi<|eot_id|>"+
+		"<|start_header_id|>assistant<|end_header_id|>\n\n", out)
+}
+
+func TestRender_QwenTools(t *testing.T) {
+	out, err := loadTemplate(t, "qwen2.5.jinja").Render(map[string]any{
+		"messages": []any{
+			map[string]any{"role": "user", "content": "Weather in Paris?"},
+			map[string]any{"role": "assistant", "content": "", "tool_calls": []any{
+				map[string]any{"id": "call_1", "type": "function", "function": map[string]any{
+					"name": "get_weather", "arguments": map[string]any{"city": "Paris"},
+				}},
+			}},
+			map[string]any{"role": "tool", "content": "sunny"},
+		},
+		"tools": []any{
+			map[string]any{"type": "function", "function": map[string]any{
+				"name":        "get_weather",
+				"description": "Get weather",
+				"parameters": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"city": map[string]any{"type": "string"}},
+				},
+			}},
+		},
+		"add_generation_prompt": true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "<|im_start|>system\nYou are Qwen, created by Alibaba Cloud. You are a helpful assistant.\n\n# Tools\n\n"+
+		"You may call one or more functions to assist with the user query.\n\n"+
+		"You are provided with function signatures within <tools></tools> XML tags:\n<tools>\n"+
+		`{"function": {"description": "Get weather", "name": "get_weather", "parameters": {"properties": {"city": {"type": "string"}}, "type": "object"}}, "type": "function"}`+
+		"\n</tools>\n\nFor each function call, return a json object with function name and arguments within <tool_call></tool_call> XML tags:\n"+
+		"<tool_call>\n{\"name\": <function-name>, \"arguments\": <args-json-object>}\n</tool_call><|im_end|>\n"+
+		"<|im_start|>user\nWeather in Paris?<|im_end|>\n"+
+		"<|im_start|>assistant\n<tool_call>\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Paris\"}}\n</tool_call><|im_end|>\n"+
+		"<|im_start|>user\n<tool_response>\nsunny\n</tool_response><|im_end|>\n"+
+		"<|im_start|>assistant\n", out)
+}
+
+func TestRender_StrftimeNow(t *testing.T) {
+	now = func() time.Time { return time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+	assert.Equal(t, "05 Mar 2026", render(t, `{{ date_string | default(strftime_now("%d %b %Y")) }}`, nil))
+}
+
+func TestRender_Errors(t *testing.T) {
+	tpl, err := Parse("{{ raise_exception('Conversation roles must alternate') }}")
+	require.NoError(t, err)
+	_, err = tpl.Render(nil)
+	var templateErr *TemplateError
+	require.True(t, errors.As(err, &templateErr))
+	assert.Equal(t, "Conversation roles must alternate", templateErr.Message)
+
+	tpl, err = Parse("{{ message.content }}")
+	require.NoError(t, err)
+	_, err = tpl.Render(nil)
+	assert.ErrorContains(t, err, "'message' is undefined")
+
+	tpl, err = Parse("{{ messages.append(1) }}")
+	require.NoError(t, err)
+	_, err = tpl.Render(map[string]any{"messages": []any{}})
+	assert.ErrorContains(t, err, "unsafe")
+
+	for _, src := range []string{
+		"{% if x %}unterminated",
+		"{{ x ",
+		"{% endfor %}",
+		"{% unknown %}",
+		"{{ x | }}",
+		"{{ 'unterminated }}",
+	} {
+		_, err := Parse(src)
+		assert.Error(t, err, src)
+	}
+}
+
+func TestIteratesOverContent(t *testing.T) {
+	openai := []string{
+		"{% for m in messages %}{% for part in m['content'] %}{{ part.text }}{% endfor %}{% endfor %}",
+		"{% for m in messages %}{% set c = m.content %}{% if c is string %}{{ c }}{% else %}{% for p in c %}{{ p.text }}{% endfor %}{% endif %}{% endfor %}",
+	}
+	for _, src := range openai {
+		tpl, err := Parse(src)
+		require.NoError(t, err)
+		assert.True(t, tpl.IteratesOverContent(), src)
+	}
+	assert.False(t, loadTemplate(t, "qwen2.5.jinja").IteratesOverContent())
+	assert.False(t, loadTemplate(t, "llama3.jinja").IteratesOverContent())
+}
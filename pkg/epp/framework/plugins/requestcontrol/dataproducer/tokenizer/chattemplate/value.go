@@ -0,0 +1,611 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chattemplate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Runtime values are nil (None), bool, int64, float64, string, []any,
+// *Dict, undefined, *namespace, *loopState, callable and boundMethod.
+
+// Dict is an insertion-ordered mapping with string keys, standing in for a
+// Python dict so that dict literals keep their order when serialized.
+type Dict struct {
+	keys []string
+	m    map[string]any
+}
+
+// NewDict returns an empty Dict.
+func NewDict() *Dict {
+	return &Dict{m: map[string]any{}}
+}
+
+// Set inserts or replaces a key, keeping the original position on replace.
+func (d *Dict) Set(key string, v any) {
+	if _, ok := d.m[key]; !ok {
+		d.keys = append(d.keys, key)
+	}
+	d.m[key] = v
+}
+
+// Get returns the value of a key.
+func (d *Dict) Get(key string) (any, bool) {
+	v, ok := d.m[key]
+	return v, ok
+}
+
+// Keys returns the keys in insertion order.
+func (d *Dict) Keys() []string {
+	return d.keys
+}
+
+func (d *Dict) len() int { return len(d.keys) }
+
+// undefined is the value of a missing variable, attribute or item. It
+// renders as an empty string but fails on attribute access.
+type undefined struct {
+	hint string
+}
+
+func (u undefined) err() error {
+	return fmt.Errorf("%s", u.hint)
+}
+
+type namespace struct {
+	attrs *Dict
+}
+
+type callable func(args []any, kwargs *Dict) (any, error)
+
+type boundMethod struct {
+	recv any
+	name string
+}
+
+// FromGo converts decoded JSON and plain Go values into runtime values.
+// Maps become Dicts with sorted keys since Go maps carry no order; callers
+// that know the intended order can pass Dicts instead. Integral floats become
+// integers so that JSON numbers print like Python's.
+func FromGo(v any) any {
+	switch x := v.(type) {
+	case nil, bool, int64, string:
+		return x
+	case *Dict:
+		d := NewDict()
+		for _, k := range x.keys {
+			d.Set(k, FromGo(x.m[k]))
+		}
+		return d
+	case int:
+		return int64(x)
+	case int32:
+		return int64(x)
+	case uint32:
+		return int64(x)
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return int64(x)
+		}
+		return x
+	case float32:
+		return FromGo(float64(x))
+	case json.Number:
+		if n, err := x.Int64(); err == nil {
+			return n
+		}
+		f, _ := x.Float64()
+		return f
+	case []any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = FromGo(e)
+		}
+		return out
+	case []string:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = e
+		}
+		return out
+	case []map[string]any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = FromGo(e)
+		}
+		return out
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		d := NewDict()
+		for _, k := range keys {
+			d.Set(k, FromGo(x[k]))
+		}
+		return d
+	default:
+		// Fall back to a JSON round trip for structs and other typed values.
+		data, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return fmt.Sprint(x)
+		}
+		return FromGo(decoded)
+	}
+}
+
+func truthy(v any) bool {
+	switch x := v.(type) {
+	case nil, undefined:
+		return false
+	case bool:
+		return x
+	case int64:
+		return x != 0
+	case float64:
+		return x != 0
+	case string:
+		return x != ""
+	case []any:
+		return len(x) > 0
+	case *Dict:
+		return x.len() > 0
+	default:
+		return true
+	}
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "NoneType"
+	case bool:
+		return "bool"
+	case int64:
+		return "int"
+	case float64:
+		return "float"
+	case string:
+		return "str"
+	case []any:
+		return "list"
+	case *Dict:
+		return "dict"
+	case undefined:
+		return "Undefined"
+	case *namespace:
+		return "Namespace"
+	case *loopState:
+		return "LoopContext"
+	default:
+		return "function"
+	}
+}
+
+// toStr renders a value as Python's str() would.
+func toStr(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case undefined:
+		return ""
+	default:
+		return repr(v)
+	}
+}
+
+// repr renders a value as Python's repr() would.
+func repr(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if x {
+			return "True"
+		}
+		return "False"
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return formatFloat(x)
+	case string:
+		return quoteString(x)
+	case undefined:
+		return ""
+	case []any:
+		parts := make([]string, len(x))
+		for i, e := range x {
+			parts[i] = repr(e)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *Dict:
+		parts := make([]string, 0, x.len())
+		for _, k := range x.keys {
+			parts = append(parts, quoteString(k)+": "+repr(x.m[k]))
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case *namespace:
+		return "<Namespace " + repr(x.attrs) + ">"
+	default:
+		return "<" + typeName(v) + ">"
+	}
+}
+
+// formatFloat matches Python's float repr: shortest round-trip digits,
+// always with a decimal point or exponent.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	abs := math.Abs(f)
+	if abs != 0 && (abs < 1e-4 || abs >= 1e16) {
+		s := strconv.FormatFloat(f, 'e', -1, 64)
+		mant, exp, _ := strings.Cut(s, "e")
+		sign := exp[0]
+		exp = strings.TrimLeft(exp[1:], "0")
+		if len(exp) < 2 {
+			exp = strings.Repeat("0", 2-len(exp)) + exp
+		}
+		return mant + "e" + string(sign) + exp
+	}
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+// quoteString quotes a string the way Python's repr() does.
+func quoteString(s string) string {
+	quote := byte('\'')
+	if strings.Contains(s, "'") && !strings.Contains(s, "\"") {
+		quote = '"'
+	}
+	var b strings.Builder
+	b.WriteByte(quote)
+	for _, r := range s {
+		switch {
+		case r == rune(quote) || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte(quote)
+	return b.String()
+}
+
+// toJSON serializes a value like Python's json.dumps with ensure_ascii off,
+// which is how transformers implements the tojson filter for chat templates.
+func toJSON(v any, indent string) (string, error) {
+	var b strings.Builder
+	if err := writeJSON(&b, v, indent, 0); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func writeJSON(b *strings.Builder, v any, indent string, depth int) error {
+	itemSep, keySep := ", ", ": "
+	if indent != "" {
+		itemSep = ","
+	}
+	newline := func(level int) {
+		if indent != "" {
+			b.WriteByte('\n')
+			b.WriteString(strings.Repeat(indent, level))
+		}
+	}
+	switch x := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(x))
+	case int64:
+		b.WriteString(strconv.FormatInt(x, 10))
+	case float64:
+		switch {
+		case math.IsNaN(x):
+			b.WriteString("NaN")
+		case math.IsInf(x, 1):
+			b.WriteString("Infinity")
+		case math.IsInf(x, -1):
+			b.WriteString("-Infinity")
+		default:
+			b.WriteString(formatFloat(x))
+		}
+	case string:
+		writeJSONString(b, x)
+	case []any:
+		if len(x) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		b.WriteByte('[')
+		for i, e := range x {
+			if i > 0 {
+				b.WriteString(itemSep)
+			}
+			newline(depth + 1)
+			if err := writeJSON(b, e, indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		b.WriteByte(']')
+	case *Dict:
+		if x.len() == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+		b.WriteByte('{')
+		for i, k := range x.keys {
+			if i > 0 {
+				b.WriteString(itemSep)
+			}
+			newline(depth + 1)
+			writeJSONString(b, k)
+			b.WriteString(keySep)
+			if err := writeJSON(b, x.m[k], indent, depth+1); err != nil {
+				return err
+			}
+		}
+		newline(depth)
+		b.WriteByte('}')
+	case *namespace:
+		return writeJSON(b, x.attrs, indent, depth)
+	default:
+		return fmt.Errorf("object of type %s is not JSON serializable", typeName(v))
+	}
+	return nil
+}
+
+func writeJSONString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+}
+
+// iterate returns the items a for loop or filter walks over.
+func iterate(v any) ([]any, error) {
+	switch x := v.(type) {
+	case []any:
+		return x, nil
+	case *Dict:
+		out := make([]any, len(x.keys))
+		for i, k := range x.keys {
+			out[i] = k
+		}
+		return out, nil
+	case string:
+		out := make([]any, 0, len(x))
+		for _, r := range x {
+			out = append(out, string(r))
+		}
+		return out, nil
+	case undefined:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("'%s' object is not iterable", typeName(v))
+	}
+}
+
+func length(v any) (int, error) {
+	switch x := v.(type) {
+	case string:
+		return utf8.RuneCountInString(x), nil
+	case []any:
+		return len(x), nil
+	case *Dict:
+		return x.len(), nil
+	case undefined:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("object of type '%s' has no len()", typeName(v))
+	}
+}
+
+func equal(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			return fa == fb
+		}
+		return false
+	}
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case string:
+		y, ok := b.(string)
+		return ok && x == y
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case *Dict:
+		y, ok := b.(*Dict)
+		if !ok || x.len() != y.len() {
+			return false
+		}
+		for _, k := range x.keys {
+			yv, ok := y.m[k]
+			if !ok || !equal(x.m[k], yv) {
+				return false
+			}
+		}
+		return true
+	case undefined:
+		_, ok := b.(undefined)
+		return ok
+	default:
+		return a == b
+	}
+}
+
+// toFloat reports the numeric value of bools and numbers, as Python treats
+// bool as an int subtype.
+func toFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+func toInt(v any) (int64, bool) {
+	switch x := v.(type) {
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	case int64:
+		return x, true
+	}
+	return 0, false
+}
+
+func compare(a, b any) (int, error) {
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			switch {
+			case fa < fb:
+				return -1, nil
+			case fa > fb:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			return strings.Compare(sa, sb), nil
+		}
+	}
+	if la, ok := a.([]any); ok {
+		if lb, ok := b.([]any); ok {
+			for i := 0; i < len(la) && i < len(lb); i++ {
+				if c, err := compare(la[i], lb[i]); err != nil || c != 0 {
+					return c, err
+				}
+			}
+			return compareInts(len(la), len(lb)), nil
+		}
+	}
+	return 0, fmt.Errorf("'<' not supported between instances of '%s' and '%s'", typeName(a), typeName(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func contains(container, item any) (bool, error) {
+	switch c := container.(type) {
+	case string:
+		s, ok := item.(string)
+		if !ok {
+			return false, fmt.Errorf("'in <string>' requires string as left operand, not %s", typeName(item))
+		}
+		return strings.Contains(c, s), nil
+	case []any:
+		for _, e := range c {
+			if equal(e, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case *Dict:
+		s, ok := item.(string)
+		if !ok {
+			return false, nil
+		}
+		_, found := c.m[s]
+		return found, nil
+	case undefined:
+		return false, nil
+	default:
+		return false, fmt.Errorf("argument of type '%s' is not iterable", typeName(container))
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// addedToken is an entry of tokenizer.json's added_tokens list. Added tokens
+// are carved out of the input before normalization and never reach the model.
+type addedToken struct {
+	ID         uint32 `json:"id"`
+	Content    string `json:"content"`
+	SingleWord bool   `json:"single_word"`
+	LStrip     bool   `json:"lstrip"`
+	RStrip     bool   `json:"rstrip"`
+	Normalized bool   `json:"normalized"`
+	Special    bool   `json:"special"`
+}
+
+// segment is either plain text or an already resolved added token.
+type segment struct {
+	text    string
+	offset  int
+	id      uint32
+	isToken bool
+}
+
+// addedMatcher finds added tokens in text, preferring the leftmost and then
+// the longest match like the upstream Aho-Corasick automaton.
+type addedMatcher struct {
+	// byFirstByte lists candidate tokens by first byte, longest first.
+	byFirstByte map[byte][]matchToken
+}
+
+type matchToken struct {
+	content string
+	token   addedToken
+}
+
+func newAddedMatcher(tokens []matchToken) *addedMatcher {
+	m := &addedMatcher{byFirstByte: map[byte][]matchToken{}}
+	for _, t := range tokens {
+		if t.content == "" {
+			continue
+		}
+		m.byFirstByte[t.content[0]] = append(m.byFirstByte[t.content[0]], t)
+	}
+	for _, list := range m.byFirstByte {
+		sort.SliceStable(list, func(i, j int) bool { return len(list[i].content) > len(list[j].content) })
+	}
+	return m
+}
+
+func (m *addedMatcher) empty() bool {
+	return m == nil || len(m.byFirstByte) == 0
+}
+
+// split carves added tokens out of s. Segments carry offsets relative to
+// base, the offset of s in the original input.
+func (m *addedMatcher) split(s string, base int) []segment {
+	if m.empty() {
+		return []segment{{text: s, offset: base}}
+	}
+	out, _ := m.scan(s, 0)
+	if base != 0 {
+		for i := range out {
+			out[i].offset += base
+		}
+	}
+	return out
+}
+
+// scan carves added tokens out of s[from:], where from is 0 or the end of an
+// added token in s. Segment offsets are relative to s. stable lists the
+// indices of the token segments whose matching, and that of everything
+// before them, looked at no byte past their end: any text starting with the
+// same bytes up to that end splits the same way up to there.
+func (m *addedMatcher) scan(s string, from int) (out []segment, stable []int) {
+	if m.empty() {
+		if from < len(s) {
+			out = append(out, segment{text: s[from:], offset: from})
+		}
+		return out, nil
+	}
+	// horizon is one past the furthest byte any matching decision examined,
+	// counting the end of s as a byte.
+	horizon := from
+	textStart := from
+	for pos := from; pos < len(s); {
+		start, stop, tok, seen, ok := m.matchAt(s, pos, textStart)
+		horizon = max(horizon, seen)
+		if !ok {
+			pos++
+			continue
+		}
+		if start > textStart {
+			out = append(out, segment{text: s[textStart:start], offset: textStart})
+		}
+		out = append(out, segment{id: tok.ID, isToken: true, offset: start})
+		if horizon <= stop {
+			stable = append(stable, len(out)-1)
+		}
+		textStart, pos = stop, stop
+	}
+	if textStart < len(s) {
+		out = append(out, segment{text: s[textStart:], offset: textStart})
+	}
+	return out, stable
+}
+
+// matchAt returns the longest acceptable added token starting at pos, with
+// its lstrip/rstrip whitespace absorbed into [start, stop). seen is one past
+// the furthest byte it examined.
+func (m *addedMatcher) matchAt(s string, pos, floor int) (start, stop int, tok addedToken, seen int, ok bool) {
+	seen = pos + 1
+	for _, cand := range m.byFirstByte[s[pos]] {
+		if n := commonPrefixLen(s[pos:], cand.content); n < len(cand.content) {
+			// The first mismatching byte, or the end of s, decided it.
+			seen = max(seen, pos+n+1)
+			continue
+		}
+		start, stop = pos, pos+len(cand.content)
+		seen = max(seen, stop)
+		if cand.token.SingleWord {
+			before, _ := utf8.DecodeLastRuneInString(s[:start])
+			after, size := utf8.DecodeRuneInString(s[stop:])
+			seen = max(seen, stop+max(size, 1))
+			if (start > 0 && isWordRune(before)) || (stop < len(s) && isWordRune(after)) {
+				continue
+			}
+		}
+		if cand.token.LStrip {
+			start = max(len(strings.TrimRightFunc(s[:start], unicode.IsSpace)), floor)
+		}
+		if cand.token.RStrip {
+			stop = len(s) - len(strings.TrimLeftFunc(s[stop:], unicode.IsSpace))
+			seen = max(seen, stop+1)
+		}
+		return start, stop, cand.token, seen, true
+	}
+	return 0, 0, addedToken{}, seen, false
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || unicode.Is(unicode.M, r)
+}
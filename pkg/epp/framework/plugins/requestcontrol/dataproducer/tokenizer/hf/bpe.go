@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	// bpeCacheSize bounds the per-word merge cache.
+	bpeCacheSize = 10000
+	// bpeCacheMaxWordLen skips caching of long words, which are rarely
+	// repeated and would crowd out the common ones.
+	bpeCacheMaxWordLen = 256
+)
+
+type pair struct {
+	left, right uint32
+}
+
+type mergeRule struct {
+	rank  int
+	newID uint32
+}
+
+// bpe is a byte-pair-encoding model.
+type bpe struct {
+	vocab        map[string]uint32
+	merges       map[pair]mergeRule
+	unkToken     string
+	prefix       string
+	suffix       string
+	fuseUnk      bool
+	byteFallback bool
+	ignoreMerges bool
+	cache        *lru.Cache[string, []uint32]
+}
+
+func parseBPE(raw json.RawMessage) (*bpe, error) {
+	var cfg struct {
+		Vocab                   map[string]uint32 `json:"vocab"`
+		Merges                  []json.RawMessage `json:"merges"`
+		UnkToken                *string           `json:"unk_token"`
+		ContinuingSubwordPrefix *string           `json:"continuing_subword_prefix"`
+		EndOfWordSuffix         *string           `json:"end_of_word_suffix"`
+		FuseUnk                 bool              `json:"fuse_unk"`
+		ByteFallback            bool              `json:"byte_fallback"`
+		IgnoreMerges            bool              `json:"ignore_merges"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid BPE model: %w", err)
+	}
+	m := &bpe{
+		vocab:        cfg.Vocab,
+		merges:       make(map[pair]mergeRule, len(cfg.Merges)),
+		unkToken:     deref(cfg.UnkToken),
+		prefix:       deref(cfg.ContinuingSubwordPrefix),
+		suffix:       deref(cfg.EndOfWordSuffix),
+		fuseUnk:      cfg.FuseUnk,
+		byteFallback: cfg.ByteFallback,
+		ignoreMerges: cfg.IgnoreMerges,
+	}
+	for rank, rawMerge := range cfg.Merges {
+		left, right, err := parseMerge(rawMerge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BPE merge %d: %w", rank, err)
+		}
+		leftID, ok := m.vocab[left]
+		if !ok {
+			return nil, fmt.Errorf("BPE merge %d references unknown token %q", rank, left)
+		}
+		rightID, ok := m.vocab[right]
+		if !ok {
+			return nil, fmt.Errorf("BPE merge %d references unknown token %q", rank, right)
+		}
+		merged := left + strings.TrimPrefix(right, m.prefix)
+		newID, ok := m.vocab[merged]
+		if !ok {
+			return nil, fmt.Errorf("BPE merge %d produces unknown token %q", rank, merged)
+		}
+		m.merges[pair{leftID, rightID}] = mergeRule{rank: rank, newID: newID}
+	}
+	cache, err := lru.New[string, []uint32](bpeCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	m.cache = cache
+	return m, nil
+}
+
+// parseMerge accepts both the legacy "a b" and the current ["a", "b"] forms.
+func parseMerge(raw json.RawMessage) (string, string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		left, right, ok := strings.Cut(s, " ")
+		if !ok || strings.Contains(right, " ") {
+			return "", "", fmt.Errorf("malformed merge %q", s)
+		}
+		return left, right, nil
+	}
+	var parts []string
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", "", err
+	}
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("merge must have 2 parts, got %d", len(parts))
+	}
+	return parts[0], parts[1], nil
+}
+
+func (m *bpe) tokenToID(token string) (uint32, bool) {
+	id, ok := m.vocab[token]
+	return id, ok
+}
+
+func (m *bpe) tokenize(word string) []uint32 {
+	if word == "" {
+		return nil
+	}
+	if m.ignoreMerges {
+		if id, ok := m.vocab[word]; ok {
+			return []uint32{id}
+		}
+	}
+	if ids, ok := m.cache.Get(word); ok {
+		return ids
+	}
+	ids := m.mergeWord(word)
+	if len(word) <= bpeCacheMaxWordLen {
+		m.cache.Add(word, ids)
+	}
+	return ids
+}
+
+// symbol is a node of the doubly linked list of word parts being merged.
+type symbol struct {
+	id         uint32
+	prev, next int
+	merged     bool
+}
+
+// mergeWord splits word into initial symbols and applies merges in rank
+// order, following tokenizers' Word::merge_all.
+func (m *bpe) mergeWord(word string) []uint32 {
+	symbols := m.initialSymbols(word)
+	if len(symbols) < 2 {
+		return symbolIDs(symbols)
+	}
+
+	queue := &mergeQueue{}
+	for i := 0; i < len(symbols)-1; i++ {
+		if rule, ok := m.merges[pair{symbols[i].id, symbols[i+1].id}]; ok {
+			queue.items = append(queue.items, mergeCandidate{pos: i, rank: rule.rank, newID: rule.newID})
+		}
+	}
+	heap.Init(queue)
+
+	for queue.Len() > 0 {
+		top := heap.Pop(queue).(mergeCandidate)
+		cur := &symbols[top.pos]
+		if cur.merged || cur.next < 0 {
+			continue
+		}
+		nextPos := cur.next
+		rule, ok := m.merges[pair{cur.id, symbols[nextPos].id}]
+		if !ok || rule.newID != top.newID {
+			// Stale candidate: one side was merged into something else.
+			continue
+		}
+		cur.id = top.newID
+		cur.next = symbols[nextPos].next
+		symbols[nextPos].merged = true
+		if cur.next >= 0 {
+			symbols[cur.next].prev = top.pos
+		}
+		if cur.prev >= 0 {
+			if rule, ok := m.merges[pair{symbols[cur.prev].id, cur.id}]; ok {
+				heap.Push(queue, mergeCandidate{pos: cur.prev, rank: rule.rank, newID: rule.newID})
+			}
+		}
+		if cur.next >= 0 {
+			if rule, ok := m.merges[pair{cur.id, symbols[cur.next].id}]; ok {
+				heap.Push(queue, mergeCandidate{pos: top.pos, rank: rule.rank, newID: rule.newID})
+			}
+		}
+	}
+	return symbolIDs(symbols)
+}
+
+func (m *bpe) initialSymbols(word string) []symbol {
+	var ids []uint32
+	unkID, hasUnk := m.vocab[m.unkToken]
+	hasUnk = hasUnk && m.unkToken != ""
+	pendingUnk := false
+
+	runes := []rune(word)
+	for i, r := range runes {
+		s := string(r)
+		if i > 0 {
+			s = m.prefix + s
+		}
+		if i == len(runes)-1 {
+			s += m.suffix
+		}
+		if id, ok := m.vocab[s]; ok {
+			if pendingUnk {
+				ids = append(ids, unkID)
+				pendingUnk = false
+			}
+			ids = append(ids, id)
+			continue
+		}
+		if m.byteFallback {
+			if byteIDs, ok := m.byteTokens(s); ok {
+				// Upstream does not flush a pending unknown here; match it.
+				ids = append(ids, byteIDs...)
+				continue
+			}
+		}
+		if !hasUnk {
+			continue
+		}
+		if pendingUnk && !m.fuseUnk {
+			ids = append(ids, unkID)
+		}
+		pendingUnk = true
+	}
+	if pendingUnk {
+		ids = append(ids, unkID)
+	}
+
+	symbols := make([]symbol, len(ids))
+	for i, id := range ids {
+		symbols[i] = symbol{id: id, prev: i - 1, next: i + 1}
+	}
+	if len(symbols) > 0 {
+		symbols[len(symbols)-1].next = -1
+	}
+	return symbols
+}
+
+func (m *bpe) byteTokens(s string) ([]uint32, bool) {
+	ids := make([]uint32, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		id, ok := m.vocab[fmt.Sprintf("<0x%02X>", s[i])]
+		if !ok {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+func symbolIDs(symbols []symbol) []uint32 {
+	ids := make([]uint32, 0, len(symbols))
+	for _, s := range symbols {
+		if !s.merged {
+			ids = append(ids, s.id)
+		}
+	}
+	return ids
+}
+
+type mergeCandidate struct {
+	pos   int
+	rank  int
+	newID uint32
+}
+
+// mergeQueue orders merge candidates by rank, then leftmost position.
+type mergeQueue struct {
+	items []mergeCandidate
+}
+
+func (q *mergeQueue) Len() int { return len(q.items) }
+func (q *mergeQueue) Less(i, j int) bool {
+	if q.items[i].rank != q.items[j].rank {
+		return q.items[i].rank < q.items[j].rank
+	}
+	return q.items[i].pos < q.items[j].pos
+}
+func (q *mergeQueue) Swap(i, j int) { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *mergeQueue) Push(x any)    { q.items = append(q.items, x.(mergeCandidate)) }
+func (q *mergeQueue) Pop() any {
+	last := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	return last
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizer rewrites a piece of input text before pre-tokenization.
+type normalizer interface {
+	normalize(s string) string
+}
+
+type typed struct {
+	Type string `json:"type"`
+}
+
+func parseNormalizer(raw json.RawMessage) (normalizer, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+	var t typed
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("invalid normalizer: %w", err)
+	}
+	switch t.Type {
+	case "NFC":
+		return unicodeNormalizer{form: norm.NFC}, nil
+	case "NFD":
+		return unicodeNormalizer{form: norm.NFD}, nil
+	case "NFKC":
+		return unicodeNormalizer{form: norm.NFKC}, nil
+	case "NFKD":
+		return unicodeNormalizer{form: norm.NFKD}, nil
+	case "Lowercase":
+		return lowercase{}, nil
+	case "StripAccents":
+		return stripAccents{}, nil
+	case "Nmt":
+		return nmt{}, nil
+	case "Strip":
+		cfg := strip{}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Strip normalizer: %w", err)
+		}
+		return cfg, nil
+	case "Prepend":
+		cfg := prepend{}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Prepend normalizer: %w", err)
+		}
+		return cfg, nil
+	case "Replace":
+		var cfg struct {
+			Pattern json.RawMessage `json:"pattern"`
+			Content string          `json:"content"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Replace normalizer: %w", err)
+		}
+		p, err := parsePattern(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Replace normalizer: %w", err)
+		}
+		return replace{pattern: p, content: cfg.Content}, nil
+	case "BertNormalizer":
+		cfg := bertNormalizer{CleanText: true, HandleChineseChars: true, Lowercase: true}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid BertNormalizer: %w", err)
+		}
+		return cfg, nil
+	case "Precompiled":
+		var cfg struct {
+			CharsMap string `json:"precompiled_charsmap"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Precompiled normalizer: %w", err)
+		}
+		if cfg.CharsMap == "" {
+			return nil, nil
+		}
+		return newPrecompiled(cfg.CharsMap)
+	case "Sequence":
+		var cfg struct {
+			Normalizers []json.RawMessage `json:"normalizers"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid normalizer Sequence: %w", err)
+		}
+		seq := make(normalizerSequence, 0, len(cfg.Normalizers))
+		for _, child := range cfg.Normalizers {
+			n, err := parseNormalizer(child)
+			if err != nil {
+				return nil, err
+			}
+			if n != nil {
+				seq = append(seq, n)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, fmt.Errorf("unsupported normalizer type %q", t.Type)
+	}
+}
+
+type normalizerSequence []normalizer
+
+func (seq normalizerSequence) normalize(s string) string {
+	for _, n := range seq {
+		s = n.normalize(s)
+	}
+	return s
+}
+
+type unicodeNormalizer struct {
+	form norm.Form
+}
+
+func (u unicodeNormalizer) normalize(s string) string {
+	return u.form.String(s)
+}
+
+type lowercase struct{}
+
+func (lowercase) normalize(s string) string {
+	return strings.ToLower(s)
+}
+
+// stripAccents drops combining marks; it is meant to follow NFD/NFKD.
+type stripAccents struct{}
+
+func (stripAccents) normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+type strip struct {
+	Left  bool `json:"strip_left"`
+	Right bool `json:"strip_right"`
+}
+
+func (st strip) normalize(s string) string {
+	if st.Left {
+		s = strings.TrimLeftFunc(s, isWhitespace)
+	}
+	if st.Right {
+		s = strings.TrimRightFunc(s, isWhitespace)
+	}
+	return s
+}
+
+type prepend struct {
+	Prepend string `json:"prepend"`
+}
+
+func (p prepend) normalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return p.Prepend + s
+}
+
+type replace struct {
+	pattern *pattern
+	content string
+}
+
+func (r replace) normalize(s string) string {
+	matches := r.pattern.findAll(s)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	prev := 0
+	for _, m := range matches {
+		b.WriteString(s[prev:m[0]])
+		b.WriteString(r.content)
+		prev = m[1]
+	}
+	b.WriteString(s[prev:])
+	return b.String()
+}
+
+// nmt maps control characters the way SentencePiece's NMT normalization does.
+type nmt struct{}
+
+func (nmt) normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x0001 && r <= 0x0008, r == 0x000B, r >= 0x000E && r <= 0x001F,
+			r == 0x007F, r == 0x008F, r == 0x009F:
+			return -1
+		case r == 0x0009, r == 0x000A, r == 0x000C, r == 0x000D, r == 0x1680,
+			r >= 0x200B && r <= 0x200F, r == 0x2028, r == 0x2029, r == 0x2581,
+			r == 0xFEFF, r == 0xFFFD:
+			return ' '
+		default:
+			return r
+		}
+	}, s)
+}
+
+type bertNormalizer struct {
+	CleanText          bool  `json:"clean_text"`
+	HandleChineseChars bool  `json:"handle_chinese_chars"`
+	StripAccents       *bool `json:"strip_accents"`
+	Lowercase          bool  `json:"lowercase"`
+}
+
+func (bn bertNormalizer) normalize(s string) string {
+	if bn.CleanText {
+		s = strings.Map(func(r rune) rune {
+			switch {
+			case r == 0, r == 0xFFFD, isBertControl(r):
+				return -1
+			case isWhitespace(r):
+				return ' '
+			default:
+				return r
+			}
+		}, s)
+	}
+	if bn.HandleChineseChars {
+		var b strings.Builder
+		for _, r := range s {
+			if isChineseChar(r) {
+				b.WriteByte(' ')
+				b.WriteRune(r)
+				b.WriteByte(' ')
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		s = b.String()
+	}
+	strip := bn.Lowercase
+	if bn.StripAccents != nil {
+		strip = *bn.StripAccents
+	}
+	if strip {
+		s = stripAccents{}.normalize(norm.NFD.String(s))
+	}
+	if bn.Lowercase {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+func isBertControl(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	// Unassigned code points (Cn) have no table in package unicode.
+	return unicode.Is(unicode.C, r) || !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z)
+}
+
+func isChineseChar(r rune) bool {
+	return (r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x20000 && r <= 0x2A6DF) ||
+		(r >= 0x2A700 && r <= 0x2B73F) ||
+		(r >= 0x2B740 && r <= 0x2B81F) ||
+		(r >= 0x2B920 && r <= 0x2CEAF) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x2F800 && r <= 0x2FA1F)
+}
+
+func isNull(raw json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(raw))
+	return trimmed == "" || trimmed == "null"
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// whitespaceClass is the Unicode White_Space property as a character class
+	// body. Oniguruma (used by the Rust tokenizers) matches \s against it,
+	// whereas RE2 only matches ASCII whitespace.
+	whitespaceClass = `\t\n\x0B\f\r \x{85}\p{Z}`
+	// wordClass is the Unicode \w character class body.
+	wordClass = `\p{L}\p{M}\p{Nd}\p{Pc}`
+
+	// whitespaceLookahead is the only lookaround construct found in the
+	// pre-tokenizer regexes of mainstream models (GPT-2, Llama 3, Qwen, ...).
+	// RE2 has no lookaround, so it is rewritten to a named group whose match is
+	// shortened by one rune when followed by a non-whitespace rune.
+	whitespaceLookahead = `\s+(?!\S)`
+	trimGroupName       = "hftrim"
+)
+
+// pattern is a Split / Replace pattern: either a literal string or a regex.
+type pattern struct {
+	literal string
+	re      *regexp.Regexp
+	// trimGroup is the submatch index of the rewritten whitespace lookahead,
+	// or -1 when the regex has none.
+	trimGroup int
+}
+
+type patternJSON struct {
+	String *string `json:"String"`
+	Regex  *string `json:"Regex"`
+}
+
+func parsePattern(raw json.RawMessage) (*pattern, error) {
+	var pj patternJSON
+	if err := json.Unmarshal(raw, &pj); err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	switch {
+	case pj.String != nil:
+		if *pj.String == "" {
+			return nil, errors.New("empty string pattern")
+		}
+		return &pattern{literal: *pj.String, trimGroup: -1}, nil
+	case pj.Regex != nil:
+		return compileRegex(*pj.Regex)
+	default:
+		return nil, errors.New("pattern must set either 'String' or 'Regex'")
+	}
+}
+
+// compileRegex translates an Oniguruma regex into an equivalent RE2 one.
+func compileRegex(src string) (*pattern, error) {
+	trim := strings.Contains(src, whitespaceLookahead)
+	if trim {
+		src = strings.ReplaceAll(src, whitespaceLookahead, `(?P<`+trimGroupName+`>\s+)`)
+	}
+	translated, err := translateRegex(src)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(translated)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported regex %q: %w", src, err)
+	}
+	p := &pattern{re: re, trimGroup: -1}
+	if trim {
+		p.trimGroup = re.SubexpIndex(trimGroupName)
+	}
+	return p, nil
+}
+
+// translateRegex rewrites the Perl classes that differ between Oniguruma and
+// RE2 to their Unicode-aware equivalents, and rejects lookaround.
+func translateRegex(src string) (string, error) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src):
+			i++
+			n := src[i]
+			switch n {
+			case 's':
+				writeClass(&b, whitespaceClass, inClass)
+			case 'w':
+				writeClass(&b, wordClass, inClass)
+			case 'd':
+				b.WriteString(`\p{Nd}`)
+			case 'D':
+				b.WriteString(`\P{Nd}`)
+			case 'S', 'W':
+				if inClass {
+					return "", fmt.Errorf("unsupported regex %q: negated class \\%c inside a character class", src, n)
+				}
+				if n == 'S' {
+					b.WriteString(`[^` + whitespaceClass + `]`)
+				} else {
+					b.WriteString(`[^` + wordClass + `]`)
+				}
+			case 'u':
+				if i+4 >= len(src) {
+					return "", fmt.Errorf("unsupported regex %q: truncated \\u escape", src)
+				}
+				b.WriteString(`\x{` + src[i+1:i+5] + `}`)
+				i += 4
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(n)
+			}
+		case inClass && strings.HasPrefix(src[i:], "[:"):
+			end := strings.Index(src[i:], ":]")
+			if end < 0 {
+				return "", fmt.Errorf("unsupported regex %q: unterminated POSIX class", src)
+			}
+			b.WriteString(src[i : i+end+2])
+			i += end + 1
+		case c == '[' && !inClass:
+			inClass = true
+			b.WriteByte(c)
+			if i+1 < len(src) && src[i+1] == '^' {
+				b.WriteByte('^')
+				i++
+			}
+			if i+1 < len(src) && src[i+1] == ']' {
+				b.WriteString(`\]`)
+				i++
+			}
+		case c == ']' && inClass:
+			inClass = false
+			b.WriteByte(c)
+		case !inClass && (strings.HasPrefix(src[i:], "(?=") || strings.HasPrefix(src[i:], "(?!") ||
+			strings.HasPrefix(src[i:], "(?<=") || strings.HasPrefix(src[i:], "(?<!")):
+			return "", fmt.Errorf("unsupported regex %q: lookaround assertions are not supported", src)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+func writeClass(b *strings.Builder, body string, inClass bool) {
+	if inClass {
+		b.WriteString(body)
+		return
+	}
+	b.WriteString("[" + body + "]")
+}
+
+// findAll returns the byte ranges of the non-overlapping matches in s.
+func (p *pattern) findAll(s string) [][2]int {
+	var matches [][2]int
+	if p.re == nil {
+		for pos := 0; pos < len(s); {
+			idx := strings.Index(s[pos:], p.literal)
+			if idx < 0 {
+				break
+			}
+			matches = append(matches, [2]int{pos + idx, pos + idx + len(p.literal)})
+			pos += idx + len(p.literal)
+		}
+		return matches
+	}
+	if p.trimGroup < 0 {
+		for _, m := range p.re.FindAllStringIndex(s, -1) {
+			matches = append(matches, [2]int{m[0], m[1]})
+		}
+		return matches
+	}
+	for pos := 0; pos <= len(s); {
+		m := p.re.FindStringSubmatchIndex(s[pos:])
+		if m == nil {
+			break
+		}
+		start, end := pos+m[0], pos+m[1]
+		if m[2*p.trimGroup] >= 0 && end < len(s) {
+			// \s+(?!\S): a whitespace run followed by a non-whitespace rune
+			// gives its last rune back to the next token.
+			next, _ := utf8.DecodeRuneInString(s[end:])
+			if !isWhitespace(next) {
+				if _, size := utf8.DecodeLastRuneInString(s[start:end]); end-size > start {
+					end -= size
+				}
+			}
+		}
+		if end == start {
+			// Empty match; step over one rune to guarantee progress.
+			if start >= len(s) {
+				break
+			}
+			_, size := utf8.DecodeRuneInString(s[start:])
+			pos = start + size
+			continue
+		}
+		matches = append(matches, [2]int{start, end})
+		pos = end
+	}
+	return matches
+}
+
+// isWhitespace reports whether r has the Unicode White_Space property.
+func isWhitespace(r rune) bool {
+	return unicode.IsSpace(r)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pieces(s string, matches [][2]int) []string {
+	out := make([]string, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, s[m[0]:m[1]])
+	}
+	return out
+}
+
+func TestCompileRegex_GPT2(t *testing.T) {
+	p, err := compileRegex(gpt2Pattern)
+	require.NoError(t, err)
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "Hello world", want: []string{"Hello", " world"}},
+		// \s+(?!\S) leaves the last space for the following word.
+		{text: "a   b", want: []string{"a", "  ", " b"}},
+		{text: "end   ", want: []string{"end", "   "}},
+		{text: "it's 42!", want: []string{"it", "'s", " 42", "!"}},
+		// Unicode whitespace counts as \s, as it does in Oniguruma; only an
+		// ASCII space may prefix a word.
+		{text: "a\u00a0\u00a0b", want: []string{"a", "\u00a0", "\u00a0", "b"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			assert.Equal(t, tc.want, pieces(tc.text, p.findAll(tc.text)))
+		})
+	}
+}
+
+func TestSplitBehaviors(t *testing.T) {
+	p := &pattern{literal: "-", trimGroup: -1}
+	text := "a-b--c"
+	spans := coverMatches(len(text), p.findAll(text), false)
+
+	tests := []struct {
+		behavior splitBehavior
+		want     []string
+	}{
+		{behavior: behaviorRemoved, want: []string{"a", "b", "c"}},
+		{behavior: behaviorIsolated, want: []string{"a", "-", "b", "-", "-", "c"}},
+		{behavior: behaviorMergedWithPrevious, want: []string{"a-", "b-", "-", "c"}},
+		{behavior: behaviorMergedWithNext, want: []string{"a", "-b", "-", "-c"}},
+		{behavior: behaviorContiguous, want: []string{"a", "-", "b", "--", "c"}},
+	}
+	for _, tc := range tests {
+		var got []string
+		for _, s := range splitSpans(split{text: text}, spans, tc.behavior) {
+			got = append(got, s.text)
+		}
+		assert.Equal(t, tc.want, got, "behavior %d", tc.behavior)
+	}
+}
+
+func TestPrecompiled(t *testing.T) {
+	// A single-entry double-array trie mapping "A" to the replacement at
+	// offset 0 of the normalized blob.
+	trie := make([]uint32, 0x142)
+	trie[0] = 0x100 << 10
+	trie[0x141] = 'A' | 1<<8 | 1<<10
+	trie[0x140] = 1 << 31
+	p := &precompiled{trie: trie, normalized: []byte("a\x00")}
+
+	assert.Equal(t, "abBa", p.normalize("AbBA"))
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// postProcessor adds the special tokens of a single-sequence encoding.
+type postProcessor interface {
+	process(ids []uint32) []uint32
+}
+
+func parsePostProcessor(raw json.RawMessage) (postProcessor, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+	var t typed
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("invalid post-processor: %w", err)
+	}
+	switch t.Type {
+	case "ByteLevel":
+		// Only trims offsets, which are not produced.
+		return nil, nil
+	case "TemplateProcessing":
+		return parseTemplateProcessing(raw)
+	case "BertProcessing", "RobertaProcessing":
+		var cfg struct {
+			Sep [2]json.RawMessage `json:"sep"`
+			Cls [2]json.RawMessage `json:"cls"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", t.Type, err)
+		}
+		var clsID, sepID uint32
+		if err := json.Unmarshal(cfg.Cls[1], &clsID); err != nil {
+			return nil, fmt.Errorf("invalid %s cls: %w", t.Type, err)
+		}
+		if err := json.Unmarshal(cfg.Sep[1], &sepID); err != nil {
+			return nil, fmt.Errorf("invalid %s sep: %w", t.Type, err)
+		}
+		return wrapProcessor{prefix: []uint32{clsID}, suffix: []uint32{sepID}}, nil
+	case "Sequence":
+		var cfg struct {
+			Processors []json.RawMessage `json:"processors"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid post-processor Sequence: %w", err)
+		}
+		seq := make(postProcessorSequence, 0, len(cfg.Processors))
+		for _, child := range cfg.Processors {
+			pp, err := parsePostProcessor(child)
+			if err != nil {
+				return nil, err
+			}
+			if pp != nil {
+				seq = append(seq, pp)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, fmt.Errorf("unsupported post-processor type %q", t.Type)
+	}
+}
+
+type postProcessorSequence []postProcessor
+
+func (seq postProcessorSequence) process(ids []uint32) []uint32 {
+	for _, pp := range seq {
+		ids = pp.process(ids)
+	}
+	return ids
+}
+
+// wrapProcessor surrounds the sequence with fixed token IDs.
+type wrapProcessor struct {
+	prefix, suffix []uint32
+}
+
+func (w wrapProcessor) process(ids []uint32) []uint32 {
+	out := make([]uint32, 0, len(w.prefix)+len(ids)+len(w.suffix))
+	out = append(out, w.prefix...)
+	out = append(out, ids...)
+	return append(out, w.suffix...)
+}
+
+// templateProcessing applies the "single" template of a TemplateProcessing
+// post-processor; the pair template does not apply to prompts.
+type templateProcessing struct {
+	items []templateItem
+}
+
+type templateItem struct {
+	sequence bool
+	ids      []uint32
+}
+
+func parseTemplateProcessing(raw json.RawMessage) (postProcessor, error) {
+	var cfg struct {
+		Single []struct {
+			SpecialToken *struct {
+				ID string `json:"id"`
+			} `json:"SpecialToken"`
+			Sequence *struct {
+				ID string `json:"id"`
+			} `json:"Sequence"`
+		} `json:"single"`
+		SpecialTokens map[string]struct {
+			IDs []uint32 `json:"ids"`
+		} `json:"special_tokens"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid TemplateProcessing: %w", err)
+	}
+	tp := &templateProcessing{}
+	for _, piece := range cfg.Single {
+		switch {
+		case piece.Sequence != nil:
+			tp.items = append(tp.items, templateItem{sequence: true})
+		case piece.SpecialToken != nil:
+			special, ok := cfg.SpecialTokens[piece.SpecialToken.ID]
+			if !ok {
+				return nil, fmt.Errorf("TemplateProcessing references undefined special token %q", piece.SpecialToken.ID)
+			}
+			tp.items = append(tp.items, templateItem{ids: special.IDs})
+		}
+	}
+	return tp, nil
+}
+
+func (tp *templateProcessing) process(ids []uint32) []uint32 {
+	out := make([]uint32, 0, len(ids)+len(tp.items))
+	for _, item := range tp.items {
+		if item.sequence {
+			out = append(out, ids...)
+		} else {
+			out = append(out, item.ids...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// precompiled is SentencePiece's precompiled character map: a double-array
+// trie over UTF-8 byte sequences whose leaves index NUL-terminated
+// replacement strings.
+type precompiled struct {
+	trie       []uint32
+	normalized []byte
+}
+
+func newPrecompiled(encoded string) (*precompiled, error) {
+	blob, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Precompiled charsmap: %w", err)
+	}
+	if len(blob) < 4 {
+		return nil, errors.New("invalid Precompiled charsmap: truncated header")
+	}
+	trieSize := int(binary.LittleEndian.Uint32(blob))
+	if trieSize%4 != 0 || 4+trieSize > len(blob) {
+		return nil, errors.New("invalid Precompiled charsmap: bad trie size")
+	}
+	trie := make([]uint32, trieSize/4)
+	for i := range trie {
+		trie[i] = binary.LittleEndian.Uint32(blob[4+4*i:])
+	}
+	return &precompiled{trie: trie, normalized: blob[4+trieSize:]}, nil
+}
+
+// normalize replaces each grapheme, or failing that each rune, with its
+// mapping. Graphemes are approximated as a base rune followed by its
+// combining marks.
+func (p *precompiled) normalize(s string) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		g := graphemeLen(s)
+		grapheme := s[:g]
+		s = s[g:]
+		if g < 6 {
+			if norm, ok := p.transform(grapheme); ok {
+				b.WriteString(norm)
+				continue
+			}
+		}
+		for _, r := range grapheme {
+			part := string(r)
+			if norm, ok := p.transform(part); ok {
+				b.WriteString(norm)
+			} else {
+				b.WriteString(part)
+			}
+		}
+	}
+	return b.String()
+}
+
+func graphemeLen(s string) int {
+	_, n := utf8.DecodeRuneInString(s)
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) && r != 0x200D {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+func (p *precompiled) transform(chunk string) (string, bool) {
+	value, ok := p.firstPrefixValue([]byte(chunk))
+	if !ok || int(value) >= len(p.normalized) {
+		return "", false
+	}
+	rest := p.normalized[value:]
+	if end := bytes.IndexByte(rest, 0); end >= 0 {
+		rest = rest[:end]
+	}
+	return string(rest), true
+}
+
+// firstPrefixValue returns the value of the shortest key in the trie that is
+// a prefix of key, mirroring the Darts common-prefix search used upstream.
+func (p *precompiled) firstPrefixValue(key []byte) (uint32, bool) {
+	if len(p.trie) == 0 {
+		return 0, false
+	}
+	nodePos := unitOffset(p.trie[0])
+	for _, c := range key {
+		if c == 0 {
+			break
+		}
+		nodePos ^= uint32(c)
+		if int(nodePos) >= len(p.trie) {
+			return 0, false
+		}
+		unit := p.trie[nodePos]
+		if unit&(1<<31|0xFF) != uint32(c) {
+			return 0, false
+		}
+		nodePos ^= unitOffset(unit)
+		if (unit>>8)&1 == 1 {
+			if int(nodePos) >= len(p.trie) {
+				return 0, false
+			}
+			return p.trie[nodePos] & (1<<31 - 1), true
+		}
+	}
+	return 0, false
+}
+
+func unitOffset(unit uint32) uint32 {
+	return (unit >> 10) << ((unit & (1 << 9)) >> 6)
+}
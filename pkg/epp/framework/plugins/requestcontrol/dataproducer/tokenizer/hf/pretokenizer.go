@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// gpt2Pattern is the word-splitting regex applied by the ByteLevel
+// pre-tokenizer when use_regex is set.
+const gpt2Pattern = `'s|'t|'re|'ve|'m|'ll|'d| ?\p{L}+| ?\p{N}+| ?[^\s\p{L}\p{N}]+|\s+(?!\S)|\s+`
+
+// preTokenizer splits normalized text into the words handed to the model.
+type preTokenizer interface {
+	preTokenize(splits []split) []split
+}
+
+func parsePreTokenizer(raw json.RawMessage) (preTokenizer, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+	var t typed
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("invalid pre-tokenizer: %w", err)
+	}
+	switch t.Type {
+	case "ByteLevel":
+		cfg := struct {
+			AddPrefixSpace bool `json:"add_prefix_space"`
+			UseRegex       bool `json:"use_regex"`
+		}{UseRegex: true}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid ByteLevel pre-tokenizer: %w", err)
+		}
+		bl := &byteLevel{addPrefixSpace: cfg.AddPrefixSpace}
+		if cfg.UseRegex {
+			p, err := compileRegex(gpt2Pattern)
+			if err != nil {
+				return nil, err
+			}
+			bl.pattern = p
+		}
+		return bl, nil
+	case "Whitespace":
+		p, err := compileRegex(`\w+|[^\w\s]+`)
+		if err != nil {
+			return nil, err
+		}
+		return &splitPreTokenizer{pattern: p, behavior: behaviorRemoved, invert: true}, nil
+	case "WhitespaceSplit":
+		return funcPreTokenizer{pred: isWhitespace, behavior: behaviorRemoved}, nil
+	case "BertPreTokenizer":
+		return preTokenizerSequence{
+			funcPreTokenizer{pred: isWhitespace, behavior: behaviorRemoved},
+			funcPreTokenizer{pred: isBertPunctuation, behavior: behaviorIsolated},
+		}, nil
+	case "Punctuation":
+		var cfg struct {
+			Behavior string `json:"behavior"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Punctuation pre-tokenizer: %w", err)
+		}
+		behavior, err := parseBehavior(cfg.Behavior)
+		if err != nil {
+			return nil, err
+		}
+		return funcPreTokenizer{pred: isBertPunctuation, behavior: behavior}, nil
+	case "Digits":
+		var cfg struct {
+			IndividualDigits bool `json:"individual_digits"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Digits pre-tokenizer: %w", err)
+		}
+		behavior := behaviorContiguous
+		if cfg.IndividualDigits {
+			behavior = behaviorIsolated
+		}
+		return funcPreTokenizer{pred: unicode.IsNumber, behavior: behavior}, nil
+	case "CharDelimiterSplit":
+		var cfg struct {
+			Delimiter string `json:"delimiter"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid CharDelimiterSplit pre-tokenizer: %w", err)
+		}
+		delim, _ := utf8.DecodeRuneInString(cfg.Delimiter)
+		return funcPreTokenizer{pred: func(r rune) bool { return r == delim }, behavior: behaviorRemoved}, nil
+	case "Metaspace":
+		return parseMetaspace(raw)
+	case "Split":
+		var cfg struct {
+			Pattern  json.RawMessage `json:"pattern"`
+			Behavior string          `json:"behavior"`
+			Invert   bool            `json:"invert"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid Split pre-tokenizer: %w", err)
+		}
+		p, err := parsePattern(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Split pre-tokenizer: %w", err)
+		}
+		behavior, err := parseBehavior(cfg.Behavior)
+		if err != nil {
+			return nil, err
+		}
+		return &splitPreTokenizer{pattern: p, behavior: behavior, invert: cfg.Invert}, nil
+	case "Sequence":
+		var cfg struct {
+			PreTokenizers []json.RawMessage `json:"pretokenizers"`
+		}
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("invalid pre-tokenizer Sequence: %w", err)
+		}
+		seq := make(preTokenizerSequence, 0, len(cfg.PreTokenizers))
+		for _, child := range cfg.PreTokenizers {
+			pt, err := parsePreTokenizer(child)
+			if err != nil {
+				return nil, err
+			}
+			if pt != nil {
+				seq = append(seq, pt)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, fmt.Errorf("unsupported pre-tokenizer type %q", t.Type)
+	}
+}
+
+type preTokenizerSequence []preTokenizer
+
+func (seq preTokenizerSequence) preTokenize(splits []split) []split {
+	for _, pt := range seq {
+		splits = pt.preTokenize(splits)
+	}
+	return splits
+}
+
+// splitPreTokenizer splits on the matches of a pattern.
+type splitPreTokenizer struct {
+	pattern  *pattern
+	behavior splitBehavior
+	invert   bool
+}
+
+func (sp *splitPreTokenizer) preTokenize(splits []split) []split {
+	out := make([]split, 0, len(splits))
+	for _, s := range splits {
+		spans := coverMatches(len(s.text), sp.pattern.findAll(s.text), sp.invert)
+		out = append(out, splitSpans(s, spans, sp.behavior)...)
+	}
+	return out
+}
+
+// funcPreTokenizer splits on every rune satisfying a predicate.
+type funcPreTokenizer struct {
+	pred     func(rune) bool
+	behavior splitBehavior
+}
+
+func (fp funcPreTokenizer) preTokenize(splits []split) []split {
+	out := make([]split, 0, len(splits))
+	for _, s := range splits {
+		spans := coverMatches(len(s.text), runeMatches(s.text, fp.pred), false)
+		out = append(out, splitSpans(s, spans, fp.behavior)...)
+	}
+	return out
+}
+
+// isBertPunctuation matches ASCII punctuation and symbols plus the Unicode
+// punctuation categories.
+func isBertPunctuation(r rune) bool {
+	if r < utf8.RuneSelf {
+		return (r >= '!' && r <= '/') || (r >= ':' && r <= '@') || (r >= '[' && r <= '`') || (r >= '{' && r <= '~')
+	}
+	return unicode.IsPunct(r)
+}
+
+// byteLevel optionally splits with the GPT-2 regex, then maps every byte of
+// each split to a printable rune.
+type byteLevel struct {
+	addPrefixSpace bool
+	pattern        *pattern
+}
+
+func (bl *byteLevel) preTokenize(splits []split) []split {
+	out := make([]split, 0, len(splits))
+	for _, s := range splits {
+		if bl.addPrefixSpace && !strings.HasPrefix(s.text, " ") {
+			s.text = " " + s.text
+		}
+		if bl.pattern == nil {
+			out = append(out, s)
+			continue
+		}
+		spans := coverMatches(len(s.text), bl.pattern.findAll(s.text), false)
+		out = append(out, splitSpans(s, spans, behaviorIsolated)...)
+	}
+	for i := range out {
+		out[i].text = byteLevelEncode(out[i].text)
+	}
+	return out
+}
+
+// metaspace replaces spaces with a marker rune (▁ by default), optionally
+// prepends it, and splits before each marker.
+type metaspace struct {
+	replacement string
+	prepend     string
+	split       bool
+}
+
+const (
+	prependAlways = "always"
+	prependFirst  = "first"
+	prependNever  = "never"
+)
+
+func parseMetaspace(raw json.RawMessage) (preTokenizer, error) {
+	var cfg struct {
+		Replacement    string  `json:"replacement"`
+		AddPrefixSpace *bool   `json:"add_prefix_space"`
+		PrependScheme  *string `json:"prepend_scheme"`
+		Split          *bool   `json:"split"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid Metaspace pre-tokenizer: %w", err)
+	}
+	ms := &metaspace{replacement: cfg.Replacement, prepend: prependAlways, split: true}
+	if ms.replacement == "" {
+		ms.replacement = "▁"
+	}
+	if cfg.Split != nil {
+		ms.split = *cfg.Split
+	}
+	switch {
+	case cfg.PrependScheme != nil:
+		switch *cfg.PrependScheme {
+		case prependAlways, prependFirst, prependNever:
+			ms.prepend = *cfg.PrependScheme
+		default:
+			return nil, fmt.Errorf("invalid Metaspace prepend_scheme %q", *cfg.PrependScheme)
+		}
+	case cfg.AddPrefixSpace != nil && !*cfg.AddPrefixSpace:
+		ms.prepend = prependNever
+	}
+	return ms, nil
+}
+
+func (ms *metaspace) preTokenize(splits []split) []split {
+	out := make([]split, 0, len(splits))
+	for _, s := range splits {
+		s.text = strings.ReplaceAll(s.text, " ", ms.replacement)
+		if !strings.HasPrefix(s.text, ms.replacement) &&
+			(ms.prepend == prependAlways || (ms.prepend == prependFirst && s.offset == 0)) {
+			s.text = ms.replacement + s.text
+		}
+		if !ms.split {
+			out = append(out, s)
+			continue
+		}
+		lit := &pattern{literal: ms.replacement, trimGroup: -1}
+		spans := coverMatches(len(s.text), lit.findAll(s.text), false)
+		out = append(out, splitSpans(s, spans, behaviorMergedWithNext)...)
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import "fmt"
+
+// split is a piece of the input flowing through the pre-tokenization stage.
+type split struct {
+	text string
+	// offset is the byte offset of the split in the original input. Only its
+	// zero-ness is relied upon, by Metaspace's "first" prepend scheme.
+	offset int
+}
+
+// splitBehavior mirrors tokenizers' SplitDelimiterBehavior.
+type splitBehavior int
+
+const (
+	behaviorRemoved splitBehavior = iota
+	behaviorIsolated
+	behaviorMergedWithPrevious
+	behaviorMergedWithNext
+	behaviorContiguous
+)
+
+func parseBehavior(s string) (splitBehavior, error) {
+	switch s {
+	case "Removed":
+		return behaviorRemoved, nil
+	case "Isolated", "":
+		return behaviorIsolated, nil
+	case "MergedWithPrevious":
+		return behaviorMergedWithPrevious, nil
+	case "MergedWithNext":
+		return behaviorMergedWithNext, nil
+	case "Contiguous":
+		return behaviorContiguous, nil
+	default:
+		return 0, fmt.Errorf("unknown split behavior %q", s)
+	}
+}
+
+// span is a byte range of a string, flagged when it matched the delimiter.
+type span struct {
+	start, end int
+	match      bool
+}
+
+// coverMatches turns sorted, non-overlapping match ranges into spans covering
+// all of a string of length n.
+func coverMatches(n int, matches [][2]int, invert bool) []span {
+	spans := make([]span, 0, 2*len(matches)+1)
+	prev := 0
+	for _, m := range matches {
+		if m[0] == m[1] {
+			continue
+		}
+		if prev != m[0] {
+			spans = append(spans, span{start: prev, end: m[0], match: invert})
+		}
+		spans = append(spans, span{start: m[0], end: m[1], match: !invert})
+		prev = m[1]
+	}
+	if prev != n {
+		spans = append(spans, span{start: prev, end: n, match: invert})
+	}
+	return spans
+}
+
+// runeMatches returns one match per rune of s satisfying pred.
+func runeMatches(s string, pred func(rune) bool) [][2]int {
+	var matches [][2]int
+	for i, r := range s {
+		if pred(r) {
+			matches = append(matches, [2]int{i, i + len(string(r))})
+		}
+	}
+	return matches
+}
+
+// splitSpans applies a delimiter behavior to the covering spans of a split,
+// following tokenizers' NormalizedString::split.
+func splitSpans(s split, spans []span, behavior splitBehavior) []split {
+	var merged []span
+	switch behavior {
+	case behaviorIsolated:
+		merged = make([]span, len(spans))
+		for i, sp := range spans {
+			merged[i] = span{start: sp.start, end: sp.end}
+		}
+	case behaviorRemoved:
+		merged = spans
+	case behaviorContiguous:
+		previous := false
+		for _, sp := range spans {
+			if sp.match == previous && len(merged) > 0 {
+				merged[len(merged)-1].end = sp.end
+			} else {
+				merged = append(merged, span{start: sp.start, end: sp.end})
+			}
+			previous = sp.match
+		}
+	case behaviorMergedWithPrevious:
+		previous := false
+		for _, sp := range spans {
+			if sp.match && !previous && len(merged) > 0 {
+				merged[len(merged)-1].end = sp.end
+			} else {
+				merged = append(merged, span{start: sp.start, end: sp.end})
+			}
+			previous = sp.match
+		}
+	case behaviorMergedWithNext:
+		previous := false
+		for i := len(spans) - 1; i >= 0; i-- {
+			sp := spans[i]
+			if sp.match && !previous && len(merged) > 0 {
+				merged[len(merged)-1].start = sp.start
+			} else {
+				merged = append(merged, span{start: sp.start, end: sp.end})
+			}
+			previous = sp.match
+		}
+		for i, j := 0, len(merged)-1; i < j; i, j = i+1, j-1 {
+			merged[i], merged[j] = merged[j], merged[i]
+		}
+	}
+
+	out := make([]split, 0, len(merged))
+	for _, sp := range merged {
+		if sp.match || sp.start == sp.end {
+			continue
+		}
+		out = append(out, split{text: s.text[sp.start:sp.end], offset: s.offset + sp.start})
+	}
+	return out
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hf implements the encoding path of Hugging Face tokenizers in pure
+// Go, driven by a tokenizer.json file: added tokens, normalizers,
+// pre-tokenizers, the BPE / WordPiece / Unigram models and the special-token
+// post-processors. Decoding, truncation, padding and offsets are out of scope.
+package hf
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Tokenizer encodes text into the token IDs a model server would produce for
+// the same tokenizer.json. It is safe for concurrent use.
+type Tokenizer struct {
+	verbatim   *addedMatcher
+	normalized *addedMatcher
+	addedIDs   map[string]uint32
+	normalizer normalizer
+	preTok     preTokenizer
+	model      model
+	post       postProcessor
+}
+
+// model maps a pre-tokenized word to token IDs.
+type model interface {
+	tokenize(word string) []uint32
+	tokenToID(token string) (uint32, bool)
+}
+
+type tokenizerJSON struct {
+	AddedTokens   []addedToken    `json:"added_tokens"`
+	Normalizer    json.RawMessage `json:"normalizer"`
+	PreTokenizer  json.RawMessage `json:"pre_tokenizer"`
+	PostProcessor json.RawMessage `json:"post_processor"`
+	Model         json.RawMessage `json:"model"`
+}
+
+// LoadFile reads and parses a tokenizer.json file.
+func LoadFile(path string) (*Tokenizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tk, err := Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tk, nil
+}
+
+// Load parses the contents of a tokenizer.json file.
+func Load(data []byte) (*Tokenizer, error) {
+	var tj tokenizerJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return nil, fmt.Errorf("invalid tokenizer.json: %w", err)
+	}
+
+	t := &Tokenizer{addedIDs: make(map[string]uint32, len(tj.AddedTokens))}
+	var err error
+	if t.normalizer, err = parseNormalizer(tj.Normalizer); err != nil {
+		return nil, err
+	}
+	if t.preTok, err = parsePreTokenizer(tj.PreTokenizer); err != nil {
+		return nil, err
+	}
+	if t.post, err = parsePostProcessor(tj.PostProcessor); err != nil {
+		return nil, err
+	}
+	if t.model, err = parseModel(tj.Model); err != nil {
+		return nil, err
+	}
+
+	var raw, normalized []matchToken
+	for _, tok := range tj.AddedTokens {
+		t.addedIDs[tok.Content] = tok.ID
+		// Tokens flagged as normalized are matched against normalized text,
+		// the rest against the raw input.
+		if tok.Normalized && t.normalizer != nil {
+			normalized = append(normalized, matchToken{content: t.normalizer.normalize(tok.Content), token: tok})
+		} else {
+			raw = append(raw, matchToken{content: tok.Content, token: tok})
+		}
+	}
+	t.verbatim = newAddedMatcher(raw)
+	t.normalized = newAddedMatcher(normalized)
+	return t, nil
+}
+
+func parseModel(raw json.RawMessage) (model, error) {
+	if isNull(raw) {
+		return nil, fmt.Errorf("tokenizer.json has no model")
+	}
+	var t struct {
+		Type   string          `json:"type"`
+		Merges json.RawMessage `json:"merges"`
+		Vocab  json.RawMessage `json:"vocab"`
+	}
+	if err := json.Unmarshal(raw, &t); err != nil {
+		return nil, fmt.Errorf("invalid model: %w", err)
+	}
+	if t.Type == "" {
+		// Older files omit the tag; infer the model from its fields.
+		switch {
+		case t.Merges != nil:
+			t.Type = "BPE"
+		case strings.HasPrefix(strings.TrimSpace(string(t.Vocab)), "["):
+			t.Type = "Unigram"
+		default:
+			t.Type = "WordPiece"
+		}
+	}
+	switch t.Type {
+	case "BPE":
+		return parseBPE(raw)
+	case "WordPiece":
+		return parseWordPiece(raw)
+	case "Unigram":
+		return parseUnigram(raw)
+	default:
+		return nil, fmt.Errorf("unsupported model type %q", t.Type)
+	}
+}
+
+// TokenToID returns the ID of a token, looking at added tokens first.
+func (t *Tokenizer) TokenToID(token string) (uint32, bool) {
+	if id, ok := t.addedIDs[token]; ok {
+		return id, true
+	}
+	return t.model.tokenToID(token)
+}
+
+// Encode tokenizes text. Added tokens appearing in the text are always
+// recognized; addSpecialTokens controls whether the post-processor template
+// (e.g. a leading BOS) is applied, mirroring add_special_tokens upstream.
+func (t *Tokenizer) Encode(text string, addSpecialTokens bool) []uint32 {
+	ids, _ := t.EncodeFrom(text, Checkpoint{}, addSpecialTokens)
+	return ids
+}
+
+// Checkpoint is a point of an encoded text, right after an added token, from
+// which EncodeFrom can resume encoding a text that starts with the same bytes.
+type Checkpoint struct {
+	// TextLen is the length in bytes of the text before the checkpoint.
+	TextLen int
+	// IDs are the token IDs of that text without special tokens. They are
+	// shared and must not be modified.
+	IDs []uint32
+}
+
+// EncodeFrom is Encode for text whose first cp.TextLen bytes are the text cp
+// was taken from; only the rest of text is tokenized. The zero Checkpoint
+// encodes all of text. It also returns the checkpoints of text in order, for
+// encoding a later text that extends this one.
+func (t *Tokenizer) EncodeFrom(text string, cp Checkpoint, addSpecialTokens bool) ([]uint32, []Checkpoint) {
+	if cp.TextLen > len(text) {
+		cp = Checkpoint{}
+	}
+	segs, stable := t.verbatim.scan(text, cp.TextLen)
+	raw := slices.Clone(cp.IDs)
+	var checkpoints []Checkpoint
+	if cp.TextLen > 0 {
+		checkpoints = append(checkpoints, cp)
+	}
+	// The checkpoints share raw's backing array, which is only appended to;
+	// their IDs are sliced once raw is complete.
+	var ends []int
+	for i := range segs {
+		raw = t.appendSegments(raw, segs[i:i+1])
+		if len(stable) == 0 || stable[0] != i {
+			continue
+		}
+		stable = stable[1:]
+		textLen := len(text)
+		if i+1 < len(segs) {
+			textLen = segs[i+1].offset
+		}
+		checkpoints = append(checkpoints, Checkpoint{TextLen: textLen})
+		ends = append(ends, len(raw))
+	}
+	for i, n := range ends {
+		checkpoints[len(checkpoints)-len(ends)+i].IDs = raw[:n:n]
+	}
+	ids := raw
+	if addSpecialTokens && t.post != nil {
+		ids = t.post.process(raw)
+	}
+	return ids, checkpoints
+}
+
+// appendSegments encodes the segments of a verbatim added-token split.
+func (t *Tokenizer) appendSegments(ids []uint32, segs []segment) []uint32 {
+	for _, seg := range segs {
+		if seg.isToken {
+			ids = append(ids, seg.id)
+			continue
+		}
+		normalized := seg.text
+		if t.normalizer != nil {
+			normalized = t.normalizer.normalize(normalized)
+		}
+		for _, sub := range t.normalized.split(normalized, seg.offset) {
+			if sub.isToken {
+				ids = append(ids, sub.id)
+				continue
+			}
+			ids = append(ids, t.encodeText(sub)...)
+		}
+	}
+	return ids
+}
+
+func (t *Tokenizer) encodeText(seg segment) []uint32 {
+	if seg.text == "" {
+		return nil
+	}
+	splits := []split{{text: seg.text, offset: seg.offset}}
+	if t.preTok != nil {
+		splits = t.preTok.preTokenize(splits)
+	}
+	var ids []uint32
+	for _, s := range splits {
+		ids = append(ids, t.model.tokenize(s.text)...)
+	}
+	return ids
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type goldenCase struct {
+	Tokenizer        string   `json:"tokenizer"`
+	Text             string   `json:"text"`
+	AddSpecialTokens bool     `json:"addSpecialTokens"`
+	IDs              []uint32 `json:"ids"`
+}
+
+// TestEncode_Golden checks every fixture tokenizer against expected token IDs.
+// The fixtures are small hand-built tokenizer.json files, one per supported
+// model type; the expected IDs are derived by hand from their vocabularies
+// and merges, not produced by the Rust tokenizers library. Real model
+// tokenizers are checked against Hugging Face output by
+// TestLocalTokenizer_Reference in the parent package.
+func TestEncode_Golden(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "golden.json"))
+	require.NoError(t, err)
+	var cases []goldenCase
+	require.NoError(t, json.Unmarshal(data, &cases))
+
+	tokenizers := map[string]*Tokenizer{}
+	for _, tc := range cases {
+		tk, ok := tokenizers[tc.Tokenizer]
+		if !ok {
+			tk, err = LoadFile(filepath.Join("testdata", tc.Tokenizer, "tokenizer.json"))
+			require.NoError(t, err, tc.Tokenizer)
+			tokenizers[tc.Tokenizer] = tk
+		}
+		t.Run(tc.Tokenizer+"/"+tc.Text, func(t *testing.T) {
+			assert.Equal(t, tc.IDs, tk.Encode(tc.Text, tc.AddSpecialTokens))
+			// A second pass is served from the BPE word cache and must agree.
+			assert.Equal(t, tc.IDs, tk.Encode(tc.Text, tc.AddSpecialTokens))
+		})
+	}
+}
+
+func TestTokenToID(t *testing.T) {
+	tk, err := LoadFile(filepath.Join("testdata", "bytelevel-bpe", "tokenizer.json"))
+	require.NoError(t, err)
+
+	id, ok := tk.TokenToID("<|eot_id|>")
+	require.True(t, ok)
+	assert.Equal(t, uint32(101), id)
+
+	id, ok = tk.TokenToID("Hello")
+	require.True(t, ok)
+	assert.Equal(t, uint32(23), id)
+
+	_, ok = tk.TokenToID("missing")
+	assert.False(t, ok)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "not json", json: `{`},
+		{name: "no model", json: `{"model": null}`},
+		{name: "unsupported model", json: `{"model": {"type": "WordLevel"}}`},
+		{name: "unsupported normalizer", json: `{"normalizer": {"type": "Bogus"}, "model": {"type": "BPE", "vocab": {}, "merges": []}}`},
+		{name: "unsupported pre-tokenizer", json: `{"pre_tokenizer": {"type": "Bogus"}, "model": {"type": "BPE", "vocab": {}, "merges": []}}`},
+		{name: "merge with unknown token", json: `{"model": {"type": "BPE", "vocab": {"a": 0}, "merges": ["a b"]}}`},
+		{name: "lookbehind regex", json: `{"pre_tokenizer": {"type": "Split", "pattern": {"Regex": "(?<=a)b"}, "behavior": "Isolated"}, "model": {"type": "BPE", "vocab": {}, "merges": []}}`},
+		{name: "wordpiece without unk", json: `{"model": {"type": "WordPiece", "vocab": {"a": 0}}}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load([]byte(tc.json))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEncodeFrom(t *testing.T) {
+	withTokens := func(t *testing.T, tokens ...addedToken) *Tokenizer {
+		data, err := os.ReadFile(filepath.Join("testdata", "bytelevel-bpe", "tokenizer.json"))
+		require.NoError(t, err)
+		var tj map[string]any
+		require.NoError(t, json.Unmarshal(data, &tj))
+		added := tj["added_tokens"].([]any)
+		for _, tok := range tokens {
+			added = append(added, tok)
+		}
+		tj["added_tokens"] = added
+		data, err = json.Marshal(tj)
+		require.NoError(t, err)
+		tk, err := Load(data)
+		require.NoError(t, err)
+		return tk
+	}
+	bytelevel, err := LoadFile(filepath.Join("testdata", "bytelevel-bpe", "tokenizer.json"))
+	require.NoError(t, err)
+	sentencepiece, err := LoadFile(filepath.Join("testdata", "sentencepiece-bpe", "tokenizer.json"))
+	require.NoError(t, err)
+	overlapping := withTokens(t, addedToken{ID: 200, Content: "<x>"}, addedToken{ID: 201, Content: "<x>y"})
+	rstrip := withTokens(t, addedToken{ID: 200, Content: "<r>", RStrip: true})
+	singleWord := withTokens(t, addedToken{ID: 200, Content: "w", SingleWord: true})
+
+	tests := []struct {
+		name      string
+		tk        *Tokenizer
+		prefix    string
+		suffix    string
+		wantReuse bool
+	}{
+		{name: "added token", tk: bytelevel, prefix: "Hello<|eot_id|>", suffix: " world", wantReuse: true},
+		{name: "no added token", tk: bytelevel, prefix: "Hello", suffix: " world"},
+		{name: "token at the end", tk: sentencepiece, prefix: "</s>Hello</s>", suffix: "Hello world", wantReuse: true},
+		{name: "longer token", tk: overlapping, prefix: "a<x>", suffix: "y b"},
+		{name: "longer token absent", tk: overlapping, prefix: "a<x>b<|eot_id|>", suffix: "<x>y", wantReuse: true},
+		{name: "rstrip absorbs suffix", tk: rstrip, prefix: "a<r>", suffix: "  b"},
+		{name: "rstrip before text", tk: rstrip, prefix: "a<r> b<|eot_id|>", suffix: "<r> c", wantReuse: true},
+		{name: "single word joined", tk: singleWord, prefix: "a w", suffix: "ord"},
+		{name: "single word kept", tk: singleWord, prefix: "a w b<|eot_id|>", suffix: "w c", wantReuse: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			text := tc.prefix + tc.suffix
+			for _, addSpecial := range []bool{false, true} {
+				_, checkpoints := tc.tk.EncodeFrom(tc.prefix, Checkpoint{}, addSpecial)
+				reused := false
+				for _, cp := range checkpoints {
+					require.Equal(t, tc.tk.Encode(tc.prefix[:cp.TextLen], false), cp.IDs)
+					ids, _ := tc.tk.EncodeFrom(text, cp, addSpecial)
+					assert.Equal(t, tc.tk.Encode(text, addSpecial), ids, "checkpoint at %d", cp.TextLen)
+					reused = true
+				}
+				assert.Equal(t, tc.wantReuse, reused)
+			}
+		})
+	}
+
+	t.Run("checkpoint past the text", func(t *testing.T) {
+		ids, _ := bytelevel.EncodeFrom("Hi", Checkpoint{TextLen: 10, IDs: []uint32{1}}, true)
+		assert.Equal(t, bytelevel.Encode("Hi", true), ids)
+	})
+}
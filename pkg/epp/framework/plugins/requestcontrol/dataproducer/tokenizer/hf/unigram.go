@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// unigramUnkPenalty is subtracted from the lowest piece score to score
+// unknown characters, as in SentencePiece.
+const unigramUnkPenalty = 10.0
+
+// unigram is the SentencePiece unigram language model; words are segmented
+// by Viterbi search over piece log-probabilities.
+type unigram struct {
+	vocab        map[string]uint32
+	scores       []float64
+	unkID        uint32
+	hasUnk       bool
+	byteFallback bool
+	maxPieceLen  int
+	minScore     float64
+}
+
+func parseUnigram(raw json.RawMessage) (*unigram, error) {
+	var cfg struct {
+		Vocab        [][2]json.RawMessage `json:"vocab"`
+		UnkID        *uint32              `json:"unk_id"`
+		ByteFallback bool                 `json:"byte_fallback"`
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid Unigram model: %w", err)
+	}
+	if len(cfg.Vocab) == 0 {
+		return nil, errors.New("unigram vocabulary is empty")
+	}
+	m := &unigram{
+		vocab:        make(map[string]uint32, len(cfg.Vocab)),
+		scores:       make([]float64, len(cfg.Vocab)),
+		byteFallback: cfg.ByteFallback,
+		minScore:     math.Inf(1),
+	}
+	for i, entry := range cfg.Vocab {
+		var piece string
+		if err := json.Unmarshal(entry[0], &piece); err != nil {
+			return nil, fmt.Errorf("invalid Unigram piece %d: %w", i, err)
+		}
+		if err := json.Unmarshal(entry[1], &m.scores[i]); err != nil {
+			return nil, fmt.Errorf("invalid Unigram score %d: %w", i, err)
+		}
+		m.vocab[piece] = uint32(i)
+		m.maxPieceLen = max(m.maxPieceLen, len(piece))
+		m.minScore = min(m.minScore, m.scores[i])
+	}
+	if cfg.UnkID != nil {
+		if int(*cfg.UnkID) >= len(cfg.Vocab) {
+			return nil, fmt.Errorf("unigram unk_id %d is out of range", *cfg.UnkID)
+		}
+		m.unkID, m.hasUnk = *cfg.UnkID, true
+	}
+	return m, nil
+}
+
+func (m *unigram) tokenToID(token string) (uint32, bool) {
+	id, ok := m.vocab[token]
+	return id, ok
+}
+
+type latticeNode struct {
+	id       uint32
+	score    float64
+	startsAt int
+	reached  bool
+}
+
+func (m *unigram) tokenize(word string) []uint32 {
+	if word == "" {
+		return nil
+	}
+	unkScore := m.minScore - unigramUnkPenalty
+	best := make([]latticeNode, len(word)+1)
+	best[0].reached = true
+
+	for start := 0; start < len(word); {
+		_, charLen := utf8.DecodeRuneInString(word[start:])
+		base := best[start].score
+		hasSingle := false
+		for end := start + 1; end <= len(word) && end-start <= m.maxPieceLen; end++ {
+			id, ok := m.vocab[word[start:end]]
+			if !ok {
+				continue
+			}
+			candidate := base + m.scores[id]
+			if node := &best[end]; !node.reached || candidate > node.score {
+				*node = latticeNode{id: id, score: candidate, startsAt: start, reached: true}
+			}
+			if end-start == charLen {
+				hasSingle = true
+			}
+		}
+		if !hasSingle {
+			candidate := base + unkScore
+			if node := &best[start+charLen]; !node.reached || candidate > node.score {
+				*node = latticeNode{id: m.unkID, score: candidate, startsAt: start, reached: true}
+			}
+		}
+		start += charLen
+	}
+
+	// Backtrack, fusing runs of unknown characters into a single piece.
+	var pieces []string
+	unkEnd := -1
+	for end := len(word); end > 0; {
+		node := best[end]
+		if m.hasUnk && node.id == m.unkID {
+			if unkEnd < 0 {
+				unkEnd = end
+			}
+		} else {
+			if unkEnd >= 0 {
+				pieces = append(pieces, word[end:unkEnd])
+				unkEnd = -1
+			}
+			pieces = append(pieces, word[node.startsAt:end])
+		}
+		end = node.startsAt
+	}
+	if unkEnd >= 0 {
+		pieces = append(pieces, word[:unkEnd])
+	}
+
+	ids := make([]uint32, 0, len(pieces))
+	for i := len(pieces) - 1; i >= 0; i-- {
+		ids = append(ids, m.pieceIDs(pieces[i])...)
+	}
+	return ids
+}
+
+func (m *unigram) pieceIDs(piece string) []uint32 {
+	if id, ok := m.vocab[piece]; ok {
+		return []uint32{id}
+	}
+	if m.byteFallback {
+		ids := make([]uint32, 0, len(piece))
+		for i := 0; i < len(piece); i++ {
+			id, ok := m.vocab[fmt.Sprintf("<0x%02X>", piece[i])]
+			if !ok {
+				break
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) == len(piece) {
+			return ids
+		}
+	}
+	if !m.hasUnk {
+		return nil
+	}
+	return []uint32{m.unkID}
+}
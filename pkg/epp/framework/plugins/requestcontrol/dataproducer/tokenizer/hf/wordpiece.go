@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hf
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// wordPiece is the greedy longest-match-first WordPiece model used by BERT.
+type wordPiece struct {
+	vocab         map[string]uint32
+	unkID         uint32
+	prefix        string
+	maxInputChars int
+}
+
+func parseWordPiece(raw json.RawMessage) (*wordPiece, error) {
+	cfg := struct {
+		Vocab                   map[string]uint32 `json:"vocab"`
+		UnkToken                string            `json:"unk_token"`
+		ContinuingSubwordPrefix string            `json:"continuing_subword_prefix"`
+		MaxInputCharsPerWord    int               `json:"max_input_chars_per_word"`
+	}{UnkToken: "[UNK]", ContinuingSubwordPrefix: "##", MaxInputCharsPerWord: 100}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid WordPiece model: %w", err)
+	}
+	unkID, ok := cfg.Vocab[cfg.UnkToken]
+	if !ok {
+		return nil, fmt.Errorf("WordPiece unk_token %q is not in the vocabulary", cfg.UnkToken)
+	}
+	return &wordPiece{
+		vocab:         cfg.Vocab,
+		unkID:         unkID,
+		prefix:        cfg.ContinuingSubwordPrefix,
+		maxInputChars: cfg.MaxInputCharsPerWord,
+	}, nil
+}
+
+func (m *wordPiece) tokenToID(token string) (uint32, bool) {
+	id, ok := m.vocab[token]
+	return id, ok
+}
+
+func (m *wordPiece) tokenize(word string) []uint32 {
+	if word == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(word) > m.maxInputChars {
+		return []uint32{m.unkID}
+	}
+	var ids []uint32
+	for start := 0; start < len(word); {
+		end := len(word)
+		found := false
+		for start < end {
+			sub := word[start:end]
+			if start > 0 {
+				sub = m.prefix + sub
+			}
+			if id, ok := m.vocab[sub]; ok {
+				ids = append(ids, id)
+				found = true
+				break
+			}
+			_, size := utf8.DecodeLastRuneInString(word[start:end])
+			end -= size
+		}
+		if !found {
+			return []uint32{m.unkID}
+		}
+		start = end
+	}
+	return ids
+}
@@ -0,0 +1,630 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/cespare/xxhash/v2"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/llm-d/llm-d-kv-cache/pkg/tokenization"
+	tokenizerTypes "github.com/llm-d/llm-d-kv-cache/pkg/tokenization/types"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer/chattemplate"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer/hf"
+)
+
+const (
+	defaultLocalCacheSize = 1024
+	// requestTemplateCacheSize bounds the parsed request-level chat templates.
+	requestTemplateCacheSize = 16
+
+	defaultTemplateName = "default"
+	toolUseTemplateName = "tool_use"
+)
+
+// specialTokenKeys are the tokenizer_config.json entries transformers exposes
+// to chat templates as variables.
+var specialTokenKeys = []string{
+	"bos_token", "eos_token", "unk_token", "sep_token", "pad_token", "cls_token", "mask_token",
+}
+
+// localConfig configures the in-process Hugging Face tokenizer backend.
+type localConfig struct {
+	// Path is a local model directory containing tokenizer.json and, for chat
+	// requests, a chat template (chat_template.jinja, tokenizer_config.json or
+	// chat_template.json).
+	Path string `json:"path"`
+	// CacheSize bounds the number of cached tokenization results. Zero uses
+	// the default; a negative value disables caching.
+	CacheSize int `json:"cacheSize,omitempty"`
+	// PrefixCacheTokens bounds the token IDs kept for incremental tokenization
+	// of chat conversations. Zero uses the default; a negative value disables
+	// incremental tokenization.
+	PrefixCacheTokens int `json:"prefixCacheTokens,omitempty"`
+}
+
+// localTokenizer tokenizes prompts in-process from a tokenizer.json and chat
+// template, reproducing what vLLM renders for the same model directory.
+type localTokenizer struct {
+	tk            *hf.Tokenizer
+	templates     map[string]*chattemplate.Template
+	specialTokens map[string]any
+	// results caches token IDs by a hash of the rendered input.
+	results *lru.Cache[uint64, []uint32]
+	// requestTemplates caches chat templates supplied on requests.
+	requestTemplates *lru.Cache[string, *chattemplate.Template]
+	// prefixes caches the token IDs of conversation prefixes so chat turns
+	// only tokenize the newly appended messages.
+	prefixes *prefixCache
+	// typedName labels the prefix cache metrics.
+	typedName plugin.TypedName
+}
+
+func newLocalTokenizer(cfg *localConfig) (*localTokenizer, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("'local.path' must be specified")
+	}
+	tk, err := hf.LoadFile(filepath.Join(cfg.Path, "tokenizer.json"))
+	if err != nil {
+		return nil, err
+	}
+	lt := &localTokenizer{tk: tk, specialTokens: map[string]any{}}
+	if err := lt.loadChatConfig(cfg.Path); err != nil {
+		return nil, err
+	}
+
+	size := cfg.CacheSize
+	if size == 0 {
+		size = defaultLocalCacheSize
+	}
+	if size > 0 {
+		if lt.results, err = lru.New[uint64, []uint32](size); err != nil {
+			return nil, err
+		}
+	}
+	prefixTokens := cfg.PrefixCacheTokens
+	if prefixTokens == 0 {
+		prefixTokens = defaultPrefixCacheTokens
+	}
+	if prefixTokens > 0 {
+		lt.prefixes = newPrefixCache(prefixTokens)
+	}
+	if lt.requestTemplates, err = lru.New[string, *chattemplate.Template](requestTemplateCacheSize); err != nil {
+		return nil, err
+	}
+	return lt, nil
+}
+
+// loadChatConfig reads the special tokens and chat templates of a model
+// directory. A standalone chat_template.jinja takes precedence over the
+// template embedded in tokenizer_config.json, which takes precedence over
+// chat_template.json.
+func (lt *localTokenizer) loadChatConfig(dir string) error {
+	var tokCfg map[string]json.RawMessage
+	if data, err := os.ReadFile(filepath.Join(dir, "tokenizer_config.json")); err == nil {
+		if err := json.Unmarshal(data, &tokCfg); err != nil {
+			return fmt.Errorf("invalid tokenizer_config.json: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, key := range specialTokenKeys {
+		if tok, ok := specialTokenContent(tokCfg[key]); ok {
+			lt.specialTokens[key] = tok
+		}
+	}
+
+	sources := map[string]string{}
+	if data, err := os.ReadFile(filepath.Join(dir, "chat_template.jinja")); err == nil {
+		sources[defaultTemplateName] = string(data)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(sources) == 0 && tokCfg["chat_template"] != nil {
+		if err := parseTemplateField(tokCfg["chat_template"], sources); err != nil {
+			return fmt.Errorf("invalid chat_template in tokenizer_config.json: %w", err)
+		}
+	}
+	if len(sources) == 0 {
+		if data, err := os.ReadFile(filepath.Join(dir, "chat_template.json")); err == nil {
+			var file struct {
+				ChatTemplate json.RawMessage `json:"chat_template"`
+			}
+			if err := json.Unmarshal(data, &file); err != nil {
+				return fmt.Errorf("invalid chat_template.json: %w", err)
+			}
+			if err := parseTemplateField(file.ChatTemplate, sources); err != nil {
+				return fmt.Errorf("invalid chat_template.json: %w", err)
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	lt.templates = make(map[string]*chattemplate.Template, len(sources))
+	for name, src := range sources {
+		tpl, err := chattemplate.Parse(src)
+		if err != nil {
+			return fmt.Errorf("chat template %q: %w", name, err)
+		}
+		lt.templates[name] = tpl
+	}
+	return nil
+}
+
+// parseTemplateField accepts a single template string or a list of named
+// templates.
+func parseTemplateField(raw json.RawMessage, out map[string]string) error {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		out[defaultTemplateName] = single
+		return nil
+	}
+	var named []struct {
+		Name     string `json:"name"`
+		Template string `json:"template"`
+	}
+	if err := json.Unmarshal(raw, &named); err != nil {
+		return err
+	}
+	for _, t := range named {
+		out[t.Name] = t.Template
+	}
+	return nil
+}
+
+// specialTokenContent reads a special token given as a string or as a
+// serialized AddedToken object.
+func specialTokenContent(raw json.RawMessage) (string, bool) {
+	if raw == nil {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, true
+	}
+	var obj struct {
+		Content *string `json:"content"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil && obj.Content != nil {
+		return *obj.Content, true
+	}
+	return "", false
+}
+
+// Render tokenizes a completions prompt. Like vLLM, special tokens such as
+// BOS are added unless the request sets add_special_tokens to false; for a
+// list of prompts only the first is tokenized.
+func (lt *localTokenizer) Render(_ context.Context, payload fwkrh.RequestPayload) ([]uint32, []tokenizerTypes.Offset, error) {
+	pm, ok := payload.AsMap()
+	if !ok {
+		return nil, nil, errors.New("local tokenizer requires a parsed PayloadMap")
+	}
+	var prompt string
+	switch p := pm["prompt"].(type) {
+	case string:
+		prompt = p
+	case []any:
+		ok = false
+		if len(p) > 0 {
+			prompt, ok = p[0].(string)
+		}
+		if !ok {
+			return nil, nil, errors.New("local tokenizer requires a string prompt")
+		}
+	default:
+		return nil, nil, errors.New("local tokenizer requires a string prompt")
+	}
+	return lt.encode(prompt, boolField(pm, "add_special_tokens", true)), nil, nil
+}
+
+// RenderChat applies the chat template and tokenizes the result. Multimodal
+// content is not supported.
+func (lt *localTokenizer) RenderChat(_ context.Context, payload fwkrh.RequestPayload) ([]uint32, *tokenization.MultiModalFeatures, error) {
+	pm, ok := payload.AsMap()
+	if !ok {
+		return nil, nil, errors.New("local tokenizer requires a parsed PayloadMap")
+	}
+	chat, err := lt.prepareChat(pm)
+	if err != nil {
+		return nil, nil, err
+	}
+	prompt, err := chat.render()
+	if err != nil {
+		return nil, nil, err
+	}
+	addSpecialTokens := boolField(pm, "add_special_tokens", false)
+	return lt.cachedEncode(prompt, addSpecialTokens, func() []uint32 {
+		return lt.encodeChat(chat, prompt, addSpecialTokens)
+	}), nil, nil
+}
+
+func (lt *localTokenizer) encode(text string, addSpecialTokens bool) []uint32 {
+	return lt.cachedEncode(text, addSpecialTokens, func() []uint32 {
+		return lt.tk.Encode(text, addSpecialTokens)
+	})
+}
+
+// cachedEncode returns the cached token IDs of text, calling encode on a miss.
+func (lt *localTokenizer) cachedEncode(text string, addSpecialTokens bool, encode func() []uint32) []uint32 {
+	if lt.results == nil {
+		return encode()
+	}
+	h := xxhash.New()
+	if addSpecialTokens {
+		_, _ = h.WriteString("1")
+	} else {
+		_, _ = h.WriteString("0")
+	}
+	_, _ = h.WriteString(text)
+	key := h.Sum64()
+	if ids, ok := lt.results.Get(key); ok {
+		return slices.Clone(ids)
+	}
+	ids := encode()
+	lt.results.Add(key, ids)
+	return slices.Clone(ids)
+}
+
+// encodeChat tokenizes a rendered chat prompt, resuming from the longest
+// checkpoint cached for a prefix of the conversation so only the text after it
+// is tokenized. The checkpoints of prompt are cached for the next turn, which
+// typically appends an assistant reply and a new user message.
+func (lt *localTokenizer) encodeChat(chat *chatPrompt, prompt string, addSpecialTokens bool) []uint32 {
+	n := len(chat.messages)
+	if chat.continueFinal {
+		// The final message is cut open and changes as it is continued.
+		n--
+	}
+	if lt.prefixes == nil || n <= 0 {
+		return lt.tk.Encode(prompt, addSpecialTokens)
+	}
+	keys, err := messagePrefixKeys(chat.seed(), chat.raw[:n])
+	if err != nil {
+		return lt.tk.Encode(prompt, addSpecialTokens)
+	}
+
+	cp, _ := lt.prefixes.longest(keys, prompt)
+	ids, checkpoints := lt.tk.EncodeFrom(prompt, cp, addSpecialTokens)
+	lt.prefixes.add(keys[n-1], prompt, checkpoints)
+	recordPrefixCacheLookup(lt.typedName, len(cp.IDs), len(ids), lt.prefixes.size())
+	return ids
+}
+
+// chatPrompt is a chat request prepared the way vLLM prepares it for
+// transformers' apply_chat_template.
+type chatPrompt struct {
+	tpl *chattemplate.Template
+	// templateKey identifies tpl: a model template name or the source of a
+	// request-level template.
+	templateKey string
+	vars        map[string]any
+	// raw holds the request messages and messages their template form.
+	raw           []any
+	messages      []any
+	continueFinal bool
+	pm            fwkrh.PayloadMap
+}
+
+func (lt *localTokenizer) prepareChat(pm fwkrh.PayloadMap) (*chatPrompt, error) {
+	rawMessages, ok := pm["messages"].([]any)
+	if !ok {
+		return nil, errors.New("local tokenizer requires a 'messages' list")
+	}
+	tools, hasTools := pm["tools"].([]any)
+	hasTools = hasTools && len(tools) > 0
+	tpl, templateKey, err := lt.selectTemplate(pm, hasTools)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := buildConversation(rawMessages, tpl.IteratesOverContent())
+	if err != nil {
+		return nil, err
+	}
+	addGenerationPrompt := boolField(pm, "add_generation_prompt", true)
+	continueFinal := boolField(pm, "continue_final_message", false)
+	if addGenerationPrompt && continueFinal {
+		return nil, errors.New("'continue_final_message' and 'add_generation_prompt' cannot both be true")
+	}
+
+	vars := make(map[string]any, len(lt.specialTokens)+8)
+	for k, v := range lt.specialTokens {
+		vars[k] = v
+	}
+	if kwargs, ok := pm["chat_template_kwargs"].(map[string]any); ok {
+		for k, v := range kwargs {
+			vars[k] = v
+		}
+	}
+	vars["messages"] = messages
+	vars["tools"] = nil
+	if hasTools {
+		vars["tools"] = normalizeTools(tools)
+	}
+	vars["documents"] = pm["documents"]
+	vars["add_generation_prompt"] = addGenerationPrompt
+
+	return &chatPrompt{
+		tpl:           tpl,
+		templateKey:   templateKey,
+		vars:          vars,
+		raw:           rawMessages,
+		messages:      messages,
+		continueFinal: continueFinal,
+		pm:            pm,
+	}, nil
+}
+
+// render renders the whole conversation as requested.
+func (c *chatPrompt) render() (string, error) {
+	rendered, err := c.tpl.Render(c.vars)
+	if err != nil {
+		return "", err
+	}
+	if c.continueFinal && len(c.messages) > 0 {
+		return truncateAfterFinalMessage(rendered, c.messages[len(c.messages)-1])
+	}
+	return rendered, nil
+}
+
+// seed identifies everything besides the messages that affects how a
+// conversation renders: the template and the request's tools, documents and
+// template arguments. The prefix cache belongs to one tokenizer, so the model
+// is implied.
+func (c *chatPrompt) seed() uint64 {
+	h := xxhash.New()
+	_, _ = h.WriteString(c.templateKey)
+	for _, key := range []string{"tools", "documents", "chat_template_kwargs"} {
+		// Values come from decoded JSON and always marshal.
+		data, _ := json.Marshal(c.pm[key])
+		_, _ = h.WriteString("\x00")
+		_, _ = h.Write(data)
+	}
+	return h.Sum64()
+}
+
+// selectTemplate returns the chat template for a request and a key that
+// identifies it.
+func (lt *localTokenizer) selectTemplate(pm fwkrh.PayloadMap, hasTools bool) (*chattemplate.Template, string, error) {
+	if src, ok := pm["chat_template"].(string); ok && src != "" {
+		key := "request:" + src
+		if tpl, ok := lt.requestTemplates.Get(src); ok {
+			return tpl, key, nil
+		}
+		tpl, err := chattemplate.Parse(src)
+		if err != nil {
+			return nil, "", err
+		}
+		lt.requestTemplates.Add(src, tpl)
+		return tpl, key, nil
+	}
+	if hasTools {
+		if tpl, ok := lt.templates[toolUseTemplateName]; ok {
+			return tpl, "model:" + toolUseTemplateName, nil
+		}
+	}
+	if tpl, ok := lt.templates[defaultTemplateName]; ok {
+		return tpl, "model:" + defaultTemplateName, nil
+	}
+	return nil, "", errors.New("no chat template is available for the local tokenizer")
+}
+
+// buildConversation converts OpenAI messages into the dicts chat templates
+// see. With openAIContent the content stays a list of text parts; otherwise
+// text parts are joined with newlines into a string.
+func buildConversation(raw []any, openAIContent bool) ([]any, error) {
+	out := make([]any, 0, len(raw))
+	for i, item := range raw {
+		msg, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("message %d is not an object", i)
+		}
+		role, _ := msg["role"].(string)
+		texts, err := contentTexts(msg["content"])
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+
+		conv := chattemplate.NewDict()
+		conv.Set("role", role)
+		if openAIContent {
+			parts := make([]any, len(texts))
+			for j, text := range texts {
+				part := chattemplate.NewDict()
+				part.Set("type", "text")
+				part.Set("text", text)
+				parts[j] = part
+			}
+			conv.Set("content", parts)
+		} else {
+			conv.Set("content", strings.Join(texts, "\n"))
+		}
+
+		switch role {
+		case "assistant":
+			if calls, ok := msg["tool_calls"].([]any); ok {
+				parsed, err := parseToolCalls(calls)
+				if err != nil {
+					return nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				conv.Set("tool_calls", parsed)
+			}
+			reasoning := msg["reasoning"]
+			if reasoning == nil {
+				reasoning = msg["reasoning_content"]
+			}
+			if reasoning != nil {
+				conv.Set("reasoning", reasoning)
+				conv.Set("reasoning_content", reasoning)
+			}
+		case "tool":
+			if id, ok := msg["tool_call_id"]; ok {
+				conv.Set("tool_call_id", id)
+			}
+		}
+		if name, ok := msg["name"].(string); ok {
+			conv.Set("name", name)
+		}
+		out = append(out, conv)
+	}
+	return out, nil
+}
+
+func contentTexts(content any) ([]string, error) {
+	switch c := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{c}, nil
+	case []any:
+		texts := make([]string, 0, len(c))
+		for _, item := range c {
+			part, ok := item.(map[string]any)
+			if !ok {
+				return nil, errors.New("content part is not an object")
+			}
+			partType, _ := part["type"].(string)
+			if partType != "text" {
+				return nil, fmt.Errorf("content part type %q is not supported by the local tokenizer", partType)
+			}
+			text, _ := part["text"].(string)
+			texts = append(texts, text)
+		}
+		return texts, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type %T", content)
+	}
+}
+
+// parseToolCalls decodes JSON-string arguments into objects, which is the
+// shape tool-use chat templates expect.
+func parseToolCalls(calls []any) ([]any, error) {
+	out := make([]any, len(calls))
+	for i, item := range calls {
+		call, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("tool call %d is not an object", i)
+		}
+		fn, _ := call["function"].(map[string]any)
+		if fn == nil {
+			out[i] = call
+			continue
+		}
+		var args any = map[string]any{}
+		switch a := fn["arguments"].(type) {
+		case string:
+			if a != "" {
+				if err := json.Unmarshal([]byte(a), &args); err != nil {
+					return nil, fmt.Errorf("tool call %d has invalid arguments: %w", i, err)
+				}
+			}
+		case map[string]any, []any:
+			args = a
+		}
+		fnCopy := make(map[string]any, len(fn))
+		for k, v := range fn {
+			fnCopy[k] = v
+		}
+		fnCopy["arguments"] = args
+		callCopy := make(map[string]any, len(call))
+		for k, v := range call {
+			callCopy[k] = v
+		}
+		callCopy["function"] = fnCopy
+		out[i] = callCopy
+	}
+	return out, nil
+}
+
+// normalizeTools reproduces vLLM's serialization of tool definitions, which
+// orders fields as declared and includes unset optional fields as null. Keys
+// inside the parameters schema are sorted since request order is not kept.
+func normalizeTools(tools []any) []any {
+	out := make([]any, len(tools))
+	for i, item := range tools {
+		tool, ok := item.(map[string]any)
+		if !ok {
+			out[i] = item
+			continue
+		}
+		fn, _ := tool["function"].(map[string]any)
+		fnDict := chattemplate.NewDict()
+		fnDict.Set("name", fn["name"])
+		fnDict.Set("description", fn["description"])
+		fnDict.Set("parameters", chattemplate.FromGo(fn["parameters"]))
+		toolType := tool["type"]
+		if toolType == nil {
+			toolType = "function"
+		}
+		d := chattemplate.NewDict()
+		d.Set("type", toolType)
+		d.Set("function", fnDict)
+		out[i] = d
+	}
+	return out
+}
+
+// truncateAfterFinalMessage cuts the rendered chat right after the final
+// message's text so the model continues it, following transformers.
+func truncateAfterFinalMessage(rendered string, last any) (string, error) {
+	msg, _ := last.(*chattemplate.Dict)
+	var final string
+	if msg != nil {
+		content, _ := msg.Get("content")
+		switch c := content.(type) {
+		case string:
+			final = c
+		case []any:
+			for j := len(c) - 1; j >= 0; j-- {
+				if part, ok := c[j].(*chattemplate.Dict); ok {
+					if text, ok := part.Get("text"); ok {
+						final, _ = text.(string)
+						break
+					}
+				}
+			}
+		}
+	}
+	trimmed := strings.TrimSpace(final)
+	loc := strings.LastIndex(rendered, trimmed)
+	if loc < 0 {
+		return "", errors.New("'continue_final_message' is set but the final message does not appear in the rendered chat")
+	}
+	if end := loc + len(strings.TrimLeftFunc(final, unicode.IsSpace)); end <= len(rendered) && rendered[loc:end] == final {
+		return rendered[:end], nil
+	}
+	return rendered[:loc+len(trimmed)], nil
+}
+
+func boolField(pm fwkrh.PayloadMap, key string, def bool) bool {
+	if v, ok := pm[key].(bool); ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer/chattemplate"
+	"github.com/llm-d/llm-d-router/test/utils"
+)
+
+// testdata/llama3-tiny is a Llama 3 style model directory with a reduced
+// vocabulary: <|begin_of_text|>=100, <|eot_id|>=101, <|start_header_id|>=102,
+// <|end_header_id|>=103, "user"=33, "assistant"=34, "\n\n"=36, "Hello"=23,
+// " world"=28.
+const localTestModelDir = "testdata/llama3-tiny"
+
+func newTestLocalTokenizer(t *testing.T) *localTokenizer {
+	t.Helper()
+	lt, err := newLocalTokenizer(&localConfig{Path: localTestModelDir})
+	require.NoError(t, err)
+	return lt
+}
+
+func userMessage(content any) map[string]any {
+	return map[string]any{"role": "user", "content": content}
+}
+
+func TestLocalTokenizer_Render(t *testing.T) {
+	lt := newTestLocalTokenizer(t)
+	ctx := context.Background()
+
+	ids, _, err := lt.Render(ctx, fwkrh.PayloadMap{"prompt": "Hello world"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{100, 23, 28}, ids)
+
+	ids, _, err = lt.Render(ctx, fwkrh.PayloadMap{"prompt": []any{"Hello world", "ignored"}, "add_special_tokens": false})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{23, 28}, ids)
+
+	_, _, err = lt.Render(ctx, fwkrh.PayloadMap{"prompt": []any{}})
+	assert.Error(t, err)
+}
+
+func TestLocalTokenizer_RenderChat(t *testing.T) {
+	lt := newTestLocalTokenizer(t)
+	header := func(role uint32) []uint32 { return []uint32{102, role, 103, 36} }
+	concat := func(parts ...[]uint32) []uint32 {
+		var out []uint32
+		for _, p := range parts {
+			out = append(out, p...)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		payload fwkrh.PayloadMap
+		want    []uint32
+	}{
+		{
+			name:    "generation prompt by default",
+			payload: fwkrh.PayloadMap{"messages": []any{userMessage("Hello world")}},
+			want:    concat([]uint32{100}, header(33), []uint32{23, 28, 101}, header(34)),
+		},
+		{
+			name: "text parts are joined",
+			payload: fwkrh.PayloadMap{
+				"messages": []any{userMessage([]any{
+					map[string]any{"type": "text", "text": "Hello"},
+					map[string]any{"type": "text", "text": "world"},
+				})},
+				"add_generation_prompt": false,
+			},
+			// "Hello\nworld": the newline is byte-encoded as "Ċ" (9) and
+			// "world" has no leading space, so it merges to "w", "or", "ld".
+			want: concat([]uint32{100}, header(33), []uint32{23, 9, 5, 25, 27, 101}),
+		},
+		{
+			name: "continue final message",
+			payload: fwkrh.PayloadMap{
+				"messages": []any{
+					userMessage("Hello"),
+					map[string]any{"role": "assistant", "content": "Hello world"},
+				},
+				"add_generation_prompt":  false,
+				"continue_final_message": true,
+			},
+			want: concat([]uint32{100}, header(33), []uint32{23, 101}, header(34), []uint32{23, 28}),
+		},
+		{
+			name: "request chat template overrides the model's",
+			payload: fwkrh.PayloadMap{
+				"messages":      []any{userMessage("Hello")},
+				"chat_template": "{{ eos_token }}{% for m in messages %}{{ m.content }}{% endfor %}",
+			},
+			want: []uint32{101, 23},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, mm, err := lt.RenderChat(context.Background(), tt.payload)
+			require.NoError(t, err)
+			assert.Nil(t, mm)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestLocalTokenizer_RenderChatErrors(t *testing.T) {
+	lt := newTestLocalTokenizer(t)
+	tests := []struct {
+		name       string
+		payload    fwkrh.PayloadMap
+		errContain string
+	}{
+		{
+			name:       "missing messages",
+			payload:    fwkrh.PayloadMap{},
+			errContain: "'messages'",
+		},
+		{
+			name: "multimodal content",
+			payload: fwkrh.PayloadMap{"messages": []any{userMessage([]any{
+				map[string]any{"type": "image_url", "image_url": map[string]any{"url": "data:"}},
+			})}},
+			errContain: "not supported",
+		},
+		{
+			name: "conflicting continuation flags",
+			payload: fwkrh.PayloadMap{
+				"messages":               []any{userMessage("Hello")},
+				"add_generation_prompt":  true,
+				"continue_final_message": true,
+			},
+			errContain: "cannot both be true",
+		},
+		{
+			name: "template raises",
+			payload: fwkrh.PayloadMap{
+				"messages":      []any{userMessage("Hello")},
+				"chat_template": "{{ raise_exception('roles must alternate') }}",
+			},
+			errContain: "roles must alternate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := lt.RenderChat(context.Background(), tt.payload)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContain)
+		})
+	}
+}
+
+func TestLocalTokenizer_CacheReturnsCopies(t *testing.T) {
+	lt := newTestLocalTokenizer(t)
+	first := lt.encode("Hello world", true)
+	first[0] = 999
+	second := lt.encode("Hello world", true)
+	assert.Equal(t, []uint32{100, 23, 28}, second)
+	assert.Equal(t, 1, lt.results.Len())
+
+	// The special-token flag is part of the key.
+	assert.Equal(t, []uint32{23, 28}, lt.encode("Hello world", false))
+	assert.Equal(t, 2, lt.results.Len())
+
+	uncached, err := newLocalTokenizer(&localConfig{Path: localTestModelDir, CacheSize: -1})
+	require.NoError(t, err)
+	assert.Nil(t, uncached.results)
+	assert.Equal(t, []uint32{100, 23, 28}, uncached.encode("Hello world", true))
+}
+
+// TestLocalTokenizer_IncrementalChat grows conversations turn by turn and
+// checks that reusing cached conversation prefixes yields the same token IDs
+// as tokenizing every prompt from scratch.
+func TestLocalTokenizer_IncrementalChat(t *testing.T) {
+	full, err := newLocalTokenizer(&localConfig{Path: localTestModelDir, CacheSize: -1, PrefixCacheTokens: -1})
+	require.NoError(t, err)
+	assistant := func(content string) map[string]any {
+		return map[string]any{"role": "assistant", "content": content}
+	}
+	turns := []any{
+		userMessage("Hello world"),
+		assistant("Hello"),
+		userMessage(" Hello\n\nworld "),
+		assistant("world world"),
+		userMessage("Hello<|eot_id|>world"),
+		assistant(""),
+		userMessage("Hello"),
+	}
+
+	tests := []struct {
+		name string
+		// extra is merged into every request.
+		extra      fwkrh.PayloadMap
+		wantReused bool
+	}{
+		{name: "model template", wantReused: true},
+		{name: "continue final message", extra: fwkrh.PayloadMap{"add_generation_prompt": false, "continue_final_message": true}, wantReused: true},
+		{name: "add special tokens", extra: fwkrh.PayloadMap{"add_special_tokens": true}, wantReused: true},
+		{
+			// The last message renders differently, so only the messages
+			// before it are reused.
+			name:       "template marks the last message",
+			extra:      fwkrh.PayloadMap{"chat_template": "{% for m in messages %}{% if loop.last %}[{{ m.content }}]{% else %}{{ m.content }}{% endif %}<|eot_id|>{% endfor %}"},
+			wantReused: true,
+		},
+		{
+			// Tokenization only resumes after an added token.
+			name:  "no added tokens",
+			extra: fwkrh.PayloadMap{"chat_template": "{% for m in messages %}{{ m.role }}\n{% endfor %}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lt, err := newLocalTokenizer(&localConfig{Path: localTestModelDir, CacheSize: -1})
+			require.NoError(t, err)
+			lt.typedName = plugin.TypedName{Type: PluginType, Name: t.Name()}
+
+			for n := 1; n <= len(turns); n++ {
+				payload := fwkrh.PayloadMap{"messages": turns[:n]}
+				for k, v := range tt.extra {
+					payload[k] = v
+				}
+				want, _, err := full.RenderChat(context.Background(), payload)
+				require.NoError(t, err)
+				got, _, err := lt.RenderChat(context.Background(), payload)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, "turn %d", n)
+			}
+
+			reused := testutil.ToFloat64(prefixCacheTokensTotal.WithLabelValues(PluginType, t.Name(), tokensReused))
+			if tt.wantReused {
+				assert.Positive(t, reused)
+				assert.Positive(t, testutil.ToFloat64(prefixCacheLookupsTotal.WithLabelValues(PluginType, t.Name(), prefixCacheHit)))
+			} else {
+				assert.Zero(t, reused)
+			}
+		})
+	}
+}
+
+func TestLocalTokenizer_IncrementalChatBranches(t *testing.T) {
+	lt := newTestLocalTokenizer(t)
+	lt.typedName = plugin.TypedName{Type: PluginType, Name: t.Name()}
+	base := []any{userMessage("Hello"), map[string]any{"role": "assistant", "content": "world"}}
+
+	_, _, err := lt.RenderChat(context.Background(), fwkrh.PayloadMap{"messages": base})
+	require.NoError(t, err)
+	for _, next := range []string{"Hello world", "world"} {
+		messages := append(append([]any{}, base...), userMessage(next))
+		got, _, err := lt.RenderChat(context.Background(), fwkrh.PayloadMap{"messages": messages})
+		require.NoError(t, err)
+		prompt, err := mustPrepare(t, lt, messages).render()
+		require.NoError(t, err)
+		assert.Equal(t, lt.tk.Encode(prompt, false), got)
+	}
+	// Both branches reuse the shared two-message prefix.
+	assert.Equal(t, 2.0, testutil.ToFloat64(prefixCacheLookupsTotal.WithLabelValues(PluginType, t.Name(), prefixCacheHit)))
+}
+
+func mustPrepare(t *testing.T, lt *localTokenizer, messages []any) *chatPrompt {
+	t.Helper()
+	chat, err := lt.prepareChat(fwkrh.PayloadMap{"messages": messages})
+	require.NoError(t, err)
+	return chat
+}
+
+func TestBuildConversation(t *testing.T) {
+	msgs, err := buildConversation([]any{
+		map[string]any{
+			"role":    "assistant",
+			"content": nil,
+			"tool_calls": []any{map[string]any{
+				"id":       "call_1",
+				"type":     "function",
+				"function": map[string]any{"name": "get_weather", "arguments": `{"city": "Paris"}`},
+			}},
+			"reasoning_content": "thinking",
+		},
+		map[string]any{"role": "tool", "tool_call_id": "call_1", "content": "sunny", "name": "get_weather"},
+	}, false)
+	require.NoError(t, err)
+
+	tpl, err := chattemplate.Parse("{% for m in messages %}{{ m | tojson }}\n{% endfor %}")
+	require.NoError(t, err)
+	out, err := tpl.Render(map[string]any{"messages": msgs})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"role": "assistant", "content": "", "tool_calls": [{"function": {"arguments": {"city": "Paris"}, "name": "get_weather"}, "id": "call_1", "type": "function"}], "reasoning": "thinking", "reasoning_content": "thinking"}`+"\n"+
+			`{"role": "tool", "content": "sunny", "tool_call_id": "call_1", "name": "get_weather"}`+"\n",
+		out)
+
+	openai, err := buildConversation([]any{userMessage("hi")}, true)
+	require.NoError(t, err)
+	out, err = tpl.Render(map[string]any{"messages": openai})
+	require.NoError(t, err)
+	assert.Equal(t, `{"role": "user", "content": [{"type": "text", "text": "hi"}]}`+"\n", out)
+}
+
+func TestNormalizeTools(t *testing.T) {
+	tpl, err := chattemplate.Parse("{{ tools | tojson }}")
+	require.NoError(t, err)
+	out, err := tpl.Render(map[string]any{"tools": normalizeTools([]any{
+		map[string]any{"type": "function", "function": map[string]any{
+			"name":       "f",
+			"parameters": map[string]any{"type": "object", "properties": map[string]any{}},
+		}},
+	})})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"type": "function", "function": {"name": "f", "description": null, "parameters": {"properties": {}, "type": "object"}}}]`, out)
+}
+
+func TestProduce_LocalBackend(t *testing.T) {
+	handle := plugin.NewEppHandle(utils.NewTestContext(t), nil)
+	p, err := PluginFactory("test", plugin.StrictDecoder(json.RawMessage(`{"local":{"path":"`+localTestModelDir+`"}}`)), handle)
+	require.NoError(t, err)
+
+	req := &scheduling.InferenceRequest{
+		Body: &fwkrh.InferenceRequestBody{
+			ChatCompletions: &fwkrh.ChatCompletionsRequest{
+				Messages:  []fwkrh.Message{{Role: "user", Content: fwkrh.Content{Raw: "Hello world"}}},
+				CacheSalt: "salt",
+			},
+			Payload: fwkrh.PayloadMap{"messages": []any{userMessage("Hello world")}},
+		},
+	}
+	tp := p.(*Plugin)
+	require.NoError(t, tp.Produce(context.Background(), req, nil))
+	require.NotNil(t, req.Body.TokenizedPrompt)
+	assert.Equal(t, []uint32{100, 102, 33, 103, 36, 23, 28, 101, 102, 34, 103, 36}, req.Body.TokenizedPrompt.TokenIDs)
+	assert.Equal(t, "salt", req.Body.TokenizedPrompt.CacheSalt)
+	assert.Zero(t, tp.ProduceTimeout())
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	metricsutil "github.com/llm-d/llm-d-router/pkg/common/observability/metrics"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	eppmetrics "github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	prefixCacheHit  = "hit"
+	prefixCacheMiss = "miss"
+
+	tokensReused    = "reused"
+	tokensTokenized = "tokenized"
+)
+
+var (
+	prefixCacheLookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "token_producer_prefix_cache_lookups_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of chat prompts looked up in the incremental tokenization cache, by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "result"},
+	)
+
+	prefixCacheTokensTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "token_producer_prefix_cache_tokens_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of chat prompt tokens reused from the incremental tokenization cache or tokenized, by source.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "source"},
+	)
+
+	prefixCacheSizeTokens = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "token_producer_prefix_cache_size_tokens",
+			Help:      metricsutil.HelpMsgWithStability("Number of token IDs held by the incremental tokenization cache.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name"},
+	)
+)
+
+// registerMetrics registers the incremental tokenization metrics. A handle
+// without a metrics registry leaves them unregistered rather than failing the
+// plugin, which predates them.
+func registerMetrics(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		return nil
+	}
+	for _, c := range []prometheus.Collector{prefixCacheLookupsTotal, prefixCacheTokensTotal, prefixCacheSizeTokens} {
+		if err := registerer.Register(c); err != nil {
+			var alreadyRegistered prometheus.AlreadyRegisteredError
+			if errors.As(err, &alreadyRegistered) && alreadyRegistered.ExistingCollector == c {
+				continue
+			}
+			return fmt.Errorf("register token producer metric: %w", err)
+		}
+	}
+	return nil
+}
+
+func recordPrefixCacheLookup(typedName fwkplugin.TypedName, reused, total, cached int) {
+	result := prefixCacheMiss
+	if reused > 0 {
+		result = prefixCacheHit
+	}
+	prefixCacheLookupsTotal.WithLabelValues(typedName.Type, typedName.Name, result).Inc()
+	prefixCacheTokensTotal.WithLabelValues(typedName.Type, typedName.Name, tokensReused).Add(float64(reused))
+	prefixCacheTokensTotal.WithLabelValues(typedName.Type, typedName.Name, tokensTokenized).Add(float64(total - reused))
+	prefixCacheSizeTokens.WithLabelValues(typedName.Type, typedName.Name).Set(float64(cached))
+}
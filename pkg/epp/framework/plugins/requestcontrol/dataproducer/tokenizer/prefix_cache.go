@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer/hf"
+)
+
+// defaultPrefixCacheTokens bounds the prefix cache to 4Mi token IDs (16 MiB).
+const defaultPrefixCacheTokens = 4 << 20
+
+// checkpointsPerEntry bounds the checkpoints kept per cached prompt. The last
+// ones are kept: the next turn of a conversation usually extends the prompt
+// up to the assistant header of its generation prompt, and otherwise up to
+// the end of its last message.
+const checkpointsPerEntry = 4
+
+// prefixCache holds the token IDs of rendered conversation prompts so a new
+// turn of a conversation only tokenizes the text appended since an earlier
+// turn. Entries are keyed by messagePrefixKeys and hold checkpoints of the
+// prompt they encode, each remembering the length and hash of the text before
+// it, so a checkpoint is only reused when the new prompt starts with exactly
+// that text. Memory is bounded by the total number of cached token IDs rather
+// than by the number of entries.
+type prefixCache struct {
+	mu        sync.Mutex
+	entries   *simplelru.LRU[uint64, prefixEntry]
+	tokens    int
+	maxTokens int
+}
+
+type prefixEntry struct {
+	// ids is shared between readers and must not be modified.
+	ids    []uint32
+	points []prefixPoint
+}
+
+// prefixPoint is a checkpoint within an entry's ids, shortest first.
+type prefixPoint struct {
+	textLen  int
+	textHash uint64
+	idsLen   int
+}
+
+func newPrefixCache(maxTokens int) *prefixCache {
+	c := &prefixCache{maxTokens: maxTokens}
+	// Only fails for a non-positive size; the entry count is bounded by
+	// maxTokens instead.
+	c.entries, _ = simplelru.NewLRU(math.MaxInt, func(_ uint64, e prefixEntry) {
+		c.tokens -= len(e.ids)
+	})
+	return c
+}
+
+// longest returns the longest cached checkpoint, under the longest prefix
+// among keys ordered from shortest to longest, of text that text starts with.
+func (c *prefixCache) longest(keys []uint64, text string) (hf.Checkpoint, bool) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		c.mu.Lock()
+		e, ok := c.entries.Get(keys[i])
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		for j := len(e.points) - 1; j >= 0; j-- {
+			p := e.points[j]
+			if p.textLen <= len(text) && xxhash.Sum64String(text[:p.textLen]) == p.textHash {
+				return hf.Checkpoint{TextLen: p.textLen, IDs: e.ids[:p.idsLen:p.idsLen]}, true
+			}
+		}
+	}
+	return hf.Checkpoint{}, false
+}
+
+// add caches the last checkpoints of the encoded text, ordered as EncodeFrom
+// returns them, evicting the least recently used entries beyond the token
+// budget.
+func (c *prefixCache) add(key uint64, text string, checkpoints []hf.Checkpoint) {
+	if len(checkpoints) == 0 {
+		return
+	}
+	checkpoints = checkpoints[max(len(checkpoints)-checkpointsPerEntry, 0):]
+	e := prefixEntry{
+		// Checkpoint IDs share one backing array, so the last one holds the
+		// others.
+		ids:    checkpoints[len(checkpoints)-1].IDs,
+		points: make([]prefixPoint, len(checkpoints)),
+	}
+	for i, cp := range checkpoints {
+		e.points[i] = prefixPoint{textLen: cp.TextLen, textHash: xxhash.Sum64String(text[:cp.TextLen]), idsLen: len(cp.IDs)}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(e.ids) > c.maxTokens {
+		return
+	}
+	if old, ok := c.entries.Peek(key); ok {
+		c.tokens -= len(old.ids)
+	}
+	c.entries.Add(key, e)
+	c.tokens += len(e.ids)
+	for c.tokens > c.maxTokens {
+		c.entries.RemoveOldest()
+	}
+}
+
+// size returns the number of cached token IDs.
+func (c *prefixCache) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens
+}
+
+// messagePrefixKeys returns a key per message, where key i identifies
+// messages[:i+1] under seed. Each key chains the previous one with the
+// message's JSON encoding, which sorts object keys.
+func messagePrefixKeys(seed uint64, messages []any) ([]uint64, error) {
+	keys := make([]uint64, len(messages))
+	h := xxhash.New()
+	var buf [8]byte
+	prev := seed
+	for i, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		h.Reset()
+		binary.LittleEndian.PutUint64(buf[:], prev)
+		_, _ = h.Write(buf[:])
+		_, _ = h.Write(data)
+		prev = h.Sum64()
+		keys[i] = prev
+	}
+	return keys, nil
+}
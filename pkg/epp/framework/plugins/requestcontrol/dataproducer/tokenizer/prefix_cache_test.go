@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer/hf"
+)
+
+// checkpoints returns checkpoints ending at each of ends in text, sharing
+// one array of token IDs where every byte of text is a token.
+func checkpoints(text string, ends ...int) []hf.Checkpoint {
+	ids := make([]uint32, len(text))
+	for i := range ids {
+		ids[i] = uint32(text[i])
+	}
+	cps := make([]hf.Checkpoint, len(ends))
+	for i, end := range ends {
+		cps[i] = hf.Checkpoint{TextLen: end, IDs: ids[:end:end]}
+	}
+	return cps
+}
+
+func TestPrefixCache_TokenBudget(t *testing.T) {
+	c := newPrefixCache(5)
+	c.add(1, "aa", checkpoints("aa", 2))
+	c.add(2, "bb", checkpoints("bb", 1, 2))
+	assert.Equal(t, 4, c.size())
+
+	// Replacing an entry accounts for the tokens it held.
+	c.add(2, "b", checkpoints("b", 1))
+	assert.Equal(t, 3, c.size())
+
+	// Exceeding the budget evicts the least recently used entry.
+	c.add(3, "ccc", checkpoints("ccc", 3))
+	assert.Equal(t, 4, c.size())
+	_, ok := c.longest([]uint64{1}, "aa")
+	assert.False(t, ok)
+
+	// An entry larger than the whole budget is not cached.
+	c.add(4, "dddddd", checkpoints("dddddd", 6))
+	assert.Equal(t, 4, c.size())
+	_, ok = c.longest([]uint64{4}, "dddddd")
+	assert.False(t, ok)
+
+	// Nothing is cached without checkpoints.
+	c.add(5, "e", nil)
+	_, ok = c.longest([]uint64{5}, "e")
+	assert.False(t, ok)
+}
+
+func TestPrefixCache_Longest(t *testing.T) {
+	c := newPrefixCache(100)
+	c.add(1, "ab", checkpoints("ab", 2))
+	c.add(2, "abcd", checkpoints("abcd", 1, 3, 4))
+
+	cp, ok := c.longest([]uint64{1, 2, 3}, "abcdef")
+	require.True(t, ok)
+	assert.Equal(t, hf.Checkpoint{TextLen: 4, IDs: []uint32{'a', 'b', 'c', 'd'}}, cp)
+
+	// Checkpoints whose text the new prompt does not start with are skipped,
+	// first within the entry of the longest key and then across entries.
+	cp, ok = c.longest([]uint64{1, 2}, "abcXef")
+	require.True(t, ok)
+	assert.Equal(t, 3, cp.TextLen)
+	cp, ok = c.longest([]uint64{1, 2}, "abXdef")
+	require.True(t, ok)
+	assert.Equal(t, 1, cp.TextLen)
+	cp, ok = c.longest([]uint64{1, 3}, "abXdef")
+	require.True(t, ok)
+	assert.Equal(t, 2, cp.TextLen)
+
+	_, ok = c.longest([]uint64{1, 2}, "Xbcd")
+	assert.False(t, ok)
+
+	// Only the last checkpoints of a prompt are kept.
+	c.add(3, "abcdef", checkpoints("abcdef", 1, 2, 3, 4, 5, 6))
+	cp, ok = c.longest([]uint64{3}, "abcXef")
+	require.True(t, ok)
+	assert.Equal(t, 3, cp.TextLen)
+	_, ok = c.longest([]uint64{3}, "abXdef")
+	assert.False(t, ok)
+}
+
+func TestMessagePrefixKeys(t *testing.T) {
+	first := map[string]any{"role": "user", "content": "Hello"}
+	reordered := map[string]any{"content": "Hello", "role": "user"}
+	second := map[string]any{"role": "assistant", "content": "Hi"}
+
+	keys, err := messagePrefixKeys(7, []any{first, second})
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.NotEqual(t, keys[0], keys[1])
+
+	branch, err := messagePrefixKeys(7, []any{reordered, first})
+	require.NoError(t, err)
+	assert.Equal(t, keys[0], branch[0])
+	assert.NotEqual(t, keys[1], branch[1])
+
+	other, err := messagePrefixKeys(8, []any{first})
+	require.NoError(t, err)
+	assert.NotEqual(t, keys[0], other[0])
+
+	_, err = messagePrefixKeys(7, []any{map[string]any{"content": func() {}}})
+	assert.Error(t, err)
+}
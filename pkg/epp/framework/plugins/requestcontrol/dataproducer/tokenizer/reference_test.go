@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+)
+
+// referenceFixture is a golden.json written by
+// scripts/generate-tokenizer-golden.py from a real model's tokenizer files.
+type referenceFixture struct {
+	Model    string `json:"model"`
+	Revision string `json:"revision"`
+	Encode   []struct {
+		Text             string   `json:"text"`
+		AddSpecialTokens bool     `json:"addSpecialTokens"`
+		IDs              []uint32 `json:"ids"`
+	} `json:"encode"`
+	Chat []struct {
+		Name                string         `json:"name"`
+		Messages            []any          `json:"messages"`
+		Tools               []any          `json:"tools"`
+		AddGenerationPrompt bool           `json:"addGenerationPrompt"`
+		ChatTemplateKwargs  map[string]any `json:"chatTemplateKwargs"`
+		Prompt              string         `json:"prompt"`
+		IDs                 []uint32       `json:"ids"`
+	} `json:"chat"`
+}
+
+// TestLocalTokenizer_Reference checks the local tokenizer against the token
+// IDs and rendered chat prompts the Hugging Face tokenizers and transformers
+// libraries produce for real models. Run scripts/generate-tokenizer-golden.py
+// to (re)generate the fixtures under testdata/reference.
+func TestLocalTokenizer_Reference(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "reference", "*", "golden.json"))
+	require.NoError(t, err)
+	if len(fixtures) == 0 {
+		t.Skip("no reference fixtures; run scripts/generate-tokenizer-golden.py to generate them")
+	}
+	ctx := context.Background()
+
+	for _, path := range fixtures {
+		dir := filepath.Dir(path)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var fixture referenceFixture
+			require.NoError(t, json.Unmarshal(data, &fixture))
+			lt, err := newLocalTokenizer(&localConfig{Path: dir, CacheSize: -1, PrefixCacheTokens: -1})
+			require.NoError(t, err, "%s@%s", fixture.Model, fixture.Revision)
+
+			for _, tc := range fixture.Encode {
+				ids, _, err := lt.Render(ctx, fwkrh.PayloadMap{"prompt": tc.Text, "add_special_tokens": tc.AddSpecialTokens})
+				require.NoError(t, err)
+				assert.Equal(t, tc.IDs, ids, "encode %q, special tokens %t", tc.Text, tc.AddSpecialTokens)
+			}
+
+			for _, tc := range fixture.Chat {
+				t.Run(tc.Name, func(t *testing.T) {
+					pm := fwkrh.PayloadMap{
+						"messages":              tc.Messages,
+						"add_generation_prompt": tc.AddGenerationPrompt,
+						"chat_template_kwargs":  tc.ChatTemplateKwargs,
+					}
+					if tc.Tools != nil {
+						pm["tools"] = tc.Tools
+					}
+					chat, err := lt.prepareChat(pm)
+					require.NoError(t, err)
+					prompt, err := chat.render()
+					require.NoError(t, err)
+					assert.Equal(t, tc.Prompt, prompt)
+
+					ids, _, err := lt.RenderChat(ctx, pm)
+					require.NoError(t, err)
+					assert.Equal(t, tc.IDs, ids)
+				})
+			}
+		})
+	}
+}
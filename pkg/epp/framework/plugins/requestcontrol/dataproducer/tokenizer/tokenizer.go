@@ -62,7 +62,8 @@ var TokenizedPromptDataKey = plugin.NewDataKey(tokenizedPromptKeyID, PluginType)
 //
 // Backend selection: `vllm` or `modelName` selects the vLLM HTTP /render
 // backend; `udsTokenizerConfig` selects the deprecated gRPC-over-UDS backend;
-// `estimate` selects the tokenizer-free byte-packing backend, which is also the
+// `local` selects the in-process Hugging Face tokenizer backend; `estimate`
+// selects the tokenizer-free byte-packing backend, which is also the
 // zero-config default when no backend is set.
 type tokenizerPluginConfig struct {
 	// TokenizerConfig configures the deprecated gRPC-over-UDS backend.
@@ -75,6 +76,9 @@ type tokenizerPluginConfig struct {
 	// Estimate selects the tokenizer-free byte-packing backend; mutually
 	// exclusive with 'vllm'/'udsTokenizerConfig' and needs no 'modelName'.
 	Estimate *estimateConfig `json:"estimate,omitempty"`
+	// Local selects the in-process backend that loads tokenizer.json and the
+	// chat template from a model directory; it needs no 'modelName'.
+	Local *localConfig `json:"local,omitempty"`
 	// ModelName is the name of the model whose tokenizer should be loaded.
 	ModelName string `json:"modelName"`
 }
@@ -129,10 +133,25 @@ func PluginFactory(name string, rawParameters *json.Decoder, handle plugin.Handl
 	}
 
 	estimate := config.Estimate != nil
+	local := config.Local != nil
 	uds := config.TokenizerConfig.IsEnabled()
-	vllm := config.VLLM != nil || config.ModelName != ""
-	if (estimate && (uds || vllm)) || (uds && vllm) {
-		return nil, fmt.Errorf("invalid configuration for '%s' plugin: only one of 'estimate', 'vllm', or 'udsTokenizerConfig' may be set", PluginType)
+	vllm := config.VLLM != nil || (config.ModelName != "" && !local)
+	selected := 0
+	for _, set := range []bool{estimate, local, uds, vllm} {
+		if set {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: only one of 'estimate', 'local', 'vllm', or 'udsTokenizerConfig' may be set", PluginType)
+	}
+	if local && config.Local.Path == "" {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'local.path' must be specified", PluginType)
+	}
+	if local {
+		if err := registerMetrics(handle.Metrics()); err != nil {
+			return nil, err
+		}
 	}
 	// modelName is required only by the real-tokenizer backends; the zero-config
 	// path selects the estimate backend, which needs none.
@@ -167,8 +186,8 @@ func LegacyPluginFactory(name string, rawParameters *json.Decoder, handle plugin
 }
 
 // NewPlugin constructs the configured backend: udsTokenizerConfig (deprecated),
-// vllm /render (selected by 'vllm' or 'modelName'), or estimate byte-packing
-// (the default when no backend is set).
+// local in-process tokenization, vllm /render (selected by 'vllm' or
+// 'modelName'), or estimate byte-packing (the default when no backend is set).
 func NewPlugin(ctx context.Context, name string, config *tokenizerPluginConfig) (*Plugin, error) {
 	var backend tokenInputProducer
 	switch {
@@ -182,6 +201,13 @@ func NewPlugin(ctx context.Context, name string, config *tokenizerPluginConfig)
 			return nil, fmt.Errorf("failed to initialize UDS tokenizer for '%s' plugin - %w", PluginType, err)
 		}
 		backend = renderBackend{tk: uds}
+	case config.Local != nil:
+		local, err := newLocalTokenizer(config.Local)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize local tokenizer for '%s' plugin - %w", PluginType, err)
+		}
+		local.typedName = plugin.TypedName{Type: PluginType, Name: name}
+		backend = renderBackend{tk: local}
 	case config.VLLM != nil || config.ModelName != "":
 		cfg := config.VLLM
 		if cfg == nil {
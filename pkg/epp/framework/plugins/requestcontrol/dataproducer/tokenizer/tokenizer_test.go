@@ -25,6 +25,7 @@ import (
 	"github.com/llm-d/llm-d-kv-cache/pkg/kvcache/kvblock"
 	"github.com/llm-d/llm-d-kv-cache/pkg/tokenization"
 	tokenizerTypes "github.com/llm-d/llm-d-kv-cache/pkg/tokenization/types"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -113,6 +114,29 @@ func TestPluginFactory_Validation(t *testing.T) {
 			expectErr:  true,
 			errContain: "estimate.image.mode must be",
 		},
+		{
+			name:      "local backend needs no modelName",
+			params:    `{"local":{"path":"testdata/llama3-tiny"}}`,
+			expectErr: false,
+		},
+		{
+			name:       "local backend requires path",
+			params:     `{"local":{}}`,
+			expectErr:  true,
+			errContain: "'local.path' must be specified",
+		},
+		{
+			name:       "local backend missing tokenizer.json",
+			params:     `{"local":{"path":"testdata/does-not-exist"}}`,
+			expectErr:  true,
+			errContain: "failed to initialize local tokenizer",
+		},
+		{
+			name:       "local backend conflicts with vllm",
+			params:     `{"modelName":"m","local":{"path":"testdata/llama3-tiny"},"vllm":{}}`,
+			expectErr:  true,
+			errContain: "only one of",
+		},
 		{
 			name:       "invalid JSON",
 			params:     `{invalid}`,
@@ -141,6 +165,21 @@ func TestPluginFactory_Validation(t *testing.T) {
 	}
 }
 
+func TestPluginFactory_RegistersLocalMetrics(t *testing.T) {
+	ctx := utils.NewTestContext(t)
+	registry := prometheus.NewRegistry()
+	handle := plugin.NewEppHandle(ctx, nil, plugin.WithMetricsRecorder(registry))
+
+	// A second local plugin reuses the registered collectors.
+	for _, name := range []string{"first", "second"} {
+		_, err := PluginFactory(name, plugin.StrictDecoder(json.RawMessage(`{"local":{"path":"testdata/llama3-tiny"}}`)), handle)
+		require.NoError(t, err)
+	}
+	for _, c := range []prometheus.Collector{prefixCacheLookupsTotal, prefixCacheTokensTotal, prefixCacheSizeTokens} {
+		assert.True(t, registry.Unregister(c))
+	}
+}
+
 func TestProduce_PopulatesTokenizedPrompt(t *testing.T) {
 	mm := &tokenization.MultiModalFeatures{
 		MMHashes: map[string][]string{"image": {"hash-a", "hash-b"}},
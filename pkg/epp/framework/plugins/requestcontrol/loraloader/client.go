@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loraloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	loadAdapterPath   = "/v1/load_lora_adapter"
+	unloadAdapterPath = "/v1/unload_lora_adapter"
+
+	// maxErrorBodyBytes bounds how much of an error response is kept in the returned error.
+	maxErrorBodyBytes = 1024
+)
+
+// adapterRequest is the body of vLLM's load and unload LoRA adapter requests.
+type adapterRequest struct {
+	LoraName string `json:"lora_name"`
+	LoraPath string `json:"lora_path,omitempty"`
+}
+
+// vllmClient calls the vLLM runtime LoRA adapter management API of an endpoint.
+type vllmClient struct {
+	scheme string
+	client *http.Client
+}
+
+func newVLLMClient(scheme string, insecureSkipVerify bool) *vllmClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if scheme == "https" {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	}
+	return &vllmClient{scheme: scheme, client: &http.Client{Transport: transport}}
+}
+
+// load loads the adapter from the given source. An adapter that is already loaded is not an error.
+func (c *vllmClient) load(ctx context.Context, address, port, adapter, source string) error {
+	status, body, err := c.post(ctx, address, port, loadAdapterPath, adapterRequest{LoraName: adapter, LoraPath: source})
+	if err != nil {
+		return err
+	}
+	if status == http.StatusOK || (status == http.StatusBadRequest && strings.Contains(body, "already been loaded")) {
+		return nil
+	}
+	return fmt.Errorf("%s returned status %d: %s", loadAdapterPath, status, body)
+}
+
+// unload unloads the adapter. An adapter that is not loaded is not an error.
+func (c *vllmClient) unload(ctx context.Context, address, port, adapter string) error {
+	status, body, err := c.post(ctx, address, port, unloadAdapterPath, adapterRequest{LoraName: adapter})
+	if err != nil {
+		return err
+	}
+	if status == http.StatusOK || status == http.StatusNotFound {
+		return nil
+	}
+	return fmt.Errorf("%s returned status %d: %s", unloadAdapterPath, status, body)
+}
+
+func (c *vllmClient) post(ctx context.Context, address, port, path string, payload adapterRequest) (int, string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to marshal %s request: %w", path, err)
+	}
+	url := c.scheme + "://" + net.JoinHostPort(address, port) + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to create %s request: %w", path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s request failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, strings.TrimSpace(string(body)), nil
+}
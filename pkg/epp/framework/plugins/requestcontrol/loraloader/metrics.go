@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loraloader
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	metricsutil "github.com/llm-d/llm-d-router/pkg/common/observability/metrics"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	eppmetrics "github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	loadResultSuccess    = "success"
+	loadResultFailure    = "failure"
+	loadResultTimeout    = "timeout"
+	loadResultNoCapacity = "no_capacity"
+)
+
+var (
+	loadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "lora_adapter_loads_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of LoRA adapter loads requested on the selected endpoint, by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "result"},
+	)
+
+	loadDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "lora_adapter_load_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Time a request was held while its LoRA adapter was loaded, including evictions.", compbasemetrics.ALPHA),
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		},
+		[]string{"plugin_type", "plugin_name"},
+	)
+
+	evictionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "lora_adapter_evictions_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of LoRA adapters unloaded to make room for another adapter.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name"},
+	)
+)
+
+func registerMetrics(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		return errors.New("LoRA adapter loader metrics registerer is required")
+	}
+	for _, collector := range []prometheus.Collector{loadsTotal, loadDuration, evictionsTotal} {
+		if err := registerer.Register(collector); err != nil {
+			var alreadyRegistered prometheus.AlreadyRegisteredError
+			if errors.As(err, &alreadyRegistered) && alreadyRegistered.ExistingCollector == collector {
+				continue
+			}
+			return fmt.Errorf("register LoRA adapter loader metric: %w", err)
+		}
+	}
+	return nil
+}
+
+func recordLoad(typedName fwkplugin.TypedName, result string, duration time.Duration) {
+	loadsTotal.WithLabelValues(typedName.Type, typedName.Name, result).Inc()
+	loadDuration.WithLabelValues(typedName.Type, typedName.Name).Observe(duration.Seconds())
+}
+
+func recordEviction(typedName fwkplugin.TypedName) {
+	evictionsTotal.WithLabelValues(typedName.Type, typedName.Name).Inc()
+}
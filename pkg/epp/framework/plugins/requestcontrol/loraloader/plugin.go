@@ -0,0 +1,410 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loraloader loads LoRA adapters on demand. Before a request for an adapter from the configured
+// catalog is sent to the selected endpoint, the adapter is loaded there through vLLM's runtime LoRA
+// adapter API, evicting an idle adapter when the endpoint is full. The plugin is also a filter that keeps
+// the endpoints able to serve the adapter.
+package loraloader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmodels "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/models"
+	sourcenotifications "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/notifications"
+)
+
+const (
+	// Type is the registered plugin type for the LoRA adapter loader.
+	Type = "lora-adapter-loader"
+
+	defaultScheme      = "http"
+	defaultLoadTimeout = 60 * time.Second
+
+	inFlightStateKey = fwkplugin.StateKey(Type + "/in-flight")
+)
+
+var errNoCapacity = errors.New("endpoint is at its maximum number of adapters and none can be evicted")
+
+// Parameters defines the JSON-configurable fields for the plugin.
+type Parameters struct {
+	// Adapters is the adapter catalog: the source vLLM loads each adapter from, keyed by adapter name.
+	// Only requests targeting an adapter in the catalog are handled.
+	Adapters map[string]string `json:"adapters"`
+	// LoadTimeout is how long a request is held while its adapter is loaded.
+	LoadTimeout metav1.Duration `json:"loadTimeout"`
+	// Scheme is the protocol scheme used to reach the model servers, "http" or "https".
+	Scheme string `json:"scheme"`
+	// InsecureSkipVerify skips the verification of the model server certificates.
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+}
+
+func defaultParameters() Parameters {
+	return Parameters{
+		LoadTimeout:        metav1.Duration{Duration: defaultLoadTimeout},
+		Scheme:             defaultScheme,
+		InsecureSkipVerify: true,
+	}
+}
+
+func (p *Parameters) validate() error {
+	if len(p.Adapters) == 0 {
+		return errors.New("adapters must not be empty")
+	}
+	for adapter, source := range p.Adapters {
+		if adapter == "" || source == "" {
+			return fmt.Errorf("adapter %q must have a non-empty name and source", adapter)
+		}
+	}
+	if p.LoadTimeout.Duration <= 0 {
+		return fmt.Errorf("loadTimeout must be > 0, got %v", p.LoadTimeout.Duration)
+	}
+	if p.Scheme != "http" && p.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme: %s", p.Scheme)
+	}
+	return nil
+}
+
+// compile-time interface assertions
+var (
+	_ fwksched.Filter                      = &Plugin{}
+	_ requestcontrol.PreRequest            = &Plugin{}
+	_ requestcontrol.ResponseBodyProcessor = &Plugin{}
+	_ fwkdl.EndpointExtractor              = &Plugin{}
+	_ fwkdl.Registrant                     = &Plugin{}
+	_ fwkplugin.ConsumerPlugin             = &Plugin{}
+)
+
+// Plugin loads catalog LoRA adapters on the selected endpoint before the request is sent to it.
+type Plugin struct {
+	typedName   fwkplugin.TypedName
+	params      Parameters
+	client      *vllmClient
+	tracker     *adapterTracker
+	pluginState *fwkplugin.PluginState
+	now         func() time.Time
+}
+
+// Factory creates a LoRA adapter loader Plugin from plugin configuration.
+func Factory(name string, rawParameters *json.Decoder, handle fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	params := defaultParameters()
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&params); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", Type, err)
+		}
+	}
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", Type, err)
+	}
+	if err := registerMetrics(handle.Metrics()); err != nil {
+		return nil, err
+	}
+	return newPlugin(name, params, fwkplugin.NewPluginState(handle.Context())), nil
+}
+
+// newPlugin creates a Plugin with the given, already validated, parameters.
+func newPlugin(name string, params Parameters, pluginState *fwkplugin.PluginState) *Plugin {
+	return &Plugin{
+		typedName:   fwkplugin.TypedName{Type: Type, Name: name},
+		params:      params,
+		client:      newVLLMClient(params.Scheme, params.InsecureSkipVerify),
+		tracker:     newAdapterTracker(),
+		pluginState: pluginState,
+		now:         time.Now,
+	}
+}
+
+// TypedName returns the plugin type and instance name.
+func (p *Plugin) TypedName() fwkplugin.TypedName {
+	return p.typedName
+}
+
+// Consumes returns the data consumed by the plugin. The adapters listed by /v1/models are used when
+// available; otherwise only the adapters with running or waiting requests are known.
+func (p *Plugin) Consumes() fwkplugin.DataDependencies {
+	return fwkplugin.DataDependencies{
+		Optional: map[fwkplugin.DataKey]any{attrmodels.ModelsAttributeKey: attrmodels.ModelDataCollection{}},
+	}
+}
+
+// Filter keeps the endpoints that have the requested catalog adapter loaded, or can load it because they
+// are below their maximum number of adapters or have an adapter that can be evicted. Requests that do not
+// target a catalog adapter are not filtered.
+func (p *Plugin) Filter(ctx context.Context, request *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) []fwksched.Endpoint {
+	if request == nil {
+		return endpoints
+	}
+	adapter := request.TargetModel
+	if _, ok := p.params.Adapters[adapter]; !ok {
+		return endpoints
+	}
+
+	filtered := make([]fwksched.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		key, ok := endpointKey(endpoint)
+		if !ok {
+			continue
+		}
+		loaded := p.loadedAdapters(key, endpoint)
+		if loaded.Has(adapter) || hasCapacity(endpoint, loaded) || p.evictionCandidate(key, endpoint, loaded, adapter) != "" {
+			filtered = append(filtered, endpoint)
+		}
+	}
+	log.FromContext(ctx).V(logutil.TRACE).Info("Filtered endpoints able to serve the LoRA adapter",
+		"adapter", adapter, "before", len(endpoints), "after", len(filtered))
+	return filtered
+}
+
+// RegisterDependencies declares that the plugin needs an endpoint-notification-source to learn when
+// endpoints are removed. The source is auto-created if not already in the config.
+func (p *Plugin) RegisterDependencies(r fwkdl.Registrar) error {
+	return r.Register(fwkdl.PendingRegistration{
+		Owner:         p.TypedName(),
+		SourceType:    sourcenotifications.EndpointNotificationSourceType,
+		Extractor:     p,
+		DefaultSource: sourcenotifications.NewEndpointDataSource(sourcenotifications.EndpointNotificationSourceType, sourcenotifications.EndpointNotificationSourceType),
+	})
+}
+
+// Extract forgets the adapters tracked on an endpoint when it is removed.
+func (p *Plugin) Extract(ctx context.Context, event fwkdl.EndpointEvent) error {
+	if event.Type != fwkdl.EventDelete || event.Endpoint == nil || event.Endpoint.GetMetadata() == nil {
+		return nil
+	}
+	key := metadataKey(event.Endpoint.GetMetadata())
+	p.tracker.remove(key)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Forgot the LoRA adapters of a removed endpoint", "endpoint", key)
+	return nil
+}
+
+// PreRequest loads the requested catalog adapter on the primary endpoint if it is not loaded there yet,
+// holding the request until the load completes or the load timeout expires. A failed load is logged and the
+// request is sent anyway, leaving the model server to reject it. The request counts as in flight for the
+// adapter until it completes, so the adapter is not evicted before the endpoint reports it running.
+func (p *Plugin) PreRequest(ctx context.Context, request *fwksched.InferenceRequest, schedulingResult *fwksched.SchedulingResult) {
+	if request == nil || schedulingResult == nil {
+		return
+	}
+	adapter := request.TargetModel
+	source, ok := p.params.Adapters[adapter]
+	if !ok {
+		return
+	}
+	result, ok := schedulingResult.ProfileResults[schedulingResult.PrimaryProfileName]
+	if !ok || result == nil || len(result.TargetEndpoints) == 0 {
+		return
+	}
+	endpoint := result.TargetEndpoints[0]
+	key, ok := endpointKey(endpoint)
+	if !ok {
+		return
+	}
+
+	if request.RequestID != "" {
+		// A request dispatched again releases its previous endpoint first.
+		p.pluginState.DeleteKey(request.RequestID, inFlightStateKey)
+		p.tracker.acquire(key, adapter)
+		p.pluginState.Write(request.RequestID, inFlightStateKey, &inFlightEntry{tracker: p.tracker, endpoint: key, adapter: adapter})
+	}
+
+	logger := log.FromContext(ctx).WithValues("adapter", adapter, "endpoint", key)
+	if p.loadedAdapters(key, endpoint).Has(adapter) {
+		p.tracker.touch(key, adapter, p.now())
+		return
+	}
+
+	start := p.now()
+	loadCtx, cancel := context.WithTimeout(ctx, p.params.LoadTimeout.Duration)
+	defer cancel()
+	err := p.ensureLoaded(loadCtx, key, endpoint, adapter, source)
+	switch {
+	case err == nil:
+		recordLoad(p.typedName, loadResultSuccess, p.now().Sub(start))
+		logger.V(logutil.DEFAULT).Info("Loaded LoRA adapter on demand", "duration", p.now().Sub(start))
+	case errors.Is(err, errNoCapacity):
+		recordLoad(p.typedName, loadResultNoCapacity, p.now().Sub(start))
+		logger.Error(err, "Failed to load LoRA adapter")
+	case errors.Is(loadCtx.Err(), context.DeadlineExceeded):
+		recordLoad(p.typedName, loadResultTimeout, p.now().Sub(start))
+		logger.Error(err, "Timed out loading LoRA adapter", "loadTimeout", p.params.LoadTimeout.Duration)
+	default:
+		recordLoad(p.typedName, loadResultFailure, p.now().Sub(start))
+		logger.Error(err, "Failed to load LoRA adapter")
+	}
+}
+
+// ResponseBody releases the request's in-flight adapter at the end of the stream.
+func (p *Plugin) ResponseBody(
+	_ context.Context,
+	request *fwksched.InferenceRequest,
+	response *requestcontrol.Response,
+	_ *fwkdl.EndpointMetadata,
+) {
+	if request == nil || response == nil || request.RequestID == "" {
+		return
+	}
+	if _, err := fwkplugin.ReadPluginStateKey[*inFlightEntry](p.pluginState, request.RequestID, inFlightStateKey); err != nil {
+		return
+	}
+	if response.EndOfStream {
+		p.pluginState.Delete(request.RequestID)
+	} else {
+		p.pluginState.Touch(request.RequestID)
+	}
+}
+
+// inFlightEntry tracks a request sent to an endpoint for a catalog adapter until the end of the response.
+// Evicting it releases the adapter, so requests that never complete (e.g. client disconnects) are eventually
+// released by the PluginState janitor.
+type inFlightEntry struct {
+	tracker  *adapterTracker
+	endpoint string
+	adapter  string
+	released atomic.Bool
+}
+
+var _ fwkplugin.EvictableStateData = (*inFlightEntry)(nil)
+
+// Clone returns the entry itself: the entry represents a single in-flight request, which must be released at
+// most once regardless of how many references exist.
+func (e *inFlightEntry) Clone() fwkplugin.StateData {
+	return e
+}
+
+// OnEvicted releases the in-flight request.
+func (e *inFlightEntry) OnEvicted(_ string, _ fwkplugin.StateKey) {
+	if e.released.CompareAndSwap(false, true) {
+		e.tracker.release(e.endpoint, e.adapter)
+	}
+}
+
+// ensureLoaded loads the adapter on the endpoint, first evicting an idle catalog adapter if the endpoint
+// is at its maximum number of adapters. Operations on an endpoint are serialized, so a request waiting for
+// another request's load of the same adapter finds it loaded.
+func (p *Plugin) ensureLoaded(ctx context.Context, key string, endpoint fwksched.Endpoint, adapter, source string) error {
+	unlock, err := p.tracker.lock(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	loaded := p.loadedAdapters(key, endpoint)
+	if loaded.Has(adapter) {
+		p.tracker.touch(key, adapter, p.now())
+		return nil
+	}
+
+	metadata := endpoint.GetMetadata()
+	if !hasCapacity(endpoint, loaded) {
+		victim := p.evictionCandidate(key, endpoint, loaded, adapter)
+		if victim == "" {
+			return errNoCapacity
+		}
+		if err := p.client.unload(ctx, metadata.GetIPAddress(), metadata.GetPort(), victim); err != nil {
+			return fmt.Errorf("failed to unload LoRA adapter %q: %w", victim, err)
+		}
+		p.tracker.setUnloaded(key, victim)
+		recordEviction(p.typedName)
+		log.FromContext(ctx).V(logutil.DEFAULT).Info("Evicted LoRA adapter", "evicted", victim, "adapter", adapter, "endpoint", key)
+	}
+
+	if err := p.client.load(ctx, metadata.GetIPAddress(), metadata.GetPort(), adapter, source); err != nil {
+		return fmt.Errorf("failed to load LoRA adapter %q: %w", adapter, err)
+	}
+	p.tracker.setLoaded(key, adapter, p.now())
+	return nil
+}
+
+// loadedAdapters returns the adapters loaded on the endpoint: those listed by /v1/models, those with running
+// or waiting requests, and the plugin's own loads and unloads the endpoint has not reported yet.
+func (p *Plugin) loadedAdapters(key string, endpoint fwksched.Endpoint) sets.Set[string] {
+	reported := sets.New[string]()
+	if attr, ok := endpoint.Get(attrmodels.ModelsAttributeKey.String()); ok {
+		if models, ok := attr.(attrmodels.ModelDataCollection); ok {
+			for _, model := range models {
+				if model.Parent != "" {
+					reported.Insert(model.ID)
+				}
+			}
+		}
+	}
+	return p.tracker.reconcile(key, reported, busyAdapters(endpoint))
+}
+
+// evictionCandidate returns the least recently used catalog adapter loaded on the endpoint without running,
+// waiting or in-flight requests, or "" if there is none. The in-flight requests cover those sent since the
+// last metrics refresh. Adapters outside the catalog are never evicted since they could not be loaded again.
+func (p *Plugin) evictionCandidate(key string, endpoint fwksched.Endpoint, loaded sets.Set[string], adapter string) string {
+	busy := busyAdapters(endpoint).Union(p.tracker.inFlight(key))
+	candidates := sets.New[string]()
+	for name := range loaded {
+		if _, inCatalog := p.params.Adapters[name]; inCatalog && name != adapter && !busy.Has(name) {
+			candidates.Insert(name)
+		}
+	}
+	return p.tracker.leastRecentlyUsed(key, candidates)
+}
+
+// busyAdapters returns the adapters with running or waiting requests on the endpoint.
+func busyAdapters(endpoint fwksched.Endpoint) sets.Set[string] {
+	busy := sets.New[string]()
+	metrics := endpoint.GetMetrics()
+	if metrics == nil {
+		return busy
+	}
+	for name := range metrics.ActiveModels {
+		busy.Insert(name)
+	}
+	for name := range metrics.WaitingModels {
+		busy.Insert(name)
+	}
+	return busy
+}
+
+// hasCapacity reports whether the endpoint can load one more adapter. Endpoints that do not report their
+// maximum number of adapters are not limited.
+func hasCapacity(endpoint fwksched.Endpoint, loaded sets.Set[string]) bool {
+	metrics := endpoint.GetMetrics()
+	return metrics == nil || metrics.MaxActiveModels <= 0 || loaded.Len() < metrics.MaxActiveModels
+}
+
+// endpointKey returns the address of the endpoint's model server, which identifies it to the plugin.
+func endpointKey(endpoint fwksched.Endpoint) (string, bool) {
+	if endpoint == nil || endpoint.GetMetadata() == nil {
+		return "", false
+	}
+	return metadataKey(endpoint.GetMetadata()), true
+}
+
+// metadataKey returns the address of the model server described by the metadata.
+func metadataKey(metadata *fwkdl.EndpointMetadata) string {
+	return net.JoinHostPort(metadata.GetIPAddress(), metadata.GetPort())
+}
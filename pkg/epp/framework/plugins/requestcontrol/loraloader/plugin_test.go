@@ -0,0 +1,421 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loraloader
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmodels "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/models"
+	testutils "github.com/llm-d/llm-d-router/test/utils"
+)
+
+const baseModel = "base"
+
+var testCatalog = map[string]string{
+	"sql":  "/adapters/sql",
+	"chat": "/adapters/chat",
+	"math": "/adapters/math",
+}
+
+// fakeVLLM is a model server implementing vLLM's runtime LoRA adapter API.
+type fakeVLLM struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	loaded   map[string]string
+	calls    []string
+	delay    time.Duration
+	failLoad bool
+}
+
+func newFakeVLLM(t *testing.T, loaded ...string) *fakeVLLM {
+	t.Helper()
+	f := &fakeVLLM{loaded: map[string]string{}}
+	for _, adapter := range loaded {
+		f.loaded[adapter] = testCatalog[adapter]
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeVLLM) handle(w http.ResponseWriter, r *http.Request) {
+	var req adapterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, r.URL.Path+" "+req.LoraName)
+	switch r.URL.Path {
+	case loadAdapterPath:
+		switch {
+		case f.failLoad:
+			http.Error(w, "failed to load adapter", http.StatusInternalServerError)
+		case f.loaded[req.LoraName] != "":
+			http.Error(w, "The lora adapter '"+req.LoraName+"' has already been loaded.", http.StatusBadRequest)
+		default:
+			f.loaded[req.LoraName] = req.LoraPath
+		}
+	case unloadAdapterPath:
+		if _, ok := f.loaded[req.LoraName]; !ok {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		delete(f.loaded, req.LoraName)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeVLLM) getCalls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeVLLM) getLoaded() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	loaded := make(map[string]string, len(f.loaded))
+	for adapter, source := range f.loaded {
+		loaded[adapter] = source
+	}
+	return loaded
+}
+
+// newEndpoint returns an endpoint served by the fake server, which reports the listed adapters through
+// /v1/models.
+func newEndpoint(t *testing.T, server *fakeVLLM, metrics *fwkdl.Metrics, reported ...string) fwksched.Endpoint {
+	t.Helper()
+	address, port := "10.0.0.1", "8000"
+	if server != nil {
+		var err error
+		address, port, err = net.SplitHostPort(server.Listener.Addr().String())
+		require.NoError(t, err)
+	}
+	if metrics == nil {
+		metrics = &fwkdl.Metrics{}
+	}
+	models := attrmodels.ModelDataCollection{{ID: baseModel}}
+	for _, adapter := range reported {
+		models = append(models, attrmodels.ModelData{ID: adapter, Parent: baseModel})
+	}
+	attrs := fwkdl.NewAttributes()
+	attrs.Put(attrmodels.ModelsAttributeKey.String(), models)
+	return fwksched.NewEndpoint(&fwkdl.EndpointMetadata{
+		NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: "pod-" + port},
+		Address:        address,
+		Port:           port,
+	}, metrics, attrs)
+}
+
+func newTestPlugin(t *testing.T, mutate func(*Parameters)) *Plugin {
+	t.Helper()
+	params := defaultParameters()
+	params.Adapters = testCatalog
+	if mutate != nil {
+		mutate(&params)
+	}
+	require.NoError(t, params.validate())
+	return newPlugin("test", params, fwkplugin.NewPluginState(t.Context()))
+}
+
+func schedulingResult(endpoint fwksched.Endpoint) *fwksched.SchedulingResult {
+	return &fwksched.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults: map[string]*fwksched.ProfileRunResult{
+			"default": {TargetEndpoints: []fwksched.Endpoint{endpoint}},
+		},
+	}
+}
+
+// sortedLoaded returns the catalog adapters the plugin considers loaded on the endpoint, in name order.
+func sortedLoaded(p *Plugin, endpoint fwksched.Endpoint) []string {
+	key, _ := endpointKey(endpoint)
+	loaded := p.loadedAdapters(key, endpoint)
+	names := make([]string, 0, loaded.Len())
+	for _, name := range []string{"chat", "math", "sql"} {
+		if loaded.Has(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		params    string
+		wantError string
+	}{
+		{name: "catalog only", params: `{"adapters": {"sql": "/adapters/sql"}}`},
+		{name: "all parameters", params: `{"adapters": {"sql": "hf://org/sql"}, "loadTimeout": "30s", "scheme": "https", "insecureSkipVerify": false}`},
+		{name: "malformed", params: `{"adapters": ["sql"]}`, wantError: "failed to parse"},
+		{name: "no adapters", params: `{}`, wantError: "adapters must not be empty"},
+		{name: "empty source", params: `{"adapters": {"sql": ""}}`, wantError: "non-empty name and source"},
+		{name: "zero load timeout", params: `{"adapters": {"sql": "/adapters/sql"}, "loadTimeout": "0s"}`, wantError: "loadTimeout must be > 0"},
+		{name: "unsupported scheme", params: `{"adapters": {"sql": "/adapters/sql"}, "scheme": "grpc"}`, wantError: "unsupported scheme"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := Factory("test-loader", fwkplugin.StrictDecoder(json.RawMessage(tc.params)), testutils.NewTestHandle(t.Context()))
+			if tc.wantError != "" {
+				require.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: Type, Name: "test-loader"}, p.TypedName())
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	full := func(active ...string) *fwkdl.Metrics {
+		metrics := &fwkdl.Metrics{MaxActiveModels: 2, ActiveModels: map[string]int{}}
+		for _, adapter := range active {
+			metrics.ActiveModels[adapter] = 1
+		}
+		return metrics
+	}
+
+	tests := []struct {
+		name        string
+		targetModel string
+		metrics     *fwkdl.Metrics
+		reported    []string
+		wantKept    bool
+	}{
+		{name: "not a catalog adapter", targetModel: "other", metrics: full("x", "y"), wantKept: true},
+		{name: "adapter loaded", targetModel: "sql", metrics: full(), reported: []string{"sql", "chat"}, wantKept: true},
+		{name: "adapter running", targetModel: "sql", metrics: full("sql", "chat"), wantKept: true},
+		{name: "capacity left", targetModel: "sql", metrics: full(), reported: []string{"chat"}, wantKept: true},
+		{name: "capacity unknown", targetModel: "sql", metrics: &fwkdl.Metrics{}, reported: []string{"chat", "math"}, wantKept: true},
+		{name: "full with idle catalog adapter", targetModel: "sql", metrics: full("chat"), reported: []string{"chat", "math"}, wantKept: true},
+		{name: "full with busy adapters", targetModel: "sql", metrics: full("chat", "math"), wantKept: false},
+		{name: "full with adapters outside the catalog", targetModel: "sql", metrics: full(), reported: []string{"x", "y"}, wantKept: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p := newTestPlugin(t, nil)
+			endpoint := newEndpoint(t, nil, tc.metrics, tc.reported...)
+			got := p.Filter(t.Context(), &fwksched.InferenceRequest{TargetModel: tc.targetModel}, []fwksched.Endpoint{endpoint})
+			if tc.wantKept {
+				assert.Equal(t, []fwksched.Endpoint{endpoint}, got)
+			} else {
+				assert.Empty(t, got)
+			}
+		})
+	}
+}
+
+func TestPreRequest_LoadsMissingAdapter(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t)
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, &fwkdl.Metrics{MaxActiveModels: 2})
+	request := &fwksched.InferenceRequest{RequestID: "1", TargetModel: "sql"}
+
+	p.PreRequest(t.Context(), request, schedulingResult(endpoint))
+	assert.Equal(t, map[string]string{"sql": "/adapters/sql"}, server.getLoaded())
+
+	// The load is known before the endpoint reports it, so the next request is not held.
+	p.PreRequest(t.Context(), request, schedulingResult(endpoint))
+	assert.Equal(t, []string{loadAdapterPath + " sql"}, server.getCalls())
+}
+
+func TestPreRequest_SkipsLoadedAndUnknownAdapters(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t, "sql")
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, &fwkdl.Metrics{MaxActiveModels: 2}, "sql")
+
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: baseModel}, schedulingResult(endpoint))
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "chat"}, &fwksched.SchedulingResult{})
+	assert.Empty(t, server.getCalls())
+}
+
+func TestPreRequest_EvictsLeastRecentlyUsedAdapter(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t, "chat", "math")
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, &fwkdl.Metrics{MaxActiveModels: 2}, "chat", "math")
+
+	// chat is used more recently than math.
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "math"}, schedulingResult(endpoint))
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "chat"}, schedulingResult(endpoint))
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+
+	assert.Equal(t, []string{unloadAdapterPath + " math", loadAdapterPath + " sql"}, server.getCalls())
+	assert.Equal(t, map[string]string{"chat": "/adapters/chat", "sql": "/adapters/sql"}, server.getLoaded())
+
+	// The endpoint still reports math until its next refresh, but the plugin knows it was evicted.
+	assert.Equal(t, []string{"chat", "sql"}, sortedLoaded(p, endpoint))
+}
+
+func TestPreRequest_DoesNotEvictBusyAdapters(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t, "chat", "math")
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, &fwkdl.Metrics{
+		MaxActiveModels: 2,
+		ActiveModels:    map[string]int{"chat": 1},
+		WaitingModels:   map[string]int{"math": 3},
+	}, "chat", "math")
+
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+	assert.Empty(t, server.getCalls())
+}
+
+func TestPreRequest_DoesNotEvictInFlightAdapters(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t, "chat", "math")
+	p := newTestPlugin(t, nil)
+	// The scraped metrics do not show the requests sent since the last refresh.
+	endpoint := newEndpoint(t, server, &fwkdl.Metrics{MaxActiveModels: 2}, "chat", "math")
+
+	chat := &fwksched.InferenceRequest{RequestID: "chat-1", TargetModel: "chat"}
+	math := &fwksched.InferenceRequest{RequestID: "math-1", TargetModel: "math"}
+	p.PreRequest(t.Context(), chat, schedulingResult(endpoint))
+	p.PreRequest(t.Context(), math, schedulingResult(endpoint))
+
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{RequestID: "sql-1", TargetModel: "sql"}, schedulingResult(endpoint))
+	assert.Empty(t, server.getCalls())
+
+	// An intermediate chunk keeps the request in flight; the end of the stream releases it.
+	p.ResponseBody(t.Context(), chat, &requestcontrol.Response{StartOfStream: true}, nil)
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{RequestID: "sql-2", TargetModel: "sql"}, schedulingResult(endpoint))
+	assert.Empty(t, server.getCalls())
+
+	p.ResponseBody(t.Context(), chat, &requestcontrol.Response{EndOfStream: true}, nil)
+	p.PreRequest(t.Context(), &fwksched.InferenceRequest{RequestID: "sql-3", TargetModel: "sql"}, schedulingResult(endpoint))
+	assert.Equal(t, []string{unloadAdapterPath + " chat", loadAdapterPath + " sql"}, server.getCalls())
+}
+
+func TestExtract_ForgetsRemovedEndpoints(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t)
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, nil)
+	request := &fwksched.InferenceRequest{RequestID: "1", TargetModel: "sql"}
+	p.PreRequest(t.Context(), request, schedulingResult(endpoint))
+
+	key, _ := endpointKey(endpoint)
+	p.tracker.mu.Lock()
+	assert.Contains(t, p.tracker.endpoints, key)
+	p.tracker.mu.Unlock()
+
+	// Updates keep the endpoint; its removal forgets it.
+	removed := fwkdl.NewEndpoint(endpoint.GetMetadata(), nil)
+	require.NoError(t, p.Extract(t.Context(), fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: removed}))
+	assert.Contains(t, sortedLoaded(p, endpoint), "sql")
+	require.NoError(t, p.Extract(t.Context(), fwkdl.EndpointEvent{Type: fwkdl.EventDelete, Endpoint: removed}))
+	p.tracker.mu.Lock()
+	assert.NotContains(t, p.tracker.endpoints, key)
+	p.tracker.mu.Unlock()
+
+	// Completing a request of the removed endpoint does not track it again.
+	p.ResponseBody(t.Context(), request, &requestcontrol.Response{EndOfStream: true}, nil)
+	p.tracker.mu.Lock()
+	assert.NotContains(t, p.tracker.endpoints, key)
+	p.tracker.mu.Unlock()
+}
+
+func TestPreRequest_LoadFailureAndTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		server := newFakeVLLM(t)
+		server.failLoad = true
+		p := newTestPlugin(t, nil)
+		endpoint := newEndpoint(t, server, nil)
+
+		p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+		p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+		// A failed load is not recorded, so the next request tries again.
+		assert.Len(t, server.getCalls(), 2)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		server := newFakeVLLM(t)
+		server.delay = time.Second
+		p := newTestPlugin(t, func(params *Parameters) {
+			params.LoadTimeout = metav1.Duration{Duration: 50 * time.Millisecond}
+		})
+		endpoint := newEndpoint(t, server, nil)
+
+		start := time.Now()
+		p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+		assert.Less(t, time.Since(start), server.delay)
+		assert.NotContains(t, sortedLoaded(p, endpoint), "sql")
+	})
+}
+
+func TestPreRequest_ConcurrentRequestsLoadOnce(t *testing.T) {
+	t.Parallel()
+	server := newFakeVLLM(t)
+	server.delay = 20 * time.Millisecond
+	p := newTestPlugin(t, nil)
+	endpoint := newEndpoint(t, server, nil)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.PreRequest(t.Context(), &fwksched.InferenceRequest{TargetModel: "sql"}, schedulingResult(endpoint))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{loadAdapterPath + " sql"}, server.getCalls())
+}
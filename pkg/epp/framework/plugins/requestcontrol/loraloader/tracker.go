@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loraloader
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// pendingChange is a load or unload the endpoint has not reported yet.
+type pendingChange int
+
+const (
+	noPendingChange pendingChange = iota
+	pendingLoad
+	pendingUnload
+)
+
+// adapterState is the plugin's knowledge of an adapter on an endpoint.
+type adapterState struct {
+	pending  pendingChange
+	lastUsed time.Time
+	// inFlight is the number of requests the plugin sent to the endpoint for the adapter that have not
+	// completed yet. Unlike the scraped running and waiting requests, it is current.
+	inFlight int
+}
+
+// endpointAdapters is the plugin's view of the adapters it loaded and unloaded on one endpoint.
+type endpointAdapters struct {
+	// sem serializes load and unload operations on the endpoint, so concurrent requests neither load the
+	// same adapter twice nor evict past the endpoint's capacity.
+	sem    chan struct{}
+	states map[string]*adapterState
+}
+
+// adapterTracker records the adapters the plugin loaded, unloaded and sent requests for, so decisions taken
+// before the next /v1/models and metrics refresh do not act on stale data.
+type adapterTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointAdapters
+}
+
+func newAdapterTracker() *adapterTracker {
+	return &adapterTracker{endpoints: map[string]*endpointAdapters{}}
+}
+
+// get returns the tracked adapters of the endpoint. It must be called with mu held.
+func (t *adapterTracker) get(endpoint string) *endpointAdapters {
+	ea, ok := t.endpoints[endpoint]
+	if !ok {
+		ea = &endpointAdapters{sem: make(chan struct{}, 1), states: map[string]*adapterState{}}
+		t.endpoints[endpoint] = ea
+	}
+	return ea
+}
+
+// lock waits for exclusive access to the endpoint's adapters and returns the function releasing it.
+func (t *adapterTracker) lock(ctx context.Context, endpoint string) (func(), error) {
+	t.mu.Lock()
+	sem := t.get(endpoint).sem
+	t.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// reconcile overlays the pending changes on the adapters reported by the endpoint and returns the adapters
+// loaded on it. A pending change is dropped once the endpoint reports it.
+func (t *adapterTracker) reconcile(endpoint string, reported, busy sets.Set[string]) sets.Set[string] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	loaded := reported.Union(busy)
+	for adapter, state := range t.get(endpoint).states {
+		switch state.pending {
+		case pendingLoad:
+			if reported.Has(adapter) {
+				state.pending = noPendingChange
+			}
+			loaded.Insert(adapter)
+		case pendingUnload:
+			if !reported.Has(adapter) {
+				state.pending = noPendingChange
+			} else if !busy.Has(adapter) {
+				loaded.Delete(adapter)
+			}
+		}
+	}
+	return loaded
+}
+
+// leastRecentlyUsed returns the candidate adapter the plugin used least recently on the endpoint. Adapters
+// the plugin never used come first, in name order.
+func (t *adapterTracker) leastRecentlyUsed(endpoint string, candidates sets.Set[string]) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	states := t.get(endpoint).states
+	names := sets.List(candidates)
+	sort.SliceStable(names, func(i, j int) bool {
+		return lastUsed(states, names[i]).Before(lastUsed(states, names[j]))
+	})
+	if len(names) == 0 {
+		return ""
+	}
+	return names[0]
+}
+
+func lastUsed(states map[string]*adapterState, adapter string) time.Time {
+	if state, ok := states[adapter]; ok {
+		return state.lastUsed
+	}
+	return time.Time{}
+}
+
+// touch records that the adapter was used on the endpoint at the given time.
+func (t *adapterTracker) touch(endpoint, adapter string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state(endpoint, adapter).lastUsed = now
+}
+
+// setLoaded records that the adapter was loaded on the endpoint at the given time.
+func (t *adapterTracker) setLoaded(endpoint, adapter string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.state(endpoint, adapter)
+	state.pending = pendingLoad
+	state.lastUsed = now
+}
+
+// setUnloaded records that the adapter was unloaded from the endpoint.
+func (t *adapterTracker) setUnloaded(endpoint, adapter string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state := t.state(endpoint, adapter)
+	state.pending = pendingUnload
+	state.lastUsed = time.Time{}
+}
+
+// acquire records a request sent to the endpoint for the adapter.
+func (t *adapterTracker) acquire(endpoint, adapter string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state(endpoint, adapter).inFlight++
+}
+
+// release records the completion of a request recorded by acquire. Requests of a removed endpoint are ignored.
+func (t *adapterTracker) release(endpoint, adapter string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ea, ok := t.endpoints[endpoint]
+	if !ok {
+		return
+	}
+	if state, ok := ea.states[adapter]; ok && state.inFlight > 0 {
+		state.inFlight--
+	}
+}
+
+// inFlight returns the adapters with requests in flight on the endpoint.
+func (t *adapterTracker) inFlight(endpoint string) sets.Set[string] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	adapters := sets.New[string]()
+	if ea, ok := t.endpoints[endpoint]; ok {
+		for adapter, state := range ea.states {
+			if state.inFlight > 0 {
+				adapters.Insert(adapter)
+			}
+		}
+	}
+	return adapters
+}
+
+// remove forgets the endpoint.
+func (t *adapterTracker) remove(endpoint string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.endpoints, endpoint)
+}
+
+// state returns the state of the adapter on the endpoint. It must be called with mu held.
+func (t *adapterTracker) state(endpoint, adapter string) *adapterState {
+	states := t.get(endpoint).states
+	state, ok := states[adapter]
+	if !ok {
+		state = &adapterState{}
+		states[adapter] = state
+	}
+	return state
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	fwkrc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+)
+
+const (
+	evictionReasonCapacity = "capacity"
+	evictionReasonExpired  = "expired"
+)
+
+type cacheEntry struct {
+	key       string
+	response  *fwkrc.CachedResponse
+	expiresAt time.Time
+}
+
+// lruCache is a bounded, least-recently-used cache whose entries expire after a fixed TTL.
+type lruCache struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+	// onEvict is called, with the lock held, for every entry removed because of capacity or expiry.
+	onEvict func(reason string)
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used entry
+	entries map[string]*list.Element
+}
+
+func newLRUCache(maxEntries int, ttl time.Duration, onEvict func(reason string)) *lruCache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		onEvict:    onEvict,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the unexpired response stored under key and marks it as recently used.
+func (c *lruCache) get(key string) (*fwkrc.CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem, evictionReasonExpired)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.response, true
+}
+
+// add stores the response under key, replacing any previous entry, and evicts the least recently used
+// entries beyond the capacity.
+func (c *lruCache) add(key string, response *fwkrc.CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.response = response
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, response: response, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back(), evictionReasonCapacity)
+	}
+}
+
+// len returns the number of entries, including expired entries that have not been looked up since.
+func (c *lruCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lruCache) remove(elem *list.Element, reason string) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+	if c.onEvict != nil {
+		c.onEvict(reason)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	fwkrc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	evictions := map[string]int{}
+	cache := newLRUCache(2, time.Minute, func(reason string) { evictions[reason]++ })
+	cache.now = func() time.Time { return now }
+
+	a := &fwkrc.CachedResponse{Body: []byte("a")}
+	b := &fwkrc.CachedResponse{Body: []byte("b")}
+	c := &fwkrc.CachedResponse{Body: []byte("c")}
+
+	cache.add("a", a)
+	cache.add("b", b)
+	got, ok := cache.get("a")
+	assert.True(t, ok)
+	assert.Same(t, a, got)
+
+	// "b" is the least recently used entry and makes room for "c".
+	cache.add("c", c)
+	assert.Equal(t, 2, cache.len())
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]int{evictionReasonCapacity: 1}, evictions)
+
+	// Entries expire a TTL after they were stored, regardless of lookups.
+	now = now.Add(30 * time.Second)
+	_, ok = cache.get("a")
+	assert.True(t, ok)
+	now = now.Add(30 * time.Second)
+	_, ok = cache.get("a")
+	assert.False(t, ok)
+	assert.Equal(t, map[string]int{evictionReasonCapacity: 1, evictionReasonExpired: 1}, evictions)
+	assert.Equal(t, 1, cache.len())
+
+	// Storing a key again replaces the response and restarts its TTL.
+	cache.add("c", a)
+	now = now.Add(59 * time.Second)
+	got, ok = cache.get("c")
+	assert.True(t, ok)
+	assert.Same(t, a, got)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	compbasemetrics "k8s.io/component-base/metrics"
+
+	metricsutil "github.com/llm-d/llm-d-router/pkg/common/observability/metrics"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	eppmetrics "github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	lookupResultHit  = "hit"
+	lookupResultMiss = "miss"
+)
+
+var (
+	lookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "response_cache_lookups_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of cacheable requests looked up in the response cache, by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "result"},
+	)
+
+	storesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "response_cache_stores_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of model server responses stored in the response cache.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name"},
+	)
+
+	evictionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "response_cache_evictions_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of entries evicted from the response cache, by reason.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name", "reason"},
+	)
+
+	entries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: eppmetrics.LLMDRouterEndpointPickerSubsystem,
+			Name:      "response_cache_entries",
+			Help:      metricsutil.HelpMsgWithStability("Number of entries in the response cache.", compbasemetrics.ALPHA),
+		},
+		[]string{"plugin_type", "plugin_name"},
+	)
+)
+
+func registerMetrics(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		return errors.New("response cache metrics registerer is required")
+	}
+	for _, collector := range []prometheus.Collector{lookupsTotal, storesTotal, evictionsTotal, entries} {
+		if err := registerer.Register(collector); err != nil {
+			var alreadyRegistered prometheus.AlreadyRegisteredError
+			if errors.As(err, &alreadyRegistered) && alreadyRegistered.ExistingCollector == collector {
+				continue
+			}
+			return fmt.Errorf("register response cache metric: %w", err)
+		}
+	}
+	return nil
+}
+
+func recordLookup(typedName fwkplugin.TypedName, result string) {
+	lookupsTotal.WithLabelValues(typedName.Type, typedName.Name, result).Inc()
+}
+
+func recordStore(typedName fwkplugin.TypedName) {
+	storesTotal.WithLabelValues(typedName.Type, typedName.Name).Inc()
+}
+
+func recordEntries(typedName fwkplugin.TypedName, size int) {
+	entries.WithLabelValues(typedName.Type, typedName.Name).Set(float64(size))
+}
+
+func recordEviction(typedName fwkplugin.TypedName, reason string) {
+	evictionsTotal.WithLabelValues(typedName.Type, typedName.Name, reason).Inc()
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package responsecache implements an opt-in exact-match response cache. Non-streaming responses are captured
+// at the end of the response and later requests of the same caller with the same target model, path and
+// normalized body are answered from the cache without being admitted, scheduled or sent to a model server.
+package responsecache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+)
+
+const (
+	// Type is the registered plugin type for the response cache.
+	Type = "response-cache"
+
+	defaultMaxEntries       = 1000
+	defaultTTL              = 5 * time.Minute
+	defaultMaxResponseBytes = 1 << 20
+
+	pendingKeyStateKey = fwkplugin.StateKey("response-cache/pending-key")
+)
+
+// defaultIdentityHeaders identify the caller by its credentials.
+var defaultIdentityHeaders = []string{"authorization"}
+
+// Parameters defines the JSON-configurable fields for the plugin.
+type Parameters struct {
+	// MaxEntries is the number of responses kept before the least recently used one is evicted.
+	MaxEntries int `json:"maxEntries"`
+	// TTL is how long a response is served from the cache after it was stored.
+	TTL metav1.Duration `json:"ttl"`
+	// MaxResponseBytes is the size of the largest response body that is stored.
+	MaxResponseBytes int `json:"maxResponseBytes"`
+	// Objectives restricts the cache to requests of the listed InferenceObjectives. Empty enables it for all
+	// requests.
+	Objectives []string `json:"objectives"`
+	// IgnoredFields are top-level request body fields left out of the cache key, e.g. fields that do not
+	// change the generated output.
+	IgnoredFields []string `json:"ignoredFields"`
+	// CacheNonDeterministic also caches requests that neither set a temperature of 0 nor a seed.
+	CacheNonDeterministic bool `json:"cacheNonDeterministic"`
+	// IdentityHeaders are the request headers identifying the caller, e.g. "authorization" or "x-tenant-id".
+	// Their values are part of the cache key, so callers never receive each other's responses, and requests
+	// carrying none of them are not cached.
+	IdentityHeaders []string `json:"identityHeaders"`
+	// CrossTenantCaching shares cached responses between all callers: the identity headers are left out of the
+	// cache key and requests without them are cached.
+	CrossTenantCaching bool `json:"crossTenantCaching"`
+}
+
+func defaultParameters() Parameters {
+	return Parameters{
+		MaxEntries:       defaultMaxEntries,
+		TTL:              metav1.Duration{Duration: defaultTTL},
+		MaxResponseBytes: defaultMaxResponseBytes,
+		IgnoredFields:    []string{"metadata"},
+		IdentityHeaders:  defaultIdentityHeaders,
+	}
+}
+
+func (p *Parameters) validate() error {
+	if p.MaxEntries <= 0 {
+		return fmt.Errorf("maxEntries must be > 0, got %d", p.MaxEntries)
+	}
+	if p.TTL.Duration <= 0 {
+		return fmt.Errorf("ttl must be > 0, got %v", p.TTL.Duration)
+	}
+	if p.MaxResponseBytes <= 0 {
+		return fmt.Errorf("maxResponseBytes must be > 0, got %d", p.MaxResponseBytes)
+	}
+	if len(p.IdentityHeaders) == 0 && !p.CrossTenantCaching {
+		return errors.New("identityHeaders must not be empty unless crossTenantCaching is enabled")
+	}
+	return nil
+}
+
+// compile-time interface assertions
+var (
+	_ requestcontrol.ResponseCache         = &Plugin{}
+	_ requestcontrol.ResponseBodyProcessor = &Plugin{}
+)
+
+// Plugin answers requests from previously captured responses.
+type Plugin struct {
+	typedName     fwkplugin.TypedName
+	params        Parameters
+	objectives    sets.Set[string]
+	ignoredFields sets.Set[string]
+	// identityHeaders are the lower-cased IdentityHeaders, in a fixed order.
+	identityHeaders []string
+	pluginState     *fwkplugin.PluginState
+	cache           *lruCache
+}
+
+// Factory creates a response cache Plugin from plugin configuration.
+func Factory(name string, rawParameters *json.Decoder, handle fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	params := defaultParameters()
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&params); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", Type, err)
+		}
+	}
+	if err := params.validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", Type, err)
+	}
+	if err := registerMetrics(handle.Metrics()); err != nil {
+		return nil, err
+	}
+	return newPlugin(name, params, fwkplugin.NewPluginState(handle.Context())), nil
+}
+
+// newPlugin creates a Plugin with the given, already validated, parameters.
+func newPlugin(name string, params Parameters, pluginState *fwkplugin.PluginState) *Plugin {
+	identityHeaders := sets.New[string]()
+	for _, header := range params.IdentityHeaders {
+		identityHeaders.Insert(strings.ToLower(header))
+	}
+	p := &Plugin{
+		typedName:       fwkplugin.TypedName{Type: Type, Name: name},
+		params:          params,
+		objectives:      sets.New(params.Objectives...),
+		ignoredFields:   sets.New(params.IgnoredFields...),
+		identityHeaders: sets.List(identityHeaders),
+		pluginState:     pluginState,
+	}
+	p.cache = newLRUCache(params.MaxEntries, params.TTL.Duration, func(reason string) {
+		recordEviction(p.typedName, reason)
+	})
+	return p
+}
+
+// TypedName returns the plugin type and instance name.
+func (p *Plugin) TypedName() fwkplugin.TypedName {
+	return p.typedName
+}
+
+// pendingKey is the cache key of a request that missed the cache, kept until its response completes.
+type pendingKey string
+
+// Clone implements fwkplugin.StateData.
+func (k pendingKey) Clone() fwkplugin.StateData {
+	return k
+}
+
+// Lookup returns the cached response for the request, if any. On a miss the cache key is remembered so the
+// response can be stored once it completes.
+func (p *Plugin) Lookup(ctx context.Context, request *fwksched.InferenceRequest) *requestcontrol.CachedResponse {
+	key, ok := p.cacheKey(request)
+	if !ok {
+		return nil
+	}
+	if cached, ok := p.cache.get(key); ok {
+		recordLookup(p.typedName, lookupResultHit)
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Response cache hit", "plugin", p.typedName)
+		return cached
+	}
+	recordLookup(p.typedName, lookupResultMiss)
+	recordEntries(p.typedName, p.cache.len())
+	if request.RequestID != "" {
+		p.pluginState.Write(request.RequestID, pendingKeyStateKey, pendingKey(key))
+	}
+	return nil
+}
+
+// ResponseBody stores successful non-streaming responses of requests that missed the cache.
+func (p *Plugin) ResponseBody(
+	ctx context.Context,
+	request *fwksched.InferenceRequest,
+	response *requestcontrol.Response,
+	_ *datalayer.EndpointMetadata,
+) {
+	if request == nil || response == nil || request.RequestID == "" || !response.EndOfStream {
+		return
+	}
+	key, err := fwkplugin.ReadPluginStateKey[pendingKey](p.pluginState, request.RequestID, pendingKeyStateKey)
+	if err != nil {
+		return
+	}
+	p.pluginState.Delete(request.RequestID)
+
+	if response.Headers[":status"] != "200" || len(response.Body) == 0 {
+		return
+	}
+	if len(response.Body) > p.params.MaxResponseBytes {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Response too large for the response cache",
+			"plugin", p.typedName, "size", len(response.Body), "maxResponseBytes", p.params.MaxResponseBytes)
+		return
+	}
+
+	headers := map[string]string{metadata.ResponseCacheKey: lookupResultHit}
+	if contentType, ok := response.Headers["content-type"]; ok {
+		headers["content-type"] = contentType
+	}
+	p.cache.add(string(key), &requestcontrol.CachedResponse{
+		Headers: headers,
+		Body:    append([]byte(nil), response.Body...),
+	})
+	recordStore(p.typedName)
+	recordEntries(p.typedName, p.cache.len())
+}
+
+// cacheKey hashes the caller identity, target model, request path and normalized request body. It returns false
+// for requests that are not cacheable: streaming or unparsed requests, requests of objectives the cache is not
+// enabled for, and, unless configured otherwise, requests without a caller identity and requests whose sampling
+// is not deterministic.
+func (p *Plugin) cacheKey(request *fwksched.InferenceRequest) (string, bool) {
+	if request == nil || request.Body == nil || request.Body.Stream || request.Body.Payload == nil {
+		return "", false
+	}
+	if p.objectives.Len() > 0 {
+		objective, _ := metadata.GetLowerCaseHeaderValue(request.Headers, metadata.ObjectiveKey)
+		if !p.objectives.Has(objective) {
+			return "", false
+		}
+	}
+	payload, ok := request.Body.Payload.AsMap()
+	if !ok {
+		return "", false
+	}
+	if !p.params.CacheNonDeterministic && !isDeterministic(payload) {
+		return "", false
+	}
+	hash := sha256.New()
+	if !p.params.CrossTenantCaching {
+		identified := false
+		for _, header := range p.identityHeaders {
+			value, ok := request.Headers[header]
+			if !ok || value == "" {
+				continue
+			}
+			identified = true
+			hash.Write([]byte(header))
+			hash.Write([]byte{0})
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+		if !identified {
+			return "", false
+		}
+	}
+
+	normalized := make(map[string]any, len(payload))
+	for field, value := range payload {
+		if field == "stream" || p.ignoredFields.Has(field) {
+			continue
+		}
+		normalized[field] = value
+	}
+	normalized["model"] = request.TargetModel
+	// encoding/json sorts map keys, so equal bodies marshal to the same bytes regardless of field order.
+	body, err := json.Marshal(normalized)
+	if err != nil {
+		return "", false
+	}
+	hash.Write([]byte(request.Headers[":path"]))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// isDeterministic reports whether the request asks for greedy sampling or a fixed seed, so that repeating it
+// is expected to produce the same output.
+func isDeterministic(payload map[string]any) bool {
+	if temperature, ok := payload["temperature"].(float64); ok && temperature == 0 {
+		return true
+	}
+	seed, ok := payload["seed"]
+	return ok && seed != nil
+}
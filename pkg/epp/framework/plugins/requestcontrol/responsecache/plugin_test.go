@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+	testutils "github.com/llm-d/llm-d-router/test/utils"
+)
+
+const testResponseBody = `{"choices":[{"text":"hi"}]}`
+
+func newTestPlugin(t *testing.T, mutate func(*Parameters)) *Plugin {
+	t.Helper()
+	params := defaultParameters()
+	if mutate != nil {
+		mutate(&params)
+	}
+	require.NoError(t, params.validate())
+	return newPlugin("test", params, fwkplugin.NewPluginState(t.Context()))
+}
+
+const testAuthorization = "Bearer alice"
+
+// newRequest creates a request to /v1/completions, authorized with testAuthorization unless headers is non-nil.
+func newRequest(t *testing.T, id, body string, headers map[string]string) *fwksched.InferenceRequest {
+	t.Helper()
+	payload := fwkrh.PayloadMap{}
+	require.NoError(t, json.Unmarshal([]byte(body), &payload))
+	stream, _ := payload["stream"].(bool)
+	if headers == nil {
+		headers = map[string]string{"authorization": testAuthorization}
+	}
+	headers[":path"] = "/v1/completions"
+	return &fwksched.InferenceRequest{
+		RequestID:   id,
+		TargetModel: "m",
+		Headers:     headers,
+		Body:        &fwkrh.InferenceRequestBody{Payload: payload, Stream: stream},
+	}
+}
+
+func completeResponse(p *Plugin, request *fwksched.InferenceRequest, status, body string) {
+	p.ResponseBody(context.Background(), request, &requestcontrol.Response{
+		Headers:     map[string]string{":status": status, "content-type": "application/json"},
+		EndOfStream: true,
+		Body:        []byte(body),
+	}, nil)
+}
+
+func TestFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		params    string
+		wantError string
+	}{
+		{name: "defaults", params: `{}`},
+		{name: "all parameters", params: `{"maxEntries": 10, "ttl": "1m", "maxResponseBytes": 4096, "objectives": ["batch"], "ignoredFields": ["user"], "cacheNonDeterministic": true, "identityHeaders": ["x-tenant-id"], "crossTenantCaching": true}`},
+		{name: "cross-tenant caching without identity headers", params: `{"identityHeaders": [], "crossTenantCaching": true}`},
+		{name: "malformed", params: `{"maxEntries": "ten"}`, wantError: "failed to parse"},
+		{name: "zero max entries", params: `{"maxEntries": 0}`, wantError: "maxEntries must be > 0"},
+		{name: "zero ttl", params: `{"ttl": "0s"}`, wantError: "ttl must be > 0"},
+		{name: "zero max response bytes", params: `{"maxResponseBytes": 0}`, wantError: "maxResponseBytes must be > 0"},
+		{name: "no identity headers", params: `{"identityHeaders": []}`, wantError: "identityHeaders must not be empty"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := Factory("test-cache", fwkplugin.StrictDecoder(json.RawMessage(tc.params)), testutils.NewTestHandle(t.Context()))
+			if tc.wantError != "" {
+				require.ErrorContains(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: Type, Name: "test-cache"}, p.TypedName())
+		})
+	}
+}
+
+func TestLookupAndStore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p := newTestPlugin(t, nil)
+
+	first := newRequest(t, "r1", `{"model":"m","prompt":"p","temperature":0,"user":"alice","metadata":{"run":"1"}}`, nil)
+	assert.Nil(t, p.Lookup(ctx, first))
+	completeResponse(p, first, "200", testResponseBody)
+
+	// Field order and ignored fields do not change the key.
+	second := newRequest(t, "r2", `{"metadata":{"run":"2"},"user":"alice","temperature":0,"prompt":"p","model":"m"}`, nil)
+	cached := p.Lookup(ctx, second)
+	require.NotNil(t, cached)
+	assert.Equal(t, testResponseBody, string(cached.Body))
+	assert.Equal(t, map[string]string{"content-type": "application/json", metadata.ResponseCacheKey: "hit"}, cached.Headers)
+
+	// The user field is part of the key.
+	assert.Nil(t, p.Lookup(ctx, newRequest(t, "r5", `{"model":"m","prompt":"p","temperature":0,"user":"bob"}`, nil)))
+
+	// Different sampling parameters are different entries.
+	assert.Nil(t, p.Lookup(ctx, newRequest(t, "r3", `{"model":"m","prompt":"p","temperature":0,"max_tokens":5}`, nil)))
+
+	// The same body on another path is a different entry.
+	otherPath := newRequest(t, "r4", `{"model":"m","prompt":"p","temperature":0}`, nil)
+	otherPath.Headers[":path"] = "/v1/chat/completions"
+	assert.Nil(t, p.Lookup(ctx, otherPath))
+}
+
+func TestNotCached(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*Parameters)
+		body    string
+		headers map[string]string
+		status  string
+	}{
+		{name: "streaming request", body: `{"model":"m","prompt":"p","temperature":0,"stream":true}`, status: "200"},
+		{name: "non-deterministic request", body: `{"model":"m","prompt":"p","temperature":0.7}`, status: "200"},
+		{name: "default temperature", body: `{"model":"m","prompt":"p"}`, status: "200"},
+		{name: "error response", body: `{"model":"m","prompt":"p","temperature":0}`, status: "500"},
+		{
+			name:   "response too large",
+			mutate: func(p *Parameters) { p.MaxResponseBytes = 4 },
+			body:   `{"model":"m","prompt":"p","temperature":0}`,
+			status: "200",
+		},
+		{
+			name:    "request without identity",
+			body:    `{"model":"m","prompt":"p","temperature":0}`,
+			headers: map[string]string{},
+			status:  "200",
+		},
+		{
+			name:    "objective not enabled",
+			mutate:  func(p *Parameters) { p.Objectives = []string{"batch"} },
+			body:    `{"model":"m","prompt":"p","temperature":0}`,
+			headers: map[string]string{metadata.ObjectiveKey: "interactive"},
+			status:  "200",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			p := newTestPlugin(t, tc.mutate)
+
+			first := newRequest(t, "r1", tc.body, tc.headers)
+			assert.Nil(t, p.Lookup(ctx, first))
+			completeResponse(p, first, tc.status, testResponseBody)
+			assert.Nil(t, p.Lookup(ctx, newRequest(t, "r2", tc.body, tc.headers)))
+			assert.Zero(t, p.cache.len())
+		})
+	}
+}
+
+func TestCachedVariants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*Parameters)
+		body    string
+		headers map[string]string
+	}{
+		{name: "fixed seed", body: `{"model":"m","prompt":"p","temperature":0.7,"seed":42}`},
+		{
+			name:   "non-deterministic requests enabled",
+			mutate: func(p *Parameters) { p.CacheNonDeterministic = true },
+			body:   `{"model":"m","prompt":"p"}`,
+		},
+		{
+			name:    "enabled objective",
+			mutate:  func(p *Parameters) { p.Objectives = []string{"batch"} },
+			body:    `{"model":"m","prompt":"p","temperature":0}`,
+			headers: map[string]string{metadata.ObjectiveKey: "batch", "authorization": testAuthorization},
+		},
+		{
+			name:    "configured identity header",
+			mutate:  func(p *Parameters) { p.IdentityHeaders = []string{"X-Tenant-ID"} },
+			body:    `{"model":"m","prompt":"p","temperature":0}`,
+			headers: map[string]string{"x-tenant-id": "team-a"},
+		},
+		{
+			name:    "cross-tenant caching without identity",
+			mutate:  func(p *Parameters) { p.CrossTenantCaching = true },
+			body:    `{"model":"m","prompt":"p","temperature":0}`,
+			headers: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			p := newTestPlugin(t, tc.mutate)
+
+			first := newRequest(t, "r1", tc.body, tc.headers)
+			assert.Nil(t, p.Lookup(ctx, first))
+			completeResponse(p, first, "200", testResponseBody)
+			assert.NotNil(t, p.Lookup(ctx, newRequest(t, "r2", tc.body, tc.headers)))
+		})
+	}
+}
+
+func TestLookup_Identity(t *testing.T) {
+	t.Parallel()
+	body := `{"model":"m","prompt":"p","temperature":0}`
+
+	tests := []struct {
+		name       string
+		mutate     func(*Parameters)
+		wantShared bool
+	}{
+		{name: "callers do not share responses"},
+		{name: "cross-tenant caching shares responses", mutate: func(p *Parameters) { p.CrossTenantCaching = true }, wantShared: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			p := newTestPlugin(t, tc.mutate)
+
+			alice := newRequest(t, "r1", body, map[string]string{"authorization": "Bearer alice"})
+			assert.Nil(t, p.Lookup(ctx, alice))
+			completeResponse(p, alice, "200", testResponseBody)
+			assert.NotNil(t, p.Lookup(ctx, newRequest(t, "r2", body, map[string]string{"authorization": "Bearer alice"})))
+
+			cached := p.Lookup(ctx, newRequest(t, "r3", body, map[string]string{"authorization": "Bearer bob"}))
+			assert.Equal(t, tc.wantShared, cached != nil)
+		})
+	}
+}
+
+func TestResponseBody_IgnoresIntermediateChunksAndUnknownRequests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	p := newTestPlugin(t, nil)
+	body := `{"model":"m","prompt":"p","temperature":0}`
+
+	// A response for a request that was never looked up is not stored.
+	completeResponse(p, newRequest(t, "unknown", body, nil), "200", testResponseBody)
+	assert.Zero(t, p.cache.len())
+
+	request := newRequest(t, "r1", body, nil)
+	assert.Nil(t, p.Lookup(ctx, request))
+	p.ResponseBody(ctx, request, &requestcontrol.Response{Headers: map[string]string{":status": "200"}, Body: []byte("partial")}, nil)
+	assert.Zero(t, p.cache.len())
+	completeResponse(p, request, "200", testResponseBody)
+	assert.Equal(t, 1, p.cache.len())
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricexpression provides a scorer and a filter evaluating a CEL expression over the
+// metrics of every candidate endpoint.
+//
+// Together with the custom-metrics-extractor, which stores arbitrary Prometheus series as
+// endpoint attributes, they allow scheduling on engine-specific signals, such as the
+// speculative-decoding acceptance rate or the preemption count, from configuration alone.
+package metricexpression
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+)
+
+const (
+	// metricsVariable is the CEL variable holding the endpoint's metrics by name.
+	metricsVariable = "metrics"
+	// labelsVariable is the CEL variable holding the endpoint's labels.
+	labelsVariable = "labels"
+)
+
+// expression is a compiled CEL expression over the metrics and labels of an endpoint.
+type expression struct {
+	source  string
+	program cel.Program
+}
+
+// compileExpression compiles the source and checks that it evaluates to one of the given types.
+func compileExpression(source string, outputTypes ...*cel.Type) (*expression, error) {
+	if source == "" {
+		return nil, errors.New("'expression' must be set")
+	}
+	env, err := cel.NewEnv(
+		cel.Variable(metricsVariable, cel.MapType(cel.StringType, cel.DoubleType)),
+		cel.Variable(labelsVariable, cel.MapType(cel.StringType, cel.StringType)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(source)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
+	}
+	if !isOneOf(ast.OutputType(), outputTypes) {
+		return nil, fmt.Errorf("expression must evaluate to one of %v, got %v", outputTypes, ast.OutputType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create program for expression: %w", err)
+	}
+	return &expression{source: source, program: program}, nil
+}
+
+func isOneOf(t *cel.Type, types []*cel.Type) bool {
+	if t.IsExactType(cel.DynType) {
+		return true
+	}
+	for _, candidate := range types {
+		if t.IsExactType(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// eval evaluates the expression against the endpoint.
+func (e *expression) eval(endpoint fwksched.Endpoint) (any, error) {
+	val, _, err := e.program.Eval(map[string]any{
+		metricsVariable: endpointMetrics(endpoint),
+		labelsVariable:  endpointLabels(endpoint),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.Value(), nil
+}
+
+// evalNumber evaluates the expression against the endpoint and converts the result to a float64.
+func (e *expression) evalNumber(endpoint fwksched.Endpoint) (float64, error) {
+	val, err := e.eval(endpoint)
+	if err != nil {
+		return 0, err
+	}
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("expression result %v is not a number", val)
+}
+
+// evalBool evaluates the expression against the endpoint and returns the boolean result.
+func (e *expression) evalBool(endpoint fwksched.Endpoint) (bool, error) {
+	val, err := e.eval(endpoint)
+	if err != nil {
+		return false, err
+	}
+	result, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression result %v is not a boolean", val)
+	}
+	return result, nil
+}
+
+// endpointMetrics returns the core metrics of the endpoint together with all of its scalar
+// metric attributes, e.g. those stored by the custom-metrics-extractor.
+func endpointMetrics(endpoint fwksched.Endpoint) map[string]float64 {
+	metrics := map[string]float64{}
+	for _, key := range endpoint.Keys() {
+		if value, ok := attrmetrics.ReadScalarMetricValue(endpoint, key); ok {
+			metrics[key] = float64(value)
+		}
+	}
+	if m := endpoint.GetMetrics(); m != nil {
+		metrics[attrmetrics.WaitingQueueSizeMetric] = float64(m.WaitingQueueSize)
+		metrics[attrmetrics.RunningRequestsSizeMetric] = float64(m.RunningRequestsSize)
+		metrics[attrmetrics.KVCacheUsagePercentMetric] = m.KVCacheUsagePercent
+	}
+	return metrics
+}
+
+// endpointLabels returns the labels of the endpoint.
+func endpointLabels(endpoint fwksched.Endpoint) map[string]string {
+	if meta := endpoint.GetMetadata(); meta != nil && meta.Labels != nil {
+		return meta.Labels
+	}
+	return map[string]string{}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricexpression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const (
+	// FilterType is the type of the metric-expression filter.
+	FilterType = "metric-expression-filter"
+)
+
+// FilterParameters configures the metric-expression filter.
+type FilterParameters struct {
+	// Expression is a CEL expression evaluating to a boolean, e.g. `metrics["preemptions"] < 1.0`.
+	Expression string `json:"expression"`
+	// KeepOnError keeps the endpoints for which the expression cannot be evaluated, e.g. because a
+	// metric was not extracted yet. Defaults to true.
+	KeepOnError *bool `json:"keepOnError,omitempty"`
+}
+
+var _ fwksched.Filter = &Filter{}
+
+// FilterFactory defines the factory function for the metric-expression filter.
+func FilterFactory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := FilterParameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", FilterType, err)
+		}
+	}
+	return NewFilter(name, parameters)
+}
+
+// NewFilter creates a metric-expression filter with the given parameters.
+func NewFilter(name string, parameters FilterParameters) (*Filter, error) {
+	expr, err := compileExpression(parameters.Expression, cel.BoolType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", FilterType, err)
+	}
+	keepOnError := true
+	if parameters.KeepOnError != nil {
+		keepOnError = *parameters.KeepOnError
+	}
+	return &Filter{
+		typedName:   fwkplugin.TypedName{Type: FilterType, Name: name},
+		expression:  expr,
+		keepOnError: keepOnError,
+	}, nil
+}
+
+// Filter keeps the endpoints for which a CEL expression over their metrics is true.
+type Filter struct {
+	typedName   fwkplugin.TypedName
+	expression  *expression
+	keepOnError bool
+}
+
+// TypedName returns the typed name of the plugin.
+func (f *Filter) TypedName() fwkplugin.TypedName {
+	return f.typedName
+}
+
+// Filter returns the endpoints for which the expression is true.
+func (f *Filter) Filter(ctx context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) []fwksched.Endpoint {
+	logger := log.FromContext(ctx)
+	filtered := make([]fwksched.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		keep, err := f.expression.evalBool(endpoint)
+		if err != nil {
+			logger.V(logutil.TRACE).Info("Failed to evaluate metric expression", "endpoint", endpoint,
+				"expression", f.expression.source, "error", err)
+			keep = f.keepOnError
+		}
+		if keep {
+			filtered = append(filtered, endpoint)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricexpression
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+func TestFilterFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{name: "bool", params: `{"expression": "metrics['a'] < 1.0"}`},
+		{name: "keep on error disabled", params: `{"expression": "'a' in metrics", "keepOnError": false}`},
+		{name: "missing expression", params: `{}`, wantErr: true},
+		{name: "not a boolean", params: `{"expression": "metrics['a']"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plugin, err := FilterFactory("filter", fwkplugin.StrictDecoder(json.RawMessage(tt.params)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: FilterType, Name: "filter"}, plugin.TypedName())
+		})
+	}
+}
+
+func TestFilterFilter(t *testing.T) {
+	t.Parallel()
+
+	low := newEndpoint("low", 1, nil, map[string]float64{"preemptions": 0})
+	high := newEndpoint("high", 1, nil, map[string]float64{"preemptions": 3})
+	unknown := newEndpoint("unknown", 1, nil, nil)
+	endpoints := []fwksched.Endpoint{low, high, unknown}
+
+	tests := []struct {
+		name       string
+		parameters FilterParameters
+		want       []fwksched.Endpoint
+	}{
+		{
+			name:       "keeps matching endpoints and fails open",
+			parameters: FilterParameters{Expression: `metrics["preemptions"] < 1.0`},
+			want:       []fwksched.Endpoint{low, unknown},
+		},
+		{
+			name:       "drops endpoints on error",
+			parameters: FilterParameters{Expression: `metrics["preemptions"] < 1.0`, KeepOnError: ptr.To(false)},
+			want:       []fwksched.Endpoint{low},
+		},
+		{
+			name:       "guarded missing metric",
+			parameters: FilterParameters{Expression: `!("preemptions" in metrics) || metrics["preemptions"] > 1.0`},
+			want:       []fwksched.Endpoint{high, unknown},
+		},
+		{
+			name:       "core metrics",
+			parameters: FilterParameters{Expression: `metrics["WaitingQueueSize"] > 1.0`},
+			want:       []fwksched.Endpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filter, err := NewFilter("filter", tt.parameters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, filter.Filter(t.Context(), nil, endpoints))
+		})
+	}
+}
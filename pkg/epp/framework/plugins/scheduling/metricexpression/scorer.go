@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricexpression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/google/cel-go/cel"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const (
+	// ScorerType is the type of the metric-expression scorer.
+	ScorerType = "metric-expression-scorer"
+)
+
+// ScorerParameters configures the metric-expression scorer.
+type ScorerParameters struct {
+	// Expression is a CEL expression evaluating to a number, e.g.
+	// `metrics["spec_decode_acceptance"] / (1.0 + metrics["WaitingQueueSize"])`.
+	Expression string `json:"expression"`
+	// LowerIsBetter scores the endpoints with the lowest result highest.
+	// By default the endpoints with the highest result score highest.
+	LowerIsBetter bool `json:"lowerIsBetter,omitempty"`
+}
+
+var _ fwksched.Scorer = &Scorer{}
+
+// ScorerFactory defines the factory function for the metric-expression scorer.
+func ScorerFactory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := ScorerParameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", ScorerType, err)
+		}
+	}
+	return NewScorer(name, parameters)
+}
+
+// NewScorer creates a metric-expression scorer with the given parameters.
+func NewScorer(name string, parameters ScorerParameters) (*Scorer, error) {
+	expr, err := compileExpression(parameters.Expression, cel.DoubleType, cel.IntType, cel.UintType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", ScorerType, err)
+	}
+	return &Scorer{
+		typedName:     fwkplugin.TypedName{Type: ScorerType, Name: name},
+		expression:    expr,
+		lowerIsBetter: parameters.LowerIsBetter,
+	}, nil
+}
+
+// Scorer scores endpoints by the result of a CEL expression over their metrics, min-max
+// normalized across the candidates. Endpoints for which the expression fails, e.g. because a
+// metric was not extracted yet, score 0.
+type Scorer struct {
+	typedName     fwkplugin.TypedName
+	expression    *expression
+	lowerIsBetter bool
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *Scorer) TypedName() fwkplugin.TypedName {
+	return s.typedName
+}
+
+// Category returns the preference the scorer applies when scoring candidate endpoints.
+func (s *Scorer) Category() fwksched.ScorerCategory {
+	return fwksched.Distribution
+}
+
+// Score evaluates the expression for every endpoint and normalizes the results to [0, 1].
+func (s *Scorer) Score(ctx context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	logger := log.FromContext(ctx)
+	results := make(map[fwksched.Endpoint]float64, len(endpoints))
+	minResult, maxResult := math.Inf(1), math.Inf(-1)
+	for _, endpoint := range endpoints {
+		result, err := s.expression.evalNumber(endpoint)
+		if err == nil && (math.IsNaN(result) || math.IsInf(result, 0)) {
+			err = fmt.Errorf("expression result %v is not finite", result)
+		}
+		if err != nil {
+			logger.V(logutil.TRACE).Info("Failed to evaluate metric expression", "endpoint", endpoint,
+				"expression", s.expression.source, "error", err)
+			continue
+		}
+		results[endpoint] = result
+		minResult = min(minResult, result)
+		maxResult = max(maxResult, result)
+	}
+
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	for _, endpoint := range endpoints {
+		result, ok := results[endpoint]
+		switch {
+		case !ok:
+			scores[endpoint] = 0
+		case maxResult == minResult:
+			scores[endpoint] = 1 // all endpoints are equally good
+		case s.lowerIsBetter:
+			scores[endpoint] = (maxResult - result) / (maxResult - minResult)
+		default:
+			scores[endpoint] = (result - minResult) / (maxResult - minResult)
+		}
+	}
+	return scores
+}
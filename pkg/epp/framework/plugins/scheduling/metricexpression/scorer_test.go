@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricexpression
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+)
+
+// newEndpoint creates an endpoint with the given waiting queue size, labels and scalar metrics.
+func newEndpoint(name string, waiting int, labels map[string]string, scalars map[string]float64) fwksched.Endpoint {
+	ep := fwksched.NewEndpoint(&fwkdl.EndpointMetadata{Labels: labels}, &fwkdl.Metrics{WaitingQueueSize: waiting}, nil)
+	ep.GetMetadata().NamespacedName.Name = name
+	for key, value := range scalars {
+		ep.Put(key, attrmetrics.ScalarMetricValue(value))
+	}
+	return ep
+}
+
+func TestScorerFactory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{name: "double", params: `{"expression": "metrics['a'] * 2.0"}`},
+		{name: "int", params: `{"expression": "size(labels)", "lowerIsBetter": true}`},
+		{name: "dyn", params: `{"expression": "dyn(metrics['a'])"}`},
+		{name: "missing expression", params: `{}`, wantErr: true},
+		{name: "syntax error", params: `{"expression": "metrics['a'] *"}`, wantErr: true},
+		{name: "not a number", params: `{"expression": "metrics['a'] > 1.0"}`, wantErr: true},
+		{name: "undeclared variable", params: `{"expression": "request.size"}`, wantErr: true},
+		{name: "unknown field", params: `{"expr": "1.0"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plugin, err := ScorerFactory("scorer", fwkplugin.StrictDecoder(json.RawMessage(tt.params)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fwkplugin.TypedName{Type: ScorerType, Name: "scorer"}, plugin.TypedName())
+		})
+	}
+}
+
+func TestScorerScore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		parameters ScorerParameters
+		endpoints  []fwksched.Endpoint
+		want       []float64
+	}{
+		{
+			name:       "higher is better",
+			parameters: ScorerParameters{Expression: `metrics["acceptance"]`},
+			endpoints: []fwksched.Endpoint{
+				newEndpoint("a", 0, nil, map[string]float64{"acceptance": 0.2}),
+				newEndpoint("b", 0, nil, map[string]float64{"acceptance": 0.8}),
+				newEndpoint("c", 0, nil, map[string]float64{"acceptance": 0.5}),
+			},
+			want: []float64{0, 1, 0.5},
+		},
+		{
+			name:       "lower is better with core metrics",
+			parameters: ScorerParameters{Expression: `metrics["WaitingQueueSize"] + metrics["preemptions"]`, LowerIsBetter: true},
+			endpoints: []fwksched.Endpoint{
+				newEndpoint("a", 4, nil, map[string]float64{"preemptions": 0}),
+				newEndpoint("b", 0, nil, map[string]float64{"preemptions": 0}),
+				newEndpoint("c", 1, nil, map[string]float64{"preemptions": 1}),
+			},
+			want: []float64{0, 1, 0.5},
+		},
+		{
+			name:       "failed evaluation scores 0",
+			parameters: ScorerParameters{Expression: `metrics["acceptance"]`},
+			endpoints: []fwksched.Endpoint{
+				newEndpoint("a", 0, nil, nil),
+				newEndpoint("b", 0, nil, map[string]float64{"acceptance": 0.8}),
+				newEndpoint("c", 0, nil, map[string]float64{"acceptance": 0.8}),
+			},
+			want: []float64{0, 1, 1},
+		},
+		{
+			name:       "labels",
+			parameters: ScorerParameters{Expression: `labels["tier"] == "fast" ? 1 : 0`},
+			endpoints: []fwksched.Endpoint{
+				newEndpoint("a", 0, map[string]string{"tier": "fast"}, nil),
+				newEndpoint("b", 0, map[string]string{"tier": "slow"}, nil),
+			},
+			want: []float64{1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			scorer, err := NewScorer("scorer", tt.parameters)
+			require.NoError(t, err)
+
+			scores := scorer.Score(t.Context(), nil, tt.endpoints)
+			require.Len(t, scores, len(tt.endpoints))
+			for i, endpoint := range tt.endpoints {
+				assert.InDelta(t, tt.want[i], scores[endpoint], 1e-9, "endpoint %s", endpoint.GetMetadata().NamespacedName.Name)
+			}
+		})
+	}
+}
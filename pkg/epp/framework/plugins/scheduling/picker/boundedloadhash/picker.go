@@ -0,0 +1,316 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package boundedloadhash implements a scheduling picker that consistently hashes a request key onto the
+// candidate endpoints while capping each endpoint's in-flight load relative to the average.
+//
+// For detailed behavioral intent and configuration, see the package README.
+package boundedloadhash
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrconcurrency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/concurrency"
+	attrsession "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/session"
+	tokenproducer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker"
+)
+
+const (
+	// BoundedLoadHashPickerType is the registered name of the bounded-load consistent-hash picker plugin.
+	BoundedLoadHashPickerType = "bounded-load-hash-picker"
+
+	// HashKeyHeader hashes the value of a request header.
+	HashKeyHeader = "header"
+	// HashKeySessionID hashes the SessionID published by the session-id-producer.
+	HashKeySessionID = "sessionID"
+	// HashKeyFairnessID hashes the request's flow control fairness ID.
+	HashKeyFairnessID = "fairnessID"
+	// HashKeyPrefix hashes the leading tokens of the tokenized prompt.
+	HashKeyPrefix = "prefix"
+
+	defaultHeaderName   = "x-session-id"
+	defaultPrefixTokens = 256
+	defaultLoadFactor   = 1.25
+)
+
+// Parameters defines the parameters of the BoundedLoadHashPicker.
+type Parameters struct {
+	picker.PickerParameters
+	// HashKey selects the request key that is hashed onto endpoints: "header" (default), "sessionID",
+	// "fairnessID" or "prefix".
+	HashKey string `json:"hashKey"`
+	// HeaderName is the request header hashed when HashKey is "header".
+	HeaderName string `json:"headerName"`
+	// PrefixTokens is the number of leading prompt tokens hashed when HashKey is "prefix".
+	PrefixTokens int `json:"prefixTokens"`
+	// LoadFactor caps the in-flight requests of an endpoint at LoadFactor times the average over the
+	// candidates (counting the request being scheduled). Must be at least 1.
+	LoadFactor float64 `json:"loadFactor"`
+	// InFlightLoadProducerName selects the inflight-load-producer instance whose counts are read.
+	InFlightLoadProducerName string `json:"inFlightLoadProducerName,omitempty"`
+}
+
+// compile-time type validation
+var (
+	_ fwksched.RequestAwarePicker = &BoundedLoadHashPicker{}
+	_ fwkplugin.ConsumerPlugin    = &BoundedLoadHashPicker{}
+)
+
+// BoundedLoadHashPickerFactory defines the factory function for BoundedLoadHashPicker.
+func BoundedLoadHashPickerFactory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{
+		PickerParameters: picker.PickerParameters{MaxNumOfEndpoints: picker.DefaultMaxNumOfEndpoints},
+		HashKey:          HashKeyHeader,
+		PrefixTokens:     defaultPrefixTokens,
+		LoadFactor:       defaultLoadFactor,
+	}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", BoundedLoadHashPickerType, err)
+		}
+	}
+
+	switch parameters.HashKey {
+	case HashKeyHeader, HashKeySessionID, HashKeyFairnessID, HashKeyPrefix:
+	default:
+		return nil, fmt.Errorf("invalid configuration for the '%s' picker: 'hashKey' must be one of %q, %q, %q or %q, got %q",
+			BoundedLoadHashPickerType, HashKeyHeader, HashKeySessionID, HashKeyFairnessID, HashKeyPrefix, parameters.HashKey)
+	}
+	if parameters.PrefixTokens <= 0 {
+		return nil, fmt.Errorf("invalid configuration for the '%s' picker: 'prefixTokens' must be positive, got %d",
+			BoundedLoadHashPickerType, parameters.PrefixTokens)
+	}
+	if parameters.LoadFactor < 1 {
+		return nil, fmt.Errorf("invalid configuration for the '%s' picker: 'loadFactor' must be at least 1, got %g",
+			BoundedLoadHashPickerType, parameters.LoadFactor)
+	}
+
+	return NewBoundedLoadHashPicker(&parameters).WithName(name), nil
+}
+
+// NewBoundedLoadHashPicker initializes a new BoundedLoadHashPicker and returns its pointer.
+func NewBoundedLoadHashPicker(params *Parameters) *BoundedLoadHashPicker {
+	maxNumOfEndpoints := params.MaxNumOfEndpoints
+	if maxNumOfEndpoints <= 0 {
+		maxNumOfEndpoints = picker.DefaultMaxNumOfEndpoints // on invalid configuration value, fallback to default value
+	}
+	hashKey := params.HashKey
+	if hashKey == "" {
+		hashKey = HashKeyHeader
+	}
+	headerName := strings.ToLower(strings.TrimSpace(params.HeaderName))
+	if headerName == "" {
+		headerName = defaultHeaderName
+	}
+	prefixTokens := params.PrefixTokens
+	if prefixTokens <= 0 {
+		prefixTokens = defaultPrefixTokens
+	}
+	loadFactor := params.LoadFactor
+	if loadFactor < 1 {
+		loadFactor = defaultLoadFactor
+	}
+
+	return &BoundedLoadHashPicker{
+		typedName:           fwkplugin.TypedName{Type: BoundedLoadHashPickerType, Name: BoundedLoadHashPickerType},
+		maxNumOfEndpoints:   maxNumOfEndpoints,
+		hashKey:             hashKey,
+		headerName:          headerName,
+		prefixTokens:        prefixTokens,
+		loadFactor:          loadFactor,
+		inFlightLoadDataKey: attrconcurrency.InFlightLoadDataKey.WithNonEmptyProducerName(params.InFlightLoadProducerName),
+	}
+}
+
+// BoundedLoadHashPicker picks endpoint(s) by consistent hashing with bounded loads: requests with the same
+// key go to the same endpoint as long as its in-flight request count stays below
+// ceil(loadFactor * (total in-flight + 1) / candidates), and overflow to the next endpoint in the key's
+// preference order otherwise.
+//
+// Hashing uses rendezvous (highest random weight) hashing over the candidates that survived filtering, so
+// adding or removing an endpoint only moves the keys that preferred it, and no ring has to be maintained
+// as the candidate set changes from request to request. Scores are not used.
+type BoundedLoadHashPicker struct {
+	typedName           fwkplugin.TypedName
+	maxNumOfEndpoints   int
+	hashKey             string
+	headerName          string
+	prefixTokens        int
+	loadFactor          float64
+	inFlightLoadDataKey fwkplugin.DataKey
+}
+
+// WithName sets the name of the picker.
+func (p *BoundedLoadHashPicker) WithName(name string) *BoundedLoadHashPicker {
+	p.typedName.Name = name
+	return p
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (p *BoundedLoadHashPicker) TypedName() fwkplugin.TypedName {
+	return p.typedName
+}
+
+// Consumes returns the in-flight load attribute used for the load cap and, depending on the hash key, the
+// request data the key is read from.
+func (p *BoundedLoadHashPicker) Consumes() fwkplugin.DataDependencies {
+	deps := fwkplugin.DataDependencies{
+		Required: map[fwkplugin.DataKey]any{p.inFlightLoadDataKey: attrconcurrency.InFlightLoad{}},
+	}
+	switch p.hashKey {
+	case HashKeyPrefix:
+		deps.Required[tokenproducer.TokenizedPromptDataKey] = fwksched.TokenizedPrompt{}
+	case HashKeySessionID:
+		deps.Required[attrsession.SessionIDDataKey] = attrsession.SessionID("")
+	}
+	return deps
+}
+
+// Pick selects endpoint(s) without a request key; each pick hashes to a random position, which spreads
+// requests evenly while still honoring the load cap.
+func (p *BoundedLoadHashPicker) Pick(ctx context.Context, scoredEndpoints []*fwksched.ScoredEndpoint) *fwksched.ProfileRunResult {
+	return p.PickForRequest(ctx, nil, scoredEndpoints)
+}
+
+// PickForRequest selects the endpoint(s) the request key hashes to, skipping endpoints at the load cap.
+// Requests without a key are hashed by request ID.
+func (p *BoundedLoadHashPicker) PickForRequest(ctx context.Context, request *fwksched.InferenceRequest,
+	scoredEndpoints []*fwksched.ScoredEndpoint) *fwksched.ProfileRunResult {
+	logger := log.FromContext(ctx)
+	key, ok := p.requestKey(request)
+	if !ok {
+		logger.V(logutil.DEBUG).Info("Request has no hash key, hashing the request ID instead", "hash-key", p.hashKey)
+	}
+
+	type candidate struct {
+		endpoint fwksched.Endpoint
+		weight   uint64
+		load     int64
+	}
+	candidates := make([]candidate, len(scoredEndpoints))
+	totalLoad := int64(0)
+	for i, scoredEndpoint := range scoredEndpoints {
+		name := scoredEndpoint.GetMetadata().NamespacedName.String()
+		load := p.inFlightRequests(scoredEndpoint)
+		candidates[i] = candidate{
+			endpoint: scoredEndpoint,
+			weight:   mix(key ^ xxhash.Sum64String(name)),
+			load:     load,
+		}
+		totalLoad += load
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int { // highest weight first
+		if a.weight > b.weight {
+			return -1
+		}
+		if a.weight < b.weight {
+			return 1
+		}
+		return 0
+	})
+
+	loadCap := int64(math.Ceil(p.loadFactor * float64(totalLoad+1) / float64(max(len(candidates), 1))))
+	logger.V(logutil.DEBUG).Info("Selecting endpoints by bounded-load consistent hashing", "max-num-of-endpoints", p.maxNumOfEndpoints,
+		"num-of-candidates", len(candidates), "total-in-flight", totalLoad, "load-cap", loadCap)
+
+	// Endpoints below the cap are taken in preference order; endpoints at the cap only fill the remaining
+	// slots when maxNumOfEndpoints exceeds the endpoints below it.
+	selectedCount := min(p.maxNumOfEndpoints, len(candidates))
+	targetEndpoints := make([]fwksched.Endpoint, 0, selectedCount)
+	var overloaded []fwksched.Endpoint
+	for _, c := range candidates {
+		if len(targetEndpoints) == selectedCount {
+			break
+		}
+		if c.load < loadCap {
+			targetEndpoints = append(targetEndpoints, c.endpoint)
+		} else {
+			overloaded = append(overloaded, c.endpoint)
+		}
+	}
+	targetEndpoints = append(targetEndpoints, overloaded[:selectedCount-len(targetEndpoints)]...)
+
+	return &fwksched.ProfileRunResult{TargetEndpoints: targetEndpoints}
+}
+
+// requestKey hashes the configured request key. Without one it falls back to the request ID, or to a
+// random key, and reports false.
+func (p *BoundedLoadHashPicker) requestKey(request *fwksched.InferenceRequest) (uint64, bool) {
+	if request != nil {
+		switch p.hashKey {
+		case HashKeyHeader:
+			if v := request.Headers[p.headerName]; v != "" {
+				return xxhash.Sum64String(v), true
+			}
+		case HashKeySessionID:
+			if v, ok := attrsession.ReadSessionID(request); ok && v != "" {
+				return xxhash.Sum64String(string(v)), true
+			}
+		case HashKeyFairnessID:
+			if request.FairnessID != "" {
+				return xxhash.Sum64String(request.FairnessID), true
+			}
+		case HashKeyPrefix:
+			if request.Body != nil && request.Body.TokenizedPrompt != nil && len(request.Body.TokenizedPrompt.TokenIDs) > 0 {
+				tokens := request.Body.TokenizedPrompt.TokenIDs
+				tokens = tokens[:min(len(tokens), p.prefixTokens)]
+				buf := make([]byte, 4*len(tokens))
+				for i, id := range tokens {
+					binary.LittleEndian.PutUint32(buf[4*i:], id)
+				}
+				return xxhash.Sum64(buf), true
+			}
+		}
+		if request.RequestID != "" {
+			return xxhash.Sum64String(request.RequestID), false
+		}
+	}
+	return picker.PickerRand.Uint64(), false
+}
+
+func (p *BoundedLoadHashPicker) inFlightRequests(endpoint fwksched.Endpoint) int64 {
+	val, ok := endpoint.Get(p.inFlightLoadDataKey.String())
+	if !ok {
+		return 0
+	}
+	if load, ok := val.(*attrconcurrency.InFlightLoad); ok && load != nil {
+		return load.Requests
+	}
+	return 0
+}
+
+// mix is the splitmix64 finalizer, used to derive an independent weight per key and endpoint pair.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
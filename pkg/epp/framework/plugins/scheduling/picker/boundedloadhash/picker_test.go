@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boundedloadhash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrconcurrency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/concurrency"
+	attrsession "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/session"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker"
+)
+
+func newEndpoints(n int) []fwksched.Endpoint {
+	endpoints := make([]fwksched.Endpoint, n)
+	for i := range endpoints {
+		endpoints[i] = fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: fmt.Sprintf("pod%d", i)}}, nil, nil)
+	}
+	return endpoints
+}
+
+func scored(endpoints []fwksched.Endpoint) []*fwksched.ScoredEndpoint {
+	out := make([]*fwksched.ScoredEndpoint, len(endpoints))
+	for i, ep := range endpoints {
+		out[i] = &fwksched.ScoredEndpoint{Endpoint: ep, Score: float64(i) / float64(len(endpoints))}
+	}
+	return out
+}
+
+func setLoad(p *BoundedLoadHashPicker, ep fwksched.Endpoint, requests int64) {
+	ep.Put(p.inFlightLoadDataKey.String(), &attrconcurrency.InFlightLoad{Requests: requests})
+}
+
+func sessionRequest(session string) *fwksched.InferenceRequest {
+	return &fwksched.InferenceRequest{RequestID: "req-" + session, Headers: map[string]string{"x-session-id": session}}
+}
+
+func pickName(t *testing.T, p *BoundedLoadHashPicker, request *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) string {
+	t.Helper()
+	result := p.PickForRequest(context.Background(), request, scored(endpoints))
+	require.Len(t, result.TargetEndpoints, 1)
+	return result.TargetEndpoints[0].GetMetadata().NamespacedName.Name
+}
+
+func TestPickForRequest_Consistent(t *testing.T) {
+	p := NewBoundedLoadHashPicker(&Parameters{})
+	endpoints := newEndpoints(5)
+
+	picked := map[string]string{}
+	for i := range 50 {
+		session := fmt.Sprintf("session-%d", i)
+		picked[session] = pickName(t, p, sessionRequest(session), endpoints)
+		// The same key keeps mapping to the same endpoint, regardless of scores or candidate order.
+		reversed := []fwksched.Endpoint{endpoints[4], endpoints[3], endpoints[2], endpoints[1], endpoints[0]}
+		assert.Equal(t, picked[session], pickName(t, p, sessionRequest(session), reversed))
+	}
+	used := map[string]bool{}
+	for _, name := range picked {
+		used[name] = true
+	}
+	assert.Greater(t, len(used), 1, "keys should spread over the endpoints")
+
+	// Removing an endpoint only moves the keys that were mapped to it.
+	remaining := endpoints[1:]
+	for session, name := range picked {
+		if name != "pod0" {
+			assert.Equal(t, name, pickName(t, p, sessionRequest(session), remaining), session)
+		}
+	}
+}
+
+func TestPickForRequest_LoadCap(t *testing.T) {
+	p := NewBoundedLoadHashPicker(&Parameters{LoadFactor: 1.25})
+	endpoints := newEndpoints(4)
+	request := sessionRequest("hot")
+	preferred := pickName(t, p, request, endpoints)
+
+	var preferredEndpoint fwksched.Endpoint
+	for _, ep := range endpoints {
+		if ep.GetMetadata().NamespacedName.Name == preferred {
+			preferredEndpoint = ep
+		}
+		setLoad(p, ep, 2)
+	}
+	// Total in-flight 8: cap = ceil(1.25 * 9 / 4) = 3, so an endpoint at 2 still takes the request.
+	assert.Equal(t, preferred, pickName(t, p, request, endpoints))
+
+	setLoad(p, preferredEndpoint, 3)
+	// Total in-flight 9: cap = ceil(1.25 * 10 / 4) = 4, still below the cap.
+	assert.Equal(t, preferred, pickName(t, p, request, endpoints))
+
+	setLoad(p, preferredEndpoint, 6)
+	// Total in-flight 12: cap = ceil(1.25 * 13 / 4) = 5, so the request overflows to another endpoint.
+	overflow := pickName(t, p, request, endpoints)
+	assert.NotEqual(t, preferred, overflow)
+	// The overflow target is stable for the key.
+	assert.Equal(t, overflow, pickName(t, p, request, endpoints))
+}
+
+func TestPickForRequest_MaxNumOfEndpoints(t *testing.T) {
+	p := NewBoundedLoadHashPicker(&Parameters{PickerParameters: picker.PickerParameters{MaxNumOfEndpoints: 3}})
+	endpoints := newEndpoints(3)
+	request := sessionRequest("abc")
+	first := p.PickForRequest(context.Background(), request, scored(endpoints)).TargetEndpoints
+	require.Len(t, first, 3)
+
+	// Overloaded endpoints are only used to fill slots after those below the cap.
+	setLoad(p, first[0], 10)
+	second := p.PickForRequest(context.Background(), request, scored(endpoints)).TargetEndpoints
+	assert.Equal(t, []fwksched.Endpoint{first[1], first[2], first[0]}, second)
+}
+
+func TestRequestKey(t *testing.T) {
+	sessionReq := &fwksched.InferenceRequest{}
+	sessionReq.PutAttribute(attrsession.SessionIDDataKey.WithNonEmptyProducerName("").String(), attrsession.SessionID("s1"))
+	prompt := func(ids ...uint32) *fwksched.InferenceRequest {
+		return &fwksched.InferenceRequest{Body: &fwkrh.InferenceRequestBody{TokenizedPrompt: &fwkrh.TokenizedPrompt{TokenIDs: ids}}}
+	}
+
+	tests := []struct {
+		name   string
+		params Parameters
+		a, b   *fwksched.InferenceRequest
+		same   bool
+		wantOK bool
+	}{
+		{
+			name:   "custom header",
+			params: Parameters{HeaderName: "X-User"},
+			a:      &fwksched.InferenceRequest{RequestID: "1", Headers: map[string]string{"x-user": "u"}},
+			b:      &fwksched.InferenceRequest{RequestID: "2", Headers: map[string]string{"x-user": "u"}},
+			same:   true,
+			wantOK: true,
+		},
+		{
+			name:   "session id attribute",
+			params: Parameters{HashKey: HashKeySessionID},
+			a:      sessionReq,
+			b:      sessionReq,
+			same:   true,
+			wantOK: true,
+		},
+		{
+			name:   "fairness id",
+			params: Parameters{HashKey: HashKeyFairnessID},
+			a:      &fwksched.InferenceRequest{RequestID: "1", FairnessID: "tenant"},
+			b:      &fwksched.InferenceRequest{RequestID: "2", FairnessID: "tenant"},
+			same:   true,
+			wantOK: true,
+		},
+		{
+			name:   "prompt prefix ignores tokens past prefixTokens",
+			params: Parameters{HashKey: HashKeyPrefix, PrefixTokens: 2},
+			a:      prompt(1, 2, 3),
+			b:      prompt(1, 2, 4),
+			same:   true,
+			wantOK: true,
+		},
+		{
+			name:   "different prompt prefix",
+			params: Parameters{HashKey: HashKeyPrefix, PrefixTokens: 2},
+			a:      prompt(1, 2),
+			b:      prompt(1, 3),
+			wantOK: true,
+		},
+		{
+			name:   "missing key falls back to the request id",
+			params: Parameters{HashKey: HashKeyFairnessID},
+			a:      &fwksched.InferenceRequest{RequestID: "1"},
+			b:      &fwksched.InferenceRequest{RequestID: "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewBoundedLoadHashPicker(&tt.params)
+			a, okA := p.requestKey(tt.a)
+			b, okB := p.requestKey(tt.b)
+			assert.Equal(t, tt.wantOK, okA)
+			assert.Equal(t, tt.wantOK, okB)
+			assert.Equal(t, tt.same, a == b)
+		})
+	}
+}
+
+func TestBoundedLoadHashPickerFactory(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		wantErr bool
+	}{
+		{name: "defaults", params: `{}`},
+		{name: "all fields", params: `{"maxNumOfEndpoints": 2, "hashKey": "prefix", "prefixTokens": 64, "loadFactor": 1.5, "inFlightLoadProducerName": "load"}`},
+		{name: "unknown hash key", params: `{"hashKey": "cookie"}`, wantErr: true},
+		{name: "load factor below one", params: `{"loadFactor": 0.5}`, wantErr: true},
+		{name: "non-positive prefix tokens", params: `{"prefixTokens": 0}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := BoundedLoadHashPickerFactory("hash", fwkplugin.StrictDecoder(json.RawMessage(tt.params)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "hash", plugin.TypedName().Name)
+		})
+	}
+
+	plugin, err := BoundedLoadHashPickerFactory("hash", fwkplugin.StrictDecoder(json.RawMessage(`{"hashKey": "prefix", "inFlightLoadProducerName": "load"}`)), nil)
+	require.NoError(t, err)
+	deps := plugin.(*BoundedLoadHashPicker).Consumes()
+	assert.Len(t, deps.Required, 2)
+}
@@ -61,6 +61,13 @@ func (r *lockedRand) Float64() float64 {
 	return r.rand.Float64()
 }
 
+func (r *lockedRand) Uint64() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rand.Uint64()
+}
+
 func (r *lockedRand) Shuffle(n int, swap func(i, j int)) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package p2c implements a power-of-two-choices scheduling picker: it samples a small number of
+// candidates weighted by score and picks the best of them.
+//
+// For detailed behavioral intent and configuration, see the package README.
+package p2c
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/picker"
+)
+
+const (
+	// P2CPickerType is the registered name of the power-of-two-choices picker plugin.
+	P2CPickerType = "p2c-picker"
+
+	// DefaultChoices is the number of candidates sampled per pick if not specified in the configuration.
+	DefaultChoices = 2
+)
+
+// Parameters defines the parameters of the P2CPicker.
+type Parameters struct {
+	picker.PickerParameters
+	// Choices is the number of candidates sampled for each picked endpoint.
+	Choices int `json:"choices"`
+}
+
+// compile-time type validation
+var _ fwksched.Picker = &P2CPicker{}
+
+// P2CPickerFactory defines the factory function for P2CPicker.
+func P2CPickerFactory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{
+		PickerParameters: picker.PickerParameters{MaxNumOfEndpoints: picker.DefaultMaxNumOfEndpoints},
+		Choices:          DefaultChoices,
+	}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", P2CPickerType, err)
+		}
+	}
+	if parameters.Choices < 1 {
+		return nil, fmt.Errorf("invalid configuration for the '%s' picker: 'choices' must be at least 1, got %d", P2CPickerType, parameters.Choices)
+	}
+
+	return NewP2CPicker(parameters.MaxNumOfEndpoints, parameters.Choices).WithName(name), nil
+}
+
+// NewP2CPicker initializes a new P2CPicker and returns its pointer.
+func NewP2CPicker(maxNumOfEndpoints, choices int) *P2CPicker {
+	if maxNumOfEndpoints <= 0 {
+		maxNumOfEndpoints = picker.DefaultMaxNumOfEndpoints // on invalid configuration value, fallback to default value
+	}
+	if choices <= 0 {
+		choices = DefaultChoices
+	}
+
+	return &P2CPicker{
+		typedName:         fwkplugin.TypedName{Type: P2CPickerType, Name: P2CPickerType},
+		maxNumOfEndpoints: maxNumOfEndpoints,
+		choices:           choices,
+	}
+}
+
+// P2CPicker picks endpoint(s) by the power of two choices: for each endpoint to pick it samples `choices`
+// distinct candidates, with probability proportional to their scores, and takes the highest scored one.
+//
+// Compared to max-score-picker, the sampling keeps a burst of requests that arrives between two metric
+// refreshes from herding onto the single best endpoint; compared to weighted-random-picker, comparing the
+// sampled candidates keeps low scored endpoints from being picked unless they are sampled together.
+type P2CPicker struct {
+	typedName         fwkplugin.TypedName
+	maxNumOfEndpoints int
+	choices           int
+}
+
+// WithName sets the name of the picker.
+func (p *P2CPicker) WithName(name string) *P2CPicker {
+	p.typedName.Name = name
+	return p
+}
+
+// TypedName returns the type and name tuple of this plugin instance.
+func (p *P2CPicker) TypedName() fwkplugin.TypedName {
+	return p.typedName
+}
+
+// Pick selects the endpoint(s) by sampling `choices` candidates weighted by score and taking the highest
+// scored one, repeated without replacement until maxNumOfEndpoints endpoints are picked.
+func (p *P2CPicker) Pick(ctx context.Context, scoredEndpoints []*fwksched.ScoredEndpoint) *fwksched.ProfileRunResult {
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Selecting endpoints from candidates by power of two choices", "max-num-of-endpoints", p.maxNumOfEndpoints,
+		"choices", p.choices, "num-of-candidates", len(scoredEndpoints), "scored-endpoints", scoredEndpoints)
+
+	// Shuffle in-place - candidates with equal sampling keys (e.g. all zero scores) are sampled uniformly.
+	picker.ShuffleScoredEndpoints(scoredEndpoints)
+
+	remaining := slices.Clone(scoredEndpoints)
+	selectedCount := min(p.maxNumOfEndpoints, len(remaining))
+	targetEndpoints := make([]fwksched.Endpoint, 0, selectedCount)
+	for range selectedCount {
+		best := sampleBest(remaining, p.choices)
+		targetEndpoints = append(targetEndpoints, remaining[best])
+		remaining = slices.Delete(remaining, best, best+1)
+	}
+
+	return &fwksched.ProfileRunResult{TargetEndpoints: targetEndpoints}
+}
+
+// sampleBest samples up to `choices` distinct candidates with A-Res weighted sampling (keyᵢ = Uᵢ^(1/wᵢ),
+// the largest keys win) and returns the index of the highest scored sampled candidate.
+func sampleBest(candidates []*fwksched.ScoredEndpoint, choices int) int {
+	keys := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		if candidate.Score <= 0 {
+			continue // zero-score candidates are only sampled when too few have a positive score
+		}
+		u := picker.PickerRand.Float64()
+		if u == 0 {
+			u = 1e-10 // Avoid 0 to ensure positive key
+		}
+		keys[i] = math.Pow(u, 1.0/candidate.Score)
+	}
+
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int { // largest key first
+		if keys[i] > keys[j] {
+			return -1
+		}
+		if keys[i] < keys[j] {
+			return 1
+		}
+		return 0
+	})
+
+	best := order[0]
+	for _, i := range order[1:min(choices, len(order))] {
+		if candidates[i].Score > candidates[best].Score {
+			best = i
+		}
+	}
+	return best
+}
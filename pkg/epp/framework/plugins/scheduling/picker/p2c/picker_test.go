@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package p2c
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const testIterations = 2000
+
+func newEndpoint(name string) fwksched.Endpoint {
+	return fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: name}}, nil, nil)
+}
+
+func endpointName(ep fwksched.Endpoint) string {
+	return ep.GetMetadata().NamespacedName.Name
+}
+
+func TestPickP2CPicker(t *testing.T) {
+	low, mid, high := newEndpoint("low"), newEndpoint("mid"), newEndpoint("high")
+	input := func() []*fwksched.ScoredEndpoint {
+		return []*fwksched.ScoredEndpoint{{Endpoint: low, Score: 0.1}, {Endpoint: mid, Score: 0.5}, {Endpoint: high, Score: 0.9}}
+	}
+
+	tests := []struct {
+		name       string
+		choices    int
+		maxPods    int
+		wantNever  []string
+		wantAlways []string
+	}{
+		{
+			// Two distinct samples out of three always include a better endpoint than the lowest scored one.
+			name:      "two choices never pick the worst of three",
+			choices:   2,
+			maxPods:   1,
+			wantNever: []string{"low"},
+		},
+		{
+			name:       "choices covering all candidates behave like max score",
+			choices:    3,
+			maxPods:    1,
+			wantAlways: []string{"high"},
+		},
+		{
+			name:       "multiple endpoints are picked without replacement",
+			choices:    5,
+			maxPods:    2,
+			wantAlways: []string{"high", "mid"},
+			wantNever:  []string{"low"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewP2CPicker(tt.maxPods, tt.choices)
+			counts := map[string]int{}
+			for range testIterations {
+				result := p.Pick(context.Background(), input())
+				require.Len(t, result.TargetEndpoints, tt.maxPods)
+				seen := map[string]bool{}
+				for _, ep := range result.TargetEndpoints {
+					name := endpointName(ep)
+					assert.False(t, seen[name], "endpoint %s picked twice", name)
+					seen[name] = true
+					counts[name]++
+				}
+			}
+			for _, name := range tt.wantNever {
+				assert.Zero(t, counts[name], "endpoint %s", name)
+			}
+			for _, name := range tt.wantAlways {
+				assert.Equal(t, testIterations, counts[name], "endpoint %s", name)
+			}
+		})
+	}
+}
+
+func TestPickP2CPicker_SpreadsLoad(t *testing.T) {
+	// With two choices the best endpoint wins most, but not all, picks.
+	p := NewP2CPicker(1, 2)
+	counts := map[string]int{}
+	for range testIterations {
+		result := p.Pick(context.Background(), []*fwksched.ScoredEndpoint{
+			{Endpoint: newEndpoint("a"), Score: 0.8},
+			{Endpoint: newEndpoint("b"), Score: 0.8},
+			{Endpoint: newEndpoint("c"), Score: 0.8},
+			{Endpoint: newEndpoint("d"), Score: 1},
+		})
+		counts[endpointName(result.TargetEndpoints[0])]++
+	}
+	assert.Greater(t, counts["d"], testIterations/3)
+	assert.Less(t, counts["d"], testIterations)
+	assert.Positive(t, counts["a"]+counts["b"]+counts["c"])
+}
+
+func TestPickP2CPicker_ZeroScores(t *testing.T) {
+	p := NewP2CPicker(1, 2)
+	counts := map[string]int{}
+	for range testIterations {
+		result := p.Pick(context.Background(), []*fwksched.ScoredEndpoint{
+			{Endpoint: newEndpoint("a"), Score: 0},
+			{Endpoint: newEndpoint("b"), Score: 0},
+		})
+		counts[endpointName(result.TargetEndpoints[0])]++
+	}
+	assert.Positive(t, counts["a"])
+	assert.Positive(t, counts["b"])
+}
+
+func TestP2CPickerFactory(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      string
+		wantErr     bool
+		wantChoices int
+		wantMax     int
+	}{
+		{name: "defaults", params: `{}`, wantChoices: DefaultChoices, wantMax: 1},
+		{name: "custom", params: `{"choices": 3, "maxNumOfEndpoints": 2}`, wantChoices: 3, wantMax: 2},
+		{name: "invalid choices", params: `{"choices": 0}`, wantErr: true},
+		{name: "unknown field", params: `{"choice": 3}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := P2CPickerFactory("p2c", fwkplugin.StrictDecoder(json.RawMessage(tt.params)), nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			p := plugin.(*P2CPicker)
+			assert.Equal(t, "p2c", p.TypedName().Name)
+			assert.Equal(t, tt.wantChoices, p.choices)
+			assert.Equal(t, tt.wantMax, p.maxNumOfEndpoints)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrdisagg "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/disagg"
 	attrprefix "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/prefix"
 	tokenproducer "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requestcontrol/dataproducer/tokenizer"
 )
@@ -51,6 +52,9 @@ type disaggDecidersParameters struct {
 type disaggProfileHandlerParameters struct {
 	Profiles disaggProfilesParameters `json:"profiles"`
 	Deciders disaggDecidersParameters `json:"deciders"`
+	// PairDecode runs the decode profile again once the prefill endpoint is selected, for its scorers
+	// to pair the decode endpoint with the prefill endpoint.
+	PairDecode bool `json:"pairDecode,omitempty"`
 }
 
 // legacyDisaggProfileHandlerParameters is the deprecated flat parameter format.
@@ -189,7 +193,7 @@ func HandlerFactory(name string, rawParameters *json.Decoder, handle plugin.Hand
 		parameters.Profiles.Decode, parameters.Profiles.Prefill, parameters.Profiles.Encode,
 		pdDecider, encodeDecider,
 	)
-	return handler.WithName(name), nil
+	return handler.WithName(name).WithPairDecode(parameters.PairDecode), nil
 }
 
 // NewDisaggProfileHandler creates a Handler directly.
@@ -226,6 +230,7 @@ type Handler struct {
 	encodeProfile  string
 	pdDecider      deciderPlugin
 	encodeDecider  deciderPlugin
+	pairDecode     bool
 }
 
 // TypedName returns the typed name of the plugin.
@@ -237,6 +242,12 @@ func (h *Handler) WithName(name string) *Handler {
 	return h
 }
 
+// WithPairDecode sets whether the decode profile runs again once the prefill endpoint is selected.
+func (h *Handler) WithPairDecode(pairDecode bool) *Handler {
+	h.pairDecode = pairDecode
+	return h
+}
+
 // Consumes defines data types consumed by this plugin (through the PD decider).
 func (*Handler) Consumes() plugin.DataDependencies {
 	return plugin.DataDependencies{
@@ -259,7 +270,8 @@ func newDisaggProfileHandler(handlerType, decodeProfile, prefillProfile, encodeP
 }
 
 // Pick implements scheduling.ProfileHandler.
-// Stages run in order: decode → encode (optional) → prefill (optional).
+// Stages run in order: decode → encode (optional) → prefill (optional) →
+// paired decode (optional).
 // Returns the next profile to execute, or an empty map when all stages are done.
 func (h *Handler) Pick(ctx context.Context, request *scheduling.InferenceRequest, profiles map[string]scheduling.SchedulerProfile,
 	profileResults map[string]*scheduling.ProfileRunResult) map[string]scheduling.SchedulerProfile {
@@ -286,6 +298,8 @@ func (h *Handler) Pick(ctx context.Context, request *scheduling.InferenceRequest
 			span.SetAttributes(attribute.String("llm_d.profile_handler.decision", "error_missing_decode_profile"))
 			return map[string]scheduling.SchedulerProfile{}
 		}
+		// Clear the prefill endpoint of a previous scheduling cycle of the request.
+		attrdisagg.WritePrefillEndpoint(request, nil)
 		span.SetAttributes(attribute.String("llm_d.profile_handler.decision", "run_decode"))
 		return map[string]scheduling.SchedulerProfile{h.decodeProfile: decodeProfile}
 	}
@@ -325,6 +339,22 @@ func (h *Handler) Pick(ctx context.Context, request *scheduling.InferenceRequest
 		}
 	}
 
+	// ── Stage 4: Paired decode (optional) ──────────────────────────────────
+	// The decode profile runs again with the selected prefill endpoint published,
+	// for its scorers (e.g. pd-topology-scorer) to weigh the pairing in. The prefill
+	// decider then decides again on the final decode endpoint.
+	if prefillRes := profileResults[h.prefillProfile]; h.pairDecode && prefillRes != nil && len(prefillRes.TargetEndpoints) > 0 {
+		if _, paired := attrdisagg.ReadPrefillEndpoint(request); !paired {
+			attrdisagg.WritePrefillEndpoint(request, prefillRes.TargetEndpoints[0])
+			span.SetAttributes(attribute.String("llm_d.profile_handler.decision", "run_paired_decode"))
+			return map[string]scheduling.SchedulerProfile{h.decodeProfile: profiles[h.decodeProfile]}
+		}
+		if !h.pdDecider.disaggregate(ctx, request, decodeRes.TargetEndpoints[0]) {
+			profileResults[h.prefillProfile] = nil
+			span.SetAttributes(attribute.String("llm_d.profile_handler.decision", "skip_paired_prefill"))
+		}
+	}
+
 	// ── All stages done: record routing decision ───────────────────────────
 	encodeUsed := profileResults[h.encodeProfile] != nil
 	prefillUsed := profileResults[h.prefillProfile] != nil
@@ -9,6 +9,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/llm-d/llm-d-router/pkg/common/routing"
@@ -16,6 +17,7 @@ import (
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrdisagg "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/disagg"
 	attrprefix "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/prefix"
 	"github.com/llm-d/llm-d-router/test/utils"
 )
@@ -142,6 +144,17 @@ func handleWithDeciders(ctx context.Context) plugin.Handle {
 	return h
 }
 
+// mockPDDecider disaggregates the requests of every decode endpoint but the declined ones.
+type mockPDDecider struct {
+	declined map[string]bool
+}
+
+func (m *mockPDDecider) TypedName() plugin.TypedName { return plugin.TypedName{} }
+
+func (m *mockPDDecider) disaggregate(_ context.Context, _ *scheduling.InferenceRequest, endpoint scheduling.Endpoint) bool {
+	return !m.declined[endpoint.GetMetadata().NamespacedName.Name]
+}
+
 type mockEncodeDecider struct {
 	allow bool
 }
@@ -250,6 +263,15 @@ func TestHandlerFactory(t *testing.T) {
 		{"unknown encodeDecider", map[string]any{
 			"deciders": map[string]any{"encode": "INVALID"},
 		}, true},
+
+		// paired decode
+		{"PD with paired decode", map[string]any{
+			"deciders":   map[string]any{"prefill": AlwaysDisaggPDDeciderPluginType},
+			"pairDecode": true,
+		}, false},
+		{"pairDecode not a boolean", map[string]any{
+			"pairDecode": "yes",
+		}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -589,6 +611,77 @@ func TestHandler_ProcessResults_PD(t *testing.T) {
 	}
 }
 
+func TestHandler_Pick_PairDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		pairDecode  bool
+		declined    map[string]bool
+		wantPaired  bool
+		wantPrefill bool
+	}{
+		{
+			name:        "decode runs again with the prefill endpoint",
+			pairDecode:  true,
+			wantPaired:  true,
+			wantPrefill: true,
+		},
+		{
+			name:       "decider declines the final decode endpoint",
+			pairDecode: true,
+			declined:   map[string]bool{"near": true},
+			wantPaired: true,
+		},
+		{
+			name:        "pairing disabled",
+			wantPrefill: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := utils.NewTestContext(t)
+			profiles := map[string]scheduling.SchedulerProfile{
+				defaultDecodeProfile:  &mockProfile{},
+				defaultPrefillProfile: &mockProfile{},
+			}
+			h := NewDisaggProfileHandler(defaultDecodeProfile, defaultPrefillProfile, "",
+				&mockPDDecider{declined: tt.declined}, nil).WithPairDecode(tt.pairDecode)
+			req := &scheduling.InferenceRequest{RequestID: "test"}
+			// A prefill endpoint left over from a previous scheduling cycle is cleared.
+			attrdisagg.WritePrefillEndpoint(req, makeProfileRunResult("stale").TargetEndpoints[0])
+			results := map[string]*scheduling.ProfileRunResult{}
+
+			assert.Contains(t, h.Pick(ctx, req, profiles, results), defaultDecodeProfile)
+			_, paired := attrdisagg.ReadPrefillEndpoint(req)
+			assert.False(t, paired)
+
+			results[defaultDecodeProfile] = makeProfileRunResult("far")
+			assert.Contains(t, h.Pick(ctx, req, profiles, results), defaultPrefillProfile)
+
+			prefillRes := makeProfileRunResult("prefill")
+			results[defaultPrefillProfile] = prefillRes
+			next := h.Pick(ctx, req, profiles, results)
+			if !tt.wantPaired {
+				assert.Empty(t, next)
+				assert.Same(t, prefillRes, results[defaultPrefillProfile])
+				return
+			}
+			assert.Contains(t, next, defaultDecodeProfile)
+			prefill, paired := attrdisagg.ReadPrefillEndpoint(req)
+			require.True(t, paired)
+			assert.Equal(t, prefillRes.TargetEndpoints[0], prefill)
+
+			results[defaultDecodeProfile] = makeProfileRunResult("near")
+			assert.Empty(t, h.Pick(ctx, req, profiles, results))
+			if tt.wantPrefill {
+				assert.Same(t, prefillRes, results[defaultPrefillProfile])
+			} else {
+				assert.Nil(t, results[defaultPrefillProfile])
+			}
+		})
+	}
+}
+
 func TestHandler_ProcessResults_NilRequest(t *testing.T) {
 	h := NewDisaggProfileHandler(defaultDecodeProfile, defaultPrefillProfile, "",
 		nil, nil)
@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrconcurrency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/concurrency"
 )
 
 const (
@@ -22,10 +25,17 @@ const (
 
 type loadAwareParameters struct {
 	Threshold int `json:"threshold"`
+	// StalenessThreshold enables blending the polled waiting queue size with the in-flight load
+	// tracked by the EPP once the endpoint's metrics are older than this. Disabled if unset.
+	StalenessThreshold       *metav1.Duration `json:"stalenessThreshold,omitempty"`
+	InFlightLoadProducerName string           `json:"inFlightLoadProducerName,omitempty"`
 }
 
 // compile-time type assertion
-var _ scheduling.Scorer = &LoadAware{}
+var (
+	_ scheduling.Scorer     = &LoadAware{}
+	_ plugin.ConsumerPlugin = &LoadAware{}
+)
 
 // Factory defines the factory function for the LoadAware
 func Factory(name string, rawParameters *json.Decoder, handle plugin.Handle) (plugin.Plugin, error) {
@@ -36,7 +46,15 @@ func Factory(name string, rawParameters *json.Decoder, handle plugin.Handle) (pl
 		}
 	}
 
-	return NewLoadAware(handle.Context(), parameters.Threshold).WithName(name), nil
+	scorer := NewLoadAware(handle.Context(), parameters.Threshold).WithName(name)
+	if parameters.StalenessThreshold != nil {
+		if parameters.StalenessThreshold.Duration <= 0 {
+			return nil, fmt.Errorf("invalid configuration for '%s' scorer: 'stalenessThreshold' must be positive, got %s",
+				LoadAwareType, parameters.StalenessThreshold.Duration)
+		}
+		scorer.WithInFlightBlend(parameters.StalenessThreshold.Duration, parameters.InFlightLoadProducerName)
+	}
+	return scorer, nil
 }
 
 // NewLoadAware creates a new load based scorer
@@ -56,6 +74,9 @@ func NewLoadAware(ctx context.Context, queueThreshold int) *LoadAware {
 type LoadAware struct {
 	typedName      plugin.TypedName
 	queueThreshold float64
+	// stalenessThreshold enables blending with the in-flight load when positive.
+	stalenessThreshold  time.Duration
+	inFlightLoadDataKey plugin.DataKey
 }
 
 // TypedName returns the typed name of the plugin.
@@ -69,6 +90,26 @@ func (s *LoadAware) WithName(name string) *LoadAware {
 	return s
 }
 
+// WithInFlightBlend makes the scorer blend an endpoint's polled waiting queue size with the
+// in-flight load tracked by the named inflight-load-producer once the endpoint's metrics are
+// older than stalenessThreshold. An empty producer name selects the default producer.
+func (s *LoadAware) WithInFlightBlend(stalenessThreshold time.Duration, inFlightLoadProducerName string) *LoadAware {
+	s.stalenessThreshold = stalenessThreshold
+	s.inFlightLoadDataKey = attrconcurrency.InFlightLoadDataKey.WithNonEmptyProducerName(inFlightLoadProducerName)
+	return s
+}
+
+// Consumes declares the in-flight load dependency when blending is enabled, so the data-layer
+// DAG auto-creates an inflight-load-producer when none is configured.
+func (s *LoadAware) Consumes() plugin.DataDependencies {
+	if s.stalenessThreshold <= 0 {
+		return plugin.DataDependencies{}
+	}
+	return plugin.DataDependencies{
+		Required: map[plugin.DataKey]any{s.inFlightLoadDataKey: attrconcurrency.InFlightLoad{}},
+	}
+}
+
 // Category returns the preference the scorer applies when scoring candidate endpoints.
 func (s *LoadAware) Category() scheduling.ScorerCategory {
 	return scheduling.Distribution
@@ -81,11 +122,13 @@ func (s *LoadAware) Category() scheduling.ScorerCategory {
 // Pod with requests in the queue will get score between 0.5 and 0.
 // Score 0 will get pod with number of requests in the queue equal to the threshold used in load-based filter
 // In the future, pods with additional capacity will get score higher than 0.5
+// When blending is enabled, stale waiting queue sizes are blended with the in-flight load.
 func (s *LoadAware) Score(_ context.Context, _ *scheduling.InferenceRequest, endpoints []scheduling.Endpoint) map[scheduling.Endpoint]float64 {
 	scoredEndpoints := make(map[scheduling.Endpoint]float64)
 
+	now := time.Now()
 	for _, endpoint := range endpoints {
-		waitingRequests := float64(endpoint.GetMetrics().WaitingQueueSize)
+		waitingRequests := s.waitingRequests(endpoint, now)
 
 		if waitingRequests == 0 {
 			scoredEndpoints[endpoint] = 0.5
@@ -98,3 +141,14 @@ func (s *LoadAware) Score(_ context.Context, _ *scheduling.InferenceRequest, end
 	}
 	return scoredEndpoints
 }
+
+func (s *LoadAware) waitingRequests(endpoint scheduling.Endpoint, now time.Time) float64 {
+	if s.stalenessThreshold <= 0 {
+		return float64(endpoint.GetMetrics().WaitingQueueSize)
+	}
+	var load *attrconcurrency.InFlightLoad
+	if val, ok := endpoint.Get(s.inFlightLoadDataKey.String()); ok {
+		load, _ = val.(*attrconcurrency.InFlightLoad)
+	}
+	return attrconcurrency.BlendedWaitingQueueSize(endpoint.GetMetrics(), load, now, s.stalenessThreshold)
+}
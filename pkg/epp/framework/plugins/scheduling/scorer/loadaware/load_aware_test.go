@@ -3,12 +3,14 @@ package loadaware_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	k8stypes "k8s.io/apimachinery/pkg/types" // Import config for thresholds
 
 	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
 	"github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrconcurrency "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/concurrency"
 	loadaware "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/loadaware"
 	"github.com/llm-d/llm-d-router/test/utils"
 )
@@ -70,3 +72,36 @@ func TestLoadBasedScorer(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBasedScorerInFlightBlend(t *testing.T) {
+	now := time.Now()
+	newEndpoint := func(name string, waiting int, metricsAge time.Duration, inFlight int64) scheduling.Endpoint {
+		endpoint := scheduling.NewEndpoint(
+			&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: name}},
+			&fwkdl.Metrics{WaitingQueueSize: waiting, RunningRequestsSize: 2, UpdateTime: now.Add(-metricsAge)},
+			nil,
+		)
+		endpoint.Put(attrconcurrency.InFlightLoadDataKey.String(), &attrconcurrency.InFlightLoad{Requests: inFlight})
+		return endpoint
+	}
+	fresh := newEndpoint("fresh", 2, 0, 12)
+	stale := newEndpoint("stale", 2, time.Hour, 12)
+
+	scorer := loadaware.NewLoadAware(utils.NewTestContext(t), 10).WithInFlightBlend(time.Minute, "")
+	got := scorer.Score(context.Background(), nil, []scheduling.Endpoint{fresh, stale})
+	want := map[scheduling.Endpoint]float64{
+		fresh: 0.4, // polled waiting queue size of 2
+		stale: 0,   // 12 in flight beyond the 2 polled running requests exceed the threshold
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected output (-want +got): %v", diff)
+	}
+
+	consumes := scorer.Consumes()
+	if _, ok := consumes.Required[attrconcurrency.InFlightLoadDataKey]; !ok {
+		t.Errorf("expected the in-flight load to be a required dependency, got %v", consumes.Required)
+	}
+	if consumes := loadaware.NewLoadAware(utils.NewTestContext(t), 10).Consumes(); len(consumes.Required) != 0 {
+		t.Errorf("expected no dependencies without blending, got %v", consumes.Required)
+	}
+}
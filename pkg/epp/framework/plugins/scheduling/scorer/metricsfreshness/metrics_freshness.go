@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricsfreshness provides a scorer, and optionally a filter, that steers traffic
+// away from endpoints whose polled metrics are stale.
+//
+// Load based scorers trust the last scraped metrics of an endpoint. When scraping an endpoint
+// fails or lags, its metrics keep showing the load it had before, so it can look idle while it
+// is flooded. The metrics-freshness plugin de-prioritizes such endpoints and, when filtering is
+// enabled, excludes them as long as an endpoint with fresh metrics remains.
+package metricsfreshness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+const (
+	// MetricsFreshnessType is the type of the metrics-freshness plugin.
+	MetricsFreshnessType = "metrics-freshness"
+
+	// defaultStalenessThreshold matches the default of the --metrics-staleness-threshold flag.
+	defaultStalenessThreshold = 2 * time.Second
+)
+
+// Parameters configures the metrics-freshness plugin.
+type Parameters struct {
+	// StalenessThreshold is the age beyond which the metrics of an endpoint are considered stale.
+	// Defaults to 2s.
+	StalenessThreshold *metav1.Duration `json:"stalenessThreshold,omitempty"`
+	// EnableFiltering determines whether the plugin also filters out endpoints with stale
+	// metrics. If false, the plugin only scores endpoints.
+	// Default is false.
+	EnableFiltering bool `json:"enableFiltering"`
+}
+
+var (
+	_ fwksched.Filter = &MetricsFreshness{}
+	_ fwksched.Scorer = &MetricsFreshness{}
+)
+
+// Factory defines the factory function for the metrics-freshness plugin.
+func Factory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", MetricsFreshnessType, err)
+		}
+	}
+	return New(name, parameters)
+}
+
+// New creates a metrics-freshness plugin with the given parameters.
+func New(name string, parameters Parameters) (*MetricsFreshness, error) {
+	threshold := defaultStalenessThreshold
+	if parameters.StalenessThreshold != nil {
+		threshold = parameters.StalenessThreshold.Duration
+	}
+	if threshold <= 0 {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'stalenessThreshold' must be positive, got %s",
+			MetricsFreshnessType, threshold)
+	}
+
+	return &MetricsFreshness{
+		typedName:          fwkplugin.TypedName{Type: MetricsFreshnessType, Name: name},
+		stalenessThreshold: threshold,
+		enableFiltering:    parameters.EnableFiltering,
+		now:                time.Now,
+	}, nil
+}
+
+// MetricsFreshness scores endpoints by the age of their metrics. Endpoints whose metrics are
+// at most stalenessThreshold old score 1, older ones score stalenessThreshold divided by the
+// age of their metrics and endpoints whose metrics were never collected score 0. When
+// filtering is enabled, endpoints with stale metrics are removed from the candidates.
+type MetricsFreshness struct {
+	typedName          fwkplugin.TypedName
+	stalenessThreshold time.Duration
+	enableFiltering    bool
+
+	now func() time.Time
+}
+
+// TypedName returns the typed name of the plugin.
+func (m *MetricsFreshness) TypedName() fwkplugin.TypedName {
+	return m.typedName
+}
+
+// Category returns the preference the scorer applies when scoring candidate endpoints.
+func (m *MetricsFreshness) Category() fwksched.ScorerCategory {
+	return fwksched.Distribution
+}
+
+// Filter keeps the endpoints whose metrics are fresh. If every endpoint has stale metrics, all
+// endpoints are returned so that requests are still served while metrics scraping is failing.
+// This is only active when enableFiltering is true.
+func (m *MetricsFreshness) Filter(ctx context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) []fwksched.Endpoint {
+	if !m.enableFiltering {
+		return endpoints
+	}
+
+	now := m.now()
+	filtered := make([]fwksched.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if endpoint.GetMetrics().Age(now) <= m.stalenessThreshold {
+			filtered = append(filtered, endpoint)
+		}
+	}
+	if len(filtered) == 0 {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("All endpoints have stale metrics, keeping all",
+			"count", len(endpoints), "stalenessThreshold", m.stalenessThreshold)
+		return endpoints
+	}
+
+	log.FromContext(ctx).V(logutil.TRACE).Info("Filtered endpoints with stale metrics", "originalCount", len(endpoints),
+		"filteredCount", len(filtered))
+	return filtered
+}
+
+// Score scores every endpoint by the freshness of its metrics.
+func (m *MetricsFreshness) Score(_ context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	now := m.now()
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	for _, endpoint := range endpoints {
+		metrics := endpoint.GetMetrics()
+		if metrics == nil || metrics.UpdateTime.IsZero() {
+			scores[endpoint] = 0
+			continue
+		}
+		age := metrics.Age(now)
+		if age <= m.stalenessThreshold {
+			scores[endpoint] = 1
+		} else {
+			scores[endpoint] = float64(m.stalenessThreshold) / float64(age)
+		}
+	}
+	return scores
+}
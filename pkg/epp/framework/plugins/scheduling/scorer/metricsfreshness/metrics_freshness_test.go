@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsfreshness
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+var testNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestPlugin(t *testing.T, parameters Parameters) *MetricsFreshness {
+	t.Helper()
+	plugin, err := New("metrics-freshness", parameters)
+	require.NoError(t, err)
+	plugin.now = func() time.Time { return testNow }
+	return plugin
+}
+
+// newEndpoint creates an endpoint whose metrics were updated age ago, or never if age is negative.
+func newEndpoint(name string, age time.Duration) fwksched.Endpoint {
+	metrics := &fwkdl.Metrics{}
+	if age >= 0 {
+		metrics.UpdateTime = testNow.Add(-age)
+	}
+	return fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name}}, metrics, nil)
+}
+
+func TestFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		jsonParams string
+		expectErr  bool
+	}{
+		{name: "defaults", jsonParams: `{}`},
+		{name: "all parameters", jsonParams: `{"stalenessThreshold": "5s", "enableFiltering": true}`},
+		{name: "zero threshold", jsonParams: `{"stalenessThreshold": "0s"}`, expectErr: true},
+		{name: "malformed JSON", jsonParams: `{"stalenessThreshold": `, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := Factory("metrics-freshness", json.NewDecoder(strings.NewReader(tt.jsonParams)), nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, MetricsFreshnessType, plugin.TypedName().Type)
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	plugin := newTestPlugin(t, Parameters{})
+	fresh := newEndpoint("fresh", time.Second)
+	stale := newEndpoint("stale", 8*time.Second)
+	never := newEndpoint("never", -1)
+
+	scores := plugin.Score(context.Background(), nil, []fwksched.Endpoint{fresh, stale, never})
+	assert.Equal(t, 1.0, scores[fresh])
+	assert.InDelta(t, 0.25, scores[stale], 1e-9)
+	assert.Zero(t, scores[never])
+}
+
+func TestFilter(t *testing.T) {
+	fresh := newEndpoint("fresh", time.Second)
+	stale := newEndpoint("stale", 8*time.Second)
+	never := newEndpoint("never", -1)
+
+	tests := []struct {
+		name            string
+		enableFiltering bool
+		endpoints       []fwksched.Endpoint
+		want            []fwksched.Endpoint
+	}{
+		{
+			name:      "filtering disabled",
+			endpoints: []fwksched.Endpoint{fresh, stale, never},
+			want:      []fwksched.Endpoint{fresh, stale, never},
+		},
+		{
+			name:            "stale endpoints excluded",
+			enableFiltering: true,
+			endpoints:       []fwksched.Endpoint{fresh, stale, never},
+			want:            []fwksched.Endpoint{fresh},
+		},
+		{
+			name:            "all stale keeps all",
+			enableFiltering: true,
+			endpoints:       []fwksched.Endpoint{stale, never},
+			want:            []fwksched.Endpoint{stale, never},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := newTestPlugin(t, Parameters{EnableFiltering: tt.enableFiltering})
+			assert.Equal(t, tt.want, plugin.Filter(context.Background(), nil, tt.endpoints))
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pdtopology provides a scorer that keeps the prefill and decode endpoints of a
+// disaggregated request close to each other.
+//
+// The disagg-profile-handler selects the decode and prefill endpoints in separate profiles, so
+// the cost of transferring the KV cache between them is otherwise ignored. Configured in the
+// decode profile, the plugin reads the topology labels of the endpoints and scores each decode
+// candidate by its distance from the prefill endpoint the handler selected for the request.
+package pdtopology
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrdisagg "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/disagg"
+)
+
+var _ fwksched.Scorer = &PDTopology{}
+
+const (
+	// PDTopologyType is the type of the P/D topology scorer.
+	PDTopologyType = "pd-topology-scorer"
+)
+
+// Tier is the distance between two endpoints, from the closest to the farthest.
+type Tier string
+
+const (
+	// TierSameNode is used for endpoints on the same node, or of the same pod.
+	TierSameNode Tier = "sameNode"
+	// TierSameDomain is used for endpoints in the same rack or NVLink domain.
+	TierSameDomain Tier = "sameDomain"
+	// TierSameZone is used for endpoints in the same zone.
+	TierSameZone Tier = "sameZone"
+	// TierCrossZone is used for endpoints in different zones.
+	TierCrossZone Tier = "crossZone"
+	// TierUnknown is used when the labels do not tell how far apart the endpoints are.
+	TierUnknown Tier = "unknown"
+)
+
+// Parameters configures the P/D topology scorer. The labels are read from the model server
+// pods, which do not carry the labels of their node, so they have no defaults; at least one
+// of them must be set.
+type Parameters struct {
+	// NodeLabel is the pod label holding the node name. The tier is not used when empty.
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// DomainLabel is the pod label holding the rack or NVLink domain. The tier is not used
+	// when empty.
+	DomainLabel string `json:"domainLabel,omitempty"`
+	// ZoneLabel is the pod label holding the zone. The tier is not used when empty.
+	ZoneLabel string `json:"zoneLabel,omitempty"`
+	// TierWeights overrides the score of the tiers, each in [0, 1].
+	TierWeights map[Tier]float64 `json:"tierWeights,omitempty"`
+}
+
+// defaultTierWeights are the scores of the tiers when not overridden.
+var defaultTierWeights = map[Tier]float64{
+	TierSameNode:   1.0,
+	TierSameDomain: 0.8,
+	TierSameZone:   0.5,
+	TierCrossZone:  0.0,
+	TierUnknown:    0.25,
+}
+
+// Factory defines the factory function for the P/D topology scorer.
+func Factory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", PDTopologyType, err)
+		}
+	}
+	return New(name, parameters)
+}
+
+// New creates a P/D topology scorer with the given parameters.
+func New(name string, parameters Parameters) (*PDTopology, error) {
+	if parameters.NodeLabel == "" && parameters.DomainLabel == "" && parameters.ZoneLabel == "" {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: %w", PDTopologyType,
+			errors.New("at least one of nodeLabel, domainLabel and zoneLabel must be set"))
+	}
+	weights := make(map[Tier]float64, len(defaultTierWeights))
+	for tier, weight := range defaultTierWeights {
+		weights[tier] = weight
+	}
+	for tier, weight := range parameters.TierWeights {
+		if _, ok := defaultTierWeights[tier]; !ok {
+			return nil, fmt.Errorf("invalid configuration for '%s' plugin: unknown tier '%s'", PDTopologyType, tier)
+		}
+		if weight < 0 || weight > 1 {
+			return nil, fmt.Errorf("invalid configuration for '%s' plugin: weight of tier '%s' must be in [0, 1], got %v",
+				PDTopologyType, tier, weight)
+		}
+		weights[tier] = weight
+	}
+
+	return &PDTopology{
+		typedName:   fwkplugin.TypedName{Type: PDTopologyType, Name: name},
+		nodeLabel:   parameters.NodeLabel,
+		domainLabel: parameters.DomainLabel,
+		zoneLabel:   parameters.ZoneLabel,
+		weights:     weights,
+	}, nil
+}
+
+// PDTopology scores a decode candidate by its distance from the prefill endpoint selected for
+// the request.
+type PDTopology struct {
+	typedName   fwkplugin.TypedName
+	nodeLabel   string
+	domainLabel string
+	zoneLabel   string
+	weights     map[Tier]float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *PDTopology) TypedName() fwkplugin.TypedName {
+	return s.typedName
+}
+
+// Category returns the preference the scorer applies when scoring candidate endpoints.
+func (s *PDTopology) Category() fwksched.ScorerCategory {
+	return fwksched.Affinity
+}
+
+// Score scores every endpoint by the weight of its tier relative to the prefill endpoint selected
+// for the request. Nothing is scored before a prefill endpoint is selected, i.e. when the decode
+// profile first runs or the request is not disaggregated.
+func (s *PDTopology) Score(ctx context.Context, request *fwksched.InferenceRequest,
+	endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	prefill, ok := attrdisagg.ReadPrefillEndpoint(request)
+	if !ok {
+		return scores
+	}
+	logger := log.FromContext(ctx).V(logutil.TRACE)
+	for _, endpoint := range endpoints {
+		tier := s.tier(prefill.GetMetadata(), endpoint.GetMetadata())
+		scores[endpoint] = s.weights[tier]
+		logger.Info("Scored decode endpoint by its distance from the prefill endpoint",
+			"decode", endpoint.GetMetadata().GetNamespacedName(), "prefill", prefill.GetMetadata().GetNamespacedName(), "tier", tier)
+	}
+	return scores
+}
+
+// tier returns the distance between two endpoints, using the closest level at which both
+// carry a label. Endpoints are in different zones only if both carry a different zone label.
+func (s *PDTopology) tier(a, b *fwkdl.EndpointMetadata) Tier {
+	if a == nil || b == nil {
+		return TierUnknown
+	}
+	if a.Address != "" && a.Address == b.Address {
+		return TierSameNode
+	}
+	if s.nodeLabel != "" && sameLabel(a, b, s.nodeLabel) {
+		return TierSameNode
+	}
+	if s.domainLabel != "" && sameLabel(a, b, s.domainLabel) {
+		return TierSameDomain
+	}
+	if s.zoneLabel == "" {
+		return TierUnknown
+	}
+	zoneA, okA := a.Labels[s.zoneLabel]
+	zoneB, okB := b.Labels[s.zoneLabel]
+	switch {
+	case !okA || !okB || zoneA == "" || zoneB == "":
+		return TierUnknown
+	case zoneA == zoneB:
+		return TierSameZone
+	default:
+		return TierCrossZone
+	}
+}
+
+// sameLabel reports whether both endpoints carry the same non-empty value for the label.
+func sameLabel(a, b *fwkdl.EndpointMetadata, label string) bool {
+	value, ok := a.Labels[label]
+	return ok && value != "" && b.Labels[label] == value
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdtopology
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrdisagg "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/disagg"
+)
+
+const (
+	nodeLabel = "example.com/node"
+	rackLabel = "example.com/nvlink-domain"
+	zoneLabel = "example.com/zone"
+)
+
+// newEndpoint creates an endpoint with the given address and topology, each of node, rack and
+// zone being left unlabeled when empty.
+func newEndpoint(name, address, node, rack, zone string) fwksched.Endpoint {
+	labels := map[string]string{}
+	for label, value := range map[string]string{nodeLabel: node, rackLabel: rack, zoneLabel: zone} {
+		if value != "" {
+			labels[label] = value
+		}
+	}
+	return fwksched.NewEndpoint(&fwkdl.EndpointMetadata{
+		NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+		Address:        address,
+		Port:           "8000",
+		Labels:         labels,
+	}, &fwkdl.Metrics{}, nil)
+}
+
+func TestFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		jsonParams string
+		expectErr  bool
+	}{
+		{name: "zone label only", jsonParams: `{"zoneLabel": "zone"}`},
+		{name: "all parameters", jsonParams: `{"nodeLabel": "node", "domainLabel": "rack", "zoneLabel": "zone", "tierWeights": {"sameDomain": 0.9, "unknown": 0}}`},
+		{name: "no labels", jsonParams: `{}`, expectErr: true},
+		{name: "unknown tier", jsonParams: `{"zoneLabel": "zone", "tierWeights": {"sameRegion": 0.5}}`, expectErr: true},
+		{name: "weight out of range", jsonParams: `{"zoneLabel": "zone", "tierWeights": {"sameZone": 1.5}}`, expectErr: true},
+		{name: "malformed JSON", jsonParams: `{"nodeLabel": `, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := Factory("pd-topology", json.NewDecoder(strings.NewReader(tt.jsonParams)), nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, PDTopologyType, plugin.TypedName().Type)
+		})
+	}
+}
+
+// newRequest creates a request paired with the given prefill endpoint, if not nil.
+func newRequest(prefill fwksched.Endpoint) *fwksched.InferenceRequest {
+	request := &fwksched.InferenceRequest{RequestID: "test"}
+	if prefill != nil {
+		attrdisagg.WritePrefillEndpoint(request, prefill)
+	}
+	return request
+}
+
+func TestScore(t *testing.T) {
+	plugin, err := New("pd-topology", Parameters{
+		NodeLabel:   nodeLabel,
+		DomainLabel: rackLabel,
+		ZoneLabel:   zoneLabel,
+		TierWeights: map[Tier]float64{TierUnknown: 0.1},
+	})
+	require.NoError(t, err)
+
+	prefill := newEndpoint("prefill", "10.0.0.1", "node-a", "rack-1", "zone-1")
+	tests := []struct {
+		name   string
+		decode fwksched.Endpoint
+		want   float64
+	}{
+		{name: "same pod", decode: newEndpoint("same-pod", "10.0.0.1", "", "", ""), want: 1.0},
+		{name: "same node", decode: newEndpoint("same-node", "10.0.0.2", "node-a", "rack-1", "zone-1"), want: 1.0},
+		{name: "same rack", decode: newEndpoint("same-rack", "10.0.0.3", "node-b", "rack-1", "zone-1"), want: 0.8},
+		{name: "same zone", decode: newEndpoint("same-zone", "10.0.0.4", "node-c", "rack-2", "zone-1"), want: 0.5},
+		{name: "cross zone", decode: newEndpoint("cross-zone", "10.0.0.5", "node-d", "rack-3", "zone-2"), want: 0.0},
+		{name: "unlabeled", decode: newEndpoint("unlabeled", "10.0.0.6", "", "", ""), want: 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := plugin.Score(t.Context(), newRequest(prefill), []fwksched.Endpoint{tt.decode})
+			assert.Equal(t, map[fwksched.Endpoint]float64{tt.decode: tt.want}, scores)
+		})
+	}
+}
+
+func TestScore_UnconfiguredLabels(t *testing.T) {
+	plugin, err := New("pd-topology", Parameters{ZoneLabel: zoneLabel})
+	require.NoError(t, err)
+
+	prefill := newEndpoint("prefill", "10.0.0.1", "node-a", "rack-1", "zone-1")
+	sameNode := newEndpoint("same-node", "10.0.0.2", "node-a", "rack-1", "zone-1")
+
+	// Without nodeLabel and domainLabel, endpoints on the same node are only known to share the zone.
+	scores := plugin.Score(t.Context(), newRequest(prefill), []fwksched.Endpoint{sameNode})
+	assert.Equal(t, defaultTierWeights[TierSameZone], scores[sameNode])
+}
+
+func TestScore_NoPrefillEndpoint(t *testing.T) {
+	plugin, err := New("pd-topology", Parameters{ZoneLabel: zoneLabel})
+	require.NoError(t, err)
+
+	decode := newEndpoint("decode", "10.0.0.2", "node-a", "rack-1", "zone-1")
+
+	// The decode profile runs before a prefill endpoint is selected, or without disaggregation.
+	assert.Empty(t, plugin.Score(t.Context(), newRequest(nil), []fwksched.Endpoint{decode}))
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slowstart provides a scorer, and optionally a filter, that ramps the
+// share of traffic a newly added endpoint receives over a configurable window.
+//
+// A pod that just became ready has an empty queue and no KV cache usage, so
+// load based scorers rank it best and flood it while its caches are still cold.
+// The slow-start plugin tracks the time since each endpoint's pod became ready
+// and derives a warm-up factor from it, which it publishes as an
+// endpoint attribute and applies to scheduling.
+package slowstart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrwarmup "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/warmup"
+	sourcenotifications "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/source/notifications"
+	slowstartconstants "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/scheduling/scorer/slowstart/constants"
+)
+
+const (
+	// SlowStartType is the type of the slow-start plugin.
+	SlowStartType = slowstartconstants.SlowStartType
+
+	// LinearCurve ramps the warm-up factor linearly from minFactor to 1.
+	LinearCurve = "linear"
+	// ExponentialCurve ramps the warm-up factor exponentially from minFactor to 1,
+	// keeping the endpoint's share small for most of the window.
+	ExponentialCurve = "exponential"
+
+	defaultWindow         = time.Minute
+	defaultMinFactor      = 0.1
+	maxDebugDumpEndpoints = 100
+)
+
+// Parameters configures the slow-start plugin.
+type Parameters struct {
+	// Window is how long after an endpoint is added its traffic is ramped up.
+	// Defaults to 1m.
+	Window *metav1.Duration `json:"window,omitempty"`
+	// Curve is the shape of the ramp, "linear" or "exponential".
+	// Defaults to "linear".
+	Curve string `json:"curve,omitempty"`
+	// MinFactor is the warm-up factor of an endpoint that was just added, in (0, 1].
+	// Defaults to 0.1.
+	MinFactor *float64 `json:"minFactor,omitempty"`
+	// EnableFiltering determines whether the plugin also filters endpoints that are
+	// warming up, keeping each of them with a probability equal to its warm-up factor.
+	// If false, the plugin only scores endpoints.
+	// Default is false.
+	EnableFiltering bool `json:"enableFiltering"`
+}
+
+var (
+	_ fwksched.Filter          = &SlowStart{}
+	_ fwksched.Scorer          = &SlowStart{}
+	_ fwkplugin.ProducerPlugin = &SlowStart{}
+	_ fwkplugin.StateDumper    = &SlowStart{}
+	_ fwkdl.EndpointExtractor  = &SlowStart{}
+	_ fwkdl.Registrant         = &SlowStart{}
+)
+
+// Factory defines the factory function for the slow-start plugin.
+func Factory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", SlowStartType, err)
+		}
+	}
+	return New(name, parameters)
+}
+
+// New creates a slow-start plugin with the given parameters.
+func New(name string, parameters Parameters) (*SlowStart, error) {
+	window := defaultWindow
+	if parameters.Window != nil {
+		window = parameters.Window.Duration
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'window' must be positive, got %s", SlowStartType, window)
+	}
+	curve := parameters.Curve
+	if curve == "" {
+		curve = LinearCurve
+	}
+	if curve != LinearCurve && curve != ExponentialCurve {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: unknown 'curve' '%s', expected '%s' or '%s'",
+			SlowStartType, curve, LinearCurve, ExponentialCurve)
+	}
+	minFactor := defaultMinFactor
+	if parameters.MinFactor != nil {
+		minFactor = *parameters.MinFactor
+	}
+	if !(minFactor > 0 && minFactor <= 1) {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'minFactor' must be in (0, 1], got %v", SlowStartType, minFactor)
+	}
+
+	return &SlowStart{
+		typedName:       fwkplugin.TypedName{Type: SlowStartType, Name: name},
+		dk:              attrwarmup.WarmUpDataKey.WithNonEmptyProducerName(name),
+		window:          window,
+		curve:           curve,
+		minFactor:       minFactor,
+		enableFiltering: parameters.EnableFiltering,
+		firstSeen:       make(map[string]time.Time),
+		now:             time.Now,
+		random:          rand.Float64,
+	}, nil
+}
+
+// SlowStart ramps the traffic of newly added endpoints. Endpoints that were added
+// less than a window ago get a warm-up factor between minFactor and 1, all other
+// endpoints get 1. The factor is used as the endpoint's score and, when filtering
+// is enabled, as the probability of keeping the endpoint as a candidate.
+type SlowStart struct {
+	typedName       fwkplugin.TypedName
+	dk              fwkplugin.DataKey
+	window          time.Duration
+	curve           string
+	minFactor       float64
+	enableFiltering bool
+
+	mu        sync.RWMutex
+	firstSeen map[string]time.Time // keyed by endpoint NamespacedName
+
+	now    func() time.Time
+	random func() float64
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *SlowStart) TypedName() fwkplugin.TypedName {
+	return s.typedName
+}
+
+// Category returns the preference the scorer applies when scoring candidate endpoints.
+func (s *SlowStart) Category() fwksched.ScorerCategory {
+	return fwksched.Distribution
+}
+
+// Produces declares the WarmUp attribute key written by this plugin.
+func (s *SlowStart) Produces() map[fwkplugin.DataKey]any {
+	return map[fwkplugin.DataKey]any{s.dk: attrwarmup.WarmUp{}}
+}
+
+// RegisterDependencies declares that this plugin needs an endpoint-notification-source to
+// learn when endpoints are added to the datastore. The source is auto-created if not already
+// in the config.
+func (s *SlowStart) RegisterDependencies(r fwkdl.Registrar) error {
+	return r.Register(fwkdl.PendingRegistration{
+		Owner:         s.TypedName(),
+		SourceType:    sourcenotifications.EndpointNotificationSourceType,
+		Extractor:     s,
+		DefaultSource: sourcenotifications.NewEndpointDataSource(sourcenotifications.EndpointNotificationSourceType, sourcenotifications.EndpointNotificationSourceType),
+	})
+}
+
+// Extract records when an endpoint's ramp starts and injects its warm-up state as a dynamic
+// attribute. The ramp starts when the endpoint's pod became ready, so endpoints that were
+// already serving keep their traffic when the EPP restarts or fails over; endpoints without a
+// known ready time start when first added to the datastore. Later updates of the endpoint do
+// not restart the ramp; an endpoint that is removed, e.g. because its pod became unready,
+// starts over when added again.
+func (s *SlowStart) Extract(ctx context.Context, event fwkdl.EndpointEvent) error {
+	if event.Endpoint == nil || event.Endpoint.GetMetadata() == nil {
+		return nil
+	}
+	meta := event.Endpoint.GetMetadata()
+	id := meta.NamespacedName.String()
+
+	switch event.Type {
+	case fwkdl.EventDelete:
+		s.mu.Lock()
+		delete(s.firstSeen, id)
+		s.mu.Unlock()
+	case fwkdl.EventAddOrUpdate:
+		s.mu.Lock()
+		if _, ok := s.firstSeen[id]; !ok {
+			start := s.now()
+			// A ready time ahead of the local clock is clock skew; start the ramp now.
+			if !meta.ReadySince.IsZero() && meta.ReadySince.Before(start) {
+				start = meta.ReadySince
+			}
+			s.firstSeen[id] = start
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Starting slow-start ramp", "endpoint", id, "start", start, "window", s.window)
+		}
+		s.mu.Unlock()
+		event.Endpoint.GetAttributes().Put(s.dk.String(), &fwkdl.DynamicAttribute{
+			Get: func() fwkdl.Cloneable {
+				warmUp, ok := s.warmUp(id)
+				if !ok {
+					return nil
+				}
+				return warmUp
+			},
+		})
+	}
+	return nil
+}
+
+// Filter keeps every warm endpoint and each warming endpoint with a probability equal to its
+// warm-up factor. If no endpoint would be kept, all endpoints are returned so that a pool made
+// only of new endpoints keeps serving. This is only active when enableFiltering is true.
+func (s *SlowStart) Filter(ctx context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) []fwksched.Endpoint {
+	if !s.enableFiltering {
+		return endpoints
+	}
+
+	filtered := make([]fwksched.Endpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if factor := s.factorOf(endpoint); factor >= 1 || s.random() < factor {
+			filtered = append(filtered, endpoint)
+		}
+	}
+	if len(filtered) == 0 {
+		return endpoints
+	}
+
+	log.FromContext(ctx).V(logutil.TRACE).Info("Filtered warming endpoints", "originalCount", len(endpoints),
+		"filteredCount", len(filtered))
+	return filtered
+}
+
+// Score scores every endpoint with its warm-up factor.
+func (s *SlowStart) Score(_ context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	for _, endpoint := range endpoints {
+		scores[endpoint] = s.factorOf(endpoint)
+	}
+	return scores
+}
+
+// factorOf returns the warm-up factor of the endpoint. Endpoints that were never seen by the
+// plugin are treated as warm.
+func (s *SlowStart) factorOf(endpoint fwksched.Endpoint) float64 {
+	if endpoint.GetMetadata() == nil {
+		return 1
+	}
+	warmUp, ok := s.warmUp(endpoint.GetMetadata().NamespacedName.String())
+	if !ok {
+		return 1
+	}
+	return warmUp.Factor
+}
+
+func (s *SlowStart) warmUp(id string) (*attrwarmup.WarmUp, bool) {
+	s.mu.RLock()
+	firstSeen, ok := s.firstSeen[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return &attrwarmup.WarmUp{FirstSeen: firstSeen, Factor: s.factor(s.now().Sub(firstSeen))}, true
+}
+
+// factor returns the warm-up factor of an endpoint that was added age ago.
+func (s *SlowStart) factor(age time.Duration) float64 {
+	if age >= s.window {
+		return 1
+	}
+	progress := math.Max(0, float64(age)/float64(s.window))
+	if s.curve == ExponentialCurve {
+		// minFactor * (1/minFactor)^progress grows from minFactor to 1.
+		return math.Pow(s.minFactor, 1-progress)
+	}
+	return s.minFactor + (1-s.minFactor)*progress
+}
+
+type slowStartState struct {
+	Window         string                   `json:"window"`
+	Curve          string                   `json:"curve"`
+	MinFactor      float64                  `json:"minFactor"`
+	Endpoints      []endpointSlowStartState `json:"endpoints"`
+	TotalEndpoints int                      `json:"totalEndpoints"`
+	MaxEndpoints   int                      `json:"maxEndpoints"`
+	Truncated      bool                     `json:"truncated"`
+}
+
+type endpointSlowStartState struct {
+	Endpoint   string    `json:"endpoint"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	AgeSeconds float64   `json:"ageSeconds"`
+	Factor     float64   `json:"factor"`
+}
+
+// DumpState implements [fwkplugin.StateDumper] and exposes the ramp of every endpoint that is
+// still warming up for the /debug/plugins/state endpoint. The newest endpoints are listed first,
+// capped to keep the debug payload bounded.
+func (s *SlowStart) DumpState() (json.RawMessage, error) {
+	now := s.now()
+	state := slowStartState{
+		Window:       s.window.String(),
+		Curve:        s.curve,
+		MinFactor:    s.minFactor,
+		Endpoints:    []endpointSlowStartState{},
+		MaxEndpoints: maxDebugDumpEndpoints,
+	}
+
+	s.mu.RLock()
+	for id, firstSeen := range s.firstSeen {
+		age := now.Sub(firstSeen)
+		if age >= s.window {
+			continue
+		}
+		state.Endpoints = append(state.Endpoints, endpointSlowStartState{
+			Endpoint:   id,
+			FirstSeen:  firstSeen,
+			AgeSeconds: age.Seconds(),
+			Factor:     s.factor(age),
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(state.Endpoints, func(i, j int) bool {
+		if state.Endpoints[i].AgeSeconds != state.Endpoints[j].AgeSeconds {
+			return state.Endpoints[i].AgeSeconds < state.Endpoints[j].AgeSeconds
+		}
+		return state.Endpoints[i].Endpoint < state.Endpoints[j].Endpoint
+	})
+	state.TotalEndpoints = len(state.Endpoints)
+	if len(state.Endpoints) > maxDebugDumpEndpoints {
+		state.Endpoints = state.Endpoints[:maxDebugDumpEndpoints]
+		state.Truncated = true
+	}
+	return json.Marshal(state)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowstart
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrwarmup "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/warmup"
+)
+
+// fakeClock is a manually advanced clock for the plugin's now function.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestPlugin(t *testing.T, parameters Parameters) (*SlowStart, *fakeClock) {
+	t.Helper()
+	plugin, err := New("slow-start", parameters)
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	plugin.now = clock.Now
+	return plugin, clock
+}
+
+func newDatalayerEndpoint(name string) fwkdl.Endpoint {
+	return fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name}}, nil)
+}
+
+func schedulingEndpoint(ep fwkdl.Endpoint) fwksched.Endpoint {
+	return fwksched.NewEndpoint(ep.GetMetadata(), ep.GetMetrics(), ep.GetAttributes())
+}
+
+func TestFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		jsonParams string
+		expectErr  bool
+	}{
+		{name: "defaults", jsonParams: `{}`},
+		{name: "all parameters", jsonParams: `{"window": "2m", "curve": "exponential", "minFactor": 0.05, "enableFiltering": true}`},
+		{name: "zero window", jsonParams: `{"window": "0s"}`, expectErr: true},
+		{name: "unknown curve", jsonParams: `{"curve": "quadratic"}`, expectErr: true},
+		{name: "zero min factor", jsonParams: `{"minFactor": 0}`, expectErr: true},
+		{name: "min factor above one", jsonParams: `{"minFactor": 1.5}`, expectErr: true},
+		{name: "malformed JSON", jsonParams: `{"window": `, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := Factory("slow-start", json.NewDecoder(strings.NewReader(tt.jsonParams)), nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, SlowStartType, plugin.TypedName().Type)
+		})
+	}
+}
+
+func TestFactorCurves(t *testing.T) {
+	minFactor := 0.01
+	tests := []struct {
+		curve string
+		age   time.Duration
+		want  float64
+	}{
+		{curve: LinearCurve, age: 0, want: 0.01},
+		{curve: LinearCurve, age: 50 * time.Second, want: 0.505},
+		{curve: LinearCurve, age: 100 * time.Second, want: 1},
+		{curve: LinearCurve, age: time.Hour, want: 1},
+		{curve: ExponentialCurve, age: 0, want: 0.01},
+		{curve: ExponentialCurve, age: 50 * time.Second, want: 0.1},
+		{curve: ExponentialCurve, age: 100 * time.Second, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.curve+"/"+tt.age.String(), func(t *testing.T) {
+			plugin, _ := newTestPlugin(t, Parameters{Curve: tt.curve, MinFactor: &minFactor, Window: durationPtr(100 * time.Second)})
+			assert.InDelta(t, tt.want, plugin.factor(tt.age), 1e-9)
+		})
+	}
+}
+
+func TestExtractTracksFirstUpsert(t *testing.T) {
+	ctx := context.Background()
+	plugin, clock := newTestPlugin(t, Parameters{Window: durationPtr(100 * time.Second)})
+	ep := newDatalayerEndpoint("pod-a")
+
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: ep}))
+	clock.now = clock.now.Add(50 * time.Second)
+
+	warmUp, ok := attrwarmup.ReadWarmUp(ep.GetAttributes(), attrwarmup.WarmUpDataKey.WithNonEmptyProducerName("slow-start").String())
+	require.True(t, ok, "the warm-up attribute should be injected")
+	assert.InDelta(t, 0.55, warmUp.Factor, 1e-9)
+
+	// A metadata update does not restart the ramp.
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: ep}))
+	assert.InDelta(t, 0.55, plugin.factorOf(schedulingEndpoint(ep)), 1e-9)
+
+	// An endpoint that is removed and added again starts over.
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventDelete, Endpoint: ep}))
+	assert.Equal(t, 1.0, plugin.factorOf(schedulingEndpoint(ep)), "untracked endpoints are treated as warm")
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: ep}))
+	assert.InDelta(t, 0.1, plugin.factorOf(schedulingEndpoint(ep)), 1e-9)
+}
+
+func TestExtractStartsAtPodReadiness(t *testing.T) {
+	ctx := context.Background()
+	plugin, clock := newTestPlugin(t, Parameters{Window: durationPtr(100 * time.Second)})
+	endpoint := func(name string, readySince time.Time) fwkdl.Endpoint {
+		return fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{
+			NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name},
+			ReadySince:     readySince,
+		}, nil)
+	}
+
+	// Pods that were ready before the EPP started, e.g. after a restart or failover.
+	serving := endpoint("serving", clock.now.Add(-time.Hour))
+	warming := endpoint("warming", clock.now.Add(-50*time.Second))
+	// A ready time ahead of the local clock starts the ramp now.
+	skewed := endpoint("skewed", clock.now.Add(time.Minute))
+	for _, ep := range []fwkdl.Endpoint{serving, warming, skewed} {
+		require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: ep}))
+	}
+
+	assert.Equal(t, 1.0, plugin.factorOf(schedulingEndpoint(serving)))
+	assert.InDelta(t, 0.55, plugin.factorOf(schedulingEndpoint(warming)), 1e-9)
+	assert.InDelta(t, 0.1, plugin.factorOf(schedulingEndpoint(skewed)), 1e-9)
+}
+
+func TestScore(t *testing.T) {
+	ctx := context.Background()
+	plugin, clock := newTestPlugin(t, Parameters{Window: durationPtr(100 * time.Second)})
+	warm := newDatalayerEndpoint("warm")
+	warming := newDatalayerEndpoint("warming")
+
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: warm}))
+	clock.now = clock.now.Add(200 * time.Second)
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: warming}))
+	clock.now = clock.now.Add(25 * time.Second)
+
+	endpoints := []fwksched.Endpoint{schedulingEndpoint(warm), schedulingEndpoint(warming)}
+	scores := plugin.Score(ctx, &fwksched.InferenceRequest{}, endpoints)
+	assert.Equal(t, 1.0, scores[endpoints[0]])
+	assert.InDelta(t, 0.325, scores[endpoints[1]], 1e-9)
+}
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+	warm := newDatalayerEndpoint("warm")
+	warming := newDatalayerEndpoint("warming")
+
+	setup := func(t *testing.T, enableFiltering bool, random float64) (*SlowStart, []fwksched.Endpoint) {
+		plugin, clock := newTestPlugin(t, Parameters{Window: durationPtr(100 * time.Second), EnableFiltering: enableFiltering})
+		plugin.random = func() float64 { return random }
+		require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: warm}))
+		clock.now = clock.now.Add(200 * time.Second)
+		require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: warming}))
+		clock.now = clock.now.Add(50 * time.Second) // factor 0.55
+		return plugin, []fwksched.Endpoint{schedulingEndpoint(warm), schedulingEndpoint(warming)}
+	}
+
+	t.Run("filtering disabled", func(t *testing.T) {
+		plugin, endpoints := setup(t, false, 0.99)
+		assert.Equal(t, endpoints, plugin.Filter(ctx, &fwksched.InferenceRequest{}, endpoints))
+	})
+
+	t.Run("warming endpoint kept below its factor", func(t *testing.T) {
+		plugin, endpoints := setup(t, true, 0.5)
+		assert.Equal(t, endpoints, plugin.Filter(ctx, &fwksched.InferenceRequest{}, endpoints))
+	})
+
+	t.Run("warming endpoint dropped above its factor", func(t *testing.T) {
+		plugin, endpoints := setup(t, true, 0.6)
+		assert.Equal(t, endpoints[:1], plugin.Filter(ctx, &fwksched.InferenceRequest{}, endpoints))
+	})
+
+	t.Run("never returns an empty set", func(t *testing.T) {
+		plugin, endpoints := setup(t, true, 0.6)
+		assert.Equal(t, endpoints[1:], plugin.Filter(ctx, &fwksched.InferenceRequest{}, endpoints[1:]))
+	})
+}
+
+func TestDumpState(t *testing.T) {
+	ctx := context.Background()
+	plugin, clock := newTestPlugin(t, Parameters{Window: durationPtr(100 * time.Second)})
+
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: newDatalayerEndpoint("warm")}))
+	clock.now = clock.now.Add(150 * time.Second)
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: newDatalayerEndpoint("older")}))
+	clock.now = clock.now.Add(40 * time.Second)
+	require.NoError(t, plugin.Extract(ctx, fwkdl.EndpointEvent{Type: fwkdl.EventAddOrUpdate, Endpoint: newDatalayerEndpoint("newer")}))
+	clock.now = clock.now.Add(10 * time.Second)
+
+	raw, err := plugin.DumpState()
+	require.NoError(t, err)
+	var state slowStartState
+	require.NoError(t, json.Unmarshal(raw, &state))
+
+	assert.Equal(t, "1m40s", state.Window)
+	assert.Equal(t, LinearCurve, state.Curve)
+	assert.Equal(t, 2, state.TotalEndpoints, "endpoints past their window are not listed")
+	require.Len(t, state.Endpoints, 2)
+	assert.Equal(t, "default/newer", state.Endpoints[0].Endpoint)
+	assert.InDelta(t, 10, state.Endpoints[0].AgeSeconds, 1e-9)
+	assert.InDelta(t, 0.19, state.Endpoints[0].Factor, 1e-9)
+	assert.Equal(t, "default/older", state.Endpoints[1].Endpoint)
+	assert.InDelta(t, 0.55, state.Endpoints[1].Factor, 1e-9)
+}
+
+func durationPtr(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trendaware provides a scorer that penalizes endpoints whose load is rising.
+//
+// Scorers based on the last scraped metrics see a snapshot: an endpoint whose queue has just
+// started to build up looks as attractive as one whose queue is draining. The trend-aware scorer
+// reads the trends published by the core-metrics-extractor's metrics history and lowers the score
+// of endpoints whose metric grows.
+package trendaware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+)
+
+const (
+	// TrendAwareType is the type of the trend-aware scorer.
+	TrendAwareType = "trend-aware"
+
+	defaultRateThreshold = 2.0
+)
+
+// Parameters configures the trend-aware scorer.
+type Parameters struct {
+	// Metric is the name of the metric whose trend is scored.
+	// Defaults to WaitingQueueSize.
+	Metric string `json:"metric,omitempty"`
+	// RateThreshold is the rate of increase, per second, at which an endpoint scores 0.
+	// Defaults to 2.
+	RateThreshold float64 `json:"rateThreshold,omitempty"`
+	// MetricsTrendsProducerName is the name of the core-metrics-extractor whose trends are read.
+	// Defaults to the default producer of the trends.
+	MetricsTrendsProducerName string `json:"metricsTrendsProducerName,omitempty"`
+}
+
+var (
+	_ fwksched.Scorer          = &TrendAware{}
+	_ fwkplugin.ConsumerPlugin = &TrendAware{}
+)
+
+// Factory defines the factory function for the trend-aware scorer.
+func Factory(name string, rawParameters *json.Decoder, _ fwkplugin.Handle) (fwkplugin.Plugin, error) {
+	parameters := Parameters{}
+	if rawParameters != nil {
+		if err := rawParameters.Decode(&parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", TrendAwareType, err)
+		}
+	}
+	return New(name, parameters)
+}
+
+// New creates a trend-aware scorer with the given parameters.
+func New(name string, parameters Parameters) (*TrendAware, error) {
+	metric := parameters.Metric
+	if metric == "" {
+		metric = attrmetrics.WaitingQueueSizeMetric
+	}
+	switch metric {
+	case attrmetrics.WaitingQueueSizeMetric, attrmetrics.RunningRequestsSizeMetric, attrmetrics.KVCacheUsagePercentMetric:
+	default:
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: unknown metric '%s'", TrendAwareType, metric)
+	}
+	rateThreshold := parameters.RateThreshold
+	if rateThreshold == 0 {
+		rateThreshold = defaultRateThreshold
+	}
+	if rateThreshold < 0 {
+		return nil, fmt.Errorf("invalid configuration for '%s' plugin: 'rateThreshold' must be positive, got %v",
+			TrendAwareType, rateThreshold)
+	}
+
+	return &TrendAware{
+		typedName:     fwkplugin.TypedName{Type: TrendAwareType, Name: name},
+		metric:        metric,
+		rateThreshold: rateThreshold,
+		trendsDataKey: attrmetrics.MetricsTrendsDataKey.WithNonEmptyProducerName(parameters.MetricsTrendsProducerName),
+	}, nil
+}
+
+// TrendAware scores endpoints by the rate at which a metric rises. Endpoints whose metric is
+// steady or falling, or that have no trend yet, score 1. Rising endpoints score linearly less,
+// reaching 0 at rateThreshold.
+type TrendAware struct {
+	typedName     fwkplugin.TypedName
+	metric        string
+	rateThreshold float64
+	trendsDataKey fwkplugin.DataKey
+}
+
+// TypedName returns the typed name of the plugin.
+func (s *TrendAware) TypedName() fwkplugin.TypedName {
+	return s.typedName
+}
+
+// Consumes declares the metrics trends as an optional dependency. The trends are only produced
+// when the history of the core-metrics-extractor is enabled, without them every endpoint scores 1.
+func (s *TrendAware) Consumes() fwkplugin.DataDependencies {
+	return fwkplugin.DataDependencies{
+		Optional: map[fwkplugin.DataKey]any{s.trendsDataKey: attrmetrics.MetricsTrends{}},
+	}
+}
+
+// Category returns the preference the scorer applies when scoring candidate endpoints.
+func (s *TrendAware) Category() fwksched.ScorerCategory {
+	return fwksched.Distribution
+}
+
+// Score scores every endpoint by the trend of the configured metric.
+func (s *TrendAware) Score(_ context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	for _, endpoint := range endpoints {
+		scores[endpoint] = 1
+		trends, ok := attrmetrics.ReadMetricsTrends(endpoint, s.trendsDataKey.String())
+		if !ok {
+			continue
+		}
+		if rate := trends[s.metric].RatePerSecond; rate > 0 {
+			scores[endpoint] = 1 - min(rate, s.rateThreshold)/s.rateThreshold
+		}
+	}
+	return scores
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trendaware
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	attrmetrics "github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/datalayer/attribute/metrics"
+)
+
+// newEndpoint creates an endpoint whose waiting queue rises at the given rate, or that has no
+// trends if trends is nil.
+func newEndpoint(name string, trends attrmetrics.MetricsTrends) fwksched.Endpoint {
+	endpoint := fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: name}}, &fwkdl.Metrics{}, nil)
+	if trends != nil {
+		endpoint.Put(attrmetrics.MetricsTrendsDataKey.String(), trends)
+	}
+	return endpoint
+}
+
+func TestFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		jsonParams string
+		expectErr  bool
+	}{
+		{name: "defaults", jsonParams: `{}`},
+		{name: "all parameters", jsonParams: `{"metric": "KVCacheUsagePercent", "rateThreshold": 0.1, "metricsTrendsProducerName": "core"}`},
+		{name: "unknown metric", jsonParams: `{"metric": "Latency"}`, expectErr: true},
+		{name: "negative threshold", jsonParams: `{"rateThreshold": -1}`, expectErr: true},
+		{name: "malformed JSON", jsonParams: `{"metric": `, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin, err := Factory("trend-aware", json.NewDecoder(strings.NewReader(tt.jsonParams)), nil)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, TrendAwareType, plugin.TypedName().Type)
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	plugin, err := New("trend-aware", Parameters{RateThreshold: 4})
+	require.NoError(t, err)
+
+	rising := func(rate float64) attrmetrics.MetricsTrends {
+		return attrmetrics.MetricsTrends{attrmetrics.WaitingQueueSizeMetric: {RatePerSecond: rate}}
+	}
+	noTrends := newEndpoint("no-trends", nil)
+	draining := newEndpoint("draining", rising(-3))
+	slow := newEndpoint("slow", rising(1))
+	fast := newEndpoint("fast", rising(10))
+	otherMetric := newEndpoint("other-metric", attrmetrics.MetricsTrends{attrmetrics.KVCacheUsagePercentMetric: {RatePerSecond: 1}})
+
+	scores := plugin.Score(context.Background(), nil, []fwksched.Endpoint{noTrends, draining, slow, fast, otherMetric})
+	assert.Equal(t, map[fwksched.Endpoint]float64{
+		noTrends:    1,
+		draining:    1,
+		slow:        0.75,
+		fast:        0,
+		otherMetric: 1,
+	}, scores)
+}
+
+func TestConsumes(t *testing.T) {
+	plugin, err := New("trend-aware", Parameters{MetricsTrendsProducerName: "core"})
+	require.NoError(t, err)
+
+	dependencies := plugin.Consumes()
+	assert.Empty(t, dependencies.Required)
+	assert.Contains(t, dependencies.Optional, attrmetrics.MetricsTrendsDataKey.WithNonEmptyProducerName("core"))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	filterPb "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+)
+
+// BodyMode is the ext_proc body send mode negotiated with the proxy. It determines the shape of the body
+// mutations the EPP sends back.
+type BodyMode = filterPb.ProcessingMode_BodySendMode
+
+const (
+	// BodyModeNone means the proxy does not send the body to the EPP.
+	BodyModeNone = filterPb.ProcessingMode_NONE
+	// BodyModeFullDuplexStreamed streams the body in both directions; the EPP answers with StreamedBodyResponse
+	// chunks independently of the chunks it received. This is the default.
+	BodyModeFullDuplexStreamed = filterPb.ProcessingMode_FULL_DUPLEX_STREAMED
+	// BodyModeStreamed sends the body in chunks and expects exactly one response per message.
+	BodyModeStreamed = filterPb.ProcessingMode_STREAMED
+	// BodyModeBuffered sends the whole body in a single message and expects a single response.
+	BodyModeBuffered = filterPb.ProcessingMode_BUFFERED
+)
+
+// SupportedBodyModes lists the request body modes a proxy may announce on a stream.
+var SupportedBodyModes = []BodyMode{BodyModeFullDuplexStreamed, BodyModeStreamed, BodyModeBuffered}
+
+// DefaultBodyModes lists the body modes accepted by ParseBodyMode as the default for streams on which the proxy does
+// not announce its protocol configuration. STREAMED is excluded: it only works with
+// send_body_without_waiting_for_header_response, which the EPP can only verify when the proxy announces it.
+var DefaultBodyModes = []BodyMode{BodyModeFullDuplexStreamed, BodyModeBuffered}
+
+// ParseBodyMode parses a body mode name as used in the Envoy ext_proc filter configuration
+// (e.g. "FULL_DUPLEX_STREAMED"). Only the modes in DefaultBodyModes are accepted.
+func ParseBodyMode(name string) (BodyMode, error) {
+	value, ok := filterPb.ProcessingMode_BodySendMode_value[strings.ToUpper(name)]
+	if ok {
+		mode := BodyMode(value)
+		for _, supported := range DefaultBodyModes {
+			if mode == supported {
+				return mode, nil
+			}
+		}
+		if mode == BodyModeStreamed {
+			return BodyModeNone, fmt.Errorf("ext_proc body mode %s cannot be the default, the proxy must announce it "+
+				"with send_body_without_waiting_for_header_response on the stream", mode)
+		}
+	}
+	return BodyModeNone, fmt.Errorf("unsupported ext_proc body mode %q, must be one of %v", name, DefaultBodyModes)
+}
+
+// applyProtocolConfig records the body modes announced by the proxy on the first message of the stream. Proxies that
+// do not announce them keep the server default.
+func applyProtocolConfig(reqCtx *RequestContext, config *extProcPb.ProtocolConfiguration) error {
+	if config == nil {
+		return nil
+	}
+	switch config.RequestBodyMode {
+	case BodyModeFullDuplexStreamed, BodyModeBuffered:
+	case BodyModeStreamed:
+		// The routing decision is carried by the request headers response, which can only be produced once the
+		// whole body has been received.
+		if !config.SendBodyWithoutWaitingForHeaderResponse {
+			return errcommon.Error{Code: errcommon.Internal,
+				Msg: "request body mode STREAMED requires send_body_without_waiting_for_header_response"}
+		}
+	default:
+		return errcommon.Error{Code: errcommon.Internal,
+			Msg: fmt.Sprintf("unsupported request body mode %s", config.RequestBodyMode)}
+	}
+	switch config.ResponseBodyMode {
+	case BodyModeNone, BodyModeFullDuplexStreamed, BodyModeStreamed, BodyModeBuffered:
+	default:
+		return errcommon.Error{Code: errcommon.Internal,
+			Msg: fmt.Sprintf("unsupported response body mode %s", config.ResponseBodyMode)}
+	}
+	reqCtx.requestBodyMode = config.RequestBodyMode
+	reqCtx.responseBodyMode = config.ResponseBodyMode
+	return nil
+}
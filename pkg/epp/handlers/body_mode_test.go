@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/openai"
+)
+
+// scriptedProcessServer replays a fixed sequence of ProcessingRequests and records the responses sent back.
+type scriptedProcessServer struct {
+	requests      []*extProcPb.ProcessingRequest
+	sentResponses []*extProcPb.ProcessingResponse
+}
+
+func (s *scriptedProcessServer) Recv() (*extProcPb.ProcessingRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *scriptedProcessServer) Send(resp *extProcPb.ProcessingResponse) error {
+	s.sentResponses = append(s.sentResponses, resp)
+	return nil
+}
+
+func (s *scriptedProcessServer) SetHeader(metadata.MD) error  { return nil }
+func (s *scriptedProcessServer) SendHeader(metadata.MD) error { return nil }
+func (s *scriptedProcessServer) SetTrailer(metadata.MD)       {}
+func (s *scriptedProcessServer) Context() context.Context     { return context.Background() }
+func (s *scriptedProcessServer) SendMsg(any) error            { return nil }
+func (s *scriptedProcessServer) RecvMsg(any) error            { return nil }
+
+func TestParseBodyMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    BodyMode
+		wantErr bool
+	}{
+		{name: "FULL_DUPLEX_STREAMED", want: BodyModeFullDuplexStreamed},
+		{name: "streamed", wantErr: true},
+		{name: "BUFFERED", want: BodyModeBuffered},
+		{name: "BUFFERED_PARTIAL", wantErr: true},
+		{name: "NONE", wantErr: true},
+		{name: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBodyMode(tt.name)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+const (
+	bodyModeTestRequest  = `{"model":"food-review","prompt":"hello"}`
+	bodyModeTestResponse = `{"model":"food-review-1","choices":[]}`
+)
+
+func bodyModeRequestHeaders(config *extProcPb.ProtocolConfiguration) *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_RequestHeaders{
+			RequestHeaders: &extProcPb.HttpHeaders{
+				Headers: &configPb.HeaderMap{
+					Headers: []*configPb.HeaderValue{{Key: ":path", RawValue: []byte("/v1/completions")}},
+				},
+			},
+		},
+		ProtocolConfig: config,
+	}
+}
+
+func bodyModeRequestBody(body string, endOfStream bool) *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_RequestBody{
+			RequestBody: &extProcPb.HttpBody{Body: []byte(body), EndOfStream: endOfStream},
+		},
+	}
+}
+
+func bodyModeResponseHeaders() *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_ResponseHeaders{
+			ResponseHeaders: &extProcPb.HttpHeaders{
+				Headers: &configPb.HeaderMap{
+					Headers: []*configPb.HeaderValue{{Key: "content-type", RawValue: []byte("application/json")}},
+				},
+			},
+		},
+	}
+}
+
+func bodyModeResponseBody(body string, endOfStream bool) *extProcPb.ProcessingRequest {
+	return &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_ResponseBody{
+			ResponseBody: &extProcPb.HttpBody{Body: []byte(body), EndOfStream: endOfStream},
+		},
+	}
+}
+
+func TestProcess_BodyModes(t *testing.T) {
+	const rewrittenResponse = `{"model":"food-review","choices":[]}`
+	// responseSplit splits the response body in the middle of the model name, which must still be rewritten.
+	responseSplit := len(`{"model":"food-rev`)
+
+	tests := []struct {
+		name        string
+		defaultMode BodyMode
+		requests    []*extProcPb.ProcessingRequest
+		// check validates the responses sent back to the proxy.
+		check func(t *testing.T, responses []*extProcPb.ProcessingResponse)
+	}{
+		{
+			name: "full duplex streamed",
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(&extProcPb.ProtocolConfiguration{
+					RequestBodyMode:  BodyModeFullDuplexStreamed,
+					ResponseBodyMode: BodyModeFullDuplexStreamed,
+				}),
+				bodyModeRequestBody(bodyModeTestRequest[:10], false),
+				bodyModeRequestBody(bodyModeTestRequest[10:], true),
+				bodyModeResponseHeaders(),
+				bodyModeResponseBody(bodyModeTestResponse[:10], false),
+				bodyModeResponseBody(bodyModeTestResponse[10:], true),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 4)
+				assert.NotEmpty(t, responses[0].GetRequestHeaders().GetResponse().GetHeaderMutation().GetSetHeaders())
+				streamed := responses[1].GetRequestBody().GetResponse().GetBodyMutation().GetStreamedResponse()
+				assert.Equal(t, bodyModeTestRequest, string(streamed.GetBody()))
+				assert.True(t, streamed.GetEndOfStream())
+				assert.NotNil(t, responses[2].GetResponseHeaders())
+				streamed = responses[3].GetResponseBody().GetResponse().GetBodyMutation().GetStreamedResponse()
+				assert.Equal(t, rewrittenResponse, string(streamed.GetBody()))
+			},
+		},
+		{
+			name: "streamed",
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(&extProcPb.ProtocolConfiguration{
+					RequestBodyMode:                         BodyModeStreamed,
+					ResponseBodyMode:                        BodyModeStreamed,
+					SendBodyWithoutWaitingForHeaderResponse: true,
+				}),
+				bodyModeRequestBody(bodyModeTestRequest[:10], false),
+				bodyModeRequestBody(bodyModeTestRequest[10:], true),
+				bodyModeResponseHeaders(),
+				bodyModeResponseBody(bodyModeTestResponse[:responseSplit], false),
+				bodyModeResponseBody(bodyModeTestResponse[responseSplit:], true),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 6)
+				headersResp := responses[0].GetRequestHeaders().GetResponse()
+				assert.Equal(t, extProcPb.CommonResponse_CONTINUE, headersResp.GetStatus())
+				assert.True(t, headersResp.GetClearRouteCache())
+				assert.NotEmpty(t, headersResp.GetHeaderMutation().GetSetHeaders())
+				assert.NotNil(t, responses[0].GetDynamicMetadata())
+				// Each request body message is answered: the first is cleared and the last carries the whole body.
+				assert.True(t, responses[1].GetRequestBody().GetResponse().GetBodyMutation().GetClearBody())
+				assert.Equal(t, bodyModeTestRequest, string(responses[2].GetRequestBody().GetResponse().GetBodyMutation().GetBody()))
+				assert.NotNil(t, responses[3].GetResponseHeaders())
+				// Each response body message is answered as it arrives. The start of the model name is held back
+				// until the next chunk, so the name split across the chunks is still rewritten.
+				first := string(responses[4].GetResponseBody().GetResponse().GetBodyMutation().GetBody())
+				second := string(responses[5].GetResponseBody().GetResponse().GetBodyMutation().GetBody())
+				assert.Equal(t, "{", first)
+				assert.Equal(t, rewrittenResponse, first+second)
+			},
+		},
+		{
+			name: "buffered",
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(&extProcPb.ProtocolConfiguration{
+					RequestBodyMode:  BodyModeBuffered,
+					ResponseBodyMode: BodyModeBuffered,
+				}),
+				bodyModeRequestBody(bodyModeTestRequest, true),
+				bodyModeResponseHeaders(),
+				bodyModeResponseBody(bodyModeTestResponse, true),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 4)
+				// The request headers are let through unchanged.
+				assert.Empty(t, responses[0].GetRequestHeaders().GetResponse().GetHeaderMutation().GetSetHeaders())
+				bodyResp := responses[1].GetRequestBody().GetResponse()
+				assert.True(t, bodyResp.GetClearRouteCache())
+				assert.NotEmpty(t, bodyResp.GetHeaderMutation().GetSetHeaders())
+				assert.Equal(t, bodyModeTestRequest, string(bodyResp.GetBodyMutation().GetBody()))
+				assert.NotNil(t, responses[1].GetDynamicMetadata())
+				assert.NotNil(t, responses[2].GetResponseHeaders())
+				assert.Equal(t, rewrittenResponse, string(responses[3].GetResponseBody().GetResponse().GetBodyMutation().GetBody()))
+			},
+		},
+		{
+			name:        "buffered by default without protocol config",
+			defaultMode: BodyModeBuffered,
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(nil),
+				bodyModeRequestBody(bodyModeTestRequest, true),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 2)
+				assert.NotNil(t, responses[0].GetRequestHeaders())
+				assert.Equal(t, bodyModeTestRequest, string(responses[1].GetRequestBody().GetResponse().GetBodyMutation().GetBody()))
+			},
+		},
+		{
+			name: "streamed waiting for header response is rejected",
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(&extProcPb.ProtocolConfiguration{
+					RequestBodyMode:  BodyModeStreamed,
+					ResponseBodyMode: BodyModeStreamed,
+				}),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 1)
+				assert.NotNil(t, responses[0].GetImmediateResponse())
+			},
+		},
+		{
+			name: "unsupported request body mode is rejected",
+			requests: []*extProcPb.ProcessingRequest{
+				bodyModeRequestHeaders(&extProcPb.ProtocolConfiguration{
+					RequestBodyMode: BodyModeNone,
+				}),
+			},
+			check: func(t *testing.T, responses []*extProcPb.ProcessingResponse) {
+				require.Len(t, responses, 1)
+				assert.NotNil(t, responses[0].GetImmediateResponse())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			director := &proxyTestDirector{endpoints: "10.0.0.1:8000"}
+			registry := NewParserRegistry([]fwkrh.Parser{openai.NewOpenAIParser()}, logr.Discard())
+			server := NewStreamingServer(nil, director, registry, 0)
+			if tt.defaultMode != BodyModeNone {
+				server.SetDefaultBodyMode(tt.defaultMode)
+			}
+			srv := &scriptedProcessServer{requests: tt.requests}
+
+			require.NoError(t, server.Process(srv))
+			tt.check(t, srv.sentResponses)
+		})
+	}
+}
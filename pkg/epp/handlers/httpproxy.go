@@ -0,0 +1,447 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/common/observability/tracing"
+	reqcommon "github.com/llm-d/llm-d-router/pkg/common/request"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+	"github.com/llm-d/llm-d-router/pkg/epp/util/request"
+)
+
+// proxyReadBufferSize is the size of the buffer used to read streamed responses from the model server.
+// Every read is forwarded to the client immediately, so this only bounds the size of a single chunk.
+const proxyReadBufferSize = 32 * 1024
+
+// hopByHopHeaders are connection-scoped headers that must not be forwarded by a proxy.
+// See https://www.rfc-editor.org/rfc/rfc9110#section-7.6.1.
+var hopByHopHeaders = map[string]struct{}{
+	"connection":          {},
+	"keep-alive":          {},
+	"proxy-connection":    {},
+	"proxy-authenticate":  {},
+	"proxy-authorization": {},
+	"te":                  {},
+	"trailer":             {},
+	"transfer-encoding":   {},
+	"upgrade":             {},
+	"host":                {},
+}
+
+// HTTPProxyServer is a standalone HTTP reverse proxy that runs the same request handling pipeline as the
+// StreamingServer, but forwards the request to the picked endpoint itself instead of returning the routing
+// decision to Envoy. It allows running the EPP without a gateway in front of it.
+type HTTPProxyServer struct {
+	streaming          *StreamingServer
+	transport          http.RoundTripper
+	maxRequestBodySize int64
+}
+
+// NewHTTPProxyServer creates an HTTPProxyServer. Requests with a body larger than maxRequestBodySize are
+// rejected with 400; a value of 0 disables the limit. If transport is nil, a clone of
+// http.DefaultTransport is used to reach the model servers.
+func NewHTTPProxyServer(datastore Datastore, director Director, parserRegistry *ParserRegistry,
+	maxRequestBodySize int64, transport http.RoundTripper) *HTTPProxyServer {
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	return &HTTPProxyServer{
+		streaming:          NewStreamingServer(datastore, director, parserRegistry, 0),
+		transport:          transport,
+		maxRequestBodySize: maxRequestBodySize,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (p *HTTPProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Join the caller's trace, mirroring what Process does with the headers forwarded by Envoy.
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := tracing.Tracer("llm-d-router/epp/httpproxy").Start(ctx, "gateway.request", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	reqCtx := &RequestContext{
+		RequestState:             RequestReceived,
+		RequestReceivedTimestamp: time.Now(),
+		Request: &Request{
+			Headers:  make(map[string]string, len(r.Header)+4),
+			Metadata: make(map[string]any),
+		},
+		Response: &Response{
+			Headers: make(map[string]string),
+		},
+	}
+	for key, values := range r.Header {
+		reqCtx.Request.Headers[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+	// Expose the pseudo-headers Envoy would send, so parsers and plugins resolve the request the same way.
+	reqCtx.Request.Headers[":method"] = r.Method
+	reqCtx.Request.Headers[":path"] = r.URL.RequestURI()
+	reqCtx.Request.Headers[":authority"] = r.Host
+	reqCtx.Request.Headers[":scheme"] = "http"
+
+	requestID := reqCtx.Request.Headers[reqcommon.RequestIDHeaderKey]
+	if requestID == "" {
+		requestID = uuid.NewString()
+		reqCtx.Request.Headers[reqcommon.RequestIDHeaderKey] = requestID
+	}
+	logger := log.FromContext(ctx).WithValues(reqcommon.RequestIDHeaderKey, requestID)
+	logger.V(logutil.DEFAULT).Info("EPP received request")
+	ctx = log.IntoContext(ctx, logger)
+
+	extractControlHeaders(reqCtx)
+
+	var err error
+	defer func() {
+		if reqCtx.ResponseStatusCode != "" {
+			metrics.RecordRequestErrCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.ResponseStatusCode)
+		} else if err != nil {
+			metrics.RecordRequestErrCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName, errcommon.CanonicalCode(err))
+		}
+		if reqCtx.RequestRunning {
+			metrics.DecRunningRequests(reqCtx.IncomingModelName)
+		}
+		// If we scheduled a pod but never completed the response (e.g. upstream error or client disconnect),
+		// force the completion hooks to run with a fresh context, as the request context may be canceled.
+		if reqCtx.TargetPod != nil && !reqCtx.ResponseComplete {
+			cleanupCtx := log.IntoContext(context.Background(), logger)
+			p.streaming.director.HandleResponseBody(cleanupCtx, reqCtx, true)
+		}
+	}()
+
+	skipResponseProcessing, err := p.handleRequest(ctx, w, r, reqCtx)
+	if err != nil {
+		logger.Error(err, "Failed to process request")
+		writeErrorResponse(w, err)
+		return
+	}
+	if reqCtx.ImmediateResponse != nil {
+		logger.V(logutil.DEFAULT).Info("EPP answered request without routing it", "targetModel", reqCtx.TargetModelName)
+		writeImmediateResponse(w, reqCtx)
+		return
+	}
+
+	resp, err := p.forward(ctx, reqCtx)
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.V(logutil.DEBUG).Info("Client disconnected before the model server responded")
+			return
+		}
+		logger.Error(err, "Failed to forward request")
+		writeErrorResponse(w, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if skipResponseProcessing {
+		logger.V(logutil.DEFAULT).Info("EPP skipped response interception, routed request",
+			"targetEndpoint", reqCtx.TargetEndpoint, "targetModel", reqCtx.TargetModelName)
+		passThroughResponse(ctx, w, resp)
+		return
+	}
+	p.handleResponse(ctx, w, reqCtx, resp)
+}
+
+// handleRequest reads and parses the request body and runs it through the director. Requests without a
+// body are routed to a random endpoint, like the ext-proc server does for header-only requests.
+func (p *HTTPProxyServer) handleRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCtx *RequestContext) (bool, error) {
+	body, err := p.readBody(w, r)
+	if err != nil {
+		return false, err
+	}
+	if len(body) == 0 {
+		endpoint := p.streaming.director.GetRandomEndpoint()
+		if endpoint == nil {
+			return false, errcommon.Error{Code: errcommon.Internal, Msg: "no pods available in datastore"}
+		}
+		reqCtx.TargetEndpoint = endpoint.GetIPAddress() + ":" + endpoint.GetPort()
+		return true, nil
+	}
+
+	reqCtx.Request.RawBody = body
+	reqCtx.RequestSize = len(body)
+
+	parser, err := p.streaming.getOrResolveParser(ctx, reqCtx)
+	if err != nil {
+		return false, errcommon.Error{Code: errcommon.BadRequest, Msg: err.Error()}
+	}
+	parseResult, err := parser.ParseRequest(ctx, reqCtx.Request.RawBody, reqCtx.Request.Headers)
+	if err != nil {
+		return false, errcommon.Error{Code: errcommon.BadRequest, Msg: err.Error()}
+	}
+	if _, err := p.streaming.director.HandleRequest(ctx, reqCtx, parseResult.Body); err != nil {
+		return false, err
+	}
+
+	if reqCtx.SchedulingRequest != nil && reqCtx.SchedulingRequest.Body != nil {
+		reqCtx.modelServerStreaming = reqCtx.SchedulingRequest.Body.Stream
+	}
+	metrics.RecordRequestCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.Priority)
+	metrics.RecordRequestSizes(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.RequestSize)
+	metrics.IncRunningRequests(reqCtx.IncomingModelName)
+	reqCtx.RequestRunning = true
+	reqCtx.RequestState = BodyRequestResponsesComplete
+	return parseResult.SkipResponseProcessing, nil
+}
+
+func (p *HTTPProxyServer) readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	reader := io.Reader(r.Body)
+	if p.maxRequestBodySize > 0 {
+		reader = http.MaxBytesReader(w, r.Body, p.maxRequestBodySize)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errcommon.Error{Code: errcommon.BadRequest,
+				Msg: fmt.Sprintf("request body exceeds the maximum size of %d bytes", maxBytesErr.Limit)}
+		}
+		return nil, errcommon.Error{Code: errcommon.BadRequest, Msg: fmt.Sprintf("failed to read request body: %v", err)}
+	}
+	return body, nil
+}
+
+// forward sends the request to the target endpoint. When the director picked several endpoints, they are
+// tried in order until one accepts the connection, matching Envoy's handling of the destination endpoint list.
+func (p *HTTPProxyServer) forward(ctx context.Context, reqCtx *RequestContext) (*http.Response, error) {
+	logger := log.FromContext(ctx)
+
+	var lastErr error
+	for endpoint := range strings.SplitSeq(reqCtx.TargetEndpoint, ",") {
+		outReq, err := p.newUpstreamRequest(ctx, reqCtx, strings.TrimSpace(endpoint))
+		if err != nil {
+			return nil, errcommon.Error{Code: errcommon.Internal, Msg: err.Error()}
+		}
+		resp, err := p.transport.RoundTrip(outReq)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		logger.V(logutil.DEFAULT).Info("Failed to reach endpoint", "endpoint", endpoint, "error", err.Error())
+		lastErr = err
+	}
+	return nil, errcommon.Error{Code: errcommon.ServiceUnavailable,
+		Msg: fmt.Sprintf("failed to reach endpoint(s) %q: %v", reqCtx.TargetEndpoint, lastErr)}
+}
+
+func (p *HTTPProxyServer) newUpstreamRequest(ctx context.Context, reqCtx *RequestContext, endpoint string) (*http.Request, error) {
+	var body io.Reader = http.NoBody
+	if len(reqCtx.Request.RawBody) > 0 {
+		body = bytes.NewReader(reqCtx.Request.RawBody)
+	}
+	headers := reqCtx.Request.Headers
+	outReq, err := http.NewRequestWithContext(ctx, headers[":method"], "http://"+endpoint+headers[":path"], body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for endpoint %q: %w", endpoint, err)
+	}
+	outReq.Host = headers[":authority"]
+
+	for key, value := range headers {
+		if strings.HasPrefix(key, ":") || isHopByHopHeader(key) || request.IsSystemOwnedHeader(key) {
+			continue
+		}
+		outReq.Header.Set(key, value)
+	}
+	outReq.Header.Set(metadata.DestinationEndpointKey, reqCtx.TargetEndpoint)
+	// Inject trace context headers for propagation to downstream services.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(outReq.Header))
+	return outReq, nil
+}
+
+// handleResponse relays the model server response to the client while running the response plugins.
+// Streamed responses are forwarded and flushed chunk by chunk; other responses are buffered so the model
+// name can be rewritten before the headers are sent.
+func (p *HTTPProxyServer) handleResponse(ctx context.Context, w http.ResponseWriter, reqCtx *RequestContext, resp *http.Response) {
+	logger := log.FromContext(ctx)
+
+	for key, values := range resp.Header {
+		reqCtx.Response.Headers[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+	reqCtx.Response.Headers[":status"] = strconv.Itoa(resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		reqCtx.ResponseStatusCode = errcommon.ModelServerError
+	}
+	if strings.Contains(reqCtx.Response.Headers["content-type"], "text/event-stream") {
+		reqCtx.modelServerStreaming = true
+	}
+	reqCtx.RequestState = ResponseReceived
+	reqCtx = p.streaming.director.HandleResponseHeader(ctx, reqCtx)
+	copyResponseHeaders(w.Header(), resp.Header, reqCtx.Response.Headers)
+	reqCtx.RequestState = HeaderResponseResponseComplete
+
+	if !reqCtx.modelServerStreaming {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			logger.Error(err, "Failed to read response body from model server")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		reqCtx.ResponseComplete = true
+		reqCtx.ResponseCompleteTimestamp = time.Now()
+		reqCtx = p.streaming.HandleResponseBody(ctx, reqCtx, body, true)
+		body = rewriteModelName(body, reqCtx.TargetModelName, reqCtx.IncomingModelName)
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(resp.StatusCode)
+		if _, err := w.Write(body); err != nil {
+			logger.V(logutil.DEBUG).Info("Failed to write response body to client", "error", err.Error())
+		}
+		reqCtx.RequestState = BodyResponseResponsesComplete
+		return
+	}
+
+	// The model name rewrite may change the size of each chunk.
+	w.Header().Del("Content-Length")
+	w.WriteHeader(resp.StatusCode)
+	rc := http.NewResponseController(w)
+	_ = rc.Flush()
+
+	buf := make([]byte, proxyReadBufferSize)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			chunk := bytes.Clone(buf[:n])
+			reqCtx = p.streaming.HandleResponseBody(ctx, reqCtx, chunk, false)
+			chunk = reqCtx.rewriteModelNameChunk(chunk, false)
+			if _, err := w.Write(chunk); err != nil {
+				logger.V(logutil.DEBUG).Info("Failed to write response chunk to client", "error", err.Error())
+				return
+			}
+			_ = rc.Flush()
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			logger.Error(readErr, "Failed to read streamed response from model server")
+			return
+		}
+	}
+	// Release the end of the last chunk held back by the model name rewrite.
+	if tail := reqCtx.rewriteModelNameChunk(nil, true); len(tail) > 0 {
+		if _, err := w.Write(tail); err != nil {
+			logger.V(logutil.DEBUG).Info("Failed to write response chunk to client", "error", err.Error())
+			return
+		}
+		_ = rc.Flush()
+	}
+	// Like Envoy, signal the end of the stream with a trailing empty chunk.
+	reqCtx.ResponseComplete = true
+	reqCtx.ResponseCompleteTimestamp = time.Now()
+	p.streaming.HandleResponseBody(ctx, reqCtx, nil, true)
+	reqCtx.RequestState = BodyResponseResponsesComplete
+}
+
+// passThroughResponse relays the model server response to the client without running the response plugins.
+func passThroughResponse(ctx context.Context, w http.ResponseWriter, resp *http.Response) {
+	copyResponseHeaders(w.Header(), resp.Header, nil)
+	w.WriteHeader(resp.StatusCode)
+	rc := http.NewResponseController(w)
+	buf := make([]byte, proxyReadBufferSize)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return
+			}
+			_ = rc.Flush()
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				log.FromContext(ctx).Error(readErr, "Failed to read response from model server")
+			}
+			return
+		}
+	}
+}
+
+// copyResponseHeaders copies the end-to-end headers of the model server response, then applies the
+// headers set by the response plugins, skipping the system-owned ones as the ext-proc server does.
+func copyResponseHeaders(dst, upstream http.Header, mutated map[string]string) {
+	for key, values := range upstream {
+		if isHopByHopHeader(key) {
+			continue
+		}
+		dst[key] = append([]string(nil), values...)
+	}
+	for key, value := range mutated {
+		if strings.HasPrefix(key, ":") || isHopByHopHeader(key) || request.IsSystemOwnedHeader(key) {
+			continue
+		}
+		if strings.Join(dst.Values(key), ",") != value {
+			dst.Set(key, value)
+		}
+	}
+}
+
+// writeErrorResponse writes the HTTP equivalent of the ImmediateResponse built by errcommon.BuildErrResponse.
+// Errors without a recognized code, which fail the ext-proc stream, are reported as 500.
+func writeErrorResponse(w http.ResponseWriter, err error) {
+	code, ok := errcommon.HTTPStatusCode(err)
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	var e errcommon.Error
+	if errors.As(err, &e) {
+		for key, value := range e.Headers {
+			w.Header().Set(key, value)
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = io.WriteString(w, err.Error())
+}
+
+// writeImmediateResponse writes the HTTP equivalent of the ImmediateResponse the ext-proc server sends when the
+// director answers a request itself.
+func writeImmediateResponse(w http.ResponseWriter, reqCtx *RequestContext) {
+	body := rewriteModelName(reqCtx.ImmediateResponse.Body, reqCtx.TargetModelName, reqCtx.IncomingModelName)
+	for key, value := range reqCtx.ImmediateResponse.Headers {
+		w.Header().Set(key, value)
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(reqCtx.ImmediateResponse.StatusCode)
+	_, _ = w.Write(body)
+}
+
+func isHopByHopHeader(key string) bool {
+	_, ok := hopByHopHeaders[strings.ToLower(key)]
+	return ok
+}
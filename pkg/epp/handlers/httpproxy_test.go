@@ -0,0 +1,374 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/openai"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+)
+
+// proxyTestDirector routes every request to the configured endpoints and records the response hooks.
+type proxyTestDirector struct {
+	endpoints string
+	err       error
+	immediate *ImmediateResponse
+
+	mu             sync.Mutex
+	headerCalls    int
+	bodyCalls      int
+	endOfStreams   int
+	lastRequestCtx *RequestContext
+}
+
+func (d *proxyTestDirector) HandleRequest(_ context.Context, reqCtx *RequestContext, body *fwkrh.InferenceRequestBody) (*RequestContext, error) {
+	if d.err != nil {
+		return reqCtx, d.err
+	}
+	reqCtx.IncomingModelName = "food-review"
+	reqCtx.TargetModelName = "food-review-1"
+	reqCtx.TargetPod = &fwkdl.EndpointMetadata{PodName: "pod-1"}
+	reqCtx.TargetEndpoint = d.endpoints
+	reqCtx.SchedulingRequest = &fwksched.InferenceRequest{Body: body, FairnessID: metadata.DefaultFairnessID}
+	if d.immediate != nil {
+		reqCtx.TargetPod = nil
+		reqCtx.TargetEndpoint = ""
+		reqCtx.ImmediateResponse = d.immediate
+	}
+	return reqCtx, nil
+}
+
+func (d *proxyTestDirector) HandleResponseHeader(_ context.Context, reqCtx *RequestContext) *RequestContext {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.headerCalls++
+	reqCtx.Response.Headers["x-plugin-header"] = "set-by-plugin"
+	return reqCtx
+}
+
+func (d *proxyTestDirector) HandleResponseBody(_ context.Context, reqCtx *RequestContext, endOfStream bool) *RequestContext {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bodyCalls++
+	if endOfStream {
+		d.endOfStreams++
+	}
+	d.lastRequestCtx = reqCtx
+	return reqCtx
+}
+
+func (d *proxyTestDirector) GetRandomEndpoint() *fwkdl.EndpointMetadata {
+	host, port, _ := net.SplitHostPort(d.endpoints)
+	return &fwkdl.EndpointMetadata{Address: host, Port: port}
+}
+
+func (d *proxyTestDirector) counts() (int, int, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.headerCalls, d.bodyCalls, d.endOfStreams
+}
+
+func newTestHTTPProxy(t *testing.T, director Director) *httptest.Server {
+	t.Helper()
+	registry := NewParserRegistry([]fwkrh.Parser{openai.NewOpenAIParser()}, logr.Discard())
+	proxy := httptest.NewServer(NewHTTPProxyServer(nil, director, registry, 1024, nil))
+	t.Cleanup(proxy.Close)
+	return proxy
+}
+
+func backendAddress(t *testing.T, backend *httptest.Server) string {
+	t.Helper()
+	u, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+	return u.Host
+}
+
+const proxyTestRequest = `{"model":"food-review","prompt":"hello"}`
+
+func TestHTTPProxyServer_NonStreaming(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"model":"food-review-1","usage":{"prompt_tokens":11,"completion_tokens":100,"total_tokens":111}}`)
+	}))
+	defer backend.Close()
+
+	director := &proxyTestDirector{endpoints: backendAddress(t, backend)}
+	proxy := newTestHTTPProxy(t, director)
+
+	req, err := http.NewRequest(http.MethodPost, proxy.URL+"/v1/completions", strings.NewReader(proxyTestRequest))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(metadata.ObjectiveKey, "critical")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"model":"food-review","usage":{"prompt_tokens":11,"completion_tokens":100,"total_tokens":111}}`, string(body),
+		"model name should be rewritten back to the client-facing name")
+	assert.Equal(t, "set-by-plugin", resp.Header.Get("x-plugin-header"))
+
+	assert.Equal(t, proxyTestRequest, gotBody)
+	assert.Equal(t, director.endpoints, gotHeaders.Get(metadata.DestinationEndpointKey))
+	assert.NotEmpty(t, gotHeaders.Get("x-request-id"), "request id should be generated when missing")
+	assert.Empty(t, gotHeaders.Get(metadata.ObjectiveKey), "control headers should not leak to the model server")
+
+	headerCalls, _, endOfStreams := director.counts()
+	assert.Equal(t, 1, headerCalls)
+	assert.Equal(t, 1, endOfStreams)
+	assert.Equal(t, 11, director.lastRequestCtx.Usage.PromptTokens)
+	assert.Equal(t, "critical", director.lastRequestCtx.ObjectiveKey)
+	assert.True(t, director.lastRequestCtx.ResponseComplete)
+	assert.Contains(t, string(director.lastRequestCtx.Response.Body), `"food-review-1"`,
+		"the complete body, as received from the model server, should be exposed to the response hooks")
+}
+
+func TestHTTPProxyServer_ImmediateResponse(t *testing.T) {
+	var backendCalls atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		backendCalls.Add(1)
+	}))
+	defer backend.Close()
+
+	director := &proxyTestDirector{
+		endpoints: backendAddress(t, backend),
+		immediate: &ImmediateResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"content-type": "application/json", metadata.ResponseCacheKey: "hit"},
+			Body:       []byte(`{"model":"food-review-1","choices":[]}`),
+		},
+	}
+	proxy := newTestHTTPProxy(t, director)
+
+	resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(proxyTestRequest))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"model":"food-review","choices":[]}`, string(body))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "hit", resp.Header.Get(metadata.ResponseCacheKey))
+	assert.Zero(t, backendCalls.Load(), "the model server should not be called")
+	headerCalls, bodyCalls, _ := director.counts()
+	assert.Zero(t, headerCalls)
+	assert.Zero(t, bodyCalls)
+}
+
+func TestHTTPProxyServer_Streaming(t *testing.T) {
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"model\":\"food-review-1\",\"choices\":[{\"text\":\"hi\"}]}\n\n")
+		w.(http.Flusher).Flush()
+		// Hold the rest of the stream until the client has received the first event.
+		<-release
+		_, _ = io.WriteString(w, "data: {\"model\":\"food-review-1\",\"choices\":[],\"usage\":{\"prompt_tokens\":7,\"completion_tokens\":2,\"total_tokens\":9}}\n\n")
+		_, _ = io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer backend.Close()
+
+	director := &proxyTestDirector{endpoints: backendAddress(t, backend)}
+	proxy := newTestHTTPProxy(t, director)
+
+	resp, err := http.Post(proxy.URL+"/v1/completions", "application/json",
+		strings.NewReader(`{"model":"food-review","prompt":"hello","stream":true}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	reader := bufio.NewReader(resp.Body)
+	first, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "data: {\"model\":\"food-review\",\"choices\":[{\"text\":\"hi\"}]}\n", first,
+		"first event should be flushed before the stream completes")
+	_, _, endOfStreams := director.counts()
+	assert.Equal(t, 0, endOfStreams, "stream should not be complete yet")
+
+	close(release)
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(rest), "data: [DONE]")
+	assert.NotContains(t, string(rest), "food-review-1")
+
+	// The completion hook runs after the last chunk has been written to the client.
+	require.Eventually(t, func() bool {
+		_, _, endOfStreams := director.counts()
+		return endOfStreams == 1
+	}, time.Second, 10*time.Millisecond)
+	director.mu.Lock()
+	defer director.mu.Unlock()
+	assert.Equal(t, 7, director.lastRequestCtx.Usage.PromptTokens)
+	assert.Equal(t, 2, director.lastRequestCtx.Usage.CompletionTokens)
+}
+
+func TestHTTPProxyServer_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		directorErr error
+		wantStatus  int
+		wantHeaders map[string]string
+		wantBody    string
+	}{
+		{
+			name:        "rejected by admission control",
+			body:        proxyTestRequest,
+			directorErr: errcommon.Error{Code: errcommon.ResourceExhausted, Msg: "no capacity", Headers: map[string]string{errcommon.RequestDroppedReasonHeaderKey: string(errcommon.RequestDroppedReasonSaturated)}},
+			wantStatus:  http.StatusTooManyRequests,
+			wantHeaders: map[string]string{errcommon.RequestDroppedReasonHeaderKey: string(errcommon.RequestDroppedReasonSaturated)},
+			wantBody:    "no capacity",
+		},
+		{
+			name:        "unrecognized error",
+			body:        proxyTestRequest,
+			directorErr: errors.New("boom"),
+			wantStatus:  http.StatusInternalServerError,
+			wantBody:    "boom",
+		},
+		{
+			name:       "invalid request body",
+			body:       `{"model":`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "request body too large",
+			body:       `{"model":"food-review","prompt":"` + strings.Repeat("a", 2048) + `"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "exceeds the maximum size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var backendCalled atomic.Bool
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				backendCalled.Store(true)
+			}))
+			defer backend.Close()
+
+			director := &proxyTestDirector{endpoints: backendAddress(t, backend), err: tt.directorErr}
+			proxy := newTestHTTPProxy(t, director)
+
+			resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Contains(t, string(body), tt.wantBody)
+			for k, v := range tt.wantHeaders {
+				assert.Equal(t, v, resp.Header.Get(k))
+			}
+			assert.False(t, backendCalled.Load(), "request should not reach the model server")
+		})
+	}
+}
+
+func TestHTTPProxyServer_EndpointFallback(t *testing.T) {
+	// Reserve an address and close it, so connecting to it is refused.
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer backend.Close()
+
+	t.Run("falls back to the next endpoint", func(t *testing.T) {
+		director := &proxyTestDirector{endpoints: unreachable + "," + backendAddress(t, backend)}
+		proxy := newTestHTTPProxy(t, director)
+
+		resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(proxyTestRequest))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("returns 503 when no endpoint is reachable", func(t *testing.T) {
+		director := &proxyTestDirector{endpoints: unreachable}
+		proxy := newTestHTTPProxy(t, director)
+
+		resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(proxyTestRequest))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+		// The completion hooks still run so plugins can release per-request state.
+		require.Eventually(t, func() bool {
+			_, _, endOfStreams := director.counts()
+			return endOfStreams == 1
+		}, time.Second, 10*time.Millisecond)
+		headerCalls, _, _ := director.counts()
+		assert.Equal(t, 0, headerCalls)
+	})
+}
+
+func TestHTTPProxyServer_NoBodyRoutesToRandomEndpoint(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"path": r.URL.RequestURI()})
+	}))
+	defer backend.Close()
+
+	director := &proxyTestDirector{endpoints: backendAddress(t, backend)}
+	proxy := newTestHTTPProxy(t, director)
+
+	resp, err := http.Get(proxy.URL + "/v1/models?limit=1")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"path":"/v1/models?limit=1"}`, string(body))
+	headerCalls, bodyCalls, _ := director.counts()
+	assert.Zero(t, headerCalls+bodyCalls, "response hooks should not run for unscheduled requests")
+}
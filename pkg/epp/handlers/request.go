@@ -51,12 +51,30 @@ func (s *StreamingServer) HandleRequestHeaders(ctx context.Context, reqCtx *Requ
 		reqCtx.Request.Headers[strings.ToLower(header.Key)] = envoy.GetHeaderValue(header)
 	}
 
-	reqCtx.ObjectiveKey, _ = metadata.GetLowerCaseHeaderValue(reqCtx.Request.Headers, metadata.ObjectiveKey)
-	reqCtx.TargetModelName, _ = metadata.GetLowerCaseHeaderValue(reqCtx.Request.Headers, metadata.ModelNameRewriteKey)
+	extractControlHeaders(reqCtx)
+
+	// In BUFFERED mode the proxy only sends the body once the request headers have been answered, so let them
+	// through unchanged; the routing decision is carried by the request body response instead.
+	if reqCtx.requestBodyMode == BodyModeBuffered {
+		reqCtx.reqHeaderResp = &extProcPb.ProcessingResponse{
+			Response: &extProcPb.ProcessingResponse_RequestHeaders{
+				RequestHeaders: &extProcPb.HeadersResponse{
+					Response: &extProcPb.CommonResponse{},
+				},
+			},
+		}
+	}
 
 	return nil
 }
 
+// extractControlHeaders populates the request context fields that are driven by EPP control headers.
+// It expects reqCtx.Request.Headers to be keyed by lower-case header names.
+func extractControlHeaders(reqCtx *RequestContext) {
+	reqCtx.ObjectiveKey, _ = metadata.GetLowerCaseHeaderValue(reqCtx.Request.Headers, metadata.ObjectiveKey)
+	reqCtx.TargetModelName, _ = metadata.GetLowerCaseHeaderValue(reqCtx.Request.Headers, metadata.ModelNameRewriteKey)
+}
+
 func (s *StreamingServer) fallbackToRandomEndpoint(ctx context.Context, reqCtx *RequestContext, requestSize int) error {
 	endpoint := s.director.GetRandomEndpoint()
 	if endpoint == nil {
@@ -64,14 +82,61 @@ func (s *StreamingServer) fallbackToRandomEndpoint(ctx context.Context, reqCtx *
 	}
 	reqCtx.TargetEndpoint = endpoint.GetIPAddress() + ":" + endpoint.GetPort()
 	reqCtx.RequestSize = requestSize
-	reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(ctx, reqCtx)
 
 	if requestSize > 0 {
-		reqCtx.reqBodyResp = envoy.GenerateRequestBodyResponses(reqCtx.Request.RawBody)
+		s.setRequestResponses(ctx, reqCtx)
+	} else {
+		reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(ctx, reqCtx)
 	}
 	return nil
 }
 
+// setRequestResponses prepares the responses carrying the routing decision once the request body has been fully
+// received, in the shape expected by the request body mode of the stream.
+func (s *StreamingServer) setRequestResponses(ctx context.Context, reqCtx *RequestContext) {
+	headerResp := s.generateRequestHeaderResponse(ctx, reqCtx)
+	switch reqCtx.requestBodyMode {
+	case BodyModeBuffered:
+		// The request headers were already answered, so the header mutation rides along with the body response.
+		commonResp := envoy.BuildReplacedBodyResponse(reqCtx.Request.RawBody)
+		commonResp.HeaderMutation = headerResp.GetRequestHeaders().GetResponse().GetHeaderMutation()
+		commonResp.ClearRouteCache = true
+		reqCtx.reqBodyResp = []*extProcPb.ProcessingResponse{
+			{
+				Response: &extProcPb.ProcessingResponse_RequestBody{
+					RequestBody: &extProcPb.BodyResponse{
+						Response: commonResp,
+					},
+				},
+				DynamicMetadata: headerResp.DynamicMetadata,
+			},
+		}
+	case BodyModeStreamed:
+		// The proxy expects one response per body message it sent, after the request headers response. The body is
+		// only known once it has been fully received, so every chunk but the last is cleared and the last one carries
+		// the whole body.
+		reqCtx.reqHeaderResp = headerResp
+		chunks := max(reqCtx.requestBodyChunks, 1)
+		reqCtx.reqBodyResp = make([]*extProcPb.ProcessingResponse, 0, chunks)
+		for i := range chunks {
+			commonResp := &extProcPb.CommonResponse{
+				BodyMutation: &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_ClearBody{ClearBody: true}},
+			}
+			if i == chunks-1 {
+				commonResp = envoy.BuildReplacedBodyResponse(reqCtx.Request.RawBody)
+			}
+			reqCtx.reqBodyResp = append(reqCtx.reqBodyResp, &extProcPb.ProcessingResponse{
+				Response: &extProcPb.ProcessingResponse_RequestBody{
+					RequestBody: &extProcPb.BodyResponse{Response: commonResp},
+				},
+			})
+		}
+	default:
+		reqCtx.reqHeaderResp = headerResp
+		reqCtx.reqBodyResp = envoy.GenerateRequestBodyResponses(reqCtx.Request.RawBody)
+	}
+}
+
 func (s *StreamingServer) generateRequestHeaderResponse(ctx context.Context, reqCtx *RequestContext) *extProcPb.ProcessingResponse {
 	// The Endpoint Picker supports two approaches to communicating the target endpoint, as a request header
 	// and as an unstructure ext-proc response metadata key/value pair. This enables different integration
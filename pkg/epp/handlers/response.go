@@ -51,6 +51,9 @@ func (s *StreamingServer) HandleResponseBody(ctx context.Context, reqCtx *Reques
 	logger.V(logutil.DEBUG).Info("HandleResponseBody is triggered", "len(responseBytes)", len(responseBytes), "endOfStream", endOfStream)
 
 	reqCtx.ResponseSize += len(responseBytes)
+	if endOfStream && !reqCtx.modelServerStreaming {
+		reqCtx.Response.Body = responseBytes
+	}
 
 	if reqCtx.FirstTokenTimestamp.IsZero() && len(responseBytes) > 0 {
 		reqCtx.FirstTokenTimestamp = time.Now()
@@ -446,6 +446,64 @@ func TestRewriteModelName(t *testing.T) {
 	}
 }
 
+func TestRewriteModelNameChunk(t *testing.T) {
+	const (
+		body       = `data: {"id":"cmpl-123","model":"vllm-backend-01","choices":[]}` + "\n\n"
+		want       = `data: {"id":"cmpl-123","model":"gpt-4-proxy","choices":[]}` + "\n\n"
+		spacedBody = `data: {"id":"cmpl-123","model": "vllm-backend-01","choices":[]}` + "\n\n"
+		spacedWant = `data: {"id":"cmpl-123","model": "gpt-4-proxy","choices":[]}` + "\n\n"
+	)
+
+	tests := []struct {
+		name       string
+		body       string
+		want       string
+		splits     []int
+		byteByByte bool
+	}{
+		{name: "single chunk", body: body, want: want},
+		{name: "split before the model field", body: body, want: want, splits: []int{len(`data: {"id":"cmpl-123",`)}},
+		{name: "split inside the field name", body: body, want: want, splits: []int{len(`data: {"id":"cmpl-123","mo`)}},
+		{name: "split inside the model name", body: body, want: want, splits: []int{len(`data: {"id":"cmpl-123","model":"vllm-back`)}},
+		{name: "split before the closing quote", body: body, want: want, splits: []int{len(`data: {"id":"cmpl-123","model":"vllm-backend-01`)}},
+		{name: "several events", body: body + body, want: want + want, splits: []int{len(body) + len(`data: {"id":"cmpl-123","model":"vl`)}},
+		{name: "split after the space", body: spacedBody, want: spacedWant, splits: []int{len(`data: {"id":"cmpl-123","model": `)}},
+		{name: "byte by byte", body: body, want: want, byteByByte: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var chunks []string
+			if tc.byteByByte {
+				for i := range tc.body {
+					chunks = append(chunks, tc.body[i:i+1])
+				}
+			} else {
+				start := 0
+				for _, split := range tc.splits {
+					chunks = append(chunks, tc.body[start:split])
+					start = split
+				}
+				chunks = append(chunks, tc.body[start:])
+			}
+
+			reqCtx := &RequestContext{TargetModelName: "vllm-backend-01", IncomingModelName: "gpt-4-proxy"}
+			var got []byte
+			for i, chunk := range chunks {
+				got = append(got, reqCtx.rewriteModelNameChunk([]byte(chunk), i == len(chunks)-1)...)
+			}
+			assert.Equal(t, tc.want, string(got))
+			assert.Empty(t, reqCtx.pendingResponseChunk)
+		})
+	}
+
+	t.Run("bytes that cannot start a model name are not held back", func(t *testing.T) {
+		reqCtx := &RequestContext{TargetModelName: "vllm-backend-01", IncomingModelName: "gpt-4-proxy"}
+		chunk := `data: {"id":"cmpl-123","choices":[]}` + "\n\n"
+		assert.Equal(t, chunk, string(reqCtx.rewriteModelNameChunk([]byte(chunk), false)))
+	})
+}
+
 func TestResponseSizeAccumulation(t *testing.T) {
 	ctx := logutil.NewTestLoggerIntoContext(context.Background())
 
@@ -65,6 +65,7 @@ func NewStreamingServer(datastore Datastore, director Director, parserRegistry *
 		datastore:         datastore,
 		parserRegistry:    parserRegistry,
 		maxPoolBufferSize: maxPoolBufferSize,
+		defaultBodyMode:   BodyModeFullDuplexStreamed,
 		bufferPool: sync.Pool{
 			New: func() any {
 				return new(bytes.Buffer)
@@ -78,6 +79,12 @@ func (s *StreamingServer) SetEvictChannelLookup(lookup EvictChannelLookup) {
 	s.evictionLookup = lookup
 }
 
+// SetDefaultBodyMode sets the body mode assumed for streams on which the proxy does not announce its protocol
+// configuration. mode must be one of DefaultBodyModes.
+func (s *StreamingServer) SetDefaultBodyMode(mode BodyMode) {
+	s.defaultBodyMode = mode
+}
+
 type Director interface {
 	HandleRequest(ctx context.Context, reqCtx *RequestContext, inferenceRequestBody *fwkrh.InferenceRequestBody) (*RequestContext, error)
 	HandleResponseHeader(ctx context.Context, reqCtx *RequestContext) *RequestContext
@@ -98,6 +105,7 @@ type StreamingServer struct {
 	evictionLookup    EvictChannelLookup // optional, set for eviction support
 	bufferPool        sync.Pool
 	maxPoolBufferSize int
+	defaultBodyMode   BodyMode
 }
 
 // RequestContext stores context information during the life time of an HTTP request.
@@ -120,18 +128,32 @@ type RequestContext struct {
 	ResponseSize              int
 	ResponseBodyStarted       bool
 	ResponseComplete          bool
-	ResponseStatusCode        string
-	RequestRunning            bool
-	Request                   *Request
-	Parser                    fwkrh.Parser
+	// ResponseUntracked is set before the request is handled when the EPP will not observe its response, so its
+	// completion hooks run as soon as it is routed.
+	ResponseUntracked  bool
+	ResponseStatusCode string
+	RequestRunning     bool
+	Request            *Request
+	Parser             fwkrh.Parser
 
 	SchedulingRequest *fwksched.InferenceRequest
 
 	RequestState         StreamRequestState
 	RequestDroppedReason errcommon.RequestDroppedReason
 	modelServerStreaming bool
+	// requestBodyMode and responseBodyMode are the ext_proc body modes of the stream, which determine the shape of
+	// the body responses.
+	requestBodyMode  BodyMode
+	responseBodyMode BodyMode
+	// requestBodyChunks counts the request body messages received, each of which is answered in the STREAMED mode.
+	requestBodyChunks int
+	// pendingResponseChunk holds the end of the previous response chunk that may be the start of a model name to
+	// rewrite, see rewriteModelNameChunk.
+	pendingResponseChunk []byte
 
 	Response *Response
+	// ImmediateResponse, when set by the director, answers the request without routing it to a model server.
+	ImmediateResponse *ImmediateResponse
 
 	reqHeaderResp  *extProcPb.ProcessingResponse
 	reqBodyResp    []*extProcPb.ProcessingResponse
@@ -150,7 +172,17 @@ type Request struct {
 type Response struct {
 	Headers         map[string]string
 	DynamicMetadata *structpb.Struct
+	// Body is the complete response body, set once a non-streaming response has been received.
+	Body []byte
+}
+
+// ImmediateResponse is a complete response the EPP sends to the client in place of the model server response.
+type ImmediateResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
 }
+
 type StreamRequestState int
 
 const (
@@ -169,6 +201,9 @@ const (
 	// The state machine sends a RequestHeadersResponse and RequestBodyResponse with the routing decision
 	// from the scheduling director to the proxy, and then gracefully closes the stream to stop further external processing.
 	RequestResponseProcessingSkipped StreamRequestState = 9
+	// RequestAnsweredImmediately indicates that the director answered the request itself, e.g. from a response cache.
+	// The state machine sends the ImmediateResponse to the proxy and closes the stream.
+	RequestAnsweredImmediately StreamRequestState = 10
 )
 
 // recvResult holds the result of a srv.Recv() call from the reader goroutine.
@@ -246,6 +281,8 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 		Response: &Response{
 			Headers: make(map[string]string),
 		},
+		requestBodyMode:  s.defaultBodyMode,
+		responseBodyMode: s.defaultBodyMode,
 	}
 
 	buf := s.bufferPool.Get().(*bytes.Buffer)
@@ -355,6 +392,11 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 
 		reqCtx.Request.Metadata = envoy.ExtractMetadataValues(req)
 
+		// The proxy announces its processing mode on the first message of the stream only.
+		if err = applyProtocolConfig(reqCtx, req.GetProtocolConfig()); err != nil {
+			return sendErrorResponse(srv, logger, err, req)
+		}
+
 		switch v := req.Request.(type) {
 		case *extProcPb.ProcessingRequest_RequestHeaders:
 			requestID := envoy.ExtractHeaderValue(v, reqcommon.RequestIDHeaderKey)
@@ -382,6 +424,7 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			loggerTrace.Info("Incoming body chunk", "EoS", v.RequestBody.EndOfStream)
 			// In the stream case, we can receive multiple request bodies.
 			buf.Write(v.RequestBody.Body)
+			reqCtx.requestBodyChunks++
 
 			// Message is buffered, we can read and decode.
 			if v.RequestBody.EndOfStream {
@@ -406,11 +449,16 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					break
 				}
 
+				reqCtx.ResponseUntracked = parseResult.SkipResponseProcessing
 				reqCtx, err = s.director.HandleRequest(ctx, reqCtx, parseResult.Body)
 				if err != nil {
 					logger.Error(err, "Error handling request")
 					break
 				}
+				if reqCtx.ImmediateResponse != nil {
+					reqCtx.RequestState = RequestAnsweredImmediately
+					break
+				}
 
 				// After scheduling, look up the eviction channel for eviction support.
 				// Setting evictCh from nil to a real channel dynamically enables the
@@ -424,8 +472,7 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					reqCtx.modelServerStreaming = reqCtx.SchedulingRequest.Body.Stream
 				}
 
-				reqCtx.reqHeaderResp = s.generateRequestHeaderResponse(ctx, reqCtx)
-				reqCtx.reqBodyResp = envoy.GenerateRequestBodyResponses(reqCtx.Request.RawBody)
+				s.setRequestResponses(ctx, reqCtx)
 				metrics.RecordRequestCounter(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.Priority)
 				metrics.RecordRequestSizes(reqCtx.IncomingModelName, reqCtx.TargetModelName, reqCtx.RequestSize)
 
@@ -454,14 +501,16 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			endOfStream := v.ResponseBody.EndOfStream
 			chunk := v.ResponseBody.Body
 
-			if reqCtx.modelServerStreaming {
+			if reqCtx.responseBodyMode == BodyModeStreamed || reqCtx.responseBodyMode == BodyModeBuffered {
+				respBody = s.handleResponseBodyMessage(ctx, reqCtx, respBody, chunk, endOfStream)
+			} else if reqCtx.modelServerStreaming {
 				if endOfStream {
 					reqCtx.ResponseComplete = true
 					reqCtx.ResponseCompleteTimestamp = time.Now()
 				}
 				s.HandleResponseBody(ctx, reqCtx, chunk, endOfStream)
 				// Rewrite the model name in response body back to the original client-facing name.
-				chunk = rewriteModelName(chunk, reqCtx.TargetModelName, reqCtx.IncomingModelName)
+				chunk = reqCtx.rewriteModelNameChunk(chunk, endOfStream)
 				// For streaming response, we send response chunk back to envoy every time we received it.
 				reqCtx.respBodyResp = generateResponseBodyResponses(chunk, endOfStream, reqCtx.Response.DynamicMetadata)
 			} else {
@@ -484,25 +533,16 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 
 		// Handle the err and fire an immediate response.
 		if err != nil {
-			if logger.V(logutil.DEBUG).Enabled() {
-				logger.V(logutil.DEBUG).Error(err, "Failed to process request", "request", req)
-			} else {
-				logger.Error(err, "Failed to process request")
-			}
-			resp, err := errcommon.BuildErrResponse(err)
-			if err != nil {
-				return err
-			}
-			if err := srv.Send(resp); err != nil {
-				logger.Error(err, "Send failed")
-				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
-			}
-			return nil
+			return sendErrorResponse(srv, logger, err, req)
 		}
 		loggerTrace.Info("checking", "request state", reqCtx.RequestState)
 		if err := reqCtx.updateStateAndSendIfNeeded(srv, logger); err != nil {
 			return err
 		}
+		if reqCtx.RequestState == RequestAnsweredImmediately {
+			logger.V(logutil.DEFAULT).Info("EPP answered request without routing it", "targetModel", reqCtx.TargetModelName)
+			return nil
+		}
 		if reqCtx.RequestState == RequestResponseProcessingSkipped {
 			logger.V(logutil.DEFAULT).Info("EPP skipped response interception, routed request",
 				"targetEndpoint", reqCtx.TargetEndpoint,
@@ -515,6 +555,24 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	}
 }
 
+// sendErrorResponse answers the proxy with an immediate response built from err, which ends the stream.
+func sendErrorResponse(srv extProcPb.ExternalProcessor_ProcessServer, logger logr.Logger, err error, req *extProcPb.ProcessingRequest) error {
+	if logger.V(logutil.DEBUG).Enabled() {
+		logger.V(logutil.DEBUG).Error(err, "Failed to process request", "request", req)
+	} else {
+		logger.Error(err, "Failed to process request")
+	}
+	resp, err := errcommon.BuildErrResponse(err)
+	if err != nil {
+		return err
+	}
+	if err := srv.Send(resp); err != nil {
+		logger.Error(err, "Send failed")
+		return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
+	}
+	return nil
+}
+
 // finishResponse ensures all post-response logic, such as metric recording
 // and state updates, is executed exactly once for the request lifecycle.
 func (s *StreamingServer) finishResponse(ctx context.Context, reqCtx *RequestContext, body []byte, modelStreaming bool, setEos bool) {
@@ -527,7 +585,7 @@ func (s *StreamingServer) finishResponse(ctx context.Context, reqCtx *RequestCon
 	reqCtx.ResponseComplete = true
 	reqCtx.ResponseCompleteTimestamp = time.Now()
 	reqCtx = s.HandleResponseBody(ctx, reqCtx, body, true)
-	if !modelStreaming {
+	if !modelStreaming && reqCtx.responseBodyMode != BodyModeStreamed && reqCtx.responseBodyMode != BodyModeBuffered {
 		// Rewrite the model name in response body back to the original client-facing name.
 		body = rewriteModelName(body, reqCtx.TargetModelName, reqCtx.IncomingModelName)
 		// For non-streaming response, we send response back to envoy after receiving all the response body.
@@ -535,6 +593,39 @@ func (s *StreamingServer) finishResponse(ctx context.Context, reqCtx *RequestCon
 	}
 }
 
+// handleResponseBodyMessage processes a response body message in the STREAMED or BUFFERED body mode and returns the
+// accumulated response body. Unlike FULL_DUPLEX_STREAMED, the proxy expects exactly one response per body message, so
+// each chunk is answered as it arrives. Non-streaming bodies are still accumulated so the response hooks see the
+// whole body at the end of the stream.
+func (s *StreamingServer) handleResponseBodyMessage(ctx context.Context, reqCtx *RequestContext, respBody, chunk []byte, endOfStream bool) []byte {
+	if reqCtx.modelServerStreaming {
+		if endOfStream {
+			reqCtx.ResponseComplete = true
+			reqCtx.ResponseCompleteTimestamp = time.Now()
+		}
+		s.HandleResponseBody(ctx, reqCtx, chunk, endOfStream)
+	} else {
+		respBody = append(respBody, chunk...)
+		if endOfStream {
+			s.finishResponse(ctx, reqCtx, respBody, reqCtx.modelServerStreaming, true)
+		}
+	}
+	// Rewrite the model name in response body back to the original client-facing name.
+	chunk = reqCtx.rewriteModelNameChunk(chunk, endOfStream)
+	resp := &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ResponseBody{
+			ResponseBody: &extProcPb.BodyResponse{
+				Response: envoy.BuildReplacedBodyResponse(chunk),
+			},
+		},
+	}
+	if endOfStream {
+		resp.DynamicMetadata = reqCtx.Response.DynamicMetadata
+	}
+	reqCtx.respBodyResp = []*extProcPb.ProcessingResponse{resp}
+	return respBody
+}
+
 // rewriteModelName replaces occurrences of the target (internal) model name with the
 // incoming (client-facing) model name in the response body bytes. This ensures clients
 // see the model name they originally requested, not the internal backend model name.
@@ -555,6 +646,46 @@ func rewriteModelName(body []byte, targetModel, incomingModel string) []byte {
 	return bytes.ReplaceAll(body, old, new)
 }
 
+// rewriteModelNameChunk rewrites the model name in a chunk of a response body received in several chunks. A model
+// name split across two chunks would be missed by rewriting each chunk on its own, so the end of a chunk that may
+// be the start of a model name is held back and prepended to the next chunk. The held back bytes are released at
+// the end of the stream.
+func (r *RequestContext) rewriteModelNameChunk(chunk []byte, endOfStream bool) []byte {
+	if r.TargetModelName == "" || r.IncomingModelName == "" || r.TargetModelName == r.IncomingModelName {
+		return chunk
+	}
+	if len(r.pendingResponseChunk) > 0 {
+		chunk = append(r.pendingResponseChunk, chunk...)
+		r.pendingResponseChunk = nil
+	}
+	chunk = rewriteModelName(chunk, r.TargetModelName, r.IncomingModelName)
+	if endOfStream {
+		return chunk
+	}
+	held := partialModelNameSuffix(chunk, r.TargetModelName)
+	if held > 0 {
+		r.pendingResponseChunk = bytes.Clone(chunk[len(chunk)-held:])
+		chunk = chunk[:len(chunk)-held]
+	}
+	return chunk
+}
+
+// partialModelNameSuffix returns the length of the longest suffix of body that is a proper prefix of one of the
+// model name patterns matched by rewriteModelName, i.e. the start of a model name that may continue in the next
+// chunk.
+func partialModelNameSuffix(body []byte, targetModel string) int {
+	longest := 0
+	for _, pattern := range []string{`"model":"` + targetModel + `"`, `"model": "` + targetModel + `"`} {
+		for n := min(len(pattern)-1, len(body)); n > longest; n-- {
+			if bytes.HasSuffix(body, []byte(pattern[:n])) {
+				longest = n
+				break
+			}
+		}
+	}
+	return longest
+}
+
 // updateStateAndSendIfNeeded checks state and can send multiple responses in a single pass, but only if ordered properly.
 // Order of requests matter in FULL_DUPLEX_STREAMING. For both request and response, the order of response sent back MUST be: Header->Body->Trailer, with trailer being optional.
 func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProcessor_ProcessServer, logger logr.Logger) error {
@@ -588,6 +719,12 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 		})
 	}
 
+	// Handle immediate answer — send the director's response to Envoy in place of the model server response.
+	if r.RequestState == RequestAnsweredImmediately {
+		loggerTrace.Info("Sending ImmediateResponse for answered request")
+		return srv.Send(r.buildImmediateResponse())
+	}
+
 	// Handle skip — send response with the director's routing decision to the proxy.
 	if r.RequestState == RequestResponseProcessingSkipped {
 		if r.reqHeaderResp != nil {
@@ -615,6 +752,8 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 			return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
 		}
 		r.RequestState = HeaderRequestResponseComplete
+		// Dump the response so it is not sent again, e.g. when response processing is skipped.
+		r.reqHeaderResp = nil
 	}
 	if r.RequestState == HeaderRequestResponseComplete && r.reqBodyResp != nil && len(r.reqBodyResp) > 0 {
 		loggerTrace.Info("Sending request body response(s)")
@@ -624,10 +763,7 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
 			}
 		}
-		logger.V(logutil.DEFAULT).Info("EPP sent request body response(s) to proxy", "modelName", r.IncomingModelName, "targetModelName", r.TargetModelName)
-		r.RequestState = BodyRequestResponsesComplete
-		metrics.IncRunningRequests(r.IncomingModelName)
-		r.RequestRunning = true
+		r.completeRequestBody(logger)
 		// Dump the response so a new stream message can begin
 		r.reqBodyResp = nil
 	}
@@ -667,3 +803,40 @@ func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProces
 	}
 	return nil
 }
+
+// buildImmediateResponse converts the director's ImmediateResponse into an ext_proc ImmediateResponse. The model
+// name in the body is rewritten back to the client-facing name, as for responses received from a model server.
+func (r *RequestContext) buildImmediateResponse() *extProcPb.ProcessingResponse {
+	ir := &extProcPb.ImmediateResponse{
+		Status: &envoyTypePb.HttpStatus{
+			Code: envoyTypePb.StatusCode(r.ImmediateResponse.StatusCode),
+		},
+		Body: rewriteModelName(r.ImmediateResponse.Body, r.TargetModelName, r.IncomingModelName),
+	}
+	if len(r.ImmediateResponse.Headers) > 0 {
+		setHeaders := make([]*configPb.HeaderValueOption, 0, len(r.ImmediateResponse.Headers))
+		for key, value := range r.ImmediateResponse.Headers {
+			setHeaders = append(setHeaders, &configPb.HeaderValueOption{
+				Header: &configPb.HeaderValue{
+					Key:      key,
+					RawValue: []byte(value),
+				},
+			})
+		}
+		ir.Headers = &extProcPb.HeaderMutation{SetHeaders: setHeaders}
+	}
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: ir,
+		},
+	}
+}
+
+// completeRequestBody marks the request as forwarded to the model server once the proxy has received the request
+// body, or its replacement.
+func (r *RequestContext) completeRequestBody(logger logr.Logger) {
+	logger.V(logutil.DEFAULT).Info("EPP sent request body response(s) to proxy", "modelName", r.IncomingModelName, "targetModelName", r.TargetModelName)
+	r.RequestState = BodyRequestResponsesComplete
+	metrics.IncRunningRequests(r.IncomingModelName)
+	r.RequestRunning = true
+}
@@ -19,6 +19,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
@@ -107,6 +108,35 @@ func TestUpdateStateAndSendIfNeeded_Evicted(t *testing.T) {
 	}
 }
 
+func TestUpdateStateAndSendIfNeeded_AnsweredImmediately(t *testing.T) {
+	t.Parallel()
+	srv := &mockProcessServer{}
+
+	reqCtx := &RequestContext{
+		RequestState:      RequestAnsweredImmediately,
+		IncomingModelName: "food-review",
+		TargetModelName:   "food-review-1",
+		ImmediateResponse: &ImmediateResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]string{"content-type": "application/json"},
+			Body:       []byte(`{"model":"food-review-1"}`),
+		},
+	}
+
+	err := reqCtx.updateStateAndSendIfNeeded(srv, logr.Discard())
+	require.NoError(t, err)
+
+	require.Len(t, srv.sentResponses, 1, "Should send exactly one response")
+	ir := srv.sentResponses[0].GetImmediateResponse()
+	require.NotNil(t, ir, "Response should be an ImmediateResponse")
+	assert.Equal(t, envoyTypePb.StatusCode_OK, ir.Status.Code)
+	assert.JSONEq(t, `{"model":"food-review"}`, string(ir.Body), "model name should be rewritten back to the client-facing name")
+	require.NotNil(t, ir.Headers)
+	require.Len(t, ir.Headers.SetHeaders, 1)
+	assert.Equal(t, "content-type", ir.Headers.SetHeaders[0].Header.Key)
+	assert.Equal(t, "application/json", string(ir.Headers.SetHeaders[0].Header.RawValue))
+}
+
 func TestUpdateStateAndSendIfNeeded_NotEvicted(t *testing.T) {
 	t.Parallel()
 	srv := &mockProcessServer{}
@@ -50,6 +50,10 @@ const (
 	TPOTSLOHeaderKey = "x-llm-d-slo-tpot-ms"
 	// OldTPOTSLOHeaderKey is the deprecated alias for TPOTSLOHeaderKey.
 	OldTPOTSLOHeaderKey = "x-slo-tpot-ms"
+	// SpilloverKey is the response header key set to the reason a request was spilled over to a secondary endpoint.
+	SpilloverKey = "x-llm-d-spillover"
+	// ResponseCacheKey is the response header key set to "hit" when a request is answered from the response cache.
+	ResponseCacheKey = "x-llm-d-response-cache"
 
 	// DefaultFairnessID is the default fairness ID used when no ID is provided in the request.
 	// This ensures that requests without explicit fairness identifiers are still grouped and managed by the Flow Control
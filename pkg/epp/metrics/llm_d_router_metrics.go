@@ -202,6 +202,15 @@ var (
 		},
 		poolLabels,
 	)
+
+	llmdEndpointMetricsAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "endpoint_metrics_age_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Time since the metrics of an endpoint were last updated.", compbasemetrics.ALPHA),
+		},
+		append([]string{"inference_pool"}, llmdEndpointLabels...),
+	)
 )
 
 // --- llm-d Scheduling Metrics ---
@@ -314,6 +323,86 @@ var (
 		},
 		[]string{"inference_pool"},
 	)
+
+	llmdFlowControlAgedDispatchesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "flow_control_aged_dispatches_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of requests dispatched ahead of a higher priority band because of priority aging.", compbasemetrics.ALPHA),
+		},
+		[]string{"inference_pool", "priority"},
+	)
+
+	llmdFlowControlDetectorSaturation = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "flow_control_detector_saturation",
+			Help:      metricsutil.HelpMsgWithStability("Current saturation level reported by an individual saturation detector (0.0 = empty, 1.0 = fully saturated).", compbasemetrics.ALPHA),
+		},
+		[]string{"detector_type", "detector_name"},
+	)
+)
+
+// --- llm-d Scale-from-zero Metrics ---
+var (
+	llmdScaleFromZeroHeldRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "scale_from_zero_held_requests",
+			Help:      metricsutil.HelpMsgWithStability("Current number of requests held while the inference pool has no ready endpoints.", compbasemetrics.ALPHA),
+		},
+		append([]string{"inference_pool"}, modelLabels...),
+	)
+
+	llmdScaleFromZeroWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "scale_from_zero_wait_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Distribution of time requests were held waiting for the inference pool to gain a ready endpoint.", compbasemetrics.ALPHA),
+			Buckets:   generalLatencyBuckets,
+		},
+		append(append([]string{"inference_pool"}, modelLabels...), "outcome"),
+	)
+
+	llmdScaleFromZeroActivationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "scale_from_zero_activations_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of activation calls sent for held demand, by result.", compbasemetrics.ALPHA),
+		},
+		append(append([]string{"inference_pool"}, modelLabels...), "result"),
+	)
+)
+
+// --- llm-d Retry Metrics ---
+var (
+	llmdRetryReschedulesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "retry_reschedules_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of proxy retries rescheduled away from the endpoints already tried.", compbasemetrics.ALPHA),
+		},
+		modelLabels,
+	)
+
+	llmdRetryBudgetExhaustedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: LLMDRouterEndpointPickerSubsystem,
+			Name:      "retry_budget_exhausted_total",
+			Help:      metricsutil.HelpMsgWithStability("Total number of proxy retries rejected because the request exhausted its retry budget.", compbasemetrics.ALPHA),
+		},
+		modelLabels,
+	)
+)
+
+// --- llm-d Spillover Metrics ---
+var llmdSpilloverDecisionsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: LLMDRouterEndpointPickerSubsystem,
+		Name:      "spillover_decisions_total",
+		Help:      metricsutil.HelpMsgWithStability("Total number of requests spilled over to secondary endpoints, by reason.", compbasemetrics.ALPHA),
+	},
+	append(append([]string{}, modelLabels...), "reason"),
 )
 
 // --- llm-d Inference Model Rewrite Metrics ---
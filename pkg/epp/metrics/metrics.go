@@ -448,6 +448,7 @@ func Register(customCollectors ...prometheus.Collector) {
 		metrics.Registry.MustRegister(llmdInferencePoolAvgRunningRequests)
 		metrics.Registry.MustRegister(inferencePoolReadyPods)
 		metrics.Registry.MustRegister(llmdInferencePoolReadyEndpoints)
+		metrics.Registry.MustRegister(llmdEndpointMetricsAge)
 		metrics.Registry.MustRegister(schedulerE2ELatency)
 		metrics.Registry.MustRegister(llmdSchedulerE2ELatency)
 		metrics.Registry.MustRegister(schedulerAttemptsTotal)
@@ -466,8 +467,16 @@ func Register(customCollectors ...prometheus.Collector) {
 		metrics.Registry.MustRegister(llmdFlowControlQueueBytes)
 		metrics.Registry.MustRegister(flowControlPoolSaturation)
 		metrics.Registry.MustRegister(llmdFlowControlPoolSaturation)
+		metrics.Registry.MustRegister(llmdFlowControlAgedDispatchesTotal)
+		metrics.Registry.MustRegister(llmdFlowControlDetectorSaturation)
 		metrics.Registry.MustRegister(flowControlRequestEnqueueDuration)
 		metrics.Registry.MustRegister(llmdFlowControlRequestEnqueueDuration)
+		metrics.Registry.MustRegister(llmdScaleFromZeroHeldRequests)
+		metrics.Registry.MustRegister(llmdScaleFromZeroWaitDuration)
+		metrics.Registry.MustRegister(llmdScaleFromZeroActivationsTotal)
+		metrics.Registry.MustRegister(llmdRetryReschedulesTotal)
+		metrics.Registry.MustRegister(llmdRetryBudgetExhaustedTotal)
+		metrics.Registry.MustRegister(llmdSpilloverDecisionsTotal)
 		metrics.Registry.MustRegister(inferenceModelRewriteDecisionsTotal)
 		metrics.Registry.MustRegister(llmdInferenceModelRewriteDecisionsTotal)
 		metrics.Registry.MustRegister(DataLayerPollErrorsTotal)
@@ -513,6 +522,7 @@ func Reset() {
 	llmdInferencePoolAvgRunningRequests.Reset()
 	inferencePoolReadyPods.Reset()
 	llmdInferencePoolReadyEndpoints.Reset()
+	llmdEndpointMetricsAge.Reset()
 	schedulerE2ELatency.Reset()
 	llmdSchedulerE2ELatency.Reset()
 	schedulerAttemptsTotal.Reset()
@@ -529,8 +539,16 @@ func Reset() {
 	llmdFlowControlQueueBytes.Reset()
 	flowControlPoolSaturation.Reset()
 	llmdFlowControlPoolSaturation.Reset()
+	llmdFlowControlAgedDispatchesTotal.Reset()
+	llmdFlowControlDetectorSaturation.Reset()
 	flowControlRequestEnqueueDuration.Reset()
 	llmdFlowControlRequestEnqueueDuration.Reset()
+	llmdScaleFromZeroHeldRequests.Reset()
+	llmdScaleFromZeroWaitDuration.Reset()
+	llmdScaleFromZeroActivationsTotal.Reset()
+	llmdRetryReschedulesTotal.Reset()
+	llmdRetryBudgetExhaustedTotal.Reset()
+	llmdSpilloverDecisionsTotal.Reset()
 	inferenceModelRewriteDecisionsTotal.Reset()
 	llmdInferenceModelRewriteDecisionsTotal.Reset()
 	DataLayerPollErrorsTotal.Reset()
@@ -694,6 +712,16 @@ func RecordInferencePoolReadyPods(name string, runningPods float64) {
 	llmdInferencePoolReadyEndpoints.WithLabelValues(name).Set(runningPods)
 }
 
+// RecordEndpointMetricsAge records how long ago the metrics of an endpoint were last updated.
+func RecordEndpointMetricsAge(inferencePool, endpointName, namespace, port string, age time.Duration) {
+	llmdEndpointMetricsAge.WithLabelValues(inferencePool, endpointName, namespace, port).Set(age.Seconds())
+}
+
+// DeleteEndpointMetricsAge removes the metrics age series of an endpoint that left the pool.
+func DeleteEndpointMetricsAge(inferencePool, endpointName, namespace, port string) {
+	llmdEndpointMetricsAge.DeleteLabelValues(inferencePool, endpointName, namespace, port)
+}
+
 // RecordSchedulerE2ELatency records the end-to-end scheduling latency.
 func RecordSchedulerE2ELatency(duration time.Duration) {
 	schedulerE2ELatency.WithLabelValues().Observe(duration.Seconds())
@@ -814,6 +842,53 @@ func RecordFlowControlPoolSaturation(inferencePool string, saturation float64) {
 	llmdFlowControlPoolSaturation.WithLabelValues(inferencePool).Set(saturation)
 }
 
+// RecordFlowControlDetectorSaturation records the saturation level reported by an individual saturation detector,
+// e.g. a component of a composite detector.
+func RecordFlowControlDetectorSaturation(detectorType, detectorName string, saturation float64) {
+	llmdFlowControlDetectorSaturation.WithLabelValues(detectorType, detectorName).Set(saturation)
+}
+
+// IncFlowControlAgedDispatches increments the counter of requests of the priority band dispatched ahead of a higher
+// priority band because of priority aging.
+func IncFlowControlAgedDispatches(inferencePool, priority string) {
+	llmdFlowControlAgedDispatchesTotal.WithLabelValues(inferencePool, priority).Inc()
+}
+
+// IncScaleFromZeroHeldRequests increments the gauge of requests held while the pool has no ready endpoints.
+func IncScaleFromZeroHeldRequests(inferencePool, modelName, targetModelName string) {
+	llmdScaleFromZeroHeldRequests.WithLabelValues(inferencePool, modelName, targetModelName).Inc()
+}
+
+// DecScaleFromZeroHeldRequests decrements the gauge of requests held while the pool has no ready endpoints.
+func DecScaleFromZeroHeldRequests(inferencePool, modelName, targetModelName string) {
+	llmdScaleFromZeroHeldRequests.WithLabelValues(inferencePool, modelName, targetModelName).Dec()
+}
+
+// RecordScaleFromZeroWaitDuration records how long a request was held waiting for a ready endpoint.
+func RecordScaleFromZeroWaitDuration(inferencePool, modelName, targetModelName, outcome string, duration time.Duration) {
+	llmdScaleFromZeroWaitDuration.WithLabelValues(inferencePool, modelName, targetModelName, outcome).Observe(duration.Seconds())
+}
+
+// RecordScaleFromZeroActivation records the result of an activation call for held demand.
+func RecordScaleFromZeroActivation(inferencePool, modelName, targetModelName, result string) {
+	llmdScaleFromZeroActivationsTotal.WithLabelValues(inferencePool, modelName, targetModelName, result).Inc()
+}
+
+// RecordRetryReschedule records a proxy retry rescheduled away from the endpoints already tried.
+func RecordRetryReschedule(modelName, targetModelName string) {
+	llmdRetryReschedulesTotal.WithLabelValues(modelName, targetModelName).Inc()
+}
+
+// RecordRetryBudgetExhausted records a proxy retry rejected because the request exhausted its retry budget.
+func RecordRetryBudgetExhausted(modelName, targetModelName string) {
+	llmdRetryBudgetExhaustedTotal.WithLabelValues(modelName, targetModelName).Inc()
+}
+
+// RecordSpilloverDecision records a request spilled over to secondary endpoints.
+func RecordSpilloverDecision(modelName, targetModelName, reason string) {
+	llmdSpilloverDecisionsTotal.WithLabelValues(modelName, targetModelName, reason).Inc()
+}
+
 // RecordInferenceModelRewriteDecision records the routing decision for InferenceModelRewrite.
 func RecordInferenceModelRewriteDecision(modelRewriteName, modelName, targetModel string) {
 	inferenceModelRewriteDecisionsTotal.WithLabelValues(modelRewriteName, modelName, targetModel).Inc()
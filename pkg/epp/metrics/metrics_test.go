@@ -1243,6 +1243,44 @@ func TestFlowControlPoolSaturationMetric(t *testing.T) {
 	require.Equal(t, 0.5, valNew)
 }
 
+func TestFlowControlDetectorSaturationMetric(t *testing.T) {
+	Reset()
+
+	RecordFlowControlDetectorSaturation("latency-saturation-detector", "ttft", 0.75)
+	val, err := testutil.GetGaugeMetricValue(llmdFlowControlDetectorSaturation.WithLabelValues("latency-saturation-detector", "ttft"))
+	require.NoError(t, err)
+	require.Equal(t, 0.75, val)
+}
+
+func TestScaleFromZeroMetrics(t *testing.T) {
+	Reset()
+
+	const (
+		pool   = "pool-1"
+		model  = "qwen-3"
+		target = "qwen-3-base"
+	)
+
+	IncScaleFromZeroHeldRequests(pool, model, target)
+	IncScaleFromZeroHeldRequests(pool, model, target)
+	val, err := testutil.GetGaugeMetricValue(llmdScaleFromZeroHeldRequests.WithLabelValues(pool, model, target))
+	require.NoError(t, err)
+	require.Equal(t, 2.0, val)
+
+	DecScaleFromZeroHeldRequests(pool, model, target)
+	val, err = testutil.GetGaugeMetricValue(llmdScaleFromZeroHeldRequests.WithLabelValues(pool, model, target))
+	require.NoError(t, err)
+	require.Equal(t, 1.0, val)
+
+	RecordScaleFromZeroWaitDuration(pool, model, target, "released", 3*time.Second)
+	count, err := testutil.GetHistogramMetricCount(llmdScaleFromZeroWaitDuration.WithLabelValues(pool, model, target, "released"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), count)
+
+	RecordScaleFromZeroActivation(pool, model, target, "success")
+	require.Equal(t, 1.0, promtestutil.ToFloat64(llmdScaleFromZeroActivationsTotal.WithLabelValues(pool, model, target, "success")))
+}
+
 func TestRecordRequestTTFT(t *testing.T) {
 	Reset()
 	ctx := logutil.NewTestLoggerIntoContext(context.Background())
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -209,6 +210,11 @@ type Director struct {
 	// streaming response path. The request context key avoids coupling independent streams that reuse the same
 	// x-request-id header.
 	responseBodyQueues sync.Map
+
+	// retryTracker, when set, reschedules requests retried by the proxy away from the endpoints they already tried.
+	retryTracker *RetryTracker
+	// spillover, when set, routes requests the pool cannot serve to secondary endpoints.
+	spillover *Spillover
 }
 
 // getInferenceObjective fetches the inferenceObjective from the datastore otherwise creates a new one based on reqCtx.
@@ -276,18 +282,50 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 		reqCtx.SchedulingRequest.FairnessID = metadata.DefaultFairnessID
 	}
 
-	// Admit may block until flow control admits the request.
+	if cached := d.runResponseCachePlugins(ctx, reqCtx.SchedulingRequest); cached != nil {
+		logger.V(logutil.DEBUG).Info("Answering request from the response cache")
+		reqCtx.ImmediateResponse = &handlers.ImmediateResponse{
+			StatusCode: http.StatusOK,
+			Headers:    cached.Headers,
+			Body:       cached.Body,
+		}
+		return reqCtx, nil
+	}
+
+	// The retry budget is checked before admission, which may block until flow control admits the request.
+	tried, err := d.reserveReschedule(reqCtx)
+	if err != nil {
+		return reqCtx, err
+	}
+	endpointCandidates := d.endpointCandidates.Locate(ctx, reqCtx.Request.Metadata)
+	if d.primarySaturated(ctx, reqCtx, endpointCandidates) {
+		spilled, err := d.spillOver(ctx, reqCtx, SpilloverReasonSaturated, tried)
+		if err != nil {
+			return reqCtx, err
+		}
+		if spilled {
+			return reqCtx, d.repackage(ctx, reqCtx, inferenceRequestBody)
+		}
+	}
+
 	if err := d.admissionController.Admit(ctx, reqCtx, priority); err != nil {
+		if ctx.Err() == nil && isShedError(err) {
+			return d.spillOverOr(ctx, reqCtx, inferenceRequestBody, SpilloverReasonRejected, tried, err)
+		}
 		return reqCtx, err
 	}
 
-	endpointCandidates := d.endpointCandidates.Locate(ctx, reqCtx.Request.Metadata)
 	if len(endpointCandidates) == 0 {
-		return reqCtx, errcommon.Error{
+		// Admission may have held the request until the pool scaled up from zero.
+		endpointCandidates = d.endpointCandidates.Locate(ctx, reqCtx.Request.Metadata)
+	}
+	if len(endpointCandidates) == 0 {
+		return d.spillOverOr(ctx, reqCtx, inferenceRequestBody, SpilloverReasonNoEndpoints, tried, errcommon.Error{
 			Code: errcommon.ServiceUnavailable,
 			Msg:  "failed to find endpoint candidates for serving the request",
-		}
+		})
 	}
+	endpointCandidates = d.excludeTriedEndpoints(ctx, endpointCandidates, tried)
 
 	snapshotOfCandidatePods := d.toSchedulerEndpoints(endpointCandidates)
 	// Prepare per request data by running DataProducer plugins.
@@ -313,7 +351,8 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 		if errors.As(err, &e) {
 			return reqCtx, e
 		}
-		return reqCtx, errcommon.Error{Code: errcommon.ResourceExhausted, Msg: fmt.Errorf("failed to find target endpoint: %w", err).Error()}
+		return d.spillOverOr(ctx, reqCtx, inferenceRequestBody, SpilloverReasonNoEndpoints, tried,
+			errcommon.Error{Code: errcommon.ResourceExhausted, Msg: fmt.Errorf("failed to find target endpoint: %w", err).Error()})
 	}
 
 	// Conditional-decode gate (RFC 7240 "Prefer: if-available"). The coordinator
@@ -463,6 +502,7 @@ func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestC
 
 	reqCtx.TargetPod = targetMetadatas[0]
 	reqCtx.TargetEndpoint = multiEndpointString
+	d.recordAttempt(reqCtx, targetEndpoints)
 
 	d.runPreRequestPlugins(ctx, reqCtx.SchedulingRequest, result)
 
@@ -519,6 +559,9 @@ func (d *Director) HandleResponseBody(ctx context.Context, reqCtx *handlers.Requ
 		EndOfStream:   endOfStream,
 		Usage:         reqCtx.Usage,
 	}
+	if endOfStream {
+		response.Body = reqCtx.Response.Body
+	}
 	requestID := reqCtx.Request.Headers[reqcommon.RequestIDHeaderKey]
 
 	if endOfStream {
@@ -600,6 +643,22 @@ func (d *Director) runPreAdmissionPlugins(ctx context.Context, request *fwksched
 	return nil
 }
 
+// runResponseCachePlugins returns the response of the first ResponseCache plugin holding one for the request.
+func (d *Director) runResponseCachePlugins(ctx context.Context, request *fwksched.InferenceRequest) *fwkrc.CachedResponse {
+	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
+	for _, plugin := range d.requestControlPlugins.responseCachePlugins {
+		loggerDebug.Info("Running ResponseCache plugin", "plugin", plugin.TypedName())
+		before := time.Now()
+		cached := plugin.Lookup(ctx, request)
+		metrics.RecordPluginProcessingLatency(fwkrc.ResponseCacheExtensionPoint, plugin.TypedName().Type, plugin.TypedName().Name, time.Since(before))
+		if cached != nil {
+			loggerDebug.Info("ResponseCache plugin returned a cached response", "plugin", plugin.TypedName())
+			return cached
+		}
+	}
+	return nil
+}
+
 func (d *Director) runDataProducerPlugins(ctx context.Context,
 	request *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) error {
 	plugins := d.requestControlPlugins.dataProducerPlugins
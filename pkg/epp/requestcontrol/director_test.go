@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"sort"
 	"sync"
 	"testing"
@@ -47,6 +48,7 @@ import (
 	reqcommon "github.com/llm-d/llm-d-router/pkg/common/request"
 	"github.com/llm-d/llm-d-router/pkg/epp/datalayer"
 	"github.com/llm-d/llm-d-router/pkg/epp/datastore"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts/mocks"
 	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
 	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
 	fwkrc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requestcontrol"
@@ -1341,6 +1343,7 @@ func TestDirector_HandleResponseBody(t *testing.T) {
 		},
 		Response: &handlers.Response{
 			Headers: map[string]string{"X-Test-Streaming-Header": "StreamValue"},
+			Body:    []byte("complete-body"),
 		},
 		TargetPod: &fwkdl.EndpointMetadata{NamespacedName: types.NamespacedName{Namespace: "namespace1", Name: "test-pod-name"}},
 	}
@@ -1373,8 +1376,10 @@ func TestDirector_HandleResponseBody(t *testing.T) {
 		assert.Equal(t, "namespace1/test-pod-name", targetPods[i])
 		if i < 2 {
 			assert.False(t, resp.EndOfStream, "EndOfStream should be false for chunk %d", i)
+			assert.Nil(t, resp.Body, "Body should only be set for the last chunk")
 		} else {
 			assert.True(t, resp.EndOfStream, "EndOfStream should be true for last chunk")
+			assert.Equal(t, []byte("complete-body"), resp.Body)
 		}
 	}
 }
@@ -1847,3 +1852,63 @@ func TestDirector_HandleRequest_ConditionalDecode(t *testing.T) {
 		})
 	}
 }
+
+// mockResponseCache answers requests whose prompt is "cached".
+type mockResponseCache struct {
+	lookups int
+}
+
+func (m *mockResponseCache) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "mock-response-cache", Name: "mock-response-cache"}
+}
+
+func (m *mockResponseCache) Lookup(_ context.Context, request *fwksched.InferenceRequest) *fwkrc.CachedResponse {
+	m.lookups++
+	if request.Body.Completions.Prompt.PlainText() != "cached" {
+		return nil
+	}
+	return &fwkrc.CachedResponse{
+		Headers: map[string]string{"content-type": "application/json"},
+		Body:    []byte(`{"choices":[]}`),
+	}
+}
+
+func TestDirector_HandleRequest_ResponseCache(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	sched := &recordingScheduler{}
+	candidates := &mocks.MockEndpointCandidates{Candidates: []fwkdl.Endpoint{newRetryTestEndpoint("10.0.0.1")}}
+	cache := &mockResponseCache{}
+	director := NewDirectorWithConfig(&mockDatastore{}, sched, &mockAdmissionController{}, candidates,
+		NewConfig().WithResponseCachePlugins(cache))
+
+	handle := func(prompt string) *handlers.RequestContext {
+		reqCtx := &handlers.RequestContext{
+			Request: &handlers.Request{
+				Headers: map[string]string{
+					reqcommon.RequestIDHeaderKey: "req-" + prompt,
+					":path":                      "/v1/completions",
+				},
+				RawBody: []byte(`{"model":"m","prompt":"` + prompt + `"}`),
+			},
+		}
+		parseResult, err := openai.NewOpenAIParser().ParseRequest(ctx, reqCtx.Request.RawBody, reqCtx.Request.Headers)
+		require.NoError(t, err)
+		reqCtx, err = director.HandleRequest(ctx, reqCtx, parseResult.Body)
+		require.NoError(t, err)
+		return reqCtx
+	}
+
+	reqCtx := handle("cached")
+	require.NotNil(t, reqCtx.ImmediateResponse, "a cache hit should answer the request immediately")
+	assert.Equal(t, http.StatusOK, reqCtx.ImmediateResponse.StatusCode)
+	assert.Equal(t, []byte(`{"choices":[]}`), reqCtx.ImmediateResponse.Body)
+	assert.Equal(t, "application/json", reqCtx.ImmediateResponse.Headers["content-type"])
+	assert.Empty(t, reqCtx.TargetEndpoint)
+	assert.Empty(t, sched.calls, "a cache hit should not be scheduled")
+
+	reqCtx = handle("fresh")
+	assert.Nil(t, reqCtx.ImmediateResponse)
+	assert.Equal(t, "10.0.0.1:8000", reqCtx.TargetEndpoint)
+	assert.Len(t, sched.calls, 1)
+	assert.Equal(t, 2, cache.lookups)
+}
@@ -30,6 +30,7 @@ func NewConfig() *Config {
 		preRequestPlugins:        []fwkrc.PreRequest{},
 		responseReceivedPlugins:  []fwkrc.ResponseHeaderProcessor{},
 		responseStreamingPlugins: []fwkrc.ResponseBodyProcessor{},
+		responseCachePlugins:     []fwkrc.ResponseCache{},
 	}
 }
 
@@ -41,6 +42,7 @@ type Config struct {
 	preRequestPlugins        []fwkrc.PreRequest
 	responseReceivedPlugins  []fwkrc.ResponseHeaderProcessor
 	responseStreamingPlugins []fwkrc.ResponseBodyProcessor
+	responseCachePlugins     []fwkrc.ResponseCache
 }
 
 // WithPreAdmissionPlugins sets the given plugins as the PreAdmitter plugins.
@@ -70,6 +72,12 @@ func (c *Config) WithResponseStreamingPlugins(plugins ...fwkrc.ResponseBodyProce
 	return c
 }
 
+// WithResponseCachePlugins sets the given plugins as the ResponseCache plugins.
+func (c *Config) WithResponseCachePlugins(plugins ...fwkrc.ResponseCache) *Config {
+	c.responseCachePlugins = plugins
+	return c
+}
+
 // WithDataProducerPlugins sets the given plugins as the DataProducer plugins.
 func (c *Config) WithDataProducerPlugins(plugins ...fwkrc.DataProducer) *Config {
 	c.dataProducerPlugins = plugins
@@ -99,6 +107,9 @@ func (c *Config) AddPlugins(pluginObjects ...plugin.Plugin) {
 		if responseStreamingPlugin, ok := plugin.(fwkrc.ResponseBodyProcessor); ok {
 			c.responseStreamingPlugins = append(c.responseStreamingPlugins, responseStreamingPlugin)
 		}
+		if responseCachePlugin, ok := plugin.(fwkrc.ResponseCache); ok {
+			c.responseCachePlugins = append(c.responseCachePlugins, responseCachePlugin)
+		}
 		if dataProducerPlugin, ok := plugin.(fwkrc.DataProducer); ok {
 			c.dataProducerPlugins = append(c.dataProducerPlugins, dataProducerPlugin)
 		}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	reqcommon "github.com/llm-d/llm-d-router/pkg/common/request"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+// retryRecord holds the endpoints already tried for a request.
+type retryRecord struct {
+	tried       sets.Set[string]
+	reschedules int
+	expiresAt   time.Time
+}
+
+// RetryTracker keeps a short-lived record of the endpoints tried by each request, keyed by request ID, so that a
+// request retried by the proxy is rescheduled away from the endpoints that already failed it.
+type RetryTracker struct {
+	budget int
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	records   map[string]*retryRecord
+	nextSweep time.Time
+}
+
+// NewRetryTracker creates a RetryTracker allowing at most budget reschedules per request. Records expire ttl after the
+// last attempt of the request.
+func NewRetryTracker(budget int, ttl time.Duration) *RetryTracker {
+	return &RetryTracker{
+		budget:  budget,
+		ttl:     ttl,
+		now:     time.Now,
+		records: make(map[string]*retryRecord),
+	}
+}
+
+// reschedule consumes one reschedule of the request's budget and returns the endpoints it already tried. found is
+// false if the request has no record. exhausted is true if the request has no reschedule left.
+func (t *RetryTracker) reschedule(requestID string) (tried sets.Set[string], found, exhausted bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.records[requestID]
+	if !ok || t.now().After(record.expiresAt) {
+		return nil, false, false
+	}
+	if record.reschedules >= t.budget {
+		return nil, true, true
+	}
+	record.reschedules++
+	return record.tried.Clone(), true, false
+}
+
+// excludeTried removes the tried endpoints from candidates. If every candidate was already tried, candidates are
+// returned unchanged so that the retry still has somewhere to go.
+func excludeTried(candidates []fwkdl.Endpoint, tried sets.Set[string]) []fwkdl.Endpoint {
+	remaining := make([]fwkdl.Endpoint, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !tried.Has(endpointAddress(candidate.GetMetadata())) {
+			remaining = append(remaining, candidate)
+		}
+	}
+	if len(remaining) == 0 {
+		return candidates
+	}
+	return remaining
+}
+
+// record adds the endpoints picked for an attempt of the request to its record.
+func (t *RetryTracker) record(requestID string, endpoints ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	if now.After(t.nextSweep) {
+		for id, record := range t.records {
+			if now.After(record.expiresAt) {
+				delete(t.records, id)
+			}
+		}
+		t.nextSweep = now.Add(t.ttl)
+	}
+
+	record, ok := t.records[requestID]
+	if !ok {
+		record = &retryRecord{tried: sets.New[string]()}
+		t.records[requestID] = record
+	}
+	record.tried.Insert(endpoints...)
+	record.expiresAt = now.Add(t.ttl)
+}
+
+// endpointAddress returns the host:port an endpoint is addressed by in the routing decision.
+func endpointAddress(endpoint *fwkdl.EndpointMetadata) string {
+	return net.JoinHostPort(endpoint.GetIPAddress(), endpoint.GetPort())
+}
+
+// retryAttempt returns the attempt number set by the proxy on the request, or 0 if it is absent or invalid.
+func retryAttempt(headers map[string]string) int {
+	attempt, err := strconv.Atoi(headers[reqcommon.AttemptCountHeaderKey])
+	if err != nil {
+		return 0
+	}
+	return attempt
+}
+
+// SetRetryTracker enables retry-aware rescheduling: requests carrying an attempt count are tracked, and their retries
+// are scheduled away from the endpoints already tried.
+func (d *Director) SetRetryTracker(tracker *RetryTracker) {
+	d.retryTracker = tracker
+}
+
+// reserveReschedule consumes a reschedule of a retried request and returns the endpoints it already tried. It runs
+// before admission so that a request with no reschedule left is rejected without waiting in flow control.
+func (d *Director) reserveReschedule(reqCtx *handlers.RequestContext) (sets.Set[string], error) {
+	if d.retryTracker == nil || retryAttempt(reqCtx.Request.Headers) <= 1 {
+		return nil, nil
+	}
+	tried, found, exhausted := d.retryTracker.reschedule(reqCtx.Request.Headers[reqcommon.RequestIDHeaderKey])
+	if !found {
+		return nil, nil
+	}
+	if exhausted {
+		metrics.RecordRetryBudgetExhausted(reqCtx.IncomingModelName, reqCtx.TargetModelName)
+		return nil, errcommon.Error{Code: errcommon.ServiceUnavailable, Msg: "retry budget exhausted for the request"}
+	}
+	metrics.RecordRetryReschedule(reqCtx.IncomingModelName, reqCtx.TargetModelName)
+	return tried, nil
+}
+
+// excludeTriedEndpoints filters the endpoints already tried out of the candidates of a retried request.
+func (d *Director) excludeTriedEndpoints(ctx context.Context, candidates []fwkdl.Endpoint, tried sets.Set[string]) []fwkdl.Endpoint {
+	if len(tried) == 0 {
+		return candidates
+	}
+	remaining := excludeTried(candidates, tried)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Rescheduling retried request away from tried endpoints",
+		"candidates", len(candidates), "remaining", len(remaining))
+	return remaining
+}
+
+// recordAttempt remembers the endpoints picked for a request carrying an attempt count, in case it is retried.
+func (d *Director) recordAttempt(reqCtx *handlers.RequestContext, endpoints []string) {
+	if d.retryTracker == nil || retryAttempt(reqCtx.Request.Headers) == 0 {
+		return
+	}
+	d.retryTracker.record(reqCtx.Request.Headers[reqcommon.RequestIDHeaderKey], endpoints...)
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	reqcommon "github.com/llm-d/llm-d-router/pkg/common/request"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts/mocks"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/openai"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+)
+
+func newRetryTestEndpoint(address string) fwkdl.Endpoint {
+	return fwkdl.NewEndpoint(&fwkdl.EndpointMetadata{Address: address, Port: "8000"}, nil)
+}
+
+func endpointAddresses(endpoints []fwkdl.Endpoint) []string {
+	addresses := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addresses = append(addresses, endpoint.GetMetadata().GetIPAddress())
+	}
+	return addresses
+}
+
+func TestRetryTracker_Reschedule(t *testing.T) {
+	candidates := []fwkdl.Endpoint{newRetryTestEndpoint("10.0.0.1"), newRetryTestEndpoint("10.0.0.2"), newRetryTestEndpoint("10.0.0.3")}
+
+	tests := []struct {
+		name          string
+		tried         []string
+		budget        int
+		wantFound     bool
+		wantExhausted bool
+		wantRemaining []string
+	}{
+		{
+			name:          "unknown request keeps all candidates",
+			budget:        1,
+			wantRemaining: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name:          "tried endpoints are excluded",
+			tried:         []string{"10.0.0.1:8000", "10.0.0.3:8000"},
+			budget:        1,
+			wantFound:     true,
+			wantRemaining: []string{"10.0.0.2"},
+		},
+		{
+			name:          "all candidates tried falls back to all candidates",
+			tried:         []string{"10.0.0.1:8000", "10.0.0.2:8000", "10.0.0.3:8000"},
+			budget:        1,
+			wantFound:     true,
+			wantRemaining: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name:          "no budget left",
+			tried:         []string{"10.0.0.1:8000"},
+			budget:        0,
+			wantFound:     true,
+			wantExhausted: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewRetryTracker(test.budget, time.Minute)
+			if len(test.tried) > 0 {
+				tracker.record("req", test.tried...)
+			}
+			tried, found, exhausted := tracker.reschedule("req")
+			assert.Equal(t, test.wantFound, found)
+			assert.Equal(t, test.wantExhausted, exhausted)
+			if !exhausted {
+				assert.Equal(t, test.wantRemaining, endpointAddresses(excludeTried(candidates, tried)))
+			}
+		})
+	}
+}
+
+func TestRetryTracker_BudgetAndExpiry(t *testing.T) {
+	now := time.Now()
+	tracker := NewRetryTracker(2, time.Minute)
+	tracker.now = func() time.Time { return now }
+
+	tracker.record("req", "10.0.0.1:8000")
+	for range 2 {
+		_, found, exhausted := tracker.reschedule("req")
+		require.True(t, found)
+		require.False(t, exhausted)
+	}
+	_, _, exhausted := tracker.reschedule("req")
+	assert.True(t, exhausted, "the third reschedule must exceed a budget of 2")
+
+	now = now.Add(2 * time.Minute)
+	tried, found, _ := tracker.reschedule("req")
+	assert.False(t, found, "expired records must be ignored")
+	assert.Empty(t, tried)
+
+	tracker.record("other", "10.0.0.2:8000")
+	assert.NotContains(t, tracker.records, "req", "expired records must be pruned")
+}
+
+// recordingScheduler picks the first endpoint it is given and remembers the endpoints of each call.
+type recordingScheduler struct {
+	calls [][]string
+}
+
+func (s *recordingScheduler) Schedule(_ context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) (*fwksched.SchedulingResult, error) {
+	addresses := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		addresses = append(addresses, endpoint.GetMetadata().GetIPAddress())
+	}
+	s.calls = append(s.calls, addresses)
+	return &fwksched.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults: map[string]*fwksched.ProfileRunResult{
+			"default": {TargetEndpoints: endpoints[:1]},
+		},
+	}, nil
+}
+
+func TestDirector_HandleRequest_RetryRescheduling(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	sched := &recordingScheduler{}
+	candidates := &mocks.MockEndpointCandidates{Candidates: []fwkdl.Endpoint{
+		newRetryTestEndpoint("10.0.0.1"), newRetryTestEndpoint("10.0.0.2"), newRetryTestEndpoint("10.0.0.3"),
+	}}
+	admission := &mockAdmissionController{}
+	director := NewDirectorWithConfig(&mockDatastore{}, sched, admission, candidates, NewConfig())
+	director.SetRetryTracker(NewRetryTracker(2, time.Minute))
+
+	handle := func(requestID string, attempt int) (*handlers.RequestContext, error) {
+		reqCtx := &handlers.RequestContext{
+			Request: &handlers.Request{
+				Headers: map[string]string{
+					reqcommon.RequestIDHeaderKey:    requestID,
+					reqcommon.AttemptCountHeaderKey: strconv.Itoa(attempt),
+					":path":                         "/v1/completions",
+				},
+				RawBody: []byte(`{"model":"m","prompt":"p"}`),
+			},
+		}
+		parseResult, err := openai.NewOpenAIParser().ParseRequest(ctx, reqCtx.Request.RawBody, reqCtx.Request.Headers)
+		require.NoError(t, err)
+		return director.HandleRequest(ctx, reqCtx, parseResult.Body)
+	}
+
+	reqCtx, err := handle("req", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8000", reqCtx.TargetEndpoint)
+
+	reqCtx, err = handle("req", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2:8000", reqCtx.TargetEndpoint)
+
+	reqCtx, err = handle("req", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.3:8000", reqCtx.TargetEndpoint)
+
+	_, err = handle("req", 4)
+	var e errcommon.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, errcommon.ServiceUnavailable, e.Code)
+
+	// A request whose budget is exhausted is rejected before admission.
+	admission.admitErr = errcommon.Error{Code: errcommon.ResourceExhausted, Msg: "shed"}
+	_, err = handle("req", 5)
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, errcommon.ServiceUnavailable, e.Code)
+	admission.admitErr = nil
+
+	// Other requests are not affected by the tries of the first one.
+	reqCtx, err = handle("other", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8000", reqCtx.TargetEndpoint)
+
+	assert.Equal(t, [][]string{
+		{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		{"10.0.0.2", "10.0.0.3"},
+		{"10.0.0.3"},
+		{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+	}, sched.calls)
+}
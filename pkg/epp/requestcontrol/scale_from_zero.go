@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+const (
+	// activationResendInterval is how often the activation URL is called again for a model whose requests remain
+	// held, in case an earlier call was lost or the autoscaler needs a reminder.
+	activationResendInterval = 10 * time.Second
+	// activationTimeout bounds a single call to the activation URL.
+	activationTimeout = 5 * time.Second
+
+	// Outcomes recorded for held requests.
+	scaleFromZeroOutcomeReleased  = "released"
+	scaleFromZeroOutcomeTimeout   = "timeout"
+	scaleFromZeroOutcomeCancelled = "cancelled"
+	scaleFromZeroOutcomeRejected  = "rejected"
+)
+
+// heldModel identifies the demand of a model in the held request bookkeeping.
+type heldModel struct {
+	modelName       string
+	targetModelName string
+}
+
+// ActivationRequest is the JSON body POSTed to the activation URL.
+type ActivationRequest struct {
+	InferencePool   string `json:"inferencePool"`
+	ModelName       string `json:"modelName"`
+	TargetModelName string `json:"targetModelName"`
+	HeldRequests    int    `json:"heldRequests"`
+}
+
+// ScaleFromZeroAdmissionController decorates the Flow Control admission controller for pools that scale to zero.
+//
+// A request arriving while the pool has no ready endpoints is held in the Flow Control layer, which does not dispatch
+// while the saturation detector reports an empty pool as saturated, for at most the configured hold timeout. While
+// requests are held their demand is exported per model and, if configured, announced to an activation URL. Held
+// requests are released by the Flow Control layer as soon as the pool gains a ready endpoint.
+type ScaleFromZeroAdmissionController struct {
+	delegate           AdmissionController
+	endpointCandidates contracts.EndpointCandidates
+	poolName           string
+	config             flowcontrol.ScaleFromZeroConfig
+	client             *http.Client
+
+	mu            sync.Mutex
+	held          int
+	heldPerModel  map[heldModel]int
+	lastActivated map[heldModel]time.Time
+}
+
+// NewScaleFromZeroAdmissionController creates a new ScaleFromZeroAdmissionController around the given delegate.
+func NewScaleFromZeroAdmissionController(
+	delegate AdmissionController,
+	endpointCandidates contracts.EndpointCandidates,
+	poolName string,
+	config flowcontrol.ScaleFromZeroConfig,
+) *ScaleFromZeroAdmissionController {
+	return &ScaleFromZeroAdmissionController{
+		delegate:           delegate,
+		endpointCandidates: endpointCandidates,
+		poolName:           poolName,
+		config:             config,
+		client:             &http.Client{Timeout: activationTimeout},
+		heldPerModel:       make(map[heldModel]int),
+		lastActivated:      make(map[heldModel]time.Time),
+	}
+}
+
+// Admit implements the AdmissionController interface. Requests are passed straight to the delegate while the pool has
+// ready endpoints.
+func (s *ScaleFromZeroAdmissionController) Admit(
+	ctx context.Context,
+	reqCtx *handlers.RequestContext,
+	priority int,
+) error {
+	if len(s.endpointCandidates.Locate(ctx, reqCtx.Request.Metadata)) > 0 {
+		return s.delegate.Admit(ctx, reqCtx, priority)
+	}
+
+	logger := log.FromContext(ctx)
+	model := heldModel{modelName: reqCtx.IncomingModelName, targetModelName: reqCtx.TargetModelName}
+	heldForModel, activate, ok := s.hold(model)
+	if !ok {
+		logger.V(logutil.DEBUG).Info("Rejecting request, scale-from-zero hold queue is full",
+			"requestID", reqCtx.SchedulingRequest.RequestID, "maxHeldRequests", s.config.MaxHeldRequests)
+		return errcommon.Error{
+			Code:    errcommon.ResourceExhausted,
+			Msg:     "no ready endpoints and too many requests are already waiting for one",
+			Headers: map[string]string{errcommon.RequestDroppedReasonHeaderKey: string(errcommon.RequestDroppedReasonSaturated)},
+		}
+	}
+	defer s.release(model)
+	metrics.IncScaleFromZeroHeldRequests(s.poolName, model.modelName, model.targetModelName)
+	defer metrics.DecScaleFromZeroHeldRequests(s.poolName, model.modelName, model.targetModelName)
+
+	logger.V(logutil.DEFAULT).Info("No ready endpoints, holding request until the pool scales up",
+		"requestID", reqCtx.SchedulingRequest.RequestID, "heldForModel", heldForModel, "holdTimeout", s.config.HoldTimeout)
+	if activate {
+		go s.activate(logger, model, heldForModel)
+	}
+
+	holdCtx, cancel := context.WithTimeout(ctx, s.config.HoldTimeout)
+	defer cancel()
+	start := time.Now()
+	err := s.delegate.Admit(holdCtx, reqCtx, priority)
+
+	outcome := scaleFromZeroOutcomeReleased
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		outcome = scaleFromZeroOutcomeCancelled
+	case errors.Is(holdCtx.Err(), context.DeadlineExceeded):
+		outcome = scaleFromZeroOutcomeTimeout
+		err = errcommon.Error{
+			Code:    errcommon.ServiceUnavailable,
+			Msg:     fmt.Sprintf("no ready endpoints within the scale-from-zero hold timeout of %s", s.config.HoldTimeout),
+			Headers: map[string]string{errcommon.RequestDroppedReasonHeaderKey: string(errcommon.RequestDroppedReasonTTLExpired)},
+		}
+	default:
+		outcome = scaleFromZeroOutcomeRejected
+	}
+	waited := time.Since(start)
+	metrics.RecordScaleFromZeroWaitDuration(s.poolName, model.modelName, model.targetModelName, outcome, waited)
+	logger.V(logutil.DEFAULT).Info("Finished holding request for scale from zero",
+		"requestID", reqCtx.SchedulingRequest.RequestID, "outcome", outcome, "waited", waited)
+	return err
+}
+
+// hold registers a held request for the model. It returns the number of requests held for the model including this
+// one, whether the activation URL should be called, and false if the hold queue is full.
+func (s *ScaleFromZeroAdmissionController) hold(model heldModel) (int, bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config.MaxHeldRequests > 0 && s.held >= s.config.MaxHeldRequests {
+		return 0, false, false
+	}
+	s.held++
+	s.heldPerModel[model]++
+	activate := false
+	if s.config.ActivationURL != "" {
+		now := time.Now()
+		if last, ok := s.lastActivated[model]; !ok || now.Sub(last) >= activationResendInterval {
+			s.lastActivated[model] = now
+			activate = true
+		}
+	}
+	return s.heldPerModel[model], activate, true
+}
+
+func (s *ScaleFromZeroAdmissionController) release(model heldModel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.held--
+	s.heldPerModel[model]--
+	if s.heldPerModel[model] <= 0 {
+		delete(s.heldPerModel, model)
+		delete(s.lastActivated, model)
+	}
+}
+
+// activate POSTs the held demand of the model to the activation URL.
+func (s *ScaleFromZeroAdmissionController) activate(logger logr.Logger, model heldModel, heldRequests int) {
+	result := "success"
+	defer func() {
+		metrics.RecordScaleFromZeroActivation(s.poolName, model.modelName, model.targetModelName, result)
+	}()
+
+	body, err := json.Marshal(ActivationRequest{
+		InferencePool:   s.poolName,
+		ModelName:       model.modelName,
+		TargetModelName: model.targetModelName,
+		HeldRequests:    heldRequests,
+	})
+	if err != nil {
+		result = "error"
+		logger.Error(err, "Failed to marshal scale-from-zero activation request")
+		return
+	}
+	resp, err := s.client.Post(s.config.ActivationURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		result = "error"
+		logger.Error(err, "Failed to call scale-from-zero activation URL", "url", s.config.ActivationURL)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		result = "error"
+		logger.Error(nil, "Scale-from-zero activation URL returned an unexpected status",
+			"url", s.config.ActivationURL, "status", resp.StatusCode)
+		return
+	}
+	logger.V(logutil.VERBOSE).Info("Called scale-from-zero activation URL", "model", model.modelName, "heldRequests", heldRequests)
+}
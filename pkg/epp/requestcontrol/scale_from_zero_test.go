@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts/mocks"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+)
+
+// blockingAdmissionController stands in for the Flow Control layer: it admits once release is closed and gives up
+// when the context ends.
+type blockingAdmissionController struct {
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (b *blockingAdmissionController) Admit(ctx context.Context, _ *handlers.RequestContext, _ int) error {
+	b.calls.Add(1)
+	select {
+	case <-b.release:
+		return nil
+	case <-ctx.Done():
+		return errcommon.Error{Code: errcommon.ServiceUnavailable, Msg: "client disconnected"}
+	}
+}
+
+func newScaleFromZeroRequest(id string) *handlers.RequestContext {
+	return &handlers.RequestContext{
+		IncomingModelName: "model-a",
+		TargetModelName:   "model-a",
+		SchedulingRequest: &fwksched.InferenceRequest{RequestID: id},
+		Request:           &handlers.Request{Metadata: map[string]any{}},
+	}
+}
+
+func TestScaleFromZeroAdmissionController_PassThroughWithEndpoints(t *testing.T) {
+	t.Parallel()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	delegate := &blockingAdmissionController{release: make(chan struct{})}
+	close(delegate.release)
+	candidates := &mocks.MockEndpointCandidates{Candidates: []fwkdl.Endpoint{fwkdl.NewEndpoint(nil, nil)}}
+	ac := NewScaleFromZeroAdmissionController(delegate, candidates, "pool", flowcontrol.ScaleFromZeroConfig{
+		HoldTimeout:     time.Minute,
+		MaxHeldRequests: 1,
+	})
+
+	require.NoError(t, ac.Admit(ctx, newScaleFromZeroRequest("req"), 0))
+	assert.Equal(t, int32(1), delegate.calls.Load())
+	assert.Equal(t, 0, ac.held, "requests for a pool with endpoints must not be held")
+}
+
+func TestScaleFromZeroAdmissionController_ReleasedWhenEndpointAppears(t *testing.T) {
+	t.Parallel()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+
+	activations := make(chan ActivationRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ActivationRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		activations <- req
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	delegate := &blockingAdmissionController{release: make(chan struct{})}
+	candidates := &mocks.MockEndpointCandidates{}
+	ac := NewScaleFromZeroAdmissionController(delegate, candidates, "pool", flowcontrol.ScaleFromZeroConfig{
+		HoldTimeout:   time.Minute,
+		ActivationURL: server.URL,
+	})
+
+	errs := make(chan error, 2)
+	go func() { errs <- ac.Admit(ctx, newScaleFromZeroRequest("req-1"), 0) }()
+
+	select {
+	case got := <-activations:
+		assert.Equal(t, ActivationRequest{InferencePool: "pool", ModelName: "model-a", TargetModelName: "model-a", HeldRequests: 1}, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the activation call")
+	}
+
+	go func() { errs <- ac.Admit(ctx, newScaleFromZeroRequest("req-2"), 0) }()
+	require.Eventually(t, func() bool {
+		ac.mu.Lock()
+		defer ac.mu.Unlock()
+		return ac.held == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case <-activations:
+		t.Fatal("activation URL should not be called again within the resend interval")
+	default:
+	}
+
+	// The Flow Control layer dispatches once the pool has an endpoint.
+	close(delegate.release)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	assert.Equal(t, 0, ac.held)
+	assert.Empty(t, ac.heldPerModel)
+}
+
+func TestScaleFromZeroAdmissionController_HoldTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	delegate := &blockingAdmissionController{release: make(chan struct{})}
+	ac := NewScaleFromZeroAdmissionController(delegate, &mocks.MockEndpointCandidates{}, "pool", flowcontrol.ScaleFromZeroConfig{
+		HoldTimeout: 20 * time.Millisecond,
+	})
+
+	err := ac.Admit(ctx, newScaleFromZeroRequest("req"), 0)
+	require.Error(t, err)
+	var e errcommon.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, errcommon.ServiceUnavailable, e.Code)
+	assert.Contains(t, e.Msg, "hold timeout")
+	assert.Equal(t, string(errcommon.RequestDroppedReasonTTLExpired), e.Headers[errcommon.RequestDroppedReasonHeaderKey])
+}
+
+func TestScaleFromZeroAdmissionController_ClientCancelled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(logutil.NewTestLoggerIntoContext(context.Background()))
+	delegate := &blockingAdmissionController{release: make(chan struct{})}
+	ac := NewScaleFromZeroAdmissionController(delegate, &mocks.MockEndpointCandidates{}, "pool", flowcontrol.ScaleFromZeroConfig{
+		HoldTimeout: time.Minute,
+	})
+
+	cancel()
+	err := ac.Admit(ctx, newScaleFromZeroRequest("req"), 0)
+	var e errcommon.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "client disconnected", e.Msg, "the delegate's error is returned for cancelled clients")
+}
+
+func TestScaleFromZeroAdmissionController_MaxHeldRequests(t *testing.T) {
+	t.Parallel()
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	delegate := &blockingAdmissionController{release: make(chan struct{})}
+	ac := NewScaleFromZeroAdmissionController(delegate, &mocks.MockEndpointCandidates{}, "pool", flowcontrol.ScaleFromZeroConfig{
+		HoldTimeout:     time.Minute,
+		MaxHeldRequests: 1,
+	})
+
+	errs := make(chan error, 1)
+	go func() { errs <- ac.Admit(ctx, newScaleFromZeroRequest("held"), 0) }()
+	require.Eventually(t, func() bool { return delegate.calls.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	err := ac.Admit(ctx, newScaleFromZeroRequest("rejected"), 0)
+	var e errcommon.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, errcommon.ResourceExhausted, e.Code)
+	assert.Equal(t, int32(1), delegate.calls.Load(), "a rejected request must not reach the delegate")
+
+	close(delegate.release)
+	require.NoError(t, <-errs)
+}
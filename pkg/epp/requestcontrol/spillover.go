@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	"github.com/llm-d/llm-d-router/pkg/epp/datalayer"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkfc "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/flowcontrol"
+	fwkrh "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/requesthandling"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+	"github.com/llm-d/llm-d-router/pkg/epp/metrics"
+)
+
+// Reasons for spilling a request over, reported in the spillover response header and metric.
+const (
+	// SpilloverReasonSaturated is used when the saturation detector reports the primary pool as saturated.
+	SpilloverReasonSaturated = "saturated"
+	// SpilloverReasonRejected is used when the admission controller sheds the request.
+	SpilloverReasonRejected = "rejected"
+	// SpilloverReasonNoEndpoints is used when the primary pool has no endpoint for the request.
+	SpilloverReasonNoEndpoints = "no-endpoints"
+)
+
+// SpilloverEndpoints holds the secondary endpoints requests spill over to. It is populated by an EndpointDiscovery
+// through fwkdl.NewDiscoveryNotifier. Its endpoints are created by the data layer like those of the primary pool, so
+// their metrics are scraped and the endpoint lifecycle plugins see them.
+type SpilloverEndpoints struct {
+	parentCtx context.Context
+	epf       datalayer.EndpointFactory
+
+	mu        sync.RWMutex
+	endpoints map[types.NamespacedName]fwkdl.Endpoint
+}
+
+var _ fwkdl.DiscoveryEndpointStore = &SpilloverEndpoints{}
+
+// NewSpilloverEndpoints creates an empty SpilloverEndpoints whose endpoints are created by epf and collected until
+// parentCtx is done.
+func NewSpilloverEndpoints(parentCtx context.Context, epf datalayer.EndpointFactory) *SpilloverEndpoints {
+	return &SpilloverEndpoints{
+		parentCtx: parentCtx,
+		epf:       epf,
+		endpoints: make(map[types.NamespacedName]fwkdl.Endpoint),
+	}
+}
+
+// EndpointUpsert adds or updates a spillover endpoint.
+func (s *SpilloverEndpoints) EndpointUpsert(ctx context.Context, meta *fwkdl.EndpointMetadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.endpoints[meta.NamespacedName]
+	if !ok {
+		// NewEndpoint returns nil when a collector already runs for the endpoint.
+		if ep := s.epf.NewEndpoint(s.parentCtx, meta); ep != nil {
+			s.endpoints[meta.NamespacedName] = ep
+		}
+		return
+	}
+	if existing.GetMetadata().Equal(meta) {
+		return
+	}
+	existing.UpdateMetadata(meta)
+	s.epf.UpdateEndpoint(ctx, existing)
+}
+
+// EndpointDelete removes a spillover endpoint.
+func (s *SpilloverEndpoints) EndpointDelete(id types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ep, ok := s.endpoints[id]; ok {
+		delete(s.endpoints, id)
+		s.epf.ReleaseEndpoint(ep)
+	}
+}
+
+// list returns the spillover endpoints.
+func (s *SpilloverEndpoints) list() []fwkdl.Endpoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	endpoints := make([]fwkdl.Endpoint, 0, len(s.endpoints))
+	for _, ep := range s.endpoints {
+		endpoints = append(endpoints, ep)
+	}
+	return endpoints
+}
+
+// Spillover routes the requests of opted-in objectives to secondary endpoints when the primary pool cannot serve
+// them. Spilled over requests bypass admission, but are otherwise handled like the requests routed to the primary
+// pool: the scheduler picks the spillover endpoint from its scraped metrics, and the PreRequest and response plugins
+// run for them.
+type Spillover struct {
+	endpoints  *SpilloverEndpoints
+	detector   fwkfc.SaturationDetector
+	objectives sets.Set[string]
+}
+
+// NewSpillover creates a Spillover for the requests of the given objectives. detector reports the saturation of the
+// primary pool; when nil, requests only spill over when they are shed or have no endpoint.
+func NewSpillover(endpoints *SpilloverEndpoints, detector fwkfc.SaturationDetector, objectives []string) *Spillover {
+	return &Spillover{
+		endpoints:  endpoints,
+		detector:   detector,
+		objectives: sets.New(objectives...),
+	}
+}
+
+// SetSpillover enables spilling requests over to secondary endpoints.
+func (d *Director) SetSpillover(spillover *Spillover) {
+	d.spillover = spillover
+}
+
+// spilloverEnabled reports whether the request may spill over: its objective opted in, and the EPP observes the end
+// of its response, which releases what the plugins acquired for it on the spillover endpoint.
+func (d *Director) spilloverEnabled(reqCtx *handlers.RequestContext) bool {
+	return d.spillover != nil && d.spillover.objectives.Has(reqCtx.ObjectiveKey) && !reqCtx.ResponseUntracked
+}
+
+// primarySaturated reports whether a request that may spill over targets a saturated primary pool, made of the given
+// candidates.
+func (d *Director) primarySaturated(ctx context.Context, reqCtx *handlers.RequestContext, candidates []fwkdl.Endpoint) bool {
+	if !d.spilloverEnabled(reqCtx) || d.spillover.detector == nil {
+		return false
+	}
+	return d.spillover.detector.Saturation(ctx, candidates) >= 1.0
+}
+
+// spillOver schedules the request over the spillover endpoints it has not tried yet and prepares it for the picked
+// one. It returns false, leaving the request unrouted, if the request may not spill over or no spillover endpoint can
+// serve it.
+func (d *Director) spillOver(ctx context.Context, reqCtx *handlers.RequestContext, reason string, tried sets.Set[string]) (bool, error) {
+	if !d.spilloverEnabled(reqCtx) {
+		return false, nil
+	}
+	logger := log.FromContext(ctx)
+	endpoints := d.spillover.endpoints.list()
+	if len(endpoints) == 0 {
+		logger.V(logutil.DEFAULT).Info("No spillover endpoint available", "reason", reason)
+		return false, nil
+	}
+	candidates := d.toSchedulerEndpoints(d.excludeTriedEndpoints(ctx, endpoints, tried))
+	if err := d.runDataProducerPlugins(ctx, reqCtx.SchedulingRequest, candidates); err != nil {
+		// Don't fail the request if DataProducer plugins fail.
+		logger.Error(err, "failed to prepare per request data for the spillover endpoints")
+	}
+	result, err := d.scheduler.Schedule(ctx, reqCtx.SchedulingRequest, candidates)
+	if err != nil || result == nil || result.ProfileResults[result.PrimaryProfileName] == nil ||
+		len(result.ProfileResults[result.PrimaryProfileName].TargetEndpoints) == 0 {
+		logger.V(logutil.DEFAULT).Info("No spillover endpoint can serve the request", "reason", reason, "error", err)
+		return false, nil
+	}
+
+	reqCtx.SchedulingRequest.SchedulingResult = result
+	if _, err := d.prepareRequest(ctx, reqCtx, result); err != nil {
+		return false, err
+	}
+	if reqCtx.Response != nil && reqCtx.Response.Headers != nil {
+		reqCtx.Response.Headers[metadata.SpilloverKey] = reason
+	}
+	metrics.RecordSpilloverDecision(reqCtx.IncomingModelName, reqCtx.TargetModelName, reason)
+	logger.V(logutil.VERBOSE).Info("Request spilled over", "reason", reason, "endpoint", reqCtx.TargetEndpoint)
+	return true, nil
+}
+
+// spillOverOr spills the request over for reason, or returns err if it cannot spill over.
+func (d *Director) spillOverOr(ctx context.Context, reqCtx *handlers.RequestContext, inferenceRequestBody *fwkrh.InferenceRequestBody,
+	reason string, tried sets.Set[string], err error) (*handlers.RequestContext, error) {
+	spilled, spillErr := d.spillOver(ctx, reqCtx, reason, tried)
+	if spillErr != nil {
+		return reqCtx, spillErr
+	}
+	if !spilled {
+		return reqCtx, err
+	}
+	return reqCtx, d.repackage(ctx, reqCtx, inferenceRequestBody)
+}
+
+// isShedError reports whether the admission controller rejected the request for lack of capacity.
+func isShedError(err error) bool {
+	var e errcommon.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Code == errcommon.ResourceExhausted || e.Code == errcommon.ServiceUnavailable
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	errcommon "github.com/llm-d/llm-d-router/pkg/common/error"
+	logutil "github.com/llm-d/llm-d-router/pkg/common/observability/logging"
+	reqcommon "github.com/llm-d/llm-d-router/pkg/common/request"
+	"github.com/llm-d/llm-d-router/pkg/epp/flowcontrol/contracts/mocks"
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+	"github.com/llm-d/llm-d-router/pkg/epp/framework/plugins/requesthandling/parsers/openai"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+	"github.com/llm-d/llm-d-router/pkg/epp/metadata"
+)
+
+// spilloverTestFactory creates endpoints without collecting their metrics and remembers the released ones.
+type spilloverTestFactory struct {
+	released []types.NamespacedName
+}
+
+func (f *spilloverTestFactory) NewEndpoint(_ context.Context, meta *fwkdl.EndpointMetadata) fwkdl.Endpoint {
+	return fwkdl.NewEndpoint(meta, fwkdl.NewMetrics())
+}
+
+func (f *spilloverTestFactory) UpdateEndpoint(context.Context, fwkdl.Endpoint) {}
+
+func (f *spilloverTestFactory) ReleaseEndpoint(ep fwkdl.Endpoint) {
+	f.released = append(f.released, ep.GetMetadata().NamespacedName)
+}
+
+func TestSpilloverEndpoints(t *testing.T) {
+	factory := &spilloverTestFactory{}
+	endpoints := NewSpilloverEndpoints(context.Background(), factory)
+	assert.Empty(t, endpoints.list(), "an empty set has no endpoint")
+
+	id := types.NamespacedName{Name: "spill-1", Namespace: "default"}
+	endpoints.EndpointUpsert(context.Background(), &fwkdl.EndpointMetadata{NamespacedName: id, Address: "10.1.0.1", Port: "8000"})
+	list := endpoints.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, "10.1.0.1", list[0].GetMetadata().GetIPAddress())
+
+	// Updates keep the endpoint, and so its scraped metrics.
+	list[0].UpdateMetrics(&fwkdl.Metrics{RunningRequestsSize: 3})
+	endpoints.EndpointUpsert(context.Background(), &fwkdl.EndpointMetadata{NamespacedName: id, Address: "10.1.0.1", Port: "8001"})
+	list = endpoints.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, "8001", list[0].GetMetadata().GetPort())
+	assert.Equal(t, 3, list[0].GetMetrics().RunningRequestsSize)
+
+	endpoints.EndpointDelete(id)
+	assert.Empty(t, endpoints.list())
+	assert.Equal(t, []types.NamespacedName{id}, factory.released, "deleted endpoints must be released")
+}
+
+// spilloverScheduler schedules the primary pool like mockScheduler and picks the spillover endpoint (those in the
+// "other" namespace) with the fewest running requests, unless rejectSpillover filters them all out.
+type spilloverScheduler struct {
+	mockScheduler
+	rejectSpillover bool
+}
+
+func (s *spilloverScheduler) Schedule(ctx context.Context, request *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) (*fwksched.SchedulingResult, error) {
+	if len(endpoints) == 0 || endpoints[0].GetMetadata().NamespacedName.Namespace != "other" {
+		return s.mockScheduler.Schedule(ctx, request, endpoints)
+	}
+	if s.rejectSpillover {
+		return nil, errors.New("no spillover endpoint left after filtering")
+	}
+	picked := endpoints[0]
+	for _, endpoint := range endpoints[1:] {
+		if endpoint.GetMetrics().RunningRequestsSize < picked.GetMetrics().RunningRequestsSize ||
+			(endpoint.GetMetrics().RunningRequestsSize == picked.GetMetrics().RunningRequestsSize &&
+				endpoint.GetMetadata().NamespacedName.Name < picked.GetMetadata().NamespacedName.Name) {
+			picked = endpoint
+		}
+	}
+	return &fwksched.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults:     map[string]*fwksched.ProfileRunResult{"default": {TargetEndpoints: []fwksched.Endpoint{picked}}},
+	}, nil
+}
+
+func TestDirector_HandleRequest_Spillover(t *testing.T) {
+	primary := &fwkdl.EndpointMetadata{
+		NamespacedName: types.NamespacedName{Name: "primary", Namespace: "default"},
+		Address:        "10.0.0.1",
+		Port:           "8000",
+	}
+	scheduleResult := &fwksched.SchedulingResult{
+		PrimaryProfileName: "default",
+		ProfileResults: map[string]*fwksched.ProfileRunResult{
+			"default": {TargetEndpoints: []fwksched.Endpoint{fwksched.NewEndpoint(primary, nil, nil)}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		objective     string
+		saturation    float64
+		admitErr      error
+		noCandidates  bool
+		scheduleErr   error
+		noSpillover   bool
+		rejectSpill   bool
+		wantEndpoint  string
+		wantReason    string
+		wantErrorCode string
+	}{
+		{
+			name:         "primary serves the request",
+			objective:    "chat",
+			wantEndpoint: "10.0.0.1:8000",
+		},
+		{
+			name:         "saturated primary spills over",
+			objective:    "chat",
+			saturation:   1.0,
+			wantEndpoint: "10.1.0.1:8000",
+			wantReason:   SpilloverReasonSaturated,
+		},
+		{
+			name:         "shed request spills over",
+			objective:    "chat",
+			admitErr:     errcommon.Error{Code: errcommon.ResourceExhausted, Msg: "queue full"},
+			wantEndpoint: "10.1.0.1:8000",
+			wantReason:   SpilloverReasonRejected,
+		},
+		{
+			name:          "other admission errors do not spill over",
+			objective:     "chat",
+			admitErr:      errcommon.Error{Code: errcommon.BadRequest, Msg: "bad request"},
+			wantErrorCode: errcommon.BadRequest,
+		},
+		{
+			name:         "no candidates spills over",
+			objective:    "chat",
+			noCandidates: true,
+			wantEndpoint: "10.1.0.1:8000",
+			wantReason:   SpilloverReasonNoEndpoints,
+		},
+		{
+			name:         "scheduling failure spills over",
+			objective:    "chat",
+			scheduleErr:  errors.New("no endpoint left after filtering"),
+			wantEndpoint: "10.1.0.1:8000",
+			wantReason:   SpilloverReasonNoEndpoints,
+		},
+		{
+			name:          "objective not opted in",
+			objective:     "batch",
+			saturation:    1.0,
+			scheduleErr:   errors.New("no endpoint left after filtering"),
+			wantErrorCode: errcommon.ResourceExhausted,
+		},
+		{
+			name:          "spillover endpoints rejected by the scheduler",
+			objective:     "chat",
+			noCandidates:  true,
+			rejectSpill:   true,
+			wantErrorCode: errcommon.ServiceUnavailable,
+		},
+		{
+			name:          "no spillover endpoint",
+			objective:     "chat",
+			noCandidates:  true,
+			noSpillover:   true,
+			wantErrorCode: errcommon.ServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := logutil.NewTestLoggerIntoContext(context.Background())
+			candidates := &mocks.MockEndpointCandidates{}
+			if !test.noCandidates {
+				candidates.Candidates = []fwkdl.Endpoint{fwkdl.NewEndpoint(primary, nil)}
+			}
+			sched := &spilloverScheduler{
+				mockScheduler:   mockScheduler{scheduleResults: scheduleResult, scheduleErr: test.scheduleErr},
+				rejectSpillover: test.rejectSpill,
+			}
+			director := NewDirectorWithConfig(&mockDatastore{}, sched, &mockAdmissionController{admitErr: test.admitErr}, candidates, NewConfig())
+
+			spilloverEndpoints := NewSpilloverEndpoints(ctx, &spilloverTestFactory{})
+			if !test.noSpillover {
+				spilloverEndpoints.EndpointUpsert(ctx, &fwkdl.EndpointMetadata{
+					NamespacedName: types.NamespacedName{Name: "spill", Namespace: "other"},
+					Address:        "10.1.0.1",
+					Port:           "8000",
+				})
+			}
+			detector := &mockSaturationDetector{SaturationFunc: func(context.Context, []fwkdl.Endpoint) float64 { return test.saturation }}
+			director.SetSpillover(NewSpillover(spilloverEndpoints, detector, []string{"chat"}))
+
+			reqCtx := &handlers.RequestContext{
+				ObjectiveKey: test.objective,
+				Request: &handlers.Request{
+					Headers: map[string]string{
+						reqcommon.RequestIDHeaderKey: "req",
+						":path":                      "/v1/completions",
+					},
+					RawBody: []byte(`{"model":"m","prompt":"p"}`),
+				},
+				Response: &handlers.Response{Headers: map[string]string{}},
+			}
+			parseResult, err := openai.NewOpenAIParser().ParseRequest(ctx, reqCtx.Request.RawBody, reqCtx.Request.Headers)
+			require.NoError(t, err)
+
+			reqCtx, err = director.HandleRequest(ctx, reqCtx, parseResult.Body)
+			if test.wantErrorCode != "" {
+				var e errcommon.Error
+				require.ErrorAs(t, err, &e)
+				assert.Equal(t, test.wantErrorCode, e.Code)
+				assert.NotContains(t, reqCtx.Response.Headers, metadata.SpilloverKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantEndpoint, reqCtx.TargetEndpoint)
+			if test.wantReason == "" {
+				assert.NotContains(t, reqCtx.Response.Headers, metadata.SpilloverKey)
+			} else {
+				assert.Equal(t, test.wantReason, reqCtx.Response.Headers[metadata.SpilloverKey])
+			}
+			assert.NotEmpty(t, reqCtx.Request.RawBody, "the request body must be repackaged")
+		})
+	}
+}
+
+func TestDirector_Spillover_PicksLeastLoadedEndpoint(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	candidates := &mocks.MockEndpointCandidates{}
+	var preRequests []string
+	preRequest := &mockPreRequestPlugin{name: "pre-request", modifyFn: func(request *fwksched.InferenceRequest) {
+		preRequests = append(preRequests, request.RequestID)
+	}}
+	director := NewDirectorWithConfig(&mockDatastore{}, &spilloverScheduler{}, &mockAdmissionController{}, candidates,
+		NewConfig().WithPreRequestPlugins(preRequest))
+	director.SetRetryTracker(NewRetryTracker(1, time.Minute))
+	spilloverEndpoints := NewSpilloverEndpoints(ctx, &spilloverTestFactory{})
+	for i, name := range []string{"spill-a", "spill-b"} {
+		spilloverEndpoints.EndpointUpsert(ctx, &fwkdl.EndpointMetadata{
+			NamespacedName: types.NamespacedName{Name: name, Namespace: "other"},
+			Address:        fmt.Sprintf("10.1.0.%d", i+1),
+			Port:           "8000",
+		})
+	}
+	director.SetSpillover(NewSpillover(spilloverEndpoints, nil, []string{"chat"}))
+
+	handle := func(id string, attempt int, untracked bool) *handlers.RequestContext {
+		reqCtx := &handlers.RequestContext{
+			ObjectiveKey:      "chat",
+			ResponseUntracked: untracked,
+			Request: &handlers.Request{
+				Headers: map[string]string{
+					reqcommon.RequestIDHeaderKey:    id,
+					reqcommon.AttemptCountHeaderKey: strconv.Itoa(attempt),
+					":path":                         "/v1/completions",
+				},
+				RawBody:  []byte(`{"model":"m","prompt":"p"}`),
+				Metadata: map[string]any{},
+			},
+			Response: &handlers.Response{Headers: map[string]string{}},
+		}
+		parseResult, err := openai.NewOpenAIParser().ParseRequest(ctx, reqCtx.Request.RawBody, reqCtx.Request.Headers)
+		require.NoError(t, err)
+		reqCtx, _ = director.HandleRequest(ctx, reqCtx, parseResult.Body)
+		return reqCtx
+	}
+
+	assert.Equal(t, "10.1.0.1:8000", handle("r1", 1, false).TargetEndpoint)
+
+	// The scheduler sees the scraped metrics of the spillover endpoints.
+	for _, ep := range spilloverEndpoints.list() {
+		if ep.GetMetadata().NamespacedName.Name == "spill-a" {
+			ep.UpdateMetrics(&fwkdl.Metrics{RunningRequestsSize: 2})
+		}
+	}
+	assert.Equal(t, "10.1.0.2:8000", handle("r2", 1, false).TargetEndpoint, "the busy endpoint is avoided")
+
+	// Spilled over requests are recorded for retries and run the PreRequest plugins.
+	assert.Equal(t, "10.1.0.1:8000", handle("r2", 2, false).TargetEndpoint, "the retry avoids the tried endpoint")
+	assert.Equal(t, []string{"r1", "r2", "r2"}, preRequests)
+
+	// Requests whose response the EPP does not observe do not spill over.
+	untracked := handle("r3", 1, true)
+	assert.Empty(t, untracked.TargetEndpoint)
+	assert.NotContains(t, untracked.Response.Headers, metadata.SpilloverKey)
+}
@@ -42,9 +42,10 @@ func NewSchedulerProfile() *SchedulerProfile {
 
 // SchedulerProfile provides a profile configuration for the scheduler which influence routing decisions.
 type SchedulerProfile struct {
-	filters []fwksched.Filter
-	scorers []*WeightedScorer
-	picker  fwksched.Picker
+	filters    []fwksched.Filter
+	scorers    []*WeightedScorer
+	picker     fwksched.Picker
+	aggregator ScoreAggregator // nil means a plain weighted sum
 }
 
 // WithFilters sets the given filter plugins as the Filter plugins.
@@ -68,6 +69,13 @@ func (p *SchedulerProfile) WithPicker(picker fwksched.Picker) *SchedulerProfile
 	return p
 }
 
+// WithScoreAggregator sets the strategy used to combine the scorer outputs into a single score per endpoint.
+// A nil aggregator restores the default weighted sum.
+func (p *SchedulerProfile) WithScoreAggregator(aggregator ScoreAggregator) *SchedulerProfile {
+	p.aggregator = aggregator
+	return p
+}
+
 // AddPlugins adds the given plugins to all scheduler plugins according to the interfaces each plugin implements.
 // A plugin may implement more than one scheduler plugin interface.
 // Special Case: In order to add a scorer, one must use the scorer.NewWeightedScorer function in order to provide a weight.
@@ -105,13 +113,21 @@ func (p *SchedulerProfile) String() string {
 	}
 
 	return fmt.Sprintf(
-		"{Filters: [%s], Scorers: [%s], Picker: %s}",
+		"{Filters: [%s], Scorers: [%s], Aggregation: %s, Picker: %s}",
 		strings.Join(filterNames, ", "),
 		strings.Join(scorerNames, ", "),
+		p.aggregationStrategy(),
 		p.picker.TypedName(),
 	)
 }
 
+func (p *SchedulerProfile) aggregationStrategy() string {
+	if p.aggregator == nil {
+		return WeightedSumAggregation
+	}
+	return p.aggregator.Strategy()
+}
+
 // Run runs a SchedulerProfile. It invokes all the SchedulerProfile plugins for the given request in this
 // order - Filters, Scorers, Picker. After completing all, it returns the result.
 func (p *SchedulerProfile) Run(ctx context.Context, request *fwksched.InferenceRequest, candidateEndpoints []fwksched.Endpoint) (*fwksched.ProfileRunResult, error) {
@@ -122,7 +138,7 @@ func (p *SchedulerProfile) Run(ctx context.Context, request *fwksched.InferenceR
 	// if we got here, there is at least one endpoint to score
 	weightedScorePerEndpoint := p.runScorerPlugins(ctx, request, endpoints)
 
-	result := p.runPickerPlugin(ctx, weightedScorePerEndpoint)
+	result := p.runPickerPlugin(ctx, request, weightedScorePerEndpoint)
 
 	return result, nil
 }
@@ -165,26 +181,71 @@ func (p *SchedulerProfile) runScorerPlugins(ctx context.Context, request *fwksch
 	debug := logger.V(logutil.DEBUG)
 	debugEnabled := debug.Enabled()
 
-	// Iterate through each scorer in the chain and accumulate the weighted scores.
-	for _, scorer := range p.scorers {
-		logger.V(logutil.VERBOSE).Info("Running scorer plugin", "plugin", scorer.TypedName())
-		before := time.Now()
-		scores := scorer.Score(ctx, request, endpoints)
-		metrics.RecordPluginProcessingLatency(scorerExtensionPoint, scorer.TypedName().Type, scorer.TypedName().Name, time.Since(before))
-		for endpoint, score := range scores { // weight is relative to the sum of weights
+	strategy := p.aggregationStrategy()
+	if p.aggregator == nil {
+		// Iterate through each scorer in the chain and accumulate the weighted scores.
+		for _, scorer := range p.scorers {
+			scores := p.runScorerPlugin(ctx, scorer, request, endpoints)
+			for endpoint, score := range scores { // weight is relative to the sum of weights
+				if debugEnabled {
+					debug.Info("Calculated score", "plugin", scorer.TypedName(), "endpoint", endpoint.GetMetadata().NamespacedName,
+						"score", score, "aggregation", strategy)
+				}
+				weightedScorePerEndpoint[endpoint] += enforceScoreRange(score) * scorer.Weight()
+			}
+		}
+		logger.V(logutil.VERBOSE).Info("Completed running scorer plugins successfully")
+		return weightedScorePerEndpoint
+	}
+
+	// Non-default strategies look at all scores of a scorer at once, so collect them into a
+	// scorer x endpoint matrix first. Endpoints a scorer did not score get 0, as in the sum.
+	weights := make([]float64, len(p.scorers))
+	matrix := make([][]float64, len(p.scorers))
+	for i, scorer := range p.scorers {
+		weights[i] = scorer.Weight()
+		matrix[i] = make([]float64, len(endpoints))
+		scores := p.runScorerPlugin(ctx, scorer, request, endpoints)
+		for j, endpoint := range endpoints {
+			score, ok := scores[endpoint]
+			if !ok {
+				continue
+			}
 			if debugEnabled {
-				debug.Info("Calculated score", "plugin", scorer.TypedName(), "endpoint", endpoint.GetMetadata().NamespacedName, "score", score)
+				debug.Info("Calculated score", "plugin", scorer.TypedName(), "endpoint", endpoint.GetMetadata().NamespacedName,
+					"score", score, "aggregation", strategy)
 			}
-			weightedScorePerEndpoint[endpoint] += enforceScoreRange(score) * scorer.Weight()
+			matrix[i][j] = enforceScoreRange(score)
+		}
+	}
+	aggregated := p.aggregator.Aggregate(weights, matrix)
+	for j, endpoint := range endpoints {
+		if j < len(aggregated) {
+			weightedScorePerEndpoint[endpoint] = aggregated[j]
+		}
+		if debugEnabled {
+			debug.Info("Aggregated score", "endpoint", endpoint.GetMetadata().NamespacedName,
+				"score", weightedScorePerEndpoint[endpoint], "aggregation", strategy)
 		}
-		debug.Info("Completed running scorer plugin successfully", "plugin", scorer.TypedName())
 	}
-	logger.V(logutil.VERBOSE).Info("Completed running scorer plugins successfully")
+	logger.V(logutil.VERBOSE).Info("Completed running scorer plugins successfully", "aggregation", strategy)
 
 	return weightedScorePerEndpoint
 }
 
-func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, weightedScorePerEndpoint map[fwksched.Endpoint]float64) *fwksched.ProfileRunResult {
+func (p *SchedulerProfile) runScorerPlugin(ctx context.Context, scorer *WeightedScorer, request *fwksched.InferenceRequest,
+	endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	logger := log.FromContext(ctx)
+	logger.V(logutil.VERBOSE).Info("Running scorer plugin", "plugin", scorer.TypedName())
+	before := time.Now()
+	scores := scorer.Score(ctx, request, endpoints)
+	metrics.RecordPluginProcessingLatency(scorerExtensionPoint, scorer.TypedName().Type, scorer.TypedName().Name, time.Since(before))
+	logger.V(logutil.DEBUG).Info("Completed running scorer plugin successfully", "plugin", scorer.TypedName())
+	return scores
+}
+
+func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, request *fwksched.InferenceRequest,
+	weightedScorePerEndpoint map[fwksched.Endpoint]float64) *fwksched.ProfileRunResult {
 	logger := log.FromContext(ctx)
 
 	// Allocate the ScoredEndpoint values as a single contiguous backing array
@@ -205,7 +266,12 @@ func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, weightedScorePer
 	logger.V(logutil.VERBOSE).Info("Running picker plugin", "plugin", p.picker.TypedName())
 	logger.V(logutil.DEBUG).Info("Candidate pods for picking", "endpoints-weighted-score", scoredEndpoints)
 	before := time.Now()
-	result := p.picker.Pick(ctx, scoredEndpoints)
+	var result *fwksched.ProfileRunResult
+	if requestAware, ok := p.picker.(fwksched.RequestAwarePicker); ok {
+		result = requestAware.PickForRequest(ctx, request, scoredEndpoints)
+	} else {
+		result = p.picker.Pick(ctx, scoredEndpoints)
+	}
 	metrics.RecordPluginProcessingLatency(pickerExtensionPoint, p.picker.TypedName().Type, p.picker.TypedName().Name, time.Since(before))
 	logger.V(logutil.DEBUG).Info("Completed running picker plugin successfully", "plugin", p.picker.TypedName(), "result", result)
 
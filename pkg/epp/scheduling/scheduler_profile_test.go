@@ -412,6 +412,44 @@ func TestRunWithOutOfRangeScores(t *testing.T) {
 	}
 }
 
+// requestAwareTestPicker records the request it is handed.
+type requestAwareTestPicker struct {
+	testPlugin
+	request *fwksched.InferenceRequest
+}
+
+func (tp *requestAwareTestPicker) PickForRequest(ctx context.Context, request *fwksched.InferenceRequest,
+	scoredEndpoints []*fwksched.ScoredEndpoint) *fwksched.ProfileRunResult {
+	tp.request = request
+	return tp.Pick(ctx, scoredEndpoints)
+}
+
+func TestRunWithRequestAwarePicker(t *testing.T) {
+	pickerPlugin := &requestAwareTestPicker{testPlugin: testPlugin{
+		TypeRes: "picker",
+		PickRes: k8stypes.NamespacedName{Name: "pod1"},
+	}}
+	profile := NewSchedulerProfile().WithPicker(pickerPlugin)
+	input := []fwksched.Endpoint{
+		fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: "pod1"}}, nil, nil),
+	}
+	request := &fwksched.InferenceRequest{TargetModel: "test-model", RequestID: uuid.NewString()}
+
+	result, err := profile.Run(context.Background(), request, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.TargetEndpoints) != 1 {
+		t.Fatalf("expected 1 target endpoint, got %d", len(result.TargetEndpoints))
+	}
+	if pickerPlugin.request != request {
+		t.Errorf("expected PickForRequest to receive the scheduled request")
+	}
+	if pickerPlugin.PickCallCount != 1 {
+		t.Errorf("expected the picker to run once, got %d", pickerPlugin.PickCallCount)
+	}
+}
+
 // TestFilterExecutionOrder verifies that filters execute in the order they are
 // registered in the scheduling profile. See also TestFilterExecutionOrderFromYAML
 // in pkg/epp/config/loader which verifies that YAML declaration order is preserved
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	// WeightedSumAggregation adds up the weighted scorer outputs. This is the default.
+	WeightedSumAggregation = "weightedSum"
+	// MinMaxAggregation rescales each scorer's outputs to [0, 1] across the candidate
+	// endpoints before the weighted sum.
+	MinMaxAggregation = "minMax"
+	// RankAggregation replaces each scorer's outputs by their normalized rank across the
+	// candidate endpoints before the weighted sum.
+	RankAggregation = "rank"
+	// LexicographicAggregation orders endpoints by the first scorer, breaking ties with the
+	// next scorer and so on. Weights are ignored.
+	LexicographicAggregation = "lexicographic"
+	// WeightedGeometricMeanAggregation combines the scorer outputs as a weighted geometric mean.
+	WeightedGeometricMeanAggregation = "weightedGeometricMean"
+)
+
+// ScoreAggregator combines the outputs of a profile's scorers into a single score per endpoint.
+type ScoreAggregator interface {
+	// Strategy returns the name of the aggregation strategy.
+	Strategy() string
+	// Aggregate receives the scorer weights and a matrix of clamped scores, where
+	// scores[i][j] is the score scorer i gave endpoint j, and returns the aggregated
+	// score of every endpoint.
+	Aggregate(weights []float64, scores [][]float64) []float64
+}
+
+// IsValidScoreAggregationStrategy reports whether strategy names a built-in aggregation strategy.
+func IsValidScoreAggregationStrategy(strategy string) bool {
+	switch strategy {
+	case WeightedSumAggregation, MinMaxAggregation, RankAggregation,
+		LexicographicAggregation, WeightedGeometricMeanAggregation:
+		return true
+	}
+	return false
+}
+
+// NewScoreAggregator returns the built-in aggregator for the given strategy. epsilon is
+// only used by the lexicographic strategy, where scores of a tier that differ by at most
+// epsilon are considered tied.
+func NewScoreAggregator(strategy string, epsilon float64) (ScoreAggregator, error) {
+	if epsilon < 0 || math.IsNaN(epsilon) {
+		return nil, fmt.Errorf("score aggregation epsilon must be non-negative, got %v", epsilon)
+	}
+	switch strategy {
+	case "", WeightedSumAggregation:
+		return &normalizedSumAggregator{strategy: WeightedSumAggregation}, nil
+	case MinMaxAggregation:
+		return &normalizedSumAggregator{strategy: MinMaxAggregation, normalize: minMaxNormalize}, nil
+	case RankAggregation:
+		return &normalizedSumAggregator{strategy: RankAggregation, normalize: rankNormalize}, nil
+	case LexicographicAggregation:
+		return &lexicographicAggregator{epsilon: epsilon}, nil
+	case WeightedGeometricMeanAggregation:
+		return &geometricMeanAggregator{}, nil
+	}
+	return nil, fmt.Errorf("unknown score aggregation strategy '%s'", strategy)
+}
+
+// normalizedSumAggregator optionally normalizes every scorer's outputs and then adds them
+// up weighted.
+type normalizedSumAggregator struct {
+	strategy  string
+	normalize func(scores []float64) []float64
+}
+
+func (a *normalizedSumAggregator) Strategy() string {
+	return a.strategy
+}
+
+func (a *normalizedSumAggregator) Aggregate(weights []float64, scores [][]float64) []float64 {
+	var result []float64
+	for i, scorerScores := range scores {
+		if result == nil {
+			result = make([]float64, len(scorerScores))
+		}
+		if a.normalize != nil {
+			scorerScores = a.normalize(scorerScores)
+		}
+		for j, score := range scorerScores {
+			result[j] += score * weights[i]
+		}
+	}
+	return result
+}
+
+// minMaxNormalize maps the lowest score to 0 and the highest to 1. A scorer that gives every
+// endpoint the same score does not discriminate between them and contributes 0.
+func minMaxNormalize(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	if len(scores) == 0 {
+		return normalized
+	}
+	lowest, highest := scores[0], scores[0]
+	for _, score := range scores[1:] {
+		lowest = math.Min(lowest, score)
+		highest = math.Max(highest, score)
+	}
+	if highest == lowest {
+		return normalized
+	}
+	for j, score := range scores {
+		normalized[j] = (score - lowest) / (highest - lowest)
+	}
+	return normalized
+}
+
+// rankNormalize maps the scores to their rank, scaled so that the worst endpoint gets 0 and
+// the best gets 1. Tied endpoints share the average of the ranks they span.
+func rankNormalize(scores []float64) []float64 {
+	normalized := make([]float64, len(scores))
+	if len(scores) < 2 {
+		for j := range normalized {
+			normalized[j] = 1
+		}
+		return normalized
+	}
+	order := make([]int, len(scores))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] < scores[order[b]] })
+	scale := float64(len(scores) - 1)
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && scores[order[end]] == scores[order[start]] {
+			end++
+		}
+		rank := float64(start+end-1) / 2 / scale
+		for _, j := range order[start:end] {
+			normalized[j] = rank
+		}
+		start = end
+	}
+	return normalized
+}
+
+// lexicographicAggregator treats the scorers, in profile order, as tiers. Endpoints are
+// ordered by their score in the first tier and only scores of later tiers break ties. The
+// resulting order is spread evenly over [0, 1], with the best endpoints scoring 1.
+type lexicographicAggregator struct {
+	epsilon float64
+}
+
+func (a *lexicographicAggregator) Strategy() string {
+	return LexicographicAggregation
+}
+
+func (a *lexicographicAggregator) Aggregate(_ []float64, scores [][]float64) []float64 {
+	if len(scores) == 0 {
+		return nil
+	}
+	n := len(scores[0])
+	result := make([]float64, n)
+	// buckets[i][j] is the tie group of endpoint j in tier i, 0 being the best.
+	buckets := make([][]int, len(scores))
+	for i, tierScores := range scores {
+		buckets[i] = a.bucketize(tierScores)
+	}
+	order := make([]int, n)
+	for j := range order {
+		order[j] = j
+	}
+	compare := func(x, y int) int {
+		for _, tier := range buckets {
+			if tier[x] != tier[y] {
+				return tier[x] - tier[y]
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(order, func(a, b int) bool { return compare(order[a], order[b]) < 0 })
+
+	groups := 1
+	for k := 1; k < n; k++ {
+		if compare(order[k-1], order[k]) != 0 {
+			groups++
+		}
+	}
+	group := 0
+	for k, j := range order {
+		if k > 0 && compare(order[k-1], j) != 0 {
+			group++
+		}
+		if groups == 1 {
+			result[j] = 1
+		} else {
+			result[j] = 1 - float64(group)/float64(groups-1)
+		}
+	}
+	return result
+}
+
+// bucketize groups the scores of a tier from highest to lowest. A group starts at its
+// highest score and absorbs every score within epsilon of it, which keeps the grouping
+// transitive.
+func (a *lexicographicAggregator) bucketize(scores []float64) []int {
+	order := make([]int, len(scores))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(x, y int) bool { return scores[order[x]] > scores[order[y]] })
+	buckets := make([]int, len(scores))
+	bucket := 0
+	head := 0.0
+	for k, j := range order {
+		if k == 0 {
+			head = scores[j]
+		} else if head-scores[j] > a.epsilon {
+			bucket++
+			head = scores[j]
+		}
+		buckets[j] = bucket
+	}
+	return buckets
+}
+
+// geometricMeanAggregator computes prod(score_i ^ (weight_i / sum(weights))). Unlike a sum,
+// an endpoint that scores 0 on any positively weighted scorer gets 0 overall, so every
+// scorer acts as a soft veto.
+type geometricMeanAggregator struct{}
+
+func (a *geometricMeanAggregator) Strategy() string {
+	return WeightedGeometricMeanAggregation
+}
+
+func (a *geometricMeanAggregator) Aggregate(weights []float64, scores [][]float64) []float64 {
+	if len(scores) == 0 {
+		return nil
+	}
+	result := make([]float64, len(scores[0]))
+	totalWeight := 0.0
+	for i := range scores {
+		if weights[i] > 0 {
+			totalWeight += weights[i]
+		}
+	}
+	if totalWeight == 0 {
+		return result
+	}
+	for j := range result {
+		logSum := 0.0
+		for i, scorerScores := range scores {
+			if weights[i] <= 0 {
+				continue
+			}
+			if scorerScores[j] <= 0 {
+				logSum = math.Inf(-1)
+				break
+			}
+			logSum += weights[i] / totalWeight * math.Log(scorerScores[j])
+		}
+		result[j] = math.Exp(logSum)
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/uuid"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	fwkdl "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/datalayer"
+	fwkplugin "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/plugin"
+	fwksched "github.com/llm-d/llm-d-router/pkg/epp/framework/interface/scheduling"
+)
+
+func TestScoreAggregators(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		epsilon  float64
+		weights  []float64
+		scores   [][]float64
+		want     []float64
+	}{
+		{
+			name:     "weighted sum",
+			strategy: WeightedSumAggregation,
+			weights:  []float64{1, 2},
+			scores:   [][]float64{{0.5, 1, 0}, {0.25, 0, 1}},
+			want:     []float64{1, 1, 2},
+		},
+		{
+			name:     "min-max rescales each scorer",
+			strategy: MinMaxAggregation,
+			weights:  []float64{1, 1},
+			scores:   [][]float64{{0.9, 0.95, 1}, {0.2, 0.1, 0}},
+			want:     []float64{1, 1, 1},
+		},
+		{
+			name:     "min-max ignores a scorer that does not discriminate",
+			strategy: MinMaxAggregation,
+			weights:  []float64{1, 5},
+			scores:   [][]float64{{0.2, 0.6, 0.4}, {0.7, 0.7, 0.7}},
+			want:     []float64{0, 1, 0.5},
+		},
+		{
+			name:     "rank averages ties",
+			strategy: RankAggregation,
+			weights:  []float64{2},
+			scores:   [][]float64{{0.3, 0.9, 0.3, 0.1}},
+			want:     []float64{1, 2, 1, 0},
+		},
+		{
+			name:     "rank of a single endpoint",
+			strategy: RankAggregation,
+			weights:  []float64{1},
+			scores:   [][]float64{{0.3}},
+			want:     []float64{1},
+		},
+		{
+			name:     "lexicographic breaks ties with the next tier",
+			strategy: LexicographicAggregation,
+			weights:  []float64{1, 100},
+			scores:   [][]float64{{0.8, 0.8, 0.9}, {0.1, 0.5, 0}},
+			want:     []float64{0, 0.5, 1},
+		},
+		{
+			name:     "lexicographic epsilon ties close scores",
+			strategy: LexicographicAggregation,
+			epsilon:  0.05,
+			weights:  []float64{1, 1},
+			scores:   [][]float64{{0.8, 0.78, 0.9, 0.72}, {0.1, 0.5, 0, 1}},
+			want:     []float64{1.0 / 3, 2.0 / 3, 1, 0},
+		},
+		{
+			name:     "lexicographic with identical endpoints",
+			strategy: LexicographicAggregation,
+			weights:  []float64{1},
+			scores:   [][]float64{{0.5, 0.5}},
+			want:     []float64{1, 1},
+		},
+		{
+			name:     "weighted geometric mean",
+			strategy: WeightedGeometricMeanAggregation,
+			weights:  []float64{1, 3},
+			scores:   [][]float64{{0.0625, 1, 0.5}, {1, 0.0625, 0}},
+			want:     []float64{0.5, 0.125, 0},
+		},
+		{
+			name:     "weighted geometric mean skips zero weights",
+			strategy: WeightedGeometricMeanAggregation,
+			weights:  []float64{1, 0},
+			scores:   [][]float64{{0.25, 0.5}, {0, 0}},
+			want:     []float64{0.25, 0.5},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			aggregator, err := NewScoreAggregator(test.strategy, test.epsilon)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if aggregator.Strategy() != test.strategy {
+				t.Errorf("expected strategy %s, got %s", test.strategy, aggregator.Strategy())
+			}
+			got := aggregator.Aggregate(test.weights, test.scores)
+			if diff := cmp.Diff(test.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("unexpected aggregated scores (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewScoreAggregatorErrors(t *testing.T) {
+	if _, err := NewScoreAggregator("median", 0); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+	if _, err := NewScoreAggregator(LexicographicAggregation, -0.1); err == nil {
+		t.Error("expected an error for a negative epsilon")
+	}
+	if _, err := NewScoreAggregator(LexicographicAggregation, math.NaN()); err == nil {
+		t.Error("expected an error for a NaN epsilon")
+	}
+	if !IsValidScoreAggregationStrategy(RankAggregation) || IsValidScoreAggregationStrategy("median") {
+		t.Error("unexpected IsValidScoreAggregationStrategy result")
+	}
+}
+
+// endpointScorer returns a fixed score per endpoint name.
+type endpointScorer struct {
+	name   string
+	scores map[string]float64
+}
+
+func (s *endpointScorer) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "endpoint-scorer", Name: s.name}
+}
+
+func (s *endpointScorer) Category() fwksched.ScorerCategory {
+	return fwksched.Distribution
+}
+
+func (s *endpointScorer) Score(_ context.Context, _ *fwksched.InferenceRequest, endpoints []fwksched.Endpoint) map[fwksched.Endpoint]float64 {
+	scores := make(map[fwksched.Endpoint]float64, len(endpoints))
+	for _, endpoint := range endpoints {
+		if score, ok := s.scores[endpoint.GetMetadata().NamespacedName.Name]; ok {
+			scores[endpoint] = score
+		}
+	}
+	return scores
+}
+
+func TestRunWithScoreAggregator(t *testing.T) {
+	// cache has no score for pod3, which is treated as 0 before normalizing.
+	queue := &endpointScorer{name: "queue", scores: map[string]float64{"pod1": 0, "pod2": 1, "pod3": 0.5}}
+	cache := &endpointScorer{name: "cache", scores: map[string]float64{"pod1": 0.55, "pod2": 0.5}}
+	input := []fwksched.Endpoint{
+		fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: "pod1"}}, nil, nil),
+		fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: "pod2"}}, nil, nil),
+		fwksched.NewEndpoint(&fwkdl.EndpointMetadata{NamespacedName: k8stypes.NamespacedName{Name: "pod3"}}, nil, nil),
+	}
+	request := &fwksched.InferenceRequest{TargetModel: "test-model", RequestID: uuid.NewString()}
+
+	tests := []struct {
+		name       string
+		aggregator ScoreAggregator
+		want       map[string]float64
+	}{
+		{
+			name: "default weighted sum",
+			want: map[string]float64{"pod1": 1.1, "pod2": 2, "pod3": 0.5},
+		},
+		{
+			name:       "min-max",
+			aggregator: mustScoreAggregator(t, MinMaxAggregation),
+			want:       map[string]float64{"pod1": 2, "pod2": 1 + 2*0.5/0.55, "pod3": 0.5},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			picker := &recordingPicker{}
+			profile := NewSchedulerProfile().
+				WithScorers(NewWeightedScorer(queue, 1), NewWeightedScorer(cache, 2)).
+				WithScoreAggregator(test.aggregator).
+				WithPicker(picker)
+
+			if _, err := profile.Run(context.Background(), request, input); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make(map[string]float64, len(picker.scored))
+			for _, scored := range picker.scored {
+				got[scored.GetMetadata().NamespacedName.Name] = scored.Score
+			}
+			if diff := cmp.Diff(test.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("unexpected scores handed to the picker (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+// recordingPicker records the scored endpoints it is handed and picks none.
+type recordingPicker struct {
+	scored []*fwksched.ScoredEndpoint
+}
+
+func (p *recordingPicker) TypedName() fwkplugin.TypedName {
+	return fwkplugin.TypedName{Type: "recording-picker", Name: "recording-picker"}
+}
+
+func (p *recordingPicker) Pick(_ context.Context, scoredEndpoints []*fwksched.ScoredEndpoint) *fwksched.ProfileRunResult {
+	p.scored = scoredEndpoints
+	return &fwksched.ProfileRunResult{}
+}
+
+func mustScoreAggregator(t *testing.T, strategy string) ScoreAggregator {
+	t.Helper()
+	aggregator, err := NewScoreAggregator(strategy, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return aggregator
+}
@@ -29,11 +29,17 @@ import (
 
 	"github.com/llm-d/llm-d-router/pkg/common/observability/logging"
 	"github.com/llm-d/llm-d-router/pkg/common/routing"
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
 )
 
 const (
 	DefaultGrpcPort      = 9002
 	DefaultPoolNamespace = "default" // default when pool namespace is empty (CLI flag default is empty)
+
+	// DefaultHTTPProxyMaxRequestBodySize bounds the request bodies buffered by the HTTP proxy server.
+	DefaultHTTPProxyMaxRequestBodySize = 64 * 1024 * 1024
+	// httpProxyReadHeaderTimeout bounds how long the HTTP proxy server waits for request headers.
+	httpProxyReadHeaderTimeout = 30 * time.Second
 )
 
 // deprecatedMetricFlags lists metric flags that are superseded by engineConfigs
@@ -57,12 +63,18 @@ type Options struct {
 	//
 	// ext_proc configuration.
 	//
-	GRPCPort              int    // gRPC port used for communicating with Envoy proxy. (TODO: uint16?)
-	EnableLeaderElection  bool   // Enables leader election for high availability
-	GRPCMaxRecvMsgSize    int    // Maximum size of a gRPC message to receive (parsed bytes).
-	GRPCMaxSendMsgSize    int    // Maximum size of a gRPC message to send (parsed bytes).
-	GRPCMaxRecvMsgSizeStr string // Raw string value from CLI flag for receive limit.
-	GRPCMaxSendMsgSizeStr string // Raw string value from CLI flag for send limit.
+	GRPCPort              int               // gRPC port used for communicating with Envoy proxy. (TODO: uint16?)
+	EnableLeaderElection  bool              // Enables leader election for high availability
+	GRPCMaxRecvMsgSize    int               // Maximum size of a gRPC message to receive (parsed bytes).
+	GRPCMaxSendMsgSize    int               // Maximum size of a gRPC message to send (parsed bytes).
+	GRPCMaxRecvMsgSizeStr string            // Raw string value from CLI flag for receive limit.
+	GRPCMaxSendMsgSizeStr string            // Raw string value from CLI flag for send limit.
+	ExtProcBodyMode       handlers.BodyMode // Body mode assumed when the proxy does not announce its processing mode (parsed).
+	ExtProcBodyModeStr    string            // Raw string value from CLI flag for the body mode.
+	//
+	// Standalone HTTP proxy configuration.
+	//
+	HTTPProxyPort int // Port of the built-in HTTP/1.1 and h2c reverse proxy, used in lieu of Envoy. Disabled when 0.
 	//
 	// InferencePool.
 	//
@@ -76,6 +88,16 @@ type Options struct {
 	EndpointTargetPorts         []int  // Target ports of model server pods.
 	DisableEndpointSubsetFilter bool   // Disables respecting destination endpoint subset metadata in EPP.
 	//
+	// Retries.
+	//
+	RetryBudget      int           // Maximum number of reschedules of a request retried by the proxy. Disabled when 0.
+	RetryTrackingTTL time.Duration // Duration for which the endpoints tried by a request are remembered.
+	//
+	// Autoscaling.
+	//
+	ExternalScalerPort             int     // Port of the KEDA external scaler gRPC server. Disabled when 0.
+	ExternalScalerTargetSaturation float64 // Saturation per replica the desired replica count aims for.
+	//
 	// MSP metrics scraping.
 	//
 	ModelServerMetricsScheme         string        // Protocol scheme used in scraping metrics from endpoints.
@@ -117,9 +139,13 @@ type Options struct {
 func NewOptions() *Options {
 	return &Options{ // "zero" values are no explicitly set
 		GRPCPort:                         DefaultGrpcPort,
+		ExtProcBodyMode:                  handlers.BodyModeFullDuplexStreamed,
+		ExtProcBodyModeStr:               handlers.BodyModeFullDuplexStreamed.String(),
 		PoolGroup:                        routing.InferencePoolAPIGroup,
 		EndpointTargetPorts:              []int{},
 		DisableEndpointSubsetFilter:      false,
+		RetryTrackingTTL:                 time.Minute,
+		ExternalScalerTargetSaturation:   0.8,
 		ModelServerMetricsScheme:         "http",
 		ModelServerMetricsPath:           "/metrics",
 		ModelServerMetricsHTTPSInsecure:  true,
@@ -152,6 +178,12 @@ func (opts *Options) AddFlags(fs *pflag.FlagSet) {
 		"Enables leader election for high availability. When enabled, readiness probes will only pass on the leader.")
 	fs.StringVar(&opts.GRPCMaxRecvMsgSizeStr, "grpc-max-recv-msg-size", opts.GRPCMaxRecvMsgSizeStr, "Maximum size of a gRPC message to receive (e.g., 10MiB, 25MB).")
 	fs.StringVar(&opts.GRPCMaxSendMsgSizeStr, "grpc-max-send-msg-size", opts.GRPCMaxSendMsgSizeStr, "Maximum size of a gRPC message to send (e.g., 10MiB, 25MB).")
+	fs.StringVar(&opts.ExtProcBodyModeStr, "ext-proc-body-mode", opts.ExtProcBodyModeStr,
+		"Body mode of the proxy's ext_proc filter, used when the proxy does not announce it on the stream. "+
+			"One of FULL_DUPLEX_STREAMED or BUFFERED; STREAMED is only supported when announced by the proxy.")
+	fs.IntVar(&opts.HTTPProxyPort, "http-proxy-port", opts.HTTPProxyPort,
+		"Port of the built-in HTTP/1.1 and h2c reverse proxy. When set, the EPP also serves inference requests directly "+
+			"and forwards them to the picked endpoint, without requiring Envoy in front of it. Disabled when 0.")
 	fs.StringVar(&opts.PoolGroup, "pool-group", opts.PoolGroup,
 		"Kubernetes resource group of the InferencePool this Endpoint Picker is associated with. Only `inference.networking.k8s.io/v1` is currently supported.")
 	fs.StringVar(&opts.PoolNamespace, "pool-namespace", opts.PoolNamespace,
@@ -164,6 +196,17 @@ func (opts *Options) AddFlags(fs *pflag.FlagSet) {
 		"Format: a comma-separated list of numbers without whitespace (e.g., '3000,3001,3002').")
 	fs.BoolVar(&opts.DisableEndpointSubsetFilter, "disable-endpoint-subset-filter", opts.DisableEndpointSubsetFilter,
 		"Disables respecting the destination endpoint subset metadata for dispatching requests in EPP.")
+	fs.IntVar(&opts.RetryBudget, "retry-budget", opts.RetryBudget,
+		"Maximum number of times a request retried by the proxy (identified by the x-envoy-attempt-count and x-request-id headers) "+
+			"is rescheduled away from the endpoints it already tried. Retries beyond the budget are rejected. Disabled when 0.")
+	fs.DurationVar(&opts.RetryTrackingTTL, "retry-tracking-ttl", opts.RetryTrackingTTL,
+		"Duration for which the endpoints tried by a request are remembered after its last attempt.")
+	fs.IntVar(&opts.ExternalScalerPort, "external-scaler-port", opts.ExternalScalerPort,
+		"Port of the gRPC server implementing the KEDA external scaler protocol, which exposes the desired replica count "+
+			"of the pool to autoscalers. Disabled when 0.")
+	fs.Float64Var(&opts.ExternalScalerTargetSaturation, "external-scaler-target-saturation", opts.ExternalScalerTargetSaturation,
+		"Saturation per replica the desired replica count reported by the external scaler aims for, in (0, 1]. "+
+			"Can be overridden per ScaledObject with the 'targetSaturation' scaler metadata.")
 	fs.StringVar(&opts.ModelServerMetricsScheme, "model-server-metrics-scheme", opts.ModelServerMetricsScheme,
 		"Protocol scheme used in scraping metrics from endpoints.")
 	_ = fs.MarkDeprecated("model-server-metrics-scheme", "This flag is deprecated. Configure via EndpointPickerConfig data layer plugin parameters instead.")
@@ -260,6 +303,13 @@ func (opts *Options) Complete() error {
 		}
 		opts.GRPCMaxSendMsgSize = int(val)
 	}
+	if opts.ExtProcBodyModeStr != "" {
+		mode, err := handlers.ParseBodyMode(opts.ExtProcBodyModeStr)
+		if err != nil {
+			return fmt.Errorf("invalid ext-proc-body-mode: %w", err)
+		}
+		opts.ExtProcBodyMode = mode
+	}
 
 	// Complete logging options.
 	return opts.LoggingOptions.Complete()
@@ -288,6 +338,25 @@ func (opts *Options) Validate() error {
 			opts.ModelServerMetricsScheme, "model-server-metrics-scheme")
 	}
 
+	if opts.HTTPProxyPort < 0 || opts.HTTPProxyPort > 65535 {
+		return fmt.Errorf("invalid port number %d in %q", opts.HTTPProxyPort, "http-proxy-port")
+	}
+	if opts.HTTPProxyPort != 0 && opts.HTTPProxyPort == opts.GRPCPort {
+		return fmt.Errorf("flag %q must differ from %q", "http-proxy-port", "grpc-port")
+	}
+
+	if opts.RetryBudget < 0 {
+		return fmt.Errorf("retry-budget must be non-negative, got %d", opts.RetryBudget)
+	}
+	if opts.RetryBudget > 0 && opts.RetryTrackingTTL <= 0 {
+		return fmt.Errorf("retry-tracking-ttl must be positive when retry-budget is set, got %s", opts.RetryTrackingTTL)
+	}
+	if opts.ExternalScalerPort < 0 || opts.ExternalScalerPort > 65535 {
+		return fmt.Errorf("invalid port number %d in %q", opts.ExternalScalerPort, "external-scaler-port")
+	}
+	if opts.ExternalScalerTargetSaturation <= 0 || opts.ExternalScalerTargetSaturation > 1 {
+		return fmt.Errorf("external-scaler-target-saturation must be in (0, 1], got %v", opts.ExternalScalerTargetSaturation)
+	}
 	if opts.GRPCMaxRecvMsgSize < 0 {
 		return fmt.Errorf("grpc-max-recv-msg-size must be non-negative, got %d", opts.GRPCMaxRecvMsgSize)
 	}
@@ -17,10 +17,13 @@ limitations under the License.
 package server
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/pflag"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
 )
 
 // TestEndpointTargetPorts
@@ -246,3 +249,139 @@ func TestValidateDirectValues(t *testing.T) {
 		t.Errorf("Expected Validate() to fail for negative GRPCMaxSendMsgSize, but it succeeded")
 	}
 }
+
+func TestHTTPProxyPortValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		port        int
+		expectError bool
+	}{
+		{name: "Disabled by default", port: 0},
+		{name: "Valid port", port: 8081},
+		{name: "Negative port", port: -1, expectError: true},
+		{name: "Over max port range", port: 65536, expectError: true},
+		{name: "Same as gRPC port", port: DefaultGrpcPort, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			opts := NewOptions()
+			opts.AddFlags(fs)
+			argv := []string{"--pool-name", "test-pool", "--http-proxy-port", strconv.Itoa(tt.port)}
+			if err := fs.Parse(argv); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			err := opts.Validate()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected Validate() to fail for http-proxy-port %d, but it succeeded", tt.port)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Validate() failed unexpectedly: %v", err)
+			}
+		})
+	}
+}
+
+func TestExtProcBodyModeFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		want        handlers.BodyMode
+		expectError bool
+	}{
+		{name: "Full duplex by default", want: handlers.BodyModeFullDuplexStreamed},
+		{name: "Streamed is not a default", args: []string{"--ext-proc-body-mode", "STREAMED"}, expectError: true},
+		{name: "Buffered lower case", args: []string{"--ext-proc-body-mode", "buffered"}, want: handlers.BodyModeBuffered},
+		{name: "Unsupported mode", args: []string{"--ext-proc-body-mode", "BUFFERED_PARTIAL"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			opts := NewOptions()
+			opts.AddFlags(fs)
+			if err := fs.Parse(append([]string{"--pool-name", "test-pool"}, tt.args...)); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			err := opts.Complete()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected Complete() to fail for %v, but it succeeded", tt.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Complete() failed unexpectedly: %v", err)
+			}
+			if opts.ExtProcBodyMode != tt.want {
+				t.Errorf("Expected body mode %s, got %s", tt.want, opts.ExtProcBodyMode)
+			}
+		})
+	}
+}
+
+func TestRetryFlagsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{name: "Disabled by default"},
+		{name: "Budget set", args: []string{"--retry-budget", "2"}},
+		{name: "Budget and TTL set", args: []string{"--retry-budget", "2", "--retry-tracking-ttl", "30s"}},
+		{name: "Negative budget", args: []string{"--retry-budget", "-1"}, expectError: true},
+		{name: "Zero TTL with budget", args: []string{"--retry-budget", "1", "--retry-tracking-ttl", "0s"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			opts := NewOptions()
+			opts.AddFlags(fs)
+			if err := fs.Parse(append([]string{"--pool-name", "test-pool"}, tt.args...)); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			err := opts.Validate()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected Validate() to fail for %v, but it succeeded", tt.args)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Validate() failed unexpectedly: %v", err)
+			}
+		})
+	}
+}
+
+func TestExternalScalerFlagsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{name: "Disabled by default"},
+		{name: "Port set", args: []string{"--external-scaler-port", "9005"}},
+		{name: "Target saturation set", args: []string{"--external-scaler-port", "9005", "--external-scaler-target-saturation", "1"}},
+		{name: "Invalid port", args: []string{"--external-scaler-port", "70000"}, expectError: true},
+		{name: "Zero target saturation", args: []string{"--external-scaler-target-saturation", "0"}, expectError: true},
+		{name: "Target saturation above 1", args: []string{"--external-scaler-target-saturation", "1.5"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			opts := NewOptions()
+			opts.AddFlags(fs)
+			if err := fs.Parse(append([]string{"--pool-name", "test-pool"}, tt.args...)); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+			err := opts.Validate()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected Validate() to fail for %v, but it succeeded", tt.args)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Validate() failed unexpectedly: %v", err)
+			}
+		})
+	}
+}
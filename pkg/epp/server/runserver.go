@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net/http"
 	"time"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
@@ -64,6 +65,8 @@ type ExtProcServerRunner struct {
 	PriorityBandControlPlane         contracts.PriorityBandControlPlane
 	GRPCMaxRecvMsgSize               int
 	GRPCMaxSendMsgSize               int
+	BodyMode                         handlers.BodyMode
+	HTTPProxyPort                    int
 }
 
 // NewDefaultExtProcServerRunner creates a runner with default values.
@@ -85,6 +88,7 @@ func NewDefaultExtProcServerRunner() *ExtProcServerRunner {
 		GrpcPort:           opts.GRPCPort,
 		GRPCMaxRecvMsgSize: opts.GRPCMaxRecvMsgSize,
 		GRPCMaxSendMsgSize: opts.GRPCMaxSendMsgSize,
+		BodyMode:           opts.ExtProcBodyMode,
 		GKNN:               gknn,
 		ControllerCfg: ControllerConfig{
 			startCrdReconcilers:       true,
@@ -201,6 +205,9 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 			poolCap = 4 * 1024 * 1024 // gRPC default 4MB
 		}
 		extProcServer := handlers.NewStreamingServer(r.Datastore, r.Director, r.ParserRegistry, poolCap)
+		if r.BodyMode != handlers.BodyModeNone {
+			extProcServer.SetDefaultBodyMode(r.BodyMode)
+		}
 		extProcPb.RegisterExternalProcessorServer(srv, extProcServer)
 
 		if r.HealthChecking {
@@ -217,3 +224,19 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 		return runnable.GRPCServer("ext-proc", srv, r.GrpcPort).Start(ctx)
 	}))
 }
+
+// AsHTTPProxyRunnable returns a Runnable that can be used to start the standalone HTTP reverse-proxy server.
+// The server accepts HTTP/1.1 and cleartext HTTP/2 (h2c) and runs the same request handling pipeline as the
+// ext-proc server, forwarding requests to the picked endpoint itself. The runnable implements
+// LeaderElectionRunnable with leader election disabled.
+func (r *ExtProcServerRunner) AsHTTPProxyRunnable() manager.Runnable {
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Handler:           handlers.NewHTTPProxyServer(r.Datastore, r.Director, r.ParserRegistry, DefaultHTTPProxyMaxRequestBodySize, nil),
+		Protocols:         &protocols,
+		ReadHeaderTimeout: httpProxyReadHeaderTimeout,
+	}
+	return runnable.NoLeaderElection(runnable.HTTPServer("http-proxy", srv, r.HTTPProxyPort))
+}
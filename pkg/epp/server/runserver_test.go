@@ -36,3 +36,15 @@ func TestRunnable(t *testing.T) {
 		t.Error("runner returned NeedLeaderElection = true, expected false")
 	}
 }
+
+func TestHTTPProxyRunnable(t *testing.T) {
+	// Make sure AsHTTPProxyRunnable() does not use leader election.
+	runner := server.NewDefaultExtProcServerRunner().AsHTTPProxyRunnable()
+	r, ok := runner.(manager.LeaderElectionRunnable)
+	if !ok {
+		t.Fatal("runner is not LeaderElectionRunnable")
+	}
+	if r.NeedLeaderElection() {
+		t.Error("runner returned NeedLeaderElection = true, expected false")
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -34,3 +36,17 @@ func IsPodReady(pod *corev1.Pod) bool {
 	}
 	return false
 }
+
+// ReadySince returns when the pod last became ready, or the zero time if it is
+// not ready or the transition time is unknown.
+func ReadySince(pod *corev1.Pod) time.Time {
+	if !IsPodReady(pod) {
+		return time.Time{}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
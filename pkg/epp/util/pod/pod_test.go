@@ -123,3 +123,63 @@ func TestIsPodReady(t *testing.T) {
 		})
 	}
 }
+
+func TestReadySince(t *testing.T) {
+	readyAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected time.Time
+	}{
+		{
+			name: "Ready pod",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.PodReady,
+							Status:             corev1.ConditionTrue,
+							LastTransitionTime: metav1.Time{Time: readyAt},
+						},
+					},
+				},
+			},
+			expected: readyAt,
+		},
+		{
+			name: "Unready pod",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:               corev1.PodReady,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: metav1.Time{Time: readyAt},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Ready pod without transition time",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					Conditions: []corev1.PodCondition{
+						{
+							Type:   corev1.PodReady,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ReadySince(tt.pod); !result.Equal(tt.expected) {
+				t.Errorf("ReadySince() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -167,7 +167,8 @@ func (s *Server) mmItemsForFanout(originalRequest map[string]any, requestID stri
 //
 // The first goroutine to fail cancels ctx so sibling encoder requests are
 // aborted at the transport layer. Every failure is logged before propagating;
-// grp.Wait returns the first non-nil error.
+// grp.Wait returns the first non-nil error. When the client disconnects, the
+// pending encoder requests are cancelled with ctx and ctx's error is returned.
 func (s *Server) fanoutEncoder(
 	ctx context.Context,
 	originalRequest map[string]any,
@@ -233,7 +234,15 @@ func (s *Server) fanoutEncoder(
 			return nil
 		})
 	}
-	return grp.Wait()
+	if err := grp.Wait(); err != nil {
+		if ctx.Err() != nil {
+			s.logger.V(logging.DEBUG).Info("client disconnected, encoder requests cancelled", "requestID", requestID)
+			recordCancelledRequest(s.config.ECConnector, cancelStageEncode)
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
 }
 
 // runPDPipeline finalizes the post-encoder request and dispatches it to the
@@ -104,6 +104,10 @@ func (s *Server) handleECNIXL(w http.ResponseWriter, r *http.Request, prefillEnd
 	if len(encodeEndPoints) > 0 {
 		params, contributed, total, err := s.fanoutEncoderCollect(r.Context(), completionRequest, encodeEndPoints, requestID)
 		if err != nil {
+			if r.Context().Err() != nil {
+				// client disconnected; nobody to report the failure to
+				return
+			}
 			s.logger.Error(err, "encoder processing failed", "requestID", requestID)
 			if err := errorBadGateway(err, w); err != nil {
 				s.logger.Error(err, "failed to send error response to client")
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -414,8 +415,10 @@ func TestFanoutEncoderParentContextCancel(t *testing.T) {
 
 	encoderURL, err := url.Parse(encoderBackend.URL)
 	assert.NoError(t, err)
-	srv := NewProxy(Config{Port: "0", DecoderURL: encoderURL})
+	srv := NewProxy(Config{Port: "0", DecoderURL: encoderURL, ECConnector: ECConnectorNIXL})
 	srv.logger = log.Log
+	cancelled := cancelledRequestsTotal.WithLabelValues(ECConnectorNIXL, cancelStageEncode)
+	before := testutil.ToFloat64(cancelled)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -435,6 +438,8 @@ func TestFanoutEncoderParentContextCancel(t *testing.T) {
 	assert.Error(t, err, "canceled parent context must surface as an error")
 	assert.Less(t, elapsed, slowEncoderDelay/2,
 		"fanoutEncoderCollect must return after parent context cancellation, not wait for slow encoder; got %s", elapsed)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, before+1, testutil.ToFloat64(cancelled), "client disconnect must be counted as a cancelled encode")
 }
 
 // TestFanoutEncoderPerErrorVisibility verifies the "sibling visibility"
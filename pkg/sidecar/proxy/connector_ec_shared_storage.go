@@ -59,6 +59,10 @@ func (s *Server) handleECSharedStorage(w http.ResponseWriter, r *http.Request, p
 	// Step 1: Process through Encoder cluster (if has MM input)
 	if len(encodeEndPoints) > 0 {
 		if err := s.fanoutEncoderPrimer(r.Context(), completionRequest, encodeEndPoints, requestID); err != nil {
+			if r.Context().Err() != nil {
+				// client disconnected; nobody to report the failure to
+				return
+			}
 			s.logger.Error(err, "encoder processing failed", "requestID", requestID)
 			if err := errorBadGateway(err, w); err != nil {
 				s.logger.Error(err, "failed to send error response to client")
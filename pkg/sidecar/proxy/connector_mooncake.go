@@ -129,7 +129,7 @@ func (s *Server) handleMooncake(w http.ResponseWriter, r *http.Request, prefillP
 
 	s.logger.V(5).Info("Decode request", "body", string(decodeBody))
 
-	s.handleMooncakeConcurrentRequests(w, r, prefillBody, decodeBody, prefillPodHostPort, dpRank)
+	s.handleMooncakeConcurrentRequests(w, r, prefillBody, decodeBody, prefillPodHostPort, dpRank, transferID)
 }
 
 // getMooncakeEngineMap returns the dp_rank -> engine_id mapping for the given prefill, querying the bootstrap server on first use and caching it.
@@ -181,18 +181,10 @@ func (s *Server) getMooncakeEngineMap(ctx context.Context, prefillHostPort, boot
 	return engineMap, nil
 }
 
-func (s *Server) handleMooncakeConcurrentRequests(w http.ResponseWriter, r *http.Request, prefillBody, decodeBody []byte, prefillHost, dpRank string) {
+func (s *Server) handleMooncakeConcurrentRequests(w http.ResponseWriter, r *http.Request, prefillBody, decodeBody []byte, prefillHost, dpRank, transferID string) {
 	tracer := tracing.Tracer()
 	ctx := r.Context()
 
-	// WithoutCancel for prefill so it isn't aborted when the decode response finishes first
-	prefillReq := cloneRequestWithBody(context.WithoutCancel(ctx), r, prefillBody)
-	decodeReq := cloneRequestWithBody(ctx, r, decodeBody)
-
-	// Route prefill to the same DP rank whose engine_id was given to decode, so the
-	// KV it produces lands on the engine decode pulls from. No-op for a single rank.
-	prefillReq.Header.Set(mooncakeDataParallelRankHeader, dpRank)
-
 	// Prefill runs in a goroutine: only populates KV cache, response is discarded.
 	// Decode runs on the main thread: writes the actual response back to the client via w.
 	ctx, prefillSpan := tracer.Start(ctx, "llm_d.pd_proxy.prefill",
@@ -215,7 +207,20 @@ func (s *Server) handleMooncakeConcurrentRequests(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Prefill isn't aborted when the decode response finishes first, but is
+	// cancelled if the client disconnects during decode.
+	prefillCtx, detachPrefill, cancelPrefill := s.asyncPrefillContext(r.Context(), KVConnectorMooncake)
+	prefillReq := cloneRequestWithBody(prefillCtx, r, prefillBody)
+	decodeReq := cloneRequestWithBody(r.Context(), r, decodeBody)
+
+	// Route prefill to the same DP rank whose engine_id was given to decode, so the
+	// KV it produces lands on the engine decode pulls from. No-op for a single rank.
+	prefillReq.Header.Set(mooncakeDataParallelRankHeader, dpRank)
+	// Identify the prefill by its transfer ID, which the prefiller can be asked to abort.
+	prefillReq.Header.Set(requestHeaderRequestID, transferID)
+
 	go func() {
+		defer cancelPrefill()
 		defer prefillSpan.End()
 		defer func() {
 			if rec := recover(); rec != nil && rec != http.ErrAbortHandler {
@@ -225,12 +230,17 @@ func (s *Server) handleMooncakeConcurrentRequests(w http.ResponseWriter, r *http
 		// buffered writer captures response for status check only, not sent to client
 		pw := &bufferedResponseWriter{}
 		prefillHandler.ServeHTTP(pw, prefillReq)
+		detachPrefill()
 		prefillDuration := time.Since(prefillStart)
 		prefillSpan.SetAttributes(
 			attribute.Int("llm_d.pd_proxy.prefill.status_code", pw.statusCode),
 			attribute.Float64("llm_d.pd_proxy.prefill.duration_ms", float64(prefillDuration.Milliseconds())),
 		)
-		if isHTTPError(pw.statusCode) {
+		switch {
+		case prefillCtx.Err() != nil:
+			prefillSpan.SetAttributes(attribute.Bool("llm_d.pd_proxy.prefill.cancelled", true))
+			prefillSpan.SetStatus(codes.Error, "prefill request cancelled")
+		case isHTTPError(pw.statusCode):
 			prefillSpan.SetStatus(codes.Error, "prefill request failed")
 		}
 		s.logger.V(5).Info("mooncake prefill request completed", "status", pw.statusCode)
@@ -250,6 +260,14 @@ func (s *Server) handleMooncakeConcurrentRequests(w http.ResponseWriter, r *http
 
 	decodeReq = decodeReq.WithContext(ctx)
 	s.decoderProxy.ServeHTTP(w, decodeReq)
+	if r.Context().Err() == nil {
+		// decode completed normally; let the prefill run to completion on its own
+		detachPrefill()
+	} else {
+		// The prefiller holds the KV blocks of a completed prefill until the
+		// decoder reads them, so ask it to release them.
+		s.abortPrefill(r, KVConnectorMooncake, prefillHost, transferID)
+	}
 
 	decodeDuration := time.Since(decodeStart)
 	decodeSpan.SetAttributes(
@@ -156,6 +156,17 @@ func (s *Server) handleNIXLV2(w http.ResponseWriter, r *http.Request, prefillPod
 		attribute.Float64("llm_d.pd_proxy.prefill.duration_ms", float64(prefillDuration.Milliseconds())),
 	)
 
+	if isHTTPError(pw.statusCode) && r.Context().Err() != nil {
+		// The prefill was cancelled with the client request; there is nobody left
+		// to fall back to decode for or to report the error to.
+		s.logger.V(4).Info("client disconnected, prefill cancelled", "request_id", uuidStr)
+		recordCancelledRequest(KVConnectorNIXLV2, cancelStagePrefill)
+		prefillSpan.SetAttributes(attribute.Bool("llm_d.pd_proxy.prefill.cancelled", true))
+		prefillSpan.SetStatus(codes.Error, "prefill request cancelled")
+		prefillSpan.End()
+		return
+	}
+
 	if isHTTPError(pw.statusCode) {
 		s.logger.Error(err, "request failed", "code", pw.statusCode, "body", pw.buffer.String())
 		prefillSpan.SetStatus(codes.Error, "prefill request failed")
@@ -205,6 +216,16 @@ func (s *Server) handleNIXLV2(w http.ResponseWriter, r *http.Request, prefillPod
 
 	s.logger.V(5).Info("received prefiller response", requestFieldKVTransferParams, pKVTransferParams)
 
+	if r.Context().Err() != nil {
+		// The client disconnected after the prefill completed. The prefiller holds the
+		// KV blocks until a decoder reads them, so the decode is skipped and the
+		// prefiller is asked to release them.
+		s.logger.V(4).Info("client disconnected before decode", "request_id", uuidStr)
+		recordSkippedDecode(KVConnectorNIXLV2)
+		s.abortPrefill(r, KVConnectorNIXLV2, prefillPodHostPort, uuidStr)
+		return
+	}
+
 	// Decode Stage
 
 	ctx, decodeSpan := tracer.Start(ctx, "llm_d.pd_proxy.decode",
@@ -18,13 +18,16 @@ package proxy
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2" // nolint:revive
 	. "github.com/onsi/gomega"    // nolint:revive
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/llm-d/llm-d-router/pkg/common/routing"
 )
@@ -629,4 +632,49 @@ var _ = Describe("NIXL Connector (v2)", func() {
 		Expect(testInfo.prefillHandler.RequestCount.Load()).To(BeNumerically("==", 0))
 		Expect(testInfo.decodeHandler.RequestCount.Load()).To(BeNumerically("==", 1))
 	})
+	It("should cancel the prefill without falling back to decode when the client disconnects", func() {
+		prefill := newHeldBackend()
+		testInfo.replaceBackends(prefill, testInfo.decodeHandler)
+		cancelled := cancelledRequestsTotal.WithLabelValues(KVConnectorNIXLV2, cancelStagePrefill)
+		before := testutil.ToFloat64(cancelled)
+
+		proxyBaseAddr := startProxy()
+		testInfo.sendAndDisconnect(proxyBaseAddr, prefill.arrived)
+
+		Eventually(prefill.cancelled.Load).Should(Equal(int32(1)))
+		Eventually(func() float64 { return testutil.ToFloat64(cancelled) }).Should(Equal(before + 1))
+		Expect(testInfo.decodeHandler.RequestCount.Load()).To(BeZero())
+	})
+
+	It("should ask the prefiller to abort a prefill whose client disconnected before decode", func() {
+		aborts := newAbortRecorder()
+		testInfo.replaceBackends(withAbortEndpoint(testInfo.prefillHandler, aborts), testInfo.decodeHandler)
+		testInfo.proxy.config.PrefillerAbortPath = defaultPrefillerAbortPath
+		aborted := prefillAbortsTotal.WithLabelValues(KVConnectorNIXLV2, prefillAbortSuccess)
+		before := testutil.ToFloat64(aborted)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		r := httptest.NewRequestWithContext(ctx, http.MethodPost, ChatCompletionsPath, nil)
+		testInfo.proxy.abortPrefill(r, KVConnectorNIXLV2, testInfo.prefillBackend.URL[len("http://"):], "abort-test")
+
+		Expect(aborts.rids).To(Receive(Equal("abort-test")))
+		var headers http.Header
+		Expect(aborts.headers).To(Receive(&headers))
+		Expect(headers.Get(requestHeaderRequestID)).To(Equal("abort-test"))
+		Expect(testutil.ToFloat64(aborted)).To(Equal(before + 1))
+		Expect(testInfo.prefillHandler.RequestCount.Load()).To(BeZero())
+		Expect(testInfo.decodeHandler.RequestCount.Load()).To(BeZero())
+	})
+
+	It("should not abort the prefill when the abort path is empty", func() {
+		aborts := newAbortRecorder()
+		testInfo.replaceBackends(withAbortEndpoint(testInfo.prefillHandler, aborts), testInfo.decodeHandler)
+		testInfo.proxy.config.PrefillerAbortPath = ""
+
+		r := httptest.NewRequest(http.MethodPost, ChatCompletionsPath, nil)
+		testInfo.proxy.abortPrefill(r, KVConnectorNIXLV2, testInfo.prefillBackend.URL[len("http://"):], "abort-test")
+
+		Expect(aborts.rids).ToNot(Receive())
+	})
 })
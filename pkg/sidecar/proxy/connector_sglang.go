@@ -17,7 +17,6 @@ limitations under the License.
 package proxy
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -68,6 +67,13 @@ func (s *Server) handleSGLang(w http.ResponseWriter, r *http.Request, prefillPod
 	// Inject bootstrap info for both prefill and decode
 	bootstrapInfo := s.addSGLangBootstrapInfo(requestData, prefillPodHostPort, roomID)
 
+	// Tag the request with an ID the prefiller can abort, unless the client set its own.
+	rid, _ := bootstrapInfo[requestFieldRID].(string)
+	if _, ok := bootstrapInfo[requestFieldRID]; !ok {
+		rid = newUUID()
+		bootstrapInfo[requestFieldRID] = rid
+	}
+
 	body, err := json.Marshal(bootstrapInfo)
 	if err != nil {
 		if err := errorJSONInvalid(err, w); err != nil {
@@ -77,10 +83,10 @@ func (s *Server) handleSGLang(w http.ResponseWriter, r *http.Request, prefillPod
 	}
 
 	// Send concurrent prefill and decode requests
-	s.handleSGLangConcurrentRequests(w, r, body, prefillPodHostPort)
+	s.handleSGLangConcurrentRequests(w, r, body, prefillPodHostPort, rid)
 }
 
-func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.Request, body []byte, prefillHost string) {
+func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.Request, body []byte, prefillHost, rid string) {
 	tracer := tracing.Tracer()
 	ctx := r.Context()
 
@@ -95,12 +101,6 @@ func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.R
 	)
 	prefillStart := time.Now()
 
-	// Create separate requests for prefill and decode
-	// Use context.WithoutCancel for prefillReq to prevent it from being aborted
-	// if the main HTTP handler (which serves decodeReq) finishes first.
-	prefillReq := cloneRequestWithBody(context.WithoutCancel(r.Context()), r, body)
-	decodeReq := cloneRequestWithBody(r.Context(), r, body)
-
 	prefillHandler, err := s.prefillerProxyHandler(prefillHost)
 	if err != nil {
 		prefillSpan.SetStatus(codes.Error, "failed to create prefill handler")
@@ -111,8 +111,16 @@ func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// Create separate requests for prefill and decode.
+	// The prefill is not aborted if the main HTTP handler (which serves decodeReq)
+	// finishes first, but is cancelled if the client disconnects during decode.
+	prefillCtx, detachPrefill, cancelPrefill := s.asyncPrefillContext(r.Context(), KVConnectorSGLang)
+	prefillReq := cloneRequestWithBody(prefillCtx, r, body)
+	decodeReq := cloneRequestWithBody(r.Context(), r, body)
+
 	// Send prefill request asynchronously
 	go func() {
+		defer cancelPrefill()
 		defer prefillSpan.End()
 		defer func() {
 			if rec := recover(); rec != nil && rec != http.ErrAbortHandler {
@@ -121,12 +129,17 @@ func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.R
 		}()
 		pw := &bufferedResponseWriter{}
 		prefillHandler.ServeHTTP(pw, prefillReq)
+		detachPrefill()
 		prefillDuration := time.Since(prefillStart)
 		prefillSpan.SetAttributes(
 			attribute.Int("llm_d.pd_proxy.prefill.status_code", pw.statusCode),
 			attribute.Float64("llm_d.pd_proxy.prefill.duration_ms", float64(prefillDuration.Milliseconds())),
 		)
-		if pw.statusCode < 200 || pw.statusCode >= 300 {
+		switch {
+		case prefillCtx.Err() != nil:
+			prefillSpan.SetAttributes(attribute.Bool("llm_d.pd_proxy.prefill.cancelled", true))
+			prefillSpan.SetStatus(codes.Error, "prefill request cancelled")
+		case pw.statusCode < 200 || pw.statusCode >= 300:
 			prefillSpan.SetStatus(codes.Error, "prefill request failed")
 		}
 		s.logger.V(5).Info("prefill request completed", "status", pw.statusCode)
@@ -147,6 +160,14 @@ func (s *Server) handleSGLangConcurrentRequests(w http.ResponseWriter, r *http.R
 	// Send decode request synchronously
 	decodeReq = decodeReq.WithContext(ctx)
 	s.decoderProxy.ServeHTTP(w, decodeReq)
+	if r.Context().Err() == nil {
+		// decode completed normally; let the prefill run to completion on its own
+		detachPrefill()
+	} else {
+		// The prefiller holds the KV blocks of a completed prefill until the
+		// decoder reads them, so ask it to release them.
+		s.abortPrefill(r, KVConnectorSGLang, prefillHost, rid)
+	}
 
 	decodeDuration := time.Since(decodeStart)
 	decodeSpan.SetAttributes(
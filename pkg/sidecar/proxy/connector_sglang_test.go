@@ -27,6 +27,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2" // nolint:revive
 	. "github.com/onsi/gomega"    // nolint:revive
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/llm-d/llm-d-router/pkg/common/routing"
 )
@@ -174,6 +175,37 @@ var _ = Describe("SGLang Connector", func() {
 		Expect(testInfo.prefillHandler.RequestCount.Load()).To(BeNumerically("==", 1))
 		Expect(testInfo.decodeHandler.RequestCount.Load()).To(BeNumerically("==", 1))
 
+		testInfo.cancelFn()
+		<-testInfo.stoppedCh
+	})
+	It("should cancel the in-flight prefill and abort it on the prefiller when the client disconnects", func() {
+		prefill, decode, aborts := newHeldBackend(), newHeldBackend(), newAbortRecorder()
+		testInfo.replaceBackends(withAbortEndpoint(prefill, aborts), decode)
+		testInfo.proxy.config.PrefillerAbortPath = defaultPrefillerAbortPath
+		cancelled := cancelledRequestsTotal.WithLabelValues(KVConnectorSGLang, cancelStagePrefill)
+		before := testutil.ToFloat64(cancelled)
+		aborted := prefillAbortsTotal.WithLabelValues(KVConnectorSGLang, prefillAbortSuccess)
+		abortedBefore := testutil.ToFloat64(aborted)
+
+		proxyBaseAddr := testInfo.startProxy()
+
+		// disconnect once both the async prefill and the decode are in flight
+		inFlight := make(chan struct{})
+		go func() {
+			<-prefill.arrived
+			<-decode.arrived
+			close(inFlight)
+		}()
+		testInfo.sendAndDisconnect(proxyBaseAddr, inFlight)
+
+		Eventually(prefill.cancelled.Load).Should(Equal(int32(1)))
+		Eventually(decode.cancelled.Load).Should(Equal(int32(1)))
+		Eventually(func() float64 { return testutil.ToFloat64(cancelled) }).Should(Equal(before + 1))
+
+		// the prefiller is asked to abort the rid the sidecar tagged the request with
+		Eventually(aborts.rids).Should(Receive(Not(BeEmpty())))
+		Eventually(func() float64 { return testutil.ToFloat64(aborted) }).Should(Equal(abortedBefore + 1))
+
 		testInfo.cancelFn()
 		<-testInfo.stoppedCh
 	})
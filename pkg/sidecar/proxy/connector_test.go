@@ -19,11 +19,14 @@ package proxy
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" // nolint:revive
 	. "github.com/onsi/gomega"    // nolint:revive
@@ -70,6 +73,103 @@ func (testInfo *sidecarTestInfo) startProxy() string {
 	return "http://" + testInfo.proxy.addr.String()
 }
 
+// heldBackend is a mock vLLM endpoint that holds every request open until the
+// caller goes away, simulating a long-running prefill or decode.
+type heldBackend struct {
+	arrived   chan struct{}
+	cancelled atomic.Int32
+}
+
+func newHeldBackend() *heldBackend {
+	return &heldBackend{arrived: make(chan struct{}, 8)}
+}
+
+func (b *heldBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the server only notices the caller going away once the body is consumed
+	_, _ = io.Copy(io.Discard, r.Body)
+	b.arrived <- struct{}{}
+	select {
+	case <-r.Context().Done():
+		b.cancelled.Add(1)
+	case <-time.After(10 * time.Second):
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// abortRecorder is a mock prefiller abort endpoint recording the aborted
+// request IDs and the headers of the aborts.
+type abortRecorder struct {
+	rids    chan string
+	headers chan http.Header
+}
+
+func newAbortRecorder() *abortRecorder {
+	return &abortRecorder{rids: make(chan string, 8), headers: make(chan http.Header, 8)}
+}
+
+func (a *abortRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	rid, _ := body[requestFieldRID].(string)
+	a.rids <- rid
+	a.headers <- r.Header.Clone()
+	w.WriteHeader(http.StatusOK)
+}
+
+// withAbortEndpoint serves the default prefiller abort path with aborts and
+// every other path with next.
+func withAbortEndpoint(next, aborts http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("POST "+defaultPrefillerAbortPath, aborts)
+	mux.Handle("/", next)
+	return mux
+}
+
+// replaceBackends swaps the mock prefill and decode servers for the given
+// handlers and rebuilds the proxy so it targets the new decoder.
+func (testInfo *sidecarTestInfo) replaceBackends(prefill, decode http.Handler) {
+	testInfo.prefillBackend.Close()
+	testInfo.decodeBackend.Close()
+
+	testInfo.prefillBackend = httptest.NewServer(prefill)
+	DeferCleanup(testInfo.prefillBackend.Close)
+	testInfo.decodeBackend = httptest.NewServer(decode)
+	DeferCleanup(testInfo.decodeBackend.Close)
+
+	decodeURL, err := url.Parse(testInfo.decodeBackend.URL)
+	Expect(err).ToNot(HaveOccurred())
+	testInfo.decodeURL = decodeURL
+
+	cfg := testInfo.proxy.config
+	cfg.DecoderURL = decodeURL
+	testInfo.proxy = NewProxy(cfg)
+}
+
+// sendAndDisconnect sends a chat completion through the proxy and drops the
+// client connection as soon as disconnect is signalled.
+func (testInfo *sidecarTestInfo) sendAndDisconnect(proxyBaseAddr string, disconnect <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, proxyBaseAddr+ChatCompletionsPath, bytes.NewReader([]byte(chatCompletionsRequestBody)))
+	Expect(err).ToNot(HaveOccurred())
+	req.Header.Add(routing.PrefillEndpointHeader, testInfo.prefillBackend.URL[len("http://"):])
+
+	go func() {
+		select {
+		case <-disconnect:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	_, err = http.DefaultClient.Do(req)
+	Expect(err).To(MatchError(context.Canceled))
+}
+
 // SGLang and Mooncake excluded: async prefill requires Eventually and bootstrap server setup.
 var connectors = []string{KVConnectorSharedStorage, KVConnectorNIXLV2}
 
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsNamespace = "llm_d"
+	metricsSubsystem = "pd_sidecar"
+
+	// cancellation stages
+	cancelStagePrefill = "prefill"
+	cancelStageEncode  = "encode"
+
+	// prefill abort results
+	prefillAbortSuccess = "success"
+	prefillAbortFailure = "failure"
+)
+
+var (
+	// metricsRegistry holds the sidecar metrics. It is kept separate from the default
+	// registry so /metrics on the proxy port keeps being forwarded to vLLM.
+	metricsRegistry = prometheus.NewRegistry()
+
+	cancelledRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "cancelled_requests_total",
+			Help:      "Total number of in-flight prefill and encoder requests cancelled because the client disconnected.",
+		},
+		[]string{"connector", "stage"},
+	)
+
+	skippedDecodesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "skipped_decodes_total",
+			Help:      "Total number of decodes not started because the client disconnected after the prefill completed.",
+		},
+		[]string{"connector"},
+	)
+
+	prefillAbortsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "prefill_aborts_total",
+			Help:      "Total number of aborts sent to the prefiller to release the KV blocks of requests whose client disconnected.",
+		},
+		[]string{"connector", "result"},
+	)
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		cancelledRequestsTotal,
+		skippedDecodesTotal,
+		prefillAbortsTotal,
+	)
+}
+
+// recordCancelledRequest counts a request to the given stage aborted by a client disconnect.
+func recordCancelledRequest(connector, stage string) {
+	cancelledRequestsTotal.WithLabelValues(connector, stage).Inc()
+}
+
+// recordSkippedDecode counts a decode not started because the client disconnected.
+func recordSkippedDecode(connector string) {
+	skippedDecodesTotal.WithLabelValues(connector).Inc()
+}
+
+// recordPrefillAbort counts an abort sent to the prefiller with its result.
+func recordPrefillAbort(connector, result string) {
+	prefillAbortsTotal.WithLabelValues(connector, result).Inc()
+}
+
+// startMetrics serves the sidecar metrics on the configured metrics port.
+func (s *Server) startMetrics(ctx context.Context) error {
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(s.config.MetricsPort))
+	if err != nil {
+		s.logger.Error(err, "Failed to start metrics server")
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelFn()
+		if err := server.Shutdown(ctx); err != nil {
+			s.logger.Error(err, "failed to gracefully shutdown metrics server")
+		}
+	}()
+
+	s.logger.Info("starting metrics server", "addr", ln.Addr().String())
+	if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
+		s.logger.Error(err, "failed to start metrics server")
+		return err
+	}
+	return nil
+}
@@ -58,6 +58,8 @@ const (
 	inlineConfiguration       = "configuration"
 	configurationFile         = "configuration-file"
 	tracingFlag               = "tracing"
+	metricsPortFlag           = "metrics-port"
+	prefillerAbortPath        = "prefiller-abort-path"
 
 	// Deprecated flags
 	connector                      = "connector"
@@ -77,6 +79,7 @@ const (
 	defaultVLLMPort              = "8001"
 	defaultDataParallelSize      = 1
 	defaultMooncakeBootstrapPort = 8998
+	defaultPrefillerAbortPath    = "/abort_request"
 
 	// TLS stages
 	prefillStage = "prefiller"
@@ -108,6 +111,8 @@ type yamlConfiguration struct {
 	MaxIdleConnsPerHost            int      `json:"max-idle-conns-per-host,omitempty"`
 	DecodeChunkSize                int      `json:"decode-chunk-size,omitempty"`
 	Tracing                        *bool    `json:"tracing,omitempty"`
+	MetricsPort                    int      `json:"metrics-port,omitempty"`
+	PrefillerAbortPath             *string  `json:"prefiller-abort-path,omitempty"`
 }
 
 // Options holds the CLI-facing configuration for the pd-sidecar proxy.
@@ -194,6 +199,7 @@ func NewOptions() *Options {
 			PoolGroup:               routing.InferencePoolAPIGroup,
 			DecodeChunkSize:         0,
 			Tracing:                 false,
+			PrefillerAbortPath:      defaultPrefillerAbortPath,
 		},
 		vllmPort:      defaultVLLMPort,
 		inferencePool: os.Getenv(envInferencePool),
@@ -228,6 +234,8 @@ func (opts *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&opts.PoolGroup, poolGroup, opts.PoolGroup, "group of the InferencePool this Endpoint Picker is associated with.")
 	fs.IntVar(&opts.DecodeChunkSize, decodeChunkSize, opts.DecodeChunkSize, "enables chunked decode mode when > 0; value is the token budget per chunk. For best performance should be a multiple of the block size.")
 	fs.BoolVar(&opts.Tracing, tracingFlag, opts.Tracing, "Enable OpenTelemetry tracing")
+	fs.IntVar(&opts.MetricsPort, metricsPortFlag, opts.MetricsPort, "the port Prometheus metrics are served on under /metrics; 0 disables the metrics endpoint")
+	fs.StringVar(&opts.PrefillerAbortPath, prefillerAbortPath, opts.PrefillerAbortPath, "the path on the prefiller to POST {\"rid\": <request id>} to, releasing the KV blocks of a request whose client disconnected; empty disables the aborts")
 
 	fs.StringSliceVar(&opts.enableTLS, enableTLS, opts.enableTLS, "stages to enable TLS for. Supported: "+supportedTLSStageNamesStr+". Can be specified multiple times or as comma-separated values.")
 	fs.StringSliceVar(&opts.tlsInsecureSkipVerify, tlsInsecureSkipVerify, opts.tlsInsecureSkipVerify, "stages to skip TLS verification for. Supported: "+supportedTLSStageNamesStr+". Can be specified multiple times or as comma-separated values.")
@@ -376,6 +384,16 @@ func (opts *Options) Validate() error {
 		return fmt.Errorf("--mooncake-bootstrap-port must be between 1 and 65535, got %d", opts.MooncakeBootstrapPort)
 	}
 
+	// Validate metrics port (0 disables the metrics endpoint)
+	if opts.MetricsPort < 0 || opts.MetricsPort > 65535 {
+		return fmt.Errorf("--metrics-port must be between 0 and 65535, got %d", opts.MetricsPort)
+	}
+
+	// Validate prefiller abort path (empty disables the aborts)
+	if opts.PrefillerAbortPath != "" && !strings.HasPrefix(opts.PrefillerAbortPath, "/") {
+		return fmt.Errorf("--prefiller-abort-path must start with '/', got %q", opts.PrefillerAbortPath)
+	}
+
 	// Validate SSRF protection requirements
 	if opts.EnableSSRFProtection {
 		if opts.InferencePoolNamespace == "" || opts.InferencePoolName == "" {
@@ -537,6 +555,12 @@ func (opts *Options) mergeYAMLConfiguration(cfg yamlConfiguration) {
 	if cfg.Tracing != nil && !opts.isFlagSet(tracingFlag) {
 		opts.Tracing = *cfg.Tracing
 	}
+	if cfg.MetricsPort != 0 && !opts.isFlagSet(metricsPortFlag) {
+		opts.MetricsPort = cfg.MetricsPort
+	}
+	if cfg.PrefillerAbortPath != nil && !opts.isFlagSet(prefillerAbortPath) {
+		opts.PrefillerAbortPath = *cfg.PrefillerAbortPath
+	}
 }
 
 // isFlagSet returns true if flag was set by user
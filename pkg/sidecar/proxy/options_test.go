@@ -64,6 +64,7 @@ max-idle-conns-per-host: 300
 decode-chunk-size: 128
 mooncake-bootstrap-port: 9000
 tracing: true
+metrics-port: 9090
 `, KVConnectorSGLang, KVConnectorNIXLV2, ECExampleConnector))
 }
 
@@ -107,7 +108,9 @@ func TestSidecarConfiguration(t *testing.T) {
 		max-idle-conns-per-host: 200,
 		decode-chunk-size: 256,
 		mooncake-bootstrap-port: 9001,
-		tracing: true
+		tracing: true,
+		metrics-port: 9091,
+		prefiller-abort-path: ''
 	}`, KVConnectorSGLang, KVConnectorNIXLV2, ECExampleConnector)
 	invalidInlineYAML := "{port: 8200, invalid-yaml}"
 
@@ -134,6 +137,8 @@ func TestSidecarConfiguration(t *testing.T) {
 				o.DataParallelSize = 3
 				o.MaxIdleConnsPerHost = 200
 				o.MooncakeBootstrapPort = 9001
+				o.MetricsPort = 9091
+				o.PrefillerAbortPath = ""
 
 				o.KVConnector = KVConnectorSGLang
 				o.connector = KVConnectorNIXLV2
@@ -179,6 +184,7 @@ func TestSidecarConfiguration(t *testing.T) {
 				o.DataParallelSize = 5
 				o.MaxIdleConnsPerHost = 300
 				o.MooncakeBootstrapPort = 9000
+				o.MetricsPort = 9090
 
 				o.KVConnector = KVConnectorSGLang
 				o.ECConnector = ECExampleConnector
@@ -228,6 +234,7 @@ func TestSidecarConfiguration(t *testing.T) {
 				certPath:                "/etc/certificates",
 				inferencePool:           "ns/inference-pool",
 				poolGroup:               "pool-group",
+				prefillerAbortPath:      "/v1/abort",
 				inlineConfiguration:     &inlineYAML,
 			},
 			expected: func(o *Options) {
@@ -236,6 +243,8 @@ func TestSidecarConfiguration(t *testing.T) {
 				o.DataParallelSize = 2
 				o.MaxIdleConnsPerHost = 200
 				o.MooncakeBootstrapPort = 9001
+				o.MetricsPort = 9091
+				o.PrefillerAbortPath = "/v1/abort"
 
 				o.KVConnector = KVConnectorSGLang
 				o.ECConnector = ECExampleConnector
@@ -300,6 +309,7 @@ func TestSidecarConfiguration(t *testing.T) {
 				configurationFile:         validYAMLPath,
 				maxIdleConnsPerHost:       400,
 				mooncakeBootstrapPortFlag: 9002,
+				metricsPortFlag:           9092,
 			},
 			expected: func(o *Options) {
 				o.Port = "8111"
@@ -307,6 +317,7 @@ func TestSidecarConfiguration(t *testing.T) {
 				o.DataParallelSize = 2
 				o.MaxIdleConnsPerHost = 400
 				o.MooncakeBootstrapPort = 9002
+				o.MetricsPort = 9092
 
 				o.KVConnector = KVConnectorSGLang
 				o.ECConnector = ECExampleConnector
@@ -462,6 +473,8 @@ func compareOptions(t *testing.T, expected, actual *Options) {
 
 	assertEqual(decodeChunkSize, expected.DecodeChunkSize, actual.DecodeChunkSize)
 	assertEqual(tracingFlag, expected.Tracing, actual.Tracing)
+	assertEqual(metricsPortFlag, expected.MetricsPort, actual.MetricsPort)
+	assertEqual(prefillerAbortPath, expected.PrefillerAbortPath, actual.PrefillerAbortPath)
 
 	assertEqual(inlineConfiguration, expected.inlineConfiguration, actual.inlineConfiguration)
 	assertEqual(configurationFile, expected.fileConfiguration, actual.fileConfiguration)
@@ -627,6 +640,55 @@ func TestValidateTLSStages(t *testing.T) {
 	}
 }
 
+func TestValidateMetricsPort(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricsPort int
+		wantErr     bool
+	}{
+		{name: "disabled", metricsPort: 0, wantErr: false},
+		{name: "valid port", metricsPort: 9090, wantErr: false},
+		{name: "negative port", metricsPort: -1, wantErr: true},
+		{name: "port out of range", metricsPort: 65536, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			opts.MetricsPort = tt.metricsPort
+			_ = opts.Complete() // Complete must be called before Validate
+			err := opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePrefillerAbortPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		abortPath string
+		wantErr   bool
+	}{
+		{name: "disabled", abortPath: "", wantErr: false},
+		{name: "default", abortPath: defaultPrefillerAbortPath, wantErr: false},
+		{name: "relative path", abortPath: "abort_request", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewOptions()
+			opts.PrefillerAbortPath = tt.abortPath
+			_ = opts.Complete() // Complete must be called before Validate
+			err := opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateSSRFProtection(t *testing.T) {
 	tests := []struct {
 		name      string
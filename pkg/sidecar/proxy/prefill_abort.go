@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// prefillAbortTimeout bounds the request aborting a prefill whose client disconnected.
+const prefillAbortTimeout = 5 * time.Second
+
+// abortPrefill asks the prefiller to abort the request with the given ID and release the KV
+// blocks it holds for it, since the client disconnected and no decoder will read them. The
+// abort is a POST of {"rid": requestID} to the configured prefiller abort path, also carrying
+// the ID in the x-request-id header; it is skipped when the path or the ID is empty.
+func (s *Server) abortPrefill(r *http.Request, connector, prefillHostPort, requestID string) {
+	if s.config.PrefillerAbortPath == "" || requestID == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), prefillAbortTimeout)
+	defer cancel()
+
+	handler, err := s.prefillerProxyHandler(prefillHostPort)
+	if err != nil {
+		s.logger.Error(err, "failed to create prefill handler for abort", "request_id", requestID)
+		recordPrefillAbort(connector, prefillAbortFailure)
+		return
+	}
+	body, err := json.Marshal(map[string]any{requestFieldRID: requestID})
+	if err != nil {
+		s.logger.Error(err, "failed to build prefill abort request", "request_id", requestID)
+		recordPrefillAbort(connector, prefillAbortFailure)
+		return
+	}
+
+	req := cloneRequestWithBody(ctx, r, body)
+	req.Method = http.MethodPost
+	req.URL.Path = s.config.PrefillerAbortPath
+	req.URL.RawPath = ""
+	req.URL.RawQuery = ""
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(requestHeaderRequestID, requestID)
+	rw := &bufferedResponseWriter{}
+	handler.ServeHTTP(rw, req)
+
+	if isHTTPError(rw.statusCode) {
+		s.logger.Error(nil, "failed to abort prefill", "request_id", requestID, "code", rw.statusCode, "body", rw.buffer.String())
+		recordPrefillAbort(connector, prefillAbortFailure)
+		return
+	}
+	s.logger.V(4).Info("aborted prefill", "request_id", requestID, "connector", connector)
+	recordPrefillAbort(connector, prefillAbortSuccess)
+}
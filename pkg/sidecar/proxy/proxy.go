@@ -75,6 +75,8 @@ const (
 	// Mooncake transfer fields
 	requestFieldTransferID          = "transfer_id"
 	requestFieldRemoteBootstrapAddr = "remote_bootstrap_addr"
+	// Prefill abort fields
+	requestFieldRID = "rid"
 
 	KVConnectorNIXLV2        = constants.KVConnectorNIXLV2
 	KVConnectorSharedStorage = constants.KVConnectorSharedStorage
@@ -191,6 +193,13 @@ type Config struct {
 
 	// Tracing enables OpenTelemetry tracing.
 	Tracing bool
+
+	// MetricsPort is the port Prometheus metrics are served on; 0 disables the metrics endpoint.
+	MetricsPort int
+
+	// PrefillerAbortPath is the path on the prefiller the request aborts releasing the KV blocks
+	// of disconnected clients are sent to; empty disables the aborts.
+	PrefillerAbortPath string
 }
 
 // MarshalJSON implements json.Marshaler for Config.
@@ -313,6 +322,12 @@ func (s *Server) Start(ctx context.Context) error {
 		return s.startHTTP(ctx)
 	})
 
+	if s.config.MetricsPort > 0 {
+		grp.Go(func() error {
+			return s.startMetrics(ctx)
+		})
+	}
+
 	return grp.Wait()
 }
 
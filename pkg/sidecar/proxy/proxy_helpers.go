@@ -196,6 +196,21 @@ func cloneRequestWithBody(ctx context.Context, r *http.Request, body []byte) *ht
 	return cloned
 }
 
+// asyncPrefillContext returns the context for a prefill request running concurrently
+// with decode. The prefill outlives ctx so it is not aborted when decode finishes
+// first, but it is cancelled when the client disconnects while the request is still
+// being served. Call detach once decode completed without a disconnect and once the
+// prefill returns; cancel releases the context.
+func (s *Server) asyncPrefillContext(ctx context.Context, connector string) (prefillCtx context.Context, detach func(), cancel context.CancelFunc) {
+	prefillCtx, cancel = context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		s.logger.V(4).Info("client disconnected, cancelling in-flight prefill", "connector", connector)
+		recordCancelledRequest(connector, cancelStagePrefill)
+		cancel()
+	})
+	return prefillCtx, func() { stop() }, cancel
+}
+
 // extractHost returns the host part of a host:port string. If parsing
 // fails (e.g. no port), the input is returned as-is.
 func extractHost(hostWithPort string) string {
@@ -0,0 +1,197 @@
+#!/usr/bin/env python3
+#
+# Copyright 2026 The llm-d Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""Generates the reference fixtures of the in-process tokenizer.
+
+For each model below, the script downloads tokenizer.json and
+tokenizer_config.json from the Hugging Face Hub and records, in golden.json:
+
+  - the token IDs the Rust `tokenizers` library produces for a set of texts,
+    with and without special tokens;
+  - for models with a chat template, the prompt `transformers` renders with
+    apply_chat_template for a set of conversations, and its token IDs without
+    special tokens, which is how vLLM tokenizes chat prompts.
+
+The Go test TestLocalTokenizer_Reference checks the tokenizer package against
+every fixture under the output directory.
+
+Usage:
+    pip install tokenizers transformers jinja2 huggingface_hub
+    scripts/generate-tokenizer-golden.py [--output DIR]
+"""
+
+import argparse
+import json
+import os
+import shutil
+
+import huggingface_hub
+import tokenizers
+import transformers
+
+DEFAULT_OUTPUT = os.path.join(
+    os.path.dirname(os.path.abspath(__file__)), "..",
+    "pkg", "epp", "framework", "plugins", "requestcontrol", "dataproducer",
+    "tokenizer", "testdata", "reference")
+
+# Fixture directory name -> Hub repository. The models cover every tokenizer
+# model type the hf package implements; the instruct models carry the Llama 3
+# and Qwen 2.5 chat templates.
+MODELS = {
+    "llama-3.2-instruct": "unsloth/Llama-3.2-1B-Instruct",  # byte-level BPE, Llama 3 template
+    "qwen2.5-instruct": "Qwen/Qwen2.5-0.5B-Instruct",  # byte-level BPE, NFC, Qwen 2.5 template
+    "llama-2": "hf-internal-testing/llama-tokenizer",  # SentencePiece BPE with byte fallback
+    "bert-base-uncased": "google-bert/bert-base-uncased",  # WordPiece
+    "t5-small": "google-t5/t5-small",  # Unigram with precompiled normalizer
+}
+
+TEXTS = [
+    "Hello world",
+    "Hello, World! How are you today?",
+    "  leading and trailing spaces  ",
+    "tabs\tand\nnew\n\nlines\r\n",
+    "trailing whitespace before text   x",
+    "it's, we're, they'll, I'd, you've, she'S",
+    "1234567 3.14159 0x1F -42",
+    "héllo wörld — naïve café",
+    "中文分词测试，日本語のテキスト",
+    "emoji 🙂🚀 and symbols ∑∫√",
+    "<|begin_of_text|>special <|eot_id|> tokens",
+    "<|im_start|>user\nhi<|im_end|>",
+    "[CLS] bert [SEP] tokens [MASK]",
+    "def f(x):\n    return x ** 2  # square\n",
+    "",
+]
+
+WEATHER_TOOL = {
+    "type": "function",
+    "function": {
+        "name": "get_weather",
+        "description": "Get the current weather in a city.",
+        "parameters": {
+            "type": "object",
+            "properties": {"city": {"type": "string", "description": "City name"}},
+            "required": ["city"],
+        },
+    },
+}
+
+CONVERSATIONS = [
+    {
+        "name": "single user turn",
+        "messages": [{"role": "user", "content": "Hello world"}],
+        "add_generation_prompt": True,
+    },
+    {
+        "name": "system and multi turn",
+        "messages": [
+            {"role": "system", "content": "You are a concise assistant."},
+            {"role": "user", "content": "What is 2+2?"},
+            {"role": "assistant", "content": "4"},
+            {"role": "user", "content": "And 3+3?\n\nAnswer briefly."},
+        ],
+        "add_generation_prompt": True,
+    },
+    {
+        "name": "no generation prompt",
+        "messages": [
+            {"role": "user", "content": "héllo 🙂"},
+            {"role": "assistant", "content": "Hi!"},
+        ],
+        "add_generation_prompt": False,
+    },
+    {
+        "name": "tools",
+        "messages": [{"role": "user", "content": "Weather in Paris?"}],
+        "tools": [WEATHER_TOOL],
+        "add_generation_prompt": True,
+    },
+]
+
+# Llama 3 templates print the current date; the fixtures pin it.
+TEMPLATE_KWARGS = {"date_string": "26 Jul 2024"}
+
+
+def fetch(repo, directory):
+    revision = huggingface_hub.HfApi().model_info(repo).sha
+    os.makedirs(directory, exist_ok=True)
+    for name in ("tokenizer.json", "tokenizer_config.json"):
+        path = huggingface_hub.hf_hub_download(repo, name, revision=revision)
+        shutil.copyfile(path, os.path.join(directory, name))
+    return revision
+
+
+def encode_cases(directory):
+    tk = tokenizers.Tokenizer.from_file(os.path.join(directory, "tokenizer.json"))
+    return [
+        {"text": text, "addSpecialTokens": special, "ids": tk.encode(text, add_special_tokens=special).ids}
+        for text in TEXTS
+        for special in (True, False)
+    ]
+
+
+def chat_cases(directory):
+    tok = transformers.AutoTokenizer.from_pretrained(directory)
+    if not tok.chat_template:
+        return []
+    cases = []
+    for conv in CONVERSATIONS:
+        # The Go tokenizer does not preserve request JSON key order, so tools
+        # are passed with sorted keys for tojson to render identically.
+        tools = json.loads(json.dumps(conv["tools"], sort_keys=True)) if "tools" in conv else None
+        prompt = tok.apply_chat_template(
+            conv["messages"],
+            tools=tools,
+            add_generation_prompt=conv["add_generation_prompt"],
+            tokenize=False,
+            **TEMPLATE_KWARGS,
+        )
+        cases.append({
+            "name": conv["name"],
+            "messages": conv["messages"],
+            "tools": tools,
+            "addGenerationPrompt": conv["add_generation_prompt"],
+            "chatTemplateKwargs": TEMPLATE_KWARGS,
+            "prompt": prompt,
+            "ids": tok.encode(prompt, add_special_tokens=False),
+        })
+    return cases
+
+
+def main():
+    parser = argparse.ArgumentParser(description=__doc__, formatter_class=argparse.RawDescriptionHelpFormatter)
+    parser.add_argument("--output", default=DEFAULT_OUTPUT, help="fixture directory")
+    args = parser.parse_args()
+
+    for name, repo in MODELS.items():
+        directory = os.path.join(args.output, name)
+        revision = fetch(repo, directory)
+        golden = {
+            "model": repo,
+            "revision": revision,
+            "tokenizers": tokenizers.__version__,
+            "transformers": transformers.__version__,
+            "encode": encode_cases(directory),
+            "chat": chat_cases(directory),
+        }
+        with open(os.path.join(directory, "golden.json"), "w", encoding="utf-8") as f:
+            json.dump(golden, f, ensure_ascii=False, indent=1)
+            f.write("\n")
+        print(f"{name}: {repo}@{revision}")
+
+
+if __name__ == "__main__":
+    main()
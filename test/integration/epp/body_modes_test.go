@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The llm-d Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package epp
+
+import (
+	"testing"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/llm-d/llm-d-router/pkg/epp/handlers"
+)
+
+// TestBodyModes_KubeInferenceObjectiveRequest runs the shared request/response test cases under every supported
+// ext_proc body mode, announced by the proxy on the first message of the stream.
+func TestBodyModes_KubeInferenceObjectiveRequest(t *testing.T) {
+	prio := func(p int) int { return p }
+
+	for _, mode := range handlers.SupportedBodyModes {
+		t.Run(mode.String(), func(t *testing.T) {
+			tests := append(commonTestCases(prio), hermeticTestCases(prio)...)
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					tc.requests = bodyModeRequests(mode, tc.requests)
+					tc.wantResponses = bodyModeResponses(mode, tc.requests, tc.wantResponses)
+					runHermeticTestCase(t, modeStandard, "", tc)
+				})
+			}
+		})
+	}
+}
+
+// bodyModeRequests adapts a FULL_DUPLEX_STREAMED request sequence to mode. The first message announces the
+// processing mode and, in BUFFERED mode, consecutive body chunks are merged into a single message.
+func bodyModeRequests(mode handlers.BodyMode, requests []*extProcPb.ProcessingRequest) []*extProcPb.ProcessingRequest {
+	adapted := make([]*extProcPb.ProcessingRequest, 0, len(requests))
+	var pending *extProcPb.ProcessingRequest // BUFFERED body message being merged.
+	flush := func() {
+		if pending != nil {
+			adapted = append(adapted, pending)
+			pending = nil
+		}
+	}
+	for _, req := range requests {
+		req = proto.Clone(req).(*extProcPb.ProcessingRequest)
+		body := req.GetRequestBody()
+		if body == nil {
+			body = req.GetResponseBody()
+		}
+		if mode != handlers.BodyModeBuffered || body == nil {
+			flush()
+			adapted = append(adapted, req)
+			continue
+		}
+		if pending == nil || (pending.GetRequestBody() == nil) != (req.GetRequestBody() == nil) {
+			flush()
+			pending = req
+		} else {
+			pendingBody := pending.GetRequestBody()
+			if pendingBody == nil {
+				pendingBody = pending.GetResponseBody()
+			}
+			pendingBody.Body = append(pendingBody.Body, body.Body...)
+			pendingBody.EndOfStream = body.EndOfStream
+		}
+		if body.EndOfStream {
+			flush()
+		}
+	}
+	flush()
+
+	if len(adapted) > 0 {
+		adapted[0].ProtocolConfig = &extProcPb.ProtocolConfiguration{
+			RequestBodyMode:                         mode,
+			ResponseBodyMode:                        mode,
+			SendBodyWithoutWaitingForHeaderResponse: mode == handlers.BodyModeStreamed,
+		}
+	}
+	return adapted
+}
+
+// bodyModeResponses adapts the responses expected in FULL_DUPLEX_STREAMED mode to the shapes sent in mode, given the
+// adapted requests. In STREAMED mode, response body messages are expected back unchanged, one response per message,
+// so the response bodies of the test cases must not be subject to a model name rewrite.
+func bodyModeResponses(mode handlers.BodyMode, requests []*extProcPb.ProcessingRequest, want []*extProcPb.ProcessingResponse) []*extProcPb.ProcessingResponse {
+	if mode == handlers.BodyModeFullDuplexStreamed {
+		return want
+	}
+
+	var respBodies []*extProcPb.HttpBody
+	hasRequestBody := false
+	reqBodies := 0
+	for _, req := range requests {
+		if body := req.GetResponseBody(); body != nil {
+			respBodies = append(respBodies, body)
+		}
+		if req.GetRequestBody() != nil {
+			reqBodies++
+		}
+		if headers := req.GetRequestHeaders(); headers != nil && !headers.EndOfStream {
+			hasRequestBody = true
+		}
+	}
+
+	adapted := make([]*extProcPb.ProcessingResponse, 0, len(want))
+	for i := 0; i < len(want); i++ {
+		resp := proto.Clone(want[i]).(*extProcPb.ProcessingResponse)
+		switch {
+		case resp.GetRequestHeaders() != nil:
+			var body []byte
+			for i+1 < len(want) && want[i+1].GetRequestBody() != nil {
+				i++
+				body = append(body, want[i].GetRequestBody().GetResponse().GetBodyMutation().GetStreamedResponse().GetBody()...)
+			}
+			if body == nil {
+				// Header-only requests are answered the same way in every mode.
+				adapted = append(adapted, resp)
+				continue
+			}
+			if mode == handlers.BodyModeStreamed {
+				// Every request body message is answered, the last one with the whole body.
+				adapted = append(adapted, resp)
+				for j := range reqBodies {
+					mutation := &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_ClearBody{ClearBody: true}}
+					if j == reqBodies-1 {
+						mutation = &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_Body{Body: body}}
+					}
+					adapted = append(adapted, &extProcPb.ProcessingResponse{
+						Response: &extProcPb.ProcessingResponse_RequestBody{
+							RequestBody: &extProcPb.BodyResponse{
+								Response: &extProcPb.CommonResponse{BodyMutation: mutation},
+							},
+						},
+					})
+				}
+				continue
+			}
+			commonResp := resp.GetRequestHeaders().GetResponse()
+			commonResp.BodyMutation = &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_Body{Body: body}}
+			adapted = append(adapted, emptyRequestHeadersResponse(), &extProcPb.ProcessingResponse{
+				Response: &extProcPb.ProcessingResponse_RequestBody{
+					RequestBody: &extProcPb.BodyResponse{Response: commonResp},
+				},
+				DynamicMetadata: resp.DynamicMetadata,
+			})
+		case resp.GetImmediateResponse() != nil:
+			// In BUFFERED mode the request headers are let through before the body is parsed.
+			if mode == handlers.BodyModeBuffered && hasRequestBody && len(adapted) == 0 {
+				adapted = append(adapted, emptyRequestHeadersResponse())
+			}
+			adapted = append(adapted, resp)
+		case resp.GetResponseBody() != nil:
+			var body []byte
+			dynamicMetadata := resp.DynamicMetadata
+			body = append(body, resp.GetResponseBody().GetResponse().GetBodyMutation().GetStreamedResponse().GetBody()...)
+			for i+1 < len(want) && want[i+1].GetResponseBody() != nil {
+				i++
+				body = append(body, want[i].GetResponseBody().GetResponse().GetBodyMutation().GetStreamedResponse().GetBody()...)
+				dynamicMetadata = want[i].DynamicMetadata
+			}
+			chunks := [][]byte{body}
+			if mode == handlers.BodyModeStreamed {
+				chunks = chunks[:0]
+				for _, respBody := range respBodies {
+					chunks = append(chunks, respBody.Body)
+				}
+			}
+			for j, chunk := range chunks {
+				chunkResp := &extProcPb.ProcessingResponse{
+					Response: &extProcPb.ProcessingResponse_ResponseBody{
+						ResponseBody: &extProcPb.BodyResponse{
+							Response: &extProcPb.CommonResponse{
+								BodyMutation: &extProcPb.BodyMutation{Mutation: &extProcPb.BodyMutation_Body{Body: chunk}},
+							},
+						},
+					},
+				}
+				if j == len(chunks)-1 {
+					chunkResp.DynamicMetadata = dynamicMetadata
+				}
+				adapted = append(adapted, chunkResp)
+			}
+		default:
+			adapted = append(adapted, resp)
+		}
+	}
+	return adapted
+}
+
+// emptyRequestHeadersResponse is the response letting the request headers through unchanged in BUFFERED mode.
+func emptyRequestHeadersResponse() *extProcPb.ProcessingResponse {
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_RequestHeaders{
+			RequestHeaders: &extProcPb.HeadersResponse{
+				Response: &extProcPb.CommonResponse{},
+			},
+		},
+	}
+}
@@ -141,23 +141,41 @@ func TestFullDuplexStreamed_KubeInferenceObjectiveRequest(t *testing.T) {
 				return p
 			}
 
-			hermeticTests := []testCase{
-				{
-					name:     "select lora despite higher kv cache (affinity)",
-					requests: integration.ReqLLM(logger, "test3", modelSQLLora, modelSQLLoraTarget),
-					pods: []PodState{
-						P(0, 10, 0.2, "foo", "bar"),
-						P(1, 10, 0.4, "foo", modelSQLLoraTarget), // Winner (Affinity overrides KV)
-						P(2, 10, 0.3, "foo"),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.2:8000", modelSQLLoraTarget, "test3"),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal(modelSQLLora, modelSQLLoraTarget, prio(2))),
-					},
-				},
-				{
-					name: "passthrough parser success",
-					configText: `
+			tests := append(commonTestCases(prio), hermeticTestCases(prio)...)
+
+			for _, tc := range tests {
+				t.Run(tc.name, func(t *testing.T) {
+					if isNoCRD && tc.requiresCRDs {
+						t.Skipf("Skipping test %q: requires CRDs, but running in standalone without crd executionMode", tc.name)
+					}
+
+					runHermeticTestCase(t, executionMode.mode, executionMode.standaloneStrategy, tc)
+				})
+			}
+		})
+	}
+}
+
+// hermeticTestCases returns the test cases specific to the hermetic suite.
+// prio adjusts expected priority label values based on execution context (e.g. 0 in NoCRD mode).
+func hermeticTestCases(prio func(int) int) []testCase {
+	return []testCase{
+		{
+			name:     "select lora despite higher kv cache (affinity)",
+			requests: integration.ReqLLM(logger, "test3", modelSQLLora, modelSQLLoraTarget),
+			pods: []PodState{
+				P(0, 10, 0.2, "foo", "bar"),
+				P(1, 10, 0.4, "foo", modelSQLLoraTarget), // Winner (Affinity overrides KV)
+				P(2, 10, 0.3, "foo"),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.2:8000", modelSQLLoraTarget, "test3"),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal(modelSQLLora, modelSQLLoraTarget, prio(2))),
+			},
+		},
+		{
+			name: "passthrough parser success",
+			configText: `
 apiVersion: inference.networking.x-k8s.io/v1alpha1
 kind: EndpointPickerConfig
 plugins:
@@ -177,223 +195,223 @@ dataLayer:
   sources:
   - pluginRef: mock-metrics-source
 `,
-					requests: integration.ReqRaw(
-						map[string]string{
-							"hi":                         "mom",
-							reqcommon.RequestIDHeaderKey: "test-request-id",
-							metadata.ObjectiveKey:        modelMyModel, // With passthrough parser, the objective key can still be used to specify priority.
-						},
-						"passthrough-parser",
-					),
-					pods: []PodState{
-						P(0, 3, 0.2),
-						P(1, 0, 0.1), // Winner
-						P(2, 10, 0.2),
-					},
-					wantResponses: ExpectPassthroughRouteTo("192.168.1.2:8000", []byte("passthrough-parser")),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal("", "", prio(2))),
-						"inference_pool_ready_pods":         cleanMetric(metricReadyPods(3)),
-					},
-				},
-				{
-					name:     "do not shed requests by default",
-					requests: integration.ReqLLM(logger, "test4", modelSQLLora, modelSQLLoraTarget),
-					pods: []PodState{
-						P(0, 6, 0.2, "foo", "bar", modelSQLLoraTarget), // Winner (Lowest saturated)
-						P(1, 0, 0.85, "foo"),
-						P(2, 10, 0.9, "foo"),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.1:8000", modelSQLLoraTarget, "test4"),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal(modelSQLLora, modelSQLLoraTarget, prio(2))),
-					},
-				},
-
-				// --- Error Handling & Edge Cases ----
-				{
-					name: "invalid json body",
-					requests: integration.ReqRaw(
-						map[string]string{"hi": "mom"},
-						"no healthy upstream",
-					),
-					pods: []PodState{
-						P(0, 0, 0.2, "foo", "bar"),
-					},
-					wantResponses: ExpectReject(
-						envoyTypePb.StatusCode_BadRequest,
-						"inference error: BadRequest - error unmarshaling request bodyMap: invalid character 'o' in literal null (expecting 'u')",
-					),
-				},
-				{
-					name: "split body across chunks",
-					requests: integration.ReqRaw(
-						map[string]string{
-							"hi":                         "mom",
-							metadata.ObjectiveKey:        modelSheddable,
-							metadata.ModelNameRewriteKey: modelSheddableTarget,
-							reqcommon.RequestIDHeaderKey: "test-request-id",
-						},
-						`{"max_tokens":100,"model":"sql-lo`,
-						`ra-sheddable","prompt":"test6","temperature":0}`,
-					),
-					pods: []PodState{
-						P(0, 4, 0.2, "foo", "bar", modelSheddableTarget),
-						P(1, 4, 0.85, "foo", modelSheddableTarget),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.1:8000", modelSheddableTarget, "test6"),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal(modelSheddable, modelSheddableTarget, prio(0))),
-					},
-				},
-				{
-					name:     "no backend pods available",
-					requests: integration.ReqHeaderOnly(map[string]string{"content-type": "application/json"}),
-					pods:     nil,
-					wantResponses: ExpectReject(envoyTypePb.StatusCode_InternalServerError,
-						"inference error: Internal - no pods available in datastore"),
-				},
-				{
-					name: "request missing model field",
-					requests: integration.ReqRaw(
-						map[string]string{"content-type": "application/json"},
-						`{"prompt":"hello world"}`,
-					),
-					wantResponses: ExpectReject(envoyTypePb.StatusCode_BadRequest,
-						"inference error: BadRequest - model not found in request body"),
-				},
-
-				// --- Subsetting & Metadata ---
-				{
-					name: "subsetting: select best from subset",
-					// Only pods in the subset list are eligible.
-					requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget,
-						"192.168.1.1:8000", "192.168.1.2:8000", "192.168.1.3:8000"),
-					pods: []PodState{
-						P(0, 0, 0.2, "foo"),
-						P(1, 0, 0.1, "foo", modelSQLLoraTarget), // Winner (Low Queue + Matches Subset)
-						P(2, 10, 0.2, "foo"),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.2:8000", modelSQLLoraTarget, "test2"),
-				},
-				{
-					name:     "subsetting: partial match",
-					requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget, "192.168.1.3:8000"),
-					pods: []PodState{
-						P(0, 0, 0.2, "foo"),
-						P(1, 0, 0.1, "foo", modelSQLLoraTarget),
-						P(2, 10, 0.2, "foo"), // Winner (Matches Subset, despite load)
-					},
-					wantResponses: ExpectRouteTo("192.168.1.3:8000", modelSQLLoraTarget, "test2"),
-				},
-				{
-					name:     "subsetting: no pods match",
-					requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget, "192.168.1.99:8000"),
-					pods: []PodState{
-						P(0, 0, 0.2, "foo"),
-						P(1, 0, 0.1, "foo", modelSQLLoraTarget),
-					},
-					wantResponses: ExpectReject(envoyTypePb.StatusCode_ServiceUnavailable,
-						"inference error: ServiceUnavailable - failed to find endpoint candidates for serving the request"),
-				},
-
-				// --- Request Modification (Passthrough & Rewrite) ---
-				{
-					name: "passthrough: model not in objectives",
-					requests: integration.ReqRaw(
-						map[string]string{
-							"hi":                         "mom",
-							metadata.ObjectiveKey:        modelDirect,
-							metadata.ModelNameRewriteKey: modelDirect,
-							reqcommon.RequestIDHeaderKey: "test-request-id",
-						},
-						`{"max_tokens":100,"model":"direct-`,
-						`model","prompt":"test6","temperature":0}`,
-					),
-					pods: []PodState{
-						P(0, 4, 0.2, "foo", "bar", modelSheddableTarget),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.1:8000", modelDirect, "test6"),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal(modelDirect, modelDirect, prio(2))),
-					},
-				},
-				{
-					name:     "rewrite request model",
-					requests: integration.ReqLLM(logger, "test-rewrite", modelToBeWritten, modelToBeWritten),
-					pods: []PodState{
-						P(0, 0, 0.1, "foo", modelAfterRewrite),
-					},
-					wantResponses: ExpectRouteTo("192.168.1.1:8000", modelAfterRewrite, "test-rewrite"),
-					wantMetrics: map[string]string{
-						"inference_objective_request_total": cleanMetric(metricReqTotal(modelToBeWritten, modelAfterRewrite, prio(0))),
-					},
-					requiresCRDs: true,
-				},
-				{
-					name: "protocol: simple GET (header only)",
-					requests: integration.ReqHeaderOnly(map[string]string{
-						"content-type": "text/event-stream",
-						"status":       "200",
-					}),
-					pods:          []PodState{P(0, 0, 0, "foo")},
-					wantResponses: nil,
-				},
-
-				// --- Response Processing (Buffering & Streaming) ---
-				{
-					name: "response buffering: multi-chunk JSON",
-					requests: ReqResponseOnly(
-						map[string]string{"content-type": "application/json"},
-						`{"max_tokens":100,"model":"sql-lo`,
-						`ra-sheddable","prompt":"test6","temperature":0}`,
-					),
-					pods: []PodState{P(0, 4, 0.2, modelSheddableTarget)},
-					wantResponses: ExpectBufferResp(
-						fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
-						"application/json"),
+			requests: integration.ReqRaw(
+				map[string]string{
+					"hi":                         "mom",
+					reqcommon.RequestIDHeaderKey: "test-request-id",
+					metadata.ObjectiveKey:        modelMyModel, // With passthrough parser, the objective key can still be used to specify priority.
 				},
-				{
-					name: "response buffering: invalid JSON",
-					requests: ReqResponseOnly(
-						map[string]string{"content-type": "application/json"},
-						"no healthy upstream",
-					),
-					pods:          []PodState{P(0, 4, 0.2, modelSheddableTarget)},
-					wantResponses: ExpectBufferResp("no healthy upstream", "application/json"),
+				"passthrough-parser",
+			),
+			pods: []PodState{
+				P(0, 3, 0.2),
+				P(1, 0, 0.1), // Winner
+				P(2, 10, 0.2),
+			},
+			wantResponses: ExpectPassthroughRouteTo("192.168.1.2:8000", []byte("passthrough-parser")),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal("", "", prio(2))),
+				"inference_pool_ready_pods":         cleanMetric(metricReadyPods(3)),
+			},
+		},
+		{
+			name:     "do not shed requests by default",
+			requests: integration.ReqLLM(logger, "test4", modelSQLLora, modelSQLLoraTarget),
+			pods: []PodState{
+				P(0, 6, 0.2, "foo", "bar", modelSQLLoraTarget), // Winner (Lowest saturated)
+				P(1, 0, 0.85, "foo"),
+				P(2, 10, 0.9, "foo"),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.1:8000", modelSQLLoraTarget, "test4"),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal(modelSQLLora, modelSQLLoraTarget, prio(2))),
+			},
+		},
+
+		// --- Error Handling & Edge Cases ----
+		{
+			name: "invalid json body",
+			requests: integration.ReqRaw(
+				map[string]string{"hi": "mom"},
+				"no healthy upstream",
+			),
+			pods: []PodState{
+				P(0, 0, 0.2, "foo", "bar"),
+			},
+			wantResponses: ExpectReject(
+				envoyTypePb.StatusCode_BadRequest,
+				"inference error: BadRequest - error unmarshaling request bodyMap: invalid character 'o' in literal null (expecting 'u')",
+			),
+		},
+		{
+			name: "split body across chunks",
+			requests: integration.ReqRaw(
+				map[string]string{
+					"hi":                         "mom",
+					metadata.ObjectiveKey:        modelSheddable,
+					metadata.ModelNameRewriteKey: modelSheddableTarget,
+					reqcommon.RequestIDHeaderKey: "test-request-id",
 				},
-				{
-					name: "response buffering: empty EOS chunk (JSON)",
-					requests: ReqResponseOnly(
-						map[string]string{"content-type": "application/json"},
-						`{"max_tokens":100,"model":"sql-lora-sheddable","prompt":"test6","temperature":0}`,
-						"",
-					),
-					pods: []PodState{P(0, 4, 0.2, modelSheddableTarget)},
-					wantResponses: ExpectBufferResp(
-						fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
-						"application/json"),
+				`{"max_tokens":100,"model":"sql-lo`,
+				`ra-sheddable","prompt":"test6","temperature":0}`,
+			),
+			pods: []PodState{
+				P(0, 4, 0.2, "foo", "bar", modelSheddableTarget),
+				P(1, 4, 0.85, "foo", modelSheddableTarget),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.1:8000", modelSheddableTarget, "test6"),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal(modelSheddable, modelSheddableTarget, prio(0))),
+			},
+		},
+		{
+			name:     "no backend pods available",
+			requests: integration.ReqHeaderOnly(map[string]string{"content-type": "application/json"}),
+			pods:     nil,
+			wantResponses: ExpectReject(envoyTypePb.StatusCode_InternalServerError,
+				"inference error: Internal - no pods available in datastore"),
+		},
+		{
+			name: "request missing model field",
+			requests: integration.ReqRaw(
+				map[string]string{"content-type": "application/json"},
+				`{"prompt":"hello world"}`,
+			),
+			wantResponses: ExpectReject(envoyTypePb.StatusCode_BadRequest,
+				"inference error: BadRequest - model not found in request body"),
+		},
+
+		// --- Subsetting & Metadata ---
+		{
+			name: "subsetting: select best from subset",
+			// Only pods in the subset list are eligible.
+			requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget,
+				"192.168.1.1:8000", "192.168.1.2:8000", "192.168.1.3:8000"),
+			pods: []PodState{
+				P(0, 0, 0.2, "foo"),
+				P(1, 0, 0.1, "foo", modelSQLLoraTarget), // Winner (Low Queue + Matches Subset)
+				P(2, 10, 0.2, "foo"),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.2:8000", modelSQLLoraTarget, "test2"),
+		},
+		{
+			name:     "subsetting: partial match",
+			requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget, "192.168.1.3:8000"),
+			pods: []PodState{
+				P(0, 0, 0.2, "foo"),
+				P(1, 0, 0.1, "foo", modelSQLLoraTarget),
+				P(2, 10, 0.2, "foo"), // Winner (Matches Subset, despite load)
+			},
+			wantResponses: ExpectRouteTo("192.168.1.3:8000", modelSQLLoraTarget, "test2"),
+		},
+		{
+			name:     "subsetting: no pods match",
+			requests: ReqSubset("test2", modelSQLLora, modelSQLLoraTarget, "192.168.1.99:8000"),
+			pods: []PodState{
+				P(0, 0, 0.2, "foo"),
+				P(1, 0, 0.1, "foo", modelSQLLoraTarget),
+			},
+			wantResponses: ExpectReject(envoyTypePb.StatusCode_ServiceUnavailable,
+				"inference error: ServiceUnavailable - failed to find endpoint candidates for serving the request"),
+		},
+
+		// --- Request Modification (Passthrough & Rewrite) ---
+		{
+			name: "passthrough: model not in objectives",
+			requests: integration.ReqRaw(
+				map[string]string{
+					"hi":                         "mom",
+					metadata.ObjectiveKey:        modelDirect,
+					metadata.ModelNameRewriteKey: modelDirect,
+					reqcommon.RequestIDHeaderKey: "test-request-id",
 				},
-				{
-					name: "response streaming: SSE token counting",
-					requests: ReqResponseOnly(
-						map[string]string{"content-type": "text/event-stream", "status": "200"},
-						// Chunk 1: Simulate a standard data chunk.
-						`data: {}`,
-						// Chunk 2: Usage data + DONE signal.
-						`data: {"usage":{"prompt_tokens":7,"total_tokens":17,"completion_tokens":10}}`+"\n"+`data: [DONE]`,
-						"", // EndOfStream
-					),
-					pods:         []PodState{P(0, 4, 0.2, modelSheddableTarget)},
-					waitForModel: modelSheddable,
-					wantResponses: ExpectStreamResp(
-						`data: {}`,
-						`data: {"usage":{"prompt_tokens":7,"total_tokens":17,"completion_tokens":10}}`+"\n"+`data: [DONE]`,
-						"",
-					),
-					// Labels are empty because we skipped the Request phase.
-					wantMetrics: map[string]string{
-						"inference_objective_input_tokens": cleanMetric(`
+				`{"max_tokens":100,"model":"direct-`,
+				`model","prompt":"test6","temperature":0}`,
+			),
+			pods: []PodState{
+				P(0, 4, 0.2, "foo", "bar", modelSheddableTarget),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.1:8000", modelDirect, "test6"),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal(modelDirect, modelDirect, prio(2))),
+			},
+		},
+		{
+			name:     "rewrite request model",
+			requests: integration.ReqLLM(logger, "test-rewrite", modelToBeWritten, modelToBeWritten),
+			pods: []PodState{
+				P(0, 0, 0.1, "foo", modelAfterRewrite),
+			},
+			wantResponses: ExpectRouteTo("192.168.1.1:8000", modelAfterRewrite, "test-rewrite"),
+			wantMetrics: map[string]string{
+				"inference_objective_request_total": cleanMetric(metricReqTotal(modelToBeWritten, modelAfterRewrite, prio(0))),
+			},
+			requiresCRDs: true,
+		},
+		{
+			name: "protocol: simple GET (header only)",
+			requests: integration.ReqHeaderOnly(map[string]string{
+				"content-type": "text/event-stream",
+				"status":       "200",
+			}),
+			pods:          []PodState{P(0, 0, 0, "foo")},
+			wantResponses: nil,
+		},
+
+		// --- Response Processing (Buffering & Streaming) ---
+		{
+			name: "response buffering: multi-chunk JSON",
+			requests: ReqResponseOnly(
+				map[string]string{"content-type": "application/json"},
+				`{"max_tokens":100,"model":"sql-lo`,
+				`ra-sheddable","prompt":"test6","temperature":0}`,
+			),
+			pods: []PodState{P(0, 4, 0.2, modelSheddableTarget)},
+			wantResponses: ExpectBufferResp(
+				fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
+				"application/json"),
+		},
+		{
+			name: "response buffering: invalid JSON",
+			requests: ReqResponseOnly(
+				map[string]string{"content-type": "application/json"},
+				"no healthy upstream",
+			),
+			pods:          []PodState{P(0, 4, 0.2, modelSheddableTarget)},
+			wantResponses: ExpectBufferResp("no healthy upstream", "application/json"),
+		},
+		{
+			name: "response buffering: empty EOS chunk (JSON)",
+			requests: ReqResponseOnly(
+				map[string]string{"content-type": "application/json"},
+				`{"max_tokens":100,"model":"sql-lora-sheddable","prompt":"test6","temperature":0}`,
+				"",
+			),
+			pods: []PodState{P(0, 4, 0.2, modelSheddableTarget)},
+			wantResponses: ExpectBufferResp(
+				fmt.Sprintf(`{"max_tokens":100,"model":%q,"prompt":"test6","temperature":0}`, modelSheddable),
+				"application/json"),
+		},
+		{
+			name: "response streaming: SSE token counting",
+			requests: ReqResponseOnly(
+				map[string]string{"content-type": "text/event-stream", "status": "200"},
+				// Chunk 1: Simulate a standard data chunk.
+				`data: {}`,
+				// Chunk 2: Usage data + DONE signal.
+				`data: {"usage":{"prompt_tokens":7,"total_tokens":17,"completion_tokens":10}}`+"\n"+`data: [DONE]`,
+				"", // EndOfStream
+			),
+			pods:         []PodState{P(0, 4, 0.2, modelSheddableTarget)},
+			waitForModel: modelSheddable,
+			wantResponses: ExpectStreamResp(
+				`data: {}`,
+				`data: {"usage":{"prompt_tokens":7,"total_tokens":17,"completion_tokens":10}}`+"\n"+`data: [DONE]`,
+				"",
+			),
+			// Labels are empty because we skipped the Request phase.
+			wantMetrics: map[string]string{
+				"inference_objective_input_tokens": cleanMetric(`
               # HELP inference_objective_input_tokens [ALPHA] [Deprecated: Use llm_d_router_epp_input_tokens] Inference objective input token count distribution for requests in each model.
               # TYPE inference_objective_input_tokens histogram
               inference_objective_input_tokens_bucket{model_name="",target_model_name="",le="1"} 0
@@ -419,91 +437,84 @@ dataLayer:
               inference_objective_input_tokens_sum{model_name="",target_model_name=""} 7
               inference_objective_input_tokens_count{model_name="",target_model_name=""} 1
               `),
-					},
-				},
-			}
-			tests := append(commonTestCases(prio), hermeticTests...)
+			},
+		},
+	}
+}
 
-			for _, tc := range tests {
-				t.Run(tc.name, func(t *testing.T) {
-					if isNoCRD && tc.requiresCRDs {
-						t.Skipf("Skipping test %q: requires CRDs, but running in standalone without crd executionMode", tc.name)
-					}
+// runHermeticTestCase runs a single test case against a fresh harness in the given execution mode.
+func runHermeticTestCase(t *testing.T, mode runMode, strategy standaloneStrategy, tc testCase) {
+	t.Helper()
+	ctx := t.Context()
 
-					ctx := t.Context()
+	var h *TestHarness
+	var harnessOpts []HarnessOption
 
-					var h *TestHarness
-					var harnessOpts []HarnessOption
+	if len(tc.wantSpans) > 0 {
+		harnessOpts = append(harnessOpts, WithTracing())
+	}
 
-					if len(tc.wantSpans) > 0 {
-						harnessOpts = append(harnessOpts, WithTracing())
-					}
+	if mode == modeStandalone {
+		harnessOpts = append(harnessOpts, WithStandaloneMode(strategy))
+	} else {
+		harnessOpts = append(harnessOpts, WithStandardMode())
+	}
 
-					if executionMode.mode == modeStandalone {
-						harnessOpts = append(harnessOpts, WithStandaloneMode(executionMode.standaloneStrategy))
-					} else {
-						harnessOpts = append(harnessOpts, WithStandardMode())
-					}
+	if tc.configText != "" {
+		harnessOpts = append(harnessOpts, WithConfigText(tc.configText))
+	}
 
-					if tc.configText != "" {
-						harnessOpts = append(harnessOpts, WithConfigText(tc.configText))
-					}
+	h = NewTestHarness(ctx, t, harnessOpts...)
 
-					h = NewTestHarness(ctx, t, harnessOpts...)
+	if mode == modeStandard || strategy == strategyWithCRD {
+		h = h.WithBaseResources()
+	}
 
-					if executionMode.mode == modeStandard || executionMode.standaloneStrategy == strategyWithCRD {
-						h = h.WithBaseResources()
-					}
+	// In standalone runMode without crd, we cannot wait for an Objective CRD to sync as it doesn't exist.
+	// We only wait for Pod discovery.
+	modelToSync := tc.waitForModel
+	if modelToSync == "" {
+		modelToSync = modelMyModel
+	}
 
-					// In standalone runMode without crd, we cannot wait for an Objective CRD to sync as it doesn't exist.
-					// We only wait for Pod discovery.
-					modelToSync := tc.waitForModel
-					if modelToSync == "" {
-						modelToSync = modelMyModel
-					}
+	h.WithPods(tc.pods).WaitForSync(len(tc.pods), modelToSync)
+	if len(tc.pods) > 0 {
+		h.WaitForReadyPodsMetric(len(tc.pods))
+	}
 
-					h.WithPods(tc.pods).WaitForSync(len(tc.pods), modelToSync)
-					if len(tc.pods) > 0 {
-						h.WaitForReadyPodsMetric(len(tc.pods))
-					}
+	responses, err := integration.StreamedRequest(t, h.Client, tc.requests, len(tc.wantResponses))
+	require.NoError(t, err)
 
-					responses, err := integration.StreamedRequest(t, h.Client, tc.requests, len(tc.wantResponses))
-					require.NoError(t, err)
+	if diff := cmp.Diff(tc.wantResponses, responses,
+		protocmp.Transform(),
+		protocmp.SortRepeated(func(a, b *configPb.HeaderValueOption) bool {
+			return a.GetHeader().GetKey() < b.GetHeader().GetKey()
+		}),
+	); diff != "" {
+		t.Errorf("Response mismatch (-want +got): %v", diff)
+	}
 
-					if diff := cmp.Diff(tc.wantResponses, responses,
-						protocmp.Transform(),
-						protocmp.SortRepeated(func(a, b *configPb.HeaderValueOption) bool {
-							return a.GetHeader().GetKey() < b.GetHeader().GetKey()
-						}),
-					); diff != "" {
-						t.Errorf("Response mismatch (-want +got): %v", diff)
-					}
+	if len(tc.wantMetrics) > 0 {
+		h.ExpectMetrics(tc.wantMetrics)
+	}
+	if len(tc.wantSpans) > 0 {
+		// Close the stream so the server finishes processing and ends the root span
+		_ = h.Client.CloseSend()
+
+		assert.Eventually(t, func() bool {
+			spans := h.GetSpans()
+			recordedSpans := make(map[string]bool)
+			for _, s := range spans {
+				recordedSpans[s.Name] = true
+			}
 
-					if len(tc.wantMetrics) > 0 {
-						h.ExpectMetrics(tc.wantMetrics)
-					}
-					if len(tc.wantSpans) > 0 {
-						// Close the stream so the server finishes processing and ends the root span
-						_ = h.Client.CloseSend()
-
-						assert.Eventually(t, func() bool {
-							spans := h.GetSpans()
-							recordedSpans := make(map[string]bool)
-							for _, s := range spans {
-								recordedSpans[s.Name] = true
-							}
-
-							for _, want := range tc.wantSpans {
-								if !recordedSpans[want] {
-									return false
-								}
-							}
-							return true
-						}, 5*time.Second, 50*time.Millisecond, "Expected spans %v not found", tc.wantSpans)
-					}
-				})
+			for _, want := range tc.wantSpans {
+				if !recordedSpans[want] {
+					return false
+				}
 			}
-		})
+			return true
+		}, 5*time.Second, 50*time.Millisecond, "Expected spans %v not found", tc.wantSpans)
 	}
 }
 